@@ -24,10 +24,12 @@ type GeneralConfig struct {
 	VerboseLevel         string `yaml:"verbose_level" envconfig:"VERBOSE_LEVEL"`
 	DumpFailedTests      bool   `yaml:"dump_failed_tests" envconfig:"DUMP_FAILED_TESTS"`
 	DryRun               bool   `yaml:"dry_run" envconfig:"DRY_RUN"`
+	FakeCluster          bool   `yaml:"fake_cluster" envconfig:"FAKE_CLUSTER"`
 	KubernetesRolePrefix string `yaml:"kubernetes_role_prefix" envconfig:"KUBERNETES_ROLE_PREFIX"`
 	WorkerLabelEnvVar    string `yaml:"worker_label" envconfig:"WORKER_LABEL"`
 	WorkerLabel          string
 	ControlPlaneLabel    string `yaml:"control_plane_label" envconfig:"CONTROL_PLANE_LABEL"`
+	StrictUninstall      bool   `yaml:"strict_uninstall" envconfig:"STRICT_UNINSTALL"`
 	WorkerLabelMap       map[string]string
 	ControlPlaneLabelMap map[string]string
 }
@@ -1,24 +1,28 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
-	"os/exec"
 	"time"
 
 	"github.com/golang/glog"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/deployment"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
 	_ "go.uber.org/mock/mockgen/model"
 )
 
 type BundleConfig struct {
 	BundleImage string
+	// RegistryImage is the opm image serving the bundle, olm.DefaultBundleRegistryImage when empty.
+	RegistryImage string
 }
 
 type Deploy interface {
 	CreateAndLabelNamespaceIfNeeded(logLevel glog.Level, ns string, labels map[string]string) (*namespace.Builder, error)
-	DeployBundle(logLevel glog.Level, bundleConfig *BundleConfig, ns string, timeout time.Duration) error
+	DeployBundle(ctx context.Context, logLevel glog.Level, bundleConfig *BundleConfig, ns string,
+		timeout time.Duration) (*olm.BundleInstaller, error)
 	WaitForReadyStatus(logLevel glog.Level, name, ns string, timeout time.Duration) error
 }
 
@@ -57,15 +61,30 @@ func (d deploy) CreateAndLabelNamespaceIfNeeded(logLevel glog.Level, ns string,
 	return nsBuilder, nil
 }
 
-func (d deploy) DeployBundle(logLevel glog.Level, bundleConfig *BundleConfig, ns string, timeout time.Duration) error {
-	cmd := exec.Command("operator-sdk", "run", "bundle", bundleConfig.BundleImage,
-		"--namespace", ns, "--timeout", timeout.String())
+// DeployBundle installs the operator bundle image in the namespace and waits up to timeout for its
+// ClusterServiceVersion to succeed. It returns the BundleInstaller, whose Cleanup deletes the registry pod, Service and
+// CatalogSource serving the bundle. A failure is returned as an *olm.InstallError, after the resources created for
+// the bundle have been deleted. The install stops early when ctx is done.
+func (d deploy) DeployBundle(ctx context.Context, logLevel glog.Level, bundleConfig *BundleConfig, ns string,
+	timeout time.Duration) (*olm.BundleInstaller, error) {
+	glog.V(logLevel).Infof("Deploying bundle '%s' in namespace '%s'", bundleConfig.BundleImage, ns)
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to wait for operator-sdk to run the bundle: %v", err)
+	bundleInstaller := olm.NewBundleInstaller(d.client, bundleConfig.BundleImage, ns).
+		WithCSVTimeout(olm.DefaultCSVSucceededCheckInterval, timeout)
+
+	if bundleConfig.RegistryImage != "" {
+		bundleInstaller.WithRegistryImage(bundleConfig.RegistryImage)
 	}
 
-	return nil
+	csvBuilder, err := bundleInstaller.InstallContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy bundle %s: %w", bundleConfig.BundleImage, err)
+	}
+
+	glog.V(logLevel).Infof("ClusterServiceVersion '%s' of bundle '%s' succeeded", csvBuilder.Object.Name,
+		bundleConfig.BundleImage)
+
+	return bundleInstaller, nil
 }
 
 func (d deploy) WaitForReadyStatus(logLevel glog.Level, name, ns string, timeout time.Duration) error {
@@ -0,0 +1,293 @@
+package gpuburn
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+)
+
+const (
+	// VerdictOK is reported by gpu_burn for a GPU that completed without calculation errors.
+	VerdictOK = "OK"
+	// VerdictFaulty is reported by gpu_burn for a GPU that produced calculation errors or died.
+	VerdictFaulty = "FAULTY"
+
+	// ResultReportFile is the default name of the gpu-burn JSON artifact in the reports directory.
+	ResultReportFile = "gpu-burn-result.json"
+)
+
+var (
+	// GPU 0: NVIDIA A100-SXM4-40GB (UUID: GPU-5f3c1c7e-...)
+	deviceLineRegex = regexp.MustCompile(`^\s*GPU (\d+): (.+?) \(UUID: ([^)]+)\)\s*$`)
+	// 100.0%  proc'd: 33000 (18000 Gflop/s) - 32000 (17500 Gflop/s)   errors: 0 - 0   temps: 65 C - 63 C
+	progressLineRegex = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)%\s+proc'd:\s*(.*?)\s+errors:\s*(.*?)\s+temps:\s*(.*?)\s*$`)
+	// GPU 0: OK
+	verdictLineRegex = regexp.MustCompile(`^\s*GPU (\d+): (OK|FAULTY)\s*$`)
+
+	processedRegex   = regexp.MustCompile(`(\d+) \((\d+(?:\.\d+)?) Gflop/s\)`)
+	errorsRegex      = regexp.MustCompile(`(\d+)\s*(\(WARNING!\)|\(DIED!\))?`)
+	temperatureRegex = regexp.MustCompile(`(\d+) C|--`)
+)
+
+// Sample is a single gpu_burn progress report for one GPU.
+type Sample struct {
+	// Percent of the requested burn duration elapsed at the time of the sample.
+	Percent float64 `json:"percent"`
+	// Processed is the number of matrix multiplications completed so far.
+	Processed int `json:"processed"`
+	// Gflops is the throughput measured since the previous sample.
+	Gflops float64 `json:"gflops"`
+	// Errors is the number of calculation errors detected so far.
+	Errors int `json:"errors"`
+	// Temperature in Celsius, 0 if gpu_burn could not read it.
+	Temperature int `json:"temperature"`
+	// Died is set once gpu_burn lost contact with the worker process of the GPU.
+	Died bool `json:"died,omitempty"`
+}
+
+// GPUResult holds everything gpu_burn reported for a single GPU index.
+type GPUResult struct {
+	Index          int      `json:"index"`
+	Model          string   `json:"model,omitempty"`
+	UUID           string   `json:"uuid,omitempty"`
+	Verdict        string   `json:"verdict"`
+	Errors         int      `json:"errors"`
+	MinGflops      float64  `json:"minGflops"`
+	MaxGflops      float64  `json:"maxGflops"`
+	AvgGflops      float64  `json:"avgGflops"`
+	MaxTemperature int      `json:"maxTemperature"`
+	Samples        []Sample `json:"samples"`
+}
+
+// Result is the typed representation of a gpu_burn run.
+type Result struct {
+	// Completed is set when gpu_burn reported 100% progress.
+	Completed bool `json:"completed"`
+	// GPUs is sorted by GPU index.
+	GPUs []GPUResult `json:"gpus"`
+}
+
+// Thresholds defines the limits a gpu_burn Result is validated against.
+type Thresholds struct {
+	// MinGflops maps a GPU model substring, e.g. "A100", to the minimum average Gflop/s expected from it.
+	// The longest matching substring wins, the empty string applies to every model.
+	MinGflops map[string]float64 `json:"minGflops,omitempty"`
+	// MaxErrors is the number of calculation errors tolerated per GPU.
+	MaxErrors int `json:"maxErrors"`
+	// MaxTemperature in Celsius, 0 disables the check.
+	MaxTemperature int `json:"maxTemperature,omitempty"`
+}
+
+// ParseResult parses the output of gpu_burn into a Result.
+func ParseResult(logs string) (*Result, error) {
+	glog.V(gpuparams.GpuLogLevel).Infof("Parsing gpu-burn output")
+
+	gpus := make(map[int]*GPUResult)
+
+	getGPU := func(index int) *GPUResult {
+		if _, ok := gpus[index]; !ok {
+			gpus[index] = &GPUResult{Index: index}
+		}
+
+		return gpus[index]
+	}
+
+	result := &Result{}
+	scanner := bufio.NewScanner(strings.NewReader(logs))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if match := verdictLineRegex.FindStringSubmatch(line); match != nil {
+			index, _ := strconv.Atoi(match[1])
+			getGPU(index).Verdict = match[2]
+
+			continue
+		}
+
+		if match := deviceLineRegex.FindStringSubmatch(line); match != nil {
+			index, _ := strconv.Atoi(match[1])
+			gpu := getGPU(index)
+			gpu.Model = match[2]
+			gpu.UUID = match[3]
+
+			continue
+		}
+
+		match := progressLineRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		samples, err := parseProgressLine(match)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse gpu-burn progress line %q: %w", line, err)
+		}
+
+		for index, sample := range samples {
+			gpu := getGPU(index)
+			gpu.Samples = append(gpu.Samples, sample)
+		}
+
+		if len(samples) > 0 && samples[0].Percent >= 100 {
+			result.Completed = true
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read gpu-burn output: %w", err)
+	}
+
+	if len(gpus) == 0 {
+		return nil, fmt.Errorf("no GPU results found in gpu-burn output")
+	}
+
+	for _, gpu := range gpus {
+		gpu.summarize()
+		result.GPUs = append(result.GPUs, *gpu)
+	}
+
+	sort.Slice(result.GPUs, func(i, j int) bool {
+		return result.GPUs[i].Index < result.GPUs[j].Index
+	})
+
+	return result, nil
+}
+
+// GPU returns the result of the GPU with the given index, or nil if gpu_burn did not report it.
+func (result *Result) GPU(index int) *GPUResult {
+	for i := range result.GPUs {
+		if result.GPUs[i].Index == index {
+			return &result.GPUs[i]
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the result against the given thresholds and returns all violations joined in a single error.
+// A run must always have completed and every GPU must have an OK verdict.
+func (result *Result) Validate(thresholds Thresholds) error {
+	var violations []error
+
+	if !result.Completed {
+		violations = append(violations, fmt.Errorf("gpu-burn did not reach 100%% progress"))
+	}
+
+	for _, gpu := range result.GPUs {
+		if gpu.Verdict != VerdictOK {
+			violations = append(violations, fmt.Errorf("GPU %d: verdict is %q, expected %q",
+				gpu.Index, gpu.Verdict, VerdictOK))
+		}
+
+		if gpu.Errors > thresholds.MaxErrors {
+			violations = append(violations, fmt.Errorf("GPU %d: %d calculation errors, at most %d allowed",
+				gpu.Index, gpu.Errors, thresholds.MaxErrors))
+		}
+
+		if thresholds.MaxTemperature > 0 && gpu.MaxTemperature > thresholds.MaxTemperature {
+			violations = append(violations, fmt.Errorf("GPU %d: temperature reached %d C, at most %d C allowed",
+				gpu.Index, gpu.MaxTemperature, thresholds.MaxTemperature))
+		}
+
+		if minGflops, ok := thresholds.minGflopsFor(gpu.Model); ok && gpu.AvgGflops < minGflops {
+			violations = append(violations, fmt.Errorf("GPU %d (%s): average %.0f Gflop/s, at least %.0f Gflop/s expected",
+				gpu.Index, gpu.Model, gpu.AvgGflops, minGflops))
+		}
+	}
+
+	return errors.Join(violations...)
+}
+
+// WriteReport stores the result as a JSON artifact in the reports directory.
+func (result *Result) WriteReport(generalConfig *config.GeneralConfig, fileName string) error {
+	content, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal gpu-burn result: %w", err)
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Writing gpu-burn result to %s", generalConfig.GetReportPath(fileName))
+
+	return generalConfig.WriteReport(fileName, content)
+}
+
+// minGflopsFor returns the Gflop/s threshold of the longest model substring matching the given model.
+func (thresholds Thresholds) minGflopsFor(model string) (float64, bool) {
+	matchedModel := ""
+	minGflops, found := 0.0, false
+
+	for thresholdModel, threshold := range thresholds.MinGflops {
+		if !strings.Contains(model, thresholdModel) || len(thresholdModel) < len(matchedModel) {
+			continue
+		}
+
+		if found && len(thresholdModel) == len(matchedModel) && thresholdModel > matchedModel {
+			continue
+		}
+
+		matchedModel, minGflops, found = thresholdModel, threshold, true
+	}
+
+	return minGflops, found
+}
+
+// summarize computes the aggregated values from the samples.
+func (gpu *GPUResult) summarize() {
+	if len(gpu.Samples) == 0 {
+		return
+	}
+
+	totalGflops := 0.0
+	gpu.MinGflops = gpu.Samples[0].Gflops
+
+	for _, sample := range gpu.Samples {
+		totalGflops += sample.Gflops
+		gpu.MinGflops = min(gpu.MinGflops, sample.Gflops)
+		gpu.MaxGflops = max(gpu.MaxGflops, sample.Gflops)
+		gpu.MaxTemperature = max(gpu.MaxTemperature, sample.Temperature)
+		gpu.Errors = max(gpu.Errors, sample.Errors)
+	}
+
+	gpu.AvgGflops = totalGflops / float64(len(gpu.Samples))
+}
+
+// parseProgressLine splits a progress line matched by progressLineRegex into one Sample per GPU.
+func parseProgressLine(match []string) ([]Sample, error) {
+	percent, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return nil, err
+	}
+
+	processed := processedRegex.FindAllStringSubmatch(match[2], -1)
+	errorCounts := errorsRegex.FindAllStringSubmatch(match[3], -1)
+	temperatures := temperatureRegex.FindAllStringSubmatch(match[4], -1)
+
+	if len(processed) != len(errorCounts) {
+		return nil, fmt.Errorf("found %d processed counters but %d error counters", len(processed), len(errorCounts))
+	}
+
+	samples := make([]Sample, len(processed))
+
+	for index := range processed {
+		samples[index].Percent = percent
+		samples[index].Processed, _ = strconv.Atoi(processed[index][1])
+		samples[index].Gflops, _ = strconv.ParseFloat(processed[index][2], 64)
+		samples[index].Errors, _ = strconv.Atoi(errorCounts[index][1])
+		samples[index].Died = errorCounts[index][2] == "(DIED!)"
+
+		if index < len(temperatures) && temperatures[index][1] != "" {
+			samples[index].Temperature, _ = strconv.Atoi(temperatures[index][1])
+		}
+	}
+
+	return samples, nil
+}
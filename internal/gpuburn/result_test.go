@@ -0,0 +1,188 @@
+package gpuburn
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+)
+
+func readTestLog(t *testing.T, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read test log %s: %v", name, err)
+	}
+
+	return string(content)
+}
+
+func TestParseResult(t *testing.T) {
+	result, err := ParseResult(readTestLog(t, "gpu-burn-ok.log"))
+	if err != nil {
+		t.Fatalf("failed to parse gpu-burn output: %v", err)
+	}
+
+	if !result.Completed {
+		t.Error("expected the run to be completed")
+	}
+
+	if len(result.GPUs) != 2 {
+		t.Fatalf("expected 2 GPUs, got %d", len(result.GPUs))
+	}
+
+	gpu := result.GPU(1)
+	if gpu == nil {
+		t.Fatal("expected a result for GPU 1")
+	}
+
+	if gpu.Model != "NVIDIA A100-SXM4-40GB" {
+		t.Errorf("unexpected model %q", gpu.Model)
+	}
+
+	if gpu.UUID != "GPU-8a9b0c1d-3e4f-4a5b-8c6d-7e8f9a0b1c2d" {
+		t.Errorf("unexpected UUID %q", gpu.UUID)
+	}
+
+	if gpu.Verdict != VerdictOK {
+		t.Errorf("expected verdict %q, got %q", VerdictOK, gpu.Verdict)
+	}
+
+	if len(gpu.Samples) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(gpu.Samples))
+	}
+
+	if gpu.Samples[2].Processed != 63928 || gpu.Samples[2].Gflops != 18261 || gpu.Samples[2].Temperature != 62 {
+		t.Errorf("unexpected last sample %+v", gpu.Samples[2])
+	}
+
+	if gpu.MinGflops != 18190 || gpu.MaxGflops != 18276 || gpu.MaxTemperature != 62 {
+		t.Errorf("unexpected aggregates %+v", gpu)
+	}
+
+	if err := result.Validate(Thresholds{MinGflops: map[string]float64{"A100": 15000}}); err != nil {
+		t.Errorf("expected the result to pass validation: %v", err)
+	}
+}
+
+func TestParseResultErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		logs string
+	}{
+		{name: "empty", logs: ""},
+		{name: "no gpu lines", logs: "ERROR No GPUs found\n"},
+		{name: "mismatched counters", logs: "10.0%  proc'd: 1 (1 Gflop/s) - 2 (2 Gflop/s)   errors: 0   temps: 40 C\n"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := ParseResult(testCase.logs); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestResultValidate(t *testing.T) {
+	testCases := []struct {
+		name               string
+		log                string
+		thresholds         Thresholds
+		expectedViolations []string
+	}{
+		{
+			name: "passing run",
+			log:  "gpu-burn-ok.log",
+			thresholds: Thresholds{
+				MinGflops:      map[string]float64{"": 1000, "A100": 18000},
+				MaxTemperature: 85,
+			},
+		},
+		{
+			name:               "throttling gpu",
+			log:                "gpu-burn-ok.log",
+			thresholds:         Thresholds{MinGflops: map[string]float64{"A100": 18300, "A100-SXM4-40GB": 18250}},
+			expectedViolations: []string{"GPU 1 (NVIDIA A100-SXM4-40GB): average 18242 Gflop/s"},
+		},
+		{
+			name:       "faulty gpu",
+			log:        "gpu-burn-faulty.log",
+			thresholds: Thresholds{MinGflops: map[string]float64{"A100": 15000}, MaxTemperature: 85},
+			expectedViolations: []string{
+				`GPU 1: verdict is "FAULTY"`,
+				"GPU 1: 7 calculation errors",
+				"GPU 1: temperature reached 91 C",
+				"GPU 1 (NVIDIA A100-SXM4-40GB): average 9059 Gflop/s",
+			},
+		},
+		{
+			name:               "incomplete run",
+			log:                "gpu-burn-incomplete.log",
+			expectedViolations: []string{"did not reach 100% progress", `GPU 0: verdict is ""`},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := ParseResult(readTestLog(t, testCase.log))
+			if err != nil {
+				t.Fatalf("failed to parse gpu-burn output: %v", err)
+			}
+
+			err = result.Validate(testCase.thresholds)
+			if len(testCase.expectedViolations) == 0 {
+				if err != nil {
+					t.Errorf("unexpected violations: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected violations, got nil")
+			}
+
+			for _, violation := range testCase.expectedViolations {
+				if !strings.Contains(err.Error(), violation) {
+					t.Errorf("expected violation %q in %q", violation, err.Error())
+				}
+			}
+
+			if testCase.log != "gpu-burn-incomplete.log" && strings.Contains(err.Error(), "GPU 0") {
+				t.Errorf("unexpected violation for the healthy GPU 0: %v", err)
+			}
+		})
+	}
+}
+
+func TestResultWriteReport(t *testing.T) {
+	result, err := ParseResult(readTestLog(t, "gpu-burn-ok.log"))
+	if err != nil {
+		t.Fatalf("failed to parse gpu-burn output: %v", err)
+	}
+
+	generalConfig := &config.GeneralConfig{ReportsDirAbsPath: t.TempDir()}
+
+	if err := result.WriteReport(generalConfig, "gpu-burn-result.json"); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+
+	content, err := os.ReadFile(generalConfig.GetReportPath("gpu-burn-result.json"))
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	var writtenResult Result
+	if err := json.Unmarshal(content, &writtenResult); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+
+	if len(writtenResult.GPUs) != 2 || writtenResult.GPUs[0].Verdict != VerdictOK {
+		t.Errorf("unexpected report content %+v", writtenResult)
+	}
+}
@@ -0,0 +1,227 @@
+package gpuburn
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ContainerName is the name of the gpu-burn container.
+	ContainerName = "gpu-burn-ctr"
+	// DefaultConfigMapName is the name of the ConfigMap holding the gpu-burn entrypoint script.
+	DefaultConfigMapName = "gpu-burn-entrypoint"
+	// PodLabelValue is the value of the "app" label set on gpu-burn pods.
+	PodLabelValue = "gpu-burn-app"
+
+	entrypointVolumeName = "entrypoint"
+	entrypointFileName   = "entrypoint.sh"
+)
+
+// Workload implements the testworkloads.Workload interface for gpu-burn.
+type Workload struct {
+	podName       string
+	image         string
+	configMapName string
+	resourceName  corev1.ResourceName
+	resourceCount int
+	withRequests  bool
+	mig           bool
+	nodeSelector  map[string]string
+	tolerations   []corev1.Toleration
+	thresholds    Thresholds
+	expectedGPUs  int
+	result        *Result
+}
+
+// NewWorkload creates a gpu-burn workload requesting a single full GPU.
+func NewWorkload(podName, image string) *Workload {
+	glog.V(gpuparams.GpuLogLevel).Infof("Creating gpu-burn workload: %s", podName)
+
+	return &Workload{
+		podName:       podName,
+		image:         image,
+		configMapName: DefaultConfigMapName,
+		resourceName:  "nvidia.com/gpu",
+		resourceCount: 1,
+		nodeSelector: map[string]string{
+			"nvidia.com/gpu.present":         "true",
+			"node-role.kubernetes.io/worker": "",
+		},
+		tolerations: []corev1.Toleration{
+			{
+				Operator: corev1.TolerationOpExists,
+			},
+			{
+				Key:      "nvidia.com/gpu",
+				Effect:   corev1.TaintEffectNoSchedule,
+				Operator: corev1.TolerationOpExists,
+			},
+		},
+		expectedGPUs: 1,
+	}
+}
+
+// WithMIG requests migCount MIG devices of the given profile instead of a full GPU.
+// For single strategy MIGs, migProfile is "gpu" resulting in "nvidia.com/gpu".
+// For other MIG profiles, migProfile is like "1g.5gb", resulting in "nvidia.com/mig-1g.5gb".
+func (w *Workload) WithMIG(migProfile string, migCount int) *Workload {
+	switch migProfile {
+	case "gpu":
+		w.resourceName = corev1.ResourceName(fmt.Sprintf("nvidia.com/%s", migProfile))
+	default:
+		w.resourceName = corev1.ResourceName(fmt.Sprintf("nvidia.com/mig-%s", migProfile))
+	}
+
+	w.resourceCount = migCount
+	w.withRequests = true
+	w.mig = true
+	w.expectedGPUs = migCount
+
+	return w
+}
+
+// WithConfigMapName sets the name of the ConfigMap holding the entrypoint script.
+func (w *Workload) WithConfigMapName(configMapName string) *Workload {
+	w.configMapName = configMapName
+	return w
+}
+
+// WithNodeSelector sets a custom node selector.
+func (w *Workload) WithNodeSelector(selector map[string]string) *Workload {
+	w.nodeSelector = selector
+	return w
+}
+
+// WithTolerations sets custom tolerations.
+func (w *Workload) WithTolerations(tolerations []corev1.Toleration) *Workload {
+	w.tolerations = tolerations
+	return w
+}
+
+// WithThresholds sets the limits the parsed gpu-burn result is validated against.
+// The Gflop/s thresholds are not applied to MIG devices.
+func (w *Workload) WithThresholds(thresholds Thresholds) *Workload {
+	w.thresholds = thresholds
+	return w
+}
+
+// Result returns the gpu-burn result parsed by CheckSuccess, or nil if it has not run yet.
+func (w *Workload) Result() *Result {
+	return w.result
+}
+
+// BuildConfigMaps returns the ConfigMap holding the gpu-burn entrypoint script.
+func (w *Workload) BuildConfigMaps() ([]*corev1.ConfigMap, error) {
+	if w.configMapName == "" {
+		return nil, fmt.Errorf("configmap name cannot be empty")
+	}
+
+	return []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: w.configMapName},
+			Data:       gpuBurnConfigMapData,
+		},
+	}, nil
+}
+
+// BuildPodSpec creates the pod specification for the gpu-burn workload.
+func (w *Workload) BuildPodSpec() (*corev1.Pod, error) {
+	glog.V(gpuparams.GpuLogLevel).Infof("Building pod spec for gpu-burn workload: %s", w.podName)
+
+	if w.podName == "" {
+		return nil, fmt.Errorf("pod name cannot be empty")
+	}
+
+	if w.image == "" {
+		return nil, fmt.Errorf("container image cannot be empty")
+	}
+
+	if w.resourceCount < 1 {
+		return nil, fmt.Errorf("gpu-burn requires at least one %s device", w.resourceName)
+	}
+
+	quantity := *resource.NewQuantity(int64(w.resourceCount), resource.DecimalSI)
+	resources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{w.resourceName: quantity},
+	}
+
+	if w.withRequests {
+		resources.Requests = corev1.ResourceList{w.resourceName: quantity}
+	}
+
+	container := testworkloads.NewUnprivilegedContainer(ContainerName, w.image, resources)
+	container.Command = []string{"/bin/" + entrypointFileName}
+	container.VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      entrypointVolumeName,
+			MountPath: "/bin/" + entrypointFileName,
+			ReadOnly:  true,
+			SubPath:   entrypointFileName,
+		},
+	}
+
+	pod := testworkloads.NewUnprivilegedPod(
+		w.podName,
+		[]corev1.Container{container},
+		w.nodeSelector,
+		w.tolerations,
+		map[string]string{"app": PodLabelValue},
+	)
+
+	pod.Spec.Volumes = []corev1.Volume{
+		{
+			Name: entrypointVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: w.configMapName},
+					DefaultMode:          ptr.To[int32](0777),
+				},
+			},
+		},
+	}
+
+	return pod, nil
+}
+
+// CheckSuccess parses the gpu-burn logs and validates them against the workload thresholds.
+// Every expected GPU, or MIG device, must have reported a verdict.
+func (w *Workload) CheckSuccess(builder *testworkloads.Builder) error {
+	glog.V(gpuparams.GpuLogLevel).Infof("Checking gpu-burn success criteria")
+
+	logs, err := builder.GetFullLogs(ContainerName)
+	if err != nil {
+		return fmt.Errorf("failed to get logs: %w", err)
+	}
+
+	w.result, err = ParseResult(logs)
+	if err != nil {
+		return err
+	}
+
+	for index := 0; index < w.expectedGPUs; index++ {
+		if w.result.GPU(index) == nil {
+			return fmt.Errorf("no gpu-burn result found for GPU %d", index)
+		}
+	}
+
+	return w.result.Validate(w.validationThresholds())
+}
+
+// validationThresholds returns the thresholds the result is validated against. gpu_burn reports MIG devices
+// under the model name of their parent GPU, whose Gflop/s thresholds do not apply to a slice of it.
+func (w *Workload) validationThresholds() Thresholds {
+	thresholds := w.thresholds
+
+	if w.mig {
+		thresholds.MinGflops = nil
+	}
+
+	return thresholds
+}
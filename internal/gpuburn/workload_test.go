@@ -0,0 +1,137 @@
+package gpuburn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWorkloadBuildPodSpec(t *testing.T) {
+	testCases := []struct {
+		name             string
+		workload         *Workload
+		expectedResource corev1.ResourceName
+		expectedCount    int64
+		expectedRequests bool
+		expectedError    bool
+	}{
+		{
+			name:             "full gpu",
+			workload:         NewWorkload("gpu-burn-pod", "gpu-burn:latest"),
+			expectedResource: "nvidia.com/gpu",
+			expectedCount:    1,
+		},
+		{
+			name:             "single strategy mig",
+			workload:         NewWorkload("gpu-burn-pod", "gpu-burn:latest").WithMIG("gpu", 7),
+			expectedResource: "nvidia.com/gpu",
+			expectedCount:    7,
+			expectedRequests: true,
+		},
+		{
+			name:             "mixed strategy mig",
+			workload:         NewWorkload("gpu-burn-pod", "gpu-burn:latest").WithMIG("1g.5gb", 2),
+			expectedResource: "nvidia.com/mig-1g.5gb",
+			expectedCount:    2,
+			expectedRequests: true,
+		},
+		{
+			name:          "empty image",
+			workload:      NewWorkload("gpu-burn-pod", ""),
+			expectedError: true,
+		},
+		{
+			name:          "no mig devices",
+			workload:      NewWorkload("gpu-burn-pod", "gpu-burn:latest").WithMIG("1g.5gb", 0),
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pod, err := testCase.workload.BuildPodSpec()
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			container := pod.Spec.Containers[0]
+			limit := container.Resources.Limits[testCase.expectedResource]
+
+			if limit.Value() != testCase.expectedCount {
+				t.Errorf("expected %d %s, got %s", testCase.expectedCount, testCase.expectedResource, limit.String())
+			}
+
+			if _, ok := container.Resources.Requests[testCase.expectedResource]; ok != testCase.expectedRequests {
+				t.Errorf("expected requests to be set: %v", testCase.expectedRequests)
+			}
+
+			if pod.Spec.Volumes[0].ConfigMap.Name != DefaultConfigMapName {
+				t.Errorf("expected the entrypoint volume to use configmap %s", DefaultConfigMapName)
+			}
+
+			if pod.Labels["app"] != PodLabelValue {
+				t.Errorf("expected label app=%s, got %v", PodLabelValue, pod.Labels)
+			}
+		})
+	}
+}
+
+func TestWorkloadConfigMapLifecycle(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+	workload := NewWorkload("gpu-burn-pod", "gpu-burn:latest").WithConfigMapName("custom-entrypoint")
+
+	builder := testworkloads.NewBuilder(apiClient, "test-gpu-burn", workload).Create()
+	if err := builder.Error(); err != nil {
+		t.Fatalf("failed to create the gpu-burn workload: %v", err)
+	}
+
+	configMap, err := apiClient.ConfigMaps("test-gpu-burn").Get(context.TODO(), "custom-entrypoint", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the entrypoint configmap to be created: %v", err)
+	}
+
+	if configMap.Data[entrypointFileName] == "" {
+		t.Error("expected the entrypoint configmap to hold the entrypoint script")
+	}
+
+	if err := builder.Delete(); err != nil {
+		t.Fatalf("failed to delete the gpu-burn workload: %v", err)
+	}
+
+	_, err = apiClient.ConfigMaps("test-gpu-burn").Get(context.TODO(), "custom-entrypoint", metav1.GetOptions{})
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the entrypoint configmap to be deleted, got %v", err)
+	}
+}
+
+func TestWorkloadValidationThresholds(t *testing.T) {
+	thresholds := Thresholds{MinGflops: map[string]float64{"A100": 15000}, MaxErrors: 1, MaxTemperature: 90}
+
+	fullGPU := NewWorkload("gpu-burn-pod", "gpu-burn:latest").WithThresholds(thresholds).validationThresholds()
+	if fullGPU.MinGflops["A100"] != 15000 {
+		t.Errorf("expected the Gflop/s thresholds to apply to full GPUs, got %v", fullGPU.MinGflops)
+	}
+
+	mig := NewWorkload("gpu-burn-pod", "gpu-burn:latest").WithMIG("1g.5gb", 2).WithThresholds(thresholds).
+		validationThresholds()
+	if len(mig.MinGflops) != 0 || mig.MaxErrors != 1 || mig.MaxTemperature != 90 {
+		t.Errorf("expected only the Gflop/s thresholds to be dropped for MIG devices, got %+v", mig)
+	}
+
+	if len(thresholds.MinGflops) != 1 {
+		t.Errorf("expected the configured thresholds to be left unchanged, got %v", thresholds.MinGflops)
+	}
+}
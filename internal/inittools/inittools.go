@@ -35,6 +35,14 @@ func init() {
 	_ = flag.Lookup("logtostderr").Value.Set("true")
 	_ = flag.Lookup("v").Value.Set(GeneralConfig.VerboseLevel)
 
+	if GeneralConfig.FakeCluster {
+		glog.V(100).Infof("FAKE_CLUSTER is set, using fake clients instead of a live API server")
+
+		APIClient = clients.GetTestClients(clients.TestClientParams{})
+
+		return
+	}
+
 	if APIClient = clients.New(""); APIClient == nil {
 		if GeneralConfig.DryRun {
 			return
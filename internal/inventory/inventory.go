@@ -0,0 +1,256 @@
+package inventory
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nodes"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiasmi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// ReportFile is the name of the JSON inventory report.
+	ReportFile = "gpu-inventory.json"
+	// JUnitReportFile is the name of the JUnit inventory report, the inventory is in the properties of its suite.
+	JUnitReportFile = "gpu-inventory_junit.xml"
+)
+
+// labelPrefixes are the prefixes of the GPU feature discovery and NFD PCI node labels kept in the inventory.
+var labelPrefixes = []string{
+	"nvidia.com/gpu.",
+	"nvidia.com/cuda.",
+	"nvidia.com/mig.",
+	"feature.node.kubernetes.io/pci-10de.",
+}
+
+// Inventory is the GPU hardware of the nodes a suite ran on.
+type Inventory struct {
+	Nodes []Node `json:"nodes"`
+}
+
+// Node is the GPU hardware of a node, from its labels and from nvidia-smi in its driver pod.
+type Node struct {
+	Name          string            `json:"name"`
+	Labels        map[string]string `json:"labels"`
+	DriverVersion string            `json:"driverVersion,omitempty"`
+	CUDAVersion   string            `json:"cudaVersion,omitempty"`
+	GPUs          []GPU             `json:"gpus,omitempty"`
+	// Error is why nvidia-smi could not be queried on the node, the labels are reported anyway.
+	Error string `json:"error,omitempty"`
+}
+
+// GPU is a GPU of a node as reported by nvidia-smi.
+type GPU struct {
+	UUID                string `json:"uuid"`
+	Product             string `json:"product"`
+	Architecture        string `json:"architecture,omitempty"`
+	PCIBusID            string `json:"pciBusId"`
+	PCIDeviceID         string `json:"pciDeviceId"`
+	VBIOSVersion        string `json:"vbiosVersion,omitempty"`
+	MemoryTotal         string `json:"memoryTotal,omitempty"`
+	MIGMode             string `json:"migMode,omitempty"`
+	MIGDevices          int    `json:"migDevices,omitempty"`
+	ECCMode             string `json:"eccMode,omitempty"`
+	CorrectableErrors   int64  `json:"correctableErrors"`
+	UncorrectableErrors int64  `json:"uncorrectableErrors"`
+	RetiredPages        int64  `json:"retiredPages"`
+	PendingRetirement   bool   `json:"pendingRetirement,omitempty"`
+	PendingRowRemapping bool   `json:"pendingRowRemapping,omitempty"`
+	PowerLimit          string `json:"powerLimit,omitempty"`
+	MaxGraphicsClock    string `json:"maxGraphicsClock,omitempty"`
+	MaxMemoryClock      string `json:"maxMemoryClock,omitempty"`
+}
+
+// Collect returns the inventory of the nodes matching nodeSelector. A node whose driver pod cannot run nvidia-smi is
+// reported with its labels and the error.
+func Collect(apiClient *clients.Settings, nodeSelector map[string]string) (*Inventory, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("cannot collect the GPU inventory with nil apiClient")
+	}
+
+	nodeBuilders, err := nodes.List(apiClient, metav1.ListOptions{LabelSelector: labels.Set(nodeSelector).String()})
+	if err != nil {
+		return nil, fmt.Errorf("error listing GPU nodes: %w", err)
+	}
+
+	inventory := &Inventory{}
+
+	for _, nodeBuilder := range nodeBuilders {
+		log, err := nvidiasmi.QueryNode(apiClient, nodeBuilder.Object.Name)
+		if err != nil {
+			glog.V(gpuparams.GpuLogLevel).Infof("Error querying nvidia-smi on node %s: %v", nodeBuilder.Object.Name,
+				err)
+		}
+
+		inventory.Nodes = append(inventory.Nodes, NewNode(nodeBuilder.Object, log, err))
+	}
+
+	return inventory, nil
+}
+
+// NewNode returns the inventory of the node from its labels and its nvidia-smi -q -x output, or the error of the
+// nvidia-smi query.
+func NewNode(node *corev1.Node, log *nvidiasmi.Log, queryErr error) Node {
+	inventoryNode := Node{Name: node.Name, Labels: map[string]string{}}
+
+	for key, value := range node.Labels {
+		if slices.ContainsFunc(labelPrefixes, func(prefix string) bool { return strings.HasPrefix(key, prefix) }) {
+			inventoryNode.Labels[key] = value
+		}
+	}
+
+	if queryErr != nil {
+		inventoryNode.Error = queryErr.Error()
+
+		return inventoryNode
+	}
+
+	if log == nil {
+		return inventoryNode
+	}
+
+	inventoryNode.DriverVersion = log.DriverVersion
+	inventoryNode.CUDAVersion = log.CUDAVersion
+
+	for _, gpu := range log.GPUs {
+		inventoryNode.GPUs = append(inventoryNode.GPUs, GPU{
+			UUID:                gpu.UUID,
+			Product:             gpu.ProductName,
+			Architecture:        gpu.ProductArchitecture,
+			PCIBusID:            gpu.PCI.BusID,
+			PCIDeviceID:         gpu.PCI.DeviceID,
+			VBIOSVersion:        gpu.VBIOSVersion,
+			MemoryTotal:         string(gpu.FBMemoryUsage.Total),
+			MIGMode:             string(gpu.MIGMode.Current),
+			MIGDevices:          len(gpu.MIGDevices),
+			ECCMode:             string(gpu.ECCMode.Current),
+			CorrectableErrors:   gpu.ECCErrors.Aggregate.Correctable(),
+			UncorrectableErrors: gpu.ECCErrors.Aggregate.Uncorrectable(),
+			RetiredPages:        gpu.RetiredPages.Count(),
+			PendingRetirement:   gpu.RetiredPages.Pending(),
+			PendingRowRemapping: gpu.RemappedRows.Pending == "Yes",
+			PowerLimit:          string(gpu.Power().Limit()),
+			MaxGraphicsClock:    string(gpu.MaxClocks.Graphics),
+			MaxMemoryClock:      string(gpu.MaxClocks.Memory),
+		})
+	}
+
+	return inventoryNode
+}
+
+// JUnitProperties returns the inventory as properties named after the node, e.g. worker-0.nvidia.com/gpu.product
+// for a label and worker-0.gpu0.product for a GPU.
+func (inventory *Inventory) JUnitProperties() []reporters.JUnitProperty {
+	var properties []reporters.JUnitProperty
+
+	for _, node := range inventory.Nodes {
+		var nodeProperties []reporters.JUnitProperty
+
+		addProperty := func(name, value string) {
+			if value != "" {
+				nodeProperties = append(nodeProperties,
+					reporters.JUnitProperty{Name: node.Name + "." + name, Value: value})
+			}
+		}
+
+		for key, value := range node.Labels {
+			addProperty(key, value)
+		}
+
+		addProperty("driverVersion", node.DriverVersion)
+		addProperty("cudaVersion", node.CUDAVersion)
+		addProperty("error", node.Error)
+
+		for index, gpu := range node.GPUs {
+			prefix := fmt.Sprintf("gpu%d.", index)
+			addProperty(prefix+"uuid", gpu.UUID)
+			addProperty(prefix+"product", gpu.Product)
+			addProperty(prefix+"architecture", gpu.Architecture)
+			addProperty(prefix+"pciBusId", gpu.PCIBusID)
+			addProperty(prefix+"pciDeviceId", gpu.PCIDeviceID)
+			addProperty(prefix+"memoryTotal", gpu.MemoryTotal)
+			addProperty(prefix+"migMode", gpu.MIGMode)
+			addProperty(prefix+"eccMode", gpu.ECCMode)
+			addProperty(prefix+"correctableErrors", strconv.FormatInt(gpu.CorrectableErrors, 10))
+			addProperty(prefix+"uncorrectableErrors", strconv.FormatInt(gpu.UncorrectableErrors, 10))
+			addProperty(prefix+"retiredPages", strconv.FormatInt(gpu.RetiredPages, 10))
+			addProperty(prefix+"powerLimit", gpu.PowerLimit)
+		}
+
+		slices.SortFunc(nodeProperties, func(a, b reporters.JUnitProperty) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+
+		properties = append(properties, nodeProperties...)
+	}
+
+	return properties
+}
+
+// String returns the inventory as indented JSON, so it reads as the JSON report when attached to a spec report.
+func (inventory *Inventory) String() string {
+	content, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("failed to marshal GPU inventory: %v", err)
+	}
+
+	return string(content)
+}
+
+// WriteReport stores the inventory as a JSON artifact in the reports directory.
+func (inventory *Inventory) WriteReport(generalConfig *config.GeneralConfig, fileName string) error {
+	content, err := json.MarshalIndent(inventory, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal GPU inventory: %w", err)
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Writing GPU inventory to %s", generalConfig.GetReportPath(fileName))
+
+	return generalConfig.WriteReport(fileName, content)
+}
+
+// WriteJUnitReport stores the inventory as the properties of a JUnit suite in the reports directory, with a test case
+// per node, so it shows up next to the test results.
+func (inventory *Inventory) WriteJUnitReport(generalConfig *config.GeneralConfig, fileName string) error {
+	suite := reporters.JUnitTestSuite{
+		Name:       "GPU inventory",
+		Timestamp:  time.Now().Format("2006-01-02T15:04:05"),
+		Properties: reporters.JUnitProperties{Properties: inventory.JUnitProperties()},
+	}
+
+	for _, node := range inventory.Nodes {
+		suite.TestCases = append(suite.TestCases, reporters.JUnitTestCase{
+			Name:      fmt.Sprintf("GPU inventory of node %s", node.Name),
+			Classname: suite.Name,
+			Status:    "passed",
+			SystemErr: node.Error,
+		})
+	}
+
+	suite.Tests = len(suite.TestCases)
+
+	suites := reporters.JUnitTestSuites{Tests: suite.Tests, TestSuites: []reporters.JUnitTestSuite{suite}}
+
+	content, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal GPU inventory JUnit report: %w", err)
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Writing GPU inventory JUnit report to %s",
+		generalConfig.GetReportPath(fileName))
+
+	return generalConfig.WriteReport(fileName, append([]byte(xml.Header), content...))
+}
@@ -0,0 +1,149 @@
+package inventory
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/reporters"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiasmi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newTestInventory returns an inventory of the H100 nvidia-smi output shared with the nvidiasmi package.
+func newTestInventory(t *testing.T) *Inventory {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("..", "..", "pkg", "nvidiasmi", "testdata", "nvidia-smi-q-x-h100.xml"))
+	if err != nil {
+		t.Fatalf("failed to read test output: %v", err)
+	}
+
+	log, err := nvidiasmi.ParseQuery(string(content))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi output: %v", err)
+	}
+
+	newNode := func(name string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{
+			"nvidia.com/gpu.product":                      "NVIDIA-H100-80GB-HBM3",
+			"nvidia.com/gpu.memory":                       "81559",
+			"nvidia.com/gpu.count":                        "2",
+			"nvidia.com/cuda.driver.major":                "550",
+			"feature.node.kubernetes.io/pci-10de.present": "true",
+			"kubernetes.io/hostname":                      name,
+			"node-role.kubernetes.io/worker":              "",
+		}}}
+	}
+
+	return &Inventory{Nodes: []Node{
+		NewNode(newNode("worker-gpu-0"), log, nil),
+		NewNode(newNode("worker-gpu-1"), nil, errors.New("no running driver pod found on node worker-gpu-1")),
+	}}
+}
+
+func TestNewNode(t *testing.T) {
+	inventory := newTestInventory(t)
+
+	node := inventory.Nodes[0]
+	if len(node.Labels) != 5 || node.Labels["nvidia.com/gpu.product"] != "NVIDIA-H100-80GB-HBM3" {
+		t.Errorf("expected the 5 GPU labels, got %v", node.Labels)
+	}
+
+	if node.DriverVersion != "550.90.07" || node.CUDAVersion != "12.4" || len(node.GPUs) != 2 {
+		t.Fatalf("unexpected node inventory %+v", node)
+	}
+
+	expected := GPU{
+		UUID:                "GPU-7f6e5d4c-3b2a-1908-f7e6-d5c4b3a29180",
+		Product:             "NVIDIA H100 80GB HBM3",
+		Architecture:        "Hopper",
+		PCIBusID:            "00000000:43:00.0",
+		PCIDeviceID:         "233010DE",
+		VBIOSVersion:        "96.00.89.00.01",
+		MemoryTotal:         "81559 MiB",
+		MIGMode:             "Disabled",
+		ECCMode:             "Enabled",
+		UncorrectableErrors: 1,
+		PendingRowRemapping: true,
+		PowerLimit:          "700.00 W",
+		MaxGraphicsClock:    "1980 MHz",
+		MaxMemoryClock:      "2619 MHz",
+	}
+	if node.GPUs[1] != expected {
+		t.Errorf("expected GPU %+v, got %+v", expected, node.GPUs[1])
+	}
+
+	if failedNode := inventory.Nodes[1]; failedNode.Error == "" || len(failedNode.GPUs) != 0 ||
+		len(failedNode.Labels) != 5 {
+		t.Errorf("expected the labels and the error of the node without driver pod, got %+v", failedNode)
+	}
+}
+
+func TestInventoryWriteReports(t *testing.T) {
+	inventory := newTestInventory(t)
+	generalConfig := &config.GeneralConfig{ReportsDirAbsPath: t.TempDir()}
+
+	if err := inventory.WriteReport(generalConfig, ReportFile); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+
+	content, err := os.ReadFile(generalConfig.GetReportPath(ReportFile))
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	var writtenInventory Inventory
+	if err := json.Unmarshal(content, &writtenInventory); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+
+	if len(writtenInventory.Nodes) != 2 || writtenInventory.Nodes[0].GPUs[0].PCIBusID != "00000000:1B:00.0" {
+		t.Errorf("unexpected report content %+v", writtenInventory)
+	}
+
+	if inventory.String() != string(content) {
+		t.Errorf("expected the inventory string to match the JSON report, got %s", inventory.String())
+	}
+
+	if err := inventory.WriteJUnitReport(generalConfig, JUnitReportFile); err != nil {
+		t.Fatalf("failed to write JUnit report: %v", err)
+	}
+
+	content, err = os.ReadFile(generalConfig.GetReportPath(JUnitReportFile))
+	if err != nil {
+		t.Fatalf("failed to read JUnit report: %v", err)
+	}
+
+	var suites reporters.JUnitTestSuites
+	if err := xml.Unmarshal(content, &suites); err != nil {
+		t.Fatalf("failed to unmarshal JUnit report: %v", err)
+	}
+
+	if len(suites.TestSuites) != 1 || len(suites.TestSuites[0].TestCases) != 2 {
+		t.Fatalf("expected a suite with a test case per node, got %+v", suites)
+	}
+
+	properties := suites.TestSuites[0].Properties
+	expectedProperties := map[string]string{
+		"worker-gpu-0.nvidia.com/gpu.product":   "NVIDIA-H100-80GB-HBM3",
+		"worker-gpu-0.driverVersion":            "550.90.07",
+		"worker-gpu-0.gpu1.pciBusId":            "00000000:43:00.0",
+		"worker-gpu-0.gpu1.uncorrectableErrors": "1",
+		"worker-gpu-1.error":                    "no running driver pod found on node worker-gpu-1",
+	}
+	for name, value := range expectedProperties {
+		if properties.WithName(name) != value {
+			t.Errorf("expected property %s=%q, got %q", name, value, properties.WithName(name))
+		}
+	}
+
+	if properties.WithName("worker-gpu-0.kubernetes.io/hostname") != "" {
+		t.Error("expected the labels unrelated to the GPU to be left out")
+	}
+}
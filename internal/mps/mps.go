@@ -11,15 +11,12 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
 
 var (
-	isFalse             bool = false
-	isTrue              bool = true
-	workerConfigMapName      = "mps-test-entrypoint"
+	workerConfigMapName = "mps-test-entrypoint"
 
 	// WorkerPodConfigMapData contains the entrypoint script for worker pods
 	WorkerPodConfigMapData = map[string]string{
@@ -28,15 +25,6 @@ set -e
 
 echo "Starting MPS worker pod..."
 
-# Check GPU availability
-NUM_GPUS=$(nvidia-smi -L | wc -l)
-if [ $NUM_GPUS -eq 0 ]; then
-  echo "ERROR: No GPUs found"
-  exit 1
-fi
-
-echo "Found $NUM_GPUS GPUs"
-
 # Set MPS environment variables
 export CUDA_VISIBLE_DEVICES=0
 export CUDA_DEVICE_ORDER=PCI_BUS_ID
@@ -99,81 +87,14 @@ func CreateWorkerPodConfigMap(apiClient *clients.Settings,
 // CreateMPSTestPod returns a Pod configured for MPS testing.
 func CreateMPSTestPod(apiClient *clients.Settings, podName, podNamespace string,
 	mpsTestImage string) (*corev1.Pod, error) {
-	var volumeDefaultMode int32 = 0777
-
-	configMapVolumeSource := &corev1.ConfigMapVolumeSource{}
-	configMapVolumeSource.Name = workerConfigMapName
-	configMapVolumeSource.DefaultMode = &volumeDefaultMode
-
-	return &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      podName,
-			Namespace: podNamespace,
-			Labels: map[string]string{
-				"app": "mps-test-app",
-			},
-		},
-		Spec: corev1.PodSpec{
-			RestartPolicy: corev1.RestartPolicyNever,
-			SecurityContext: &corev1.PodSecurityContext{
-				RunAsNonRoot:   &isTrue,
-				SeccompProfile: &corev1.SeccompProfile{Type: "RuntimeDefault"},
-			},
-			Tolerations: []corev1.Toleration{
-				{
-					Operator: corev1.TolerationOpExists,
-				},
-				{
-					Key:      "nvidia.com/gpu",
-					Effect:   corev1.TaintEffectNoSchedule,
-					Operator: corev1.TolerationOpExists,
-				},
-			},
-			Containers: []corev1.Container{
-				{
-					Image:           mpsTestImage,
-					ImagePullPolicy: corev1.PullAlways,
-					SecurityContext: &corev1.SecurityContext{
-						AllowPrivilegeEscalation: &isFalse,
-						Capabilities: &corev1.Capabilities{
-							Drop: []corev1.Capability{
-								"ALL",
-							},
-						},
-					},
-					Name: "mps-test-ctr",
-					Command: []string{
-						"/bin/entrypoint.sh",
-					},
-					Resources: corev1.ResourceRequirements{
-						Limits: corev1.ResourceList{
-							"nvidia.com/gpu": resource.MustParse("1"),
-						},
-					},
-					VolumeMounts: []corev1.VolumeMount{
-						{
-							Name:      "entrypoint",
-							MountPath: "/bin/entrypoint.sh",
-							ReadOnly:  true,
-							SubPath:   "entrypoint.sh",
-						},
-					},
-				},
-			},
-			Volumes: []corev1.Volume{
-				{
-					Name: "entrypoint",
-					VolumeSource: corev1.VolumeSource{
-						ConfigMap: configMapVolumeSource,
-					},
-				},
-			},
-			NodeSelector: map[string]string{
-				"nvidia.com/gpu.present":         "true",
-				"node-role.kubernetes.io/worker": "",
-			},
-		},
-	}, nil
+	mpsTestPod, err := NewWorkload(podName, mpsTestImage).BuildPodSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	mpsTestPod.Namespace = podNamespace
+
+	return mpsTestPod, nil
 }
 
 // CreateDevicePluginConfigMap creates a ConfigMap with the device plugin configuration for MPS
@@ -0,0 +1,151 @@
+package mps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ContainerName is the name of the MPS worker container.
+	ContainerName = "mps-test-ctr"
+	// PodLabelValue is the value of the "app" label set on MPS worker pods.
+	PodLabelValue = "mps-test-app"
+	// SuccessIndicator is printed by the worker entrypoint once all iterations completed.
+	SuccessIndicator = "Continuous workload completed"
+)
+
+// Workload implements the testworkloads.Workload interface for an MPS worker pod.
+type Workload struct {
+	podName      string
+	image        string
+	resources    corev1.ResourceRequirements
+	nodeSelector map[string]string
+	tolerations  []corev1.Toleration
+}
+
+// NewWorkload creates an MPS worker workload requesting a single, possibly shared, GPU.
+func NewWorkload(podName, image string) *Workload {
+	glog.V(gpuparams.GpuLogLevel).Infof("Creating MPS workload: %s", podName)
+
+	return &Workload{
+		podName: podName,
+		image:   image,
+		resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				"nvidia.com/gpu": resource.MustParse("1"),
+			},
+		},
+		nodeSelector: map[string]string{
+			"nvidia.com/gpu.present":         "true",
+			"node-role.kubernetes.io/worker": "",
+		},
+		tolerations: []corev1.Toleration{
+			{
+				Operator: corev1.TolerationOpExists,
+			},
+			{
+				Key:      "nvidia.com/gpu",
+				Effect:   corev1.TaintEffectNoSchedule,
+				Operator: corev1.TolerationOpExists,
+			},
+		},
+	}
+}
+
+// WithResources sets custom resource requirements, e.g. a renamed "nvidia.com/gpu.shared" resource.
+func (w *Workload) WithResources(resources corev1.ResourceRequirements) *Workload {
+	w.resources = resources
+	return w
+}
+
+// WithNodeSelector sets a custom node selector.
+func (w *Workload) WithNodeSelector(selector map[string]string) *Workload {
+	w.nodeSelector = selector
+	return w
+}
+
+// WithTolerations sets custom tolerations.
+func (w *Workload) WithTolerations(tolerations []corev1.Toleration) *Workload {
+	w.tolerations = tolerations
+	return w
+}
+
+// BuildConfigMaps returns the ConfigMap holding the MPS worker entrypoint script.
+func (w *Workload) BuildConfigMaps() ([]*corev1.ConfigMap, error) {
+	return []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: workerConfigMapName},
+			Data:       WorkerPodConfigMapData,
+		},
+	}, nil
+}
+
+// BuildPodSpec creates the pod specification for the MPS worker workload.
+func (w *Workload) BuildPodSpec() (*corev1.Pod, error) {
+	glog.V(gpuparams.GpuLogLevel).Infof("Building pod spec for MPS workload: %s", w.podName)
+
+	if w.podName == "" {
+		return nil, fmt.Errorf("pod name cannot be empty")
+	}
+
+	if w.image == "" {
+		return nil, fmt.Errorf("container image cannot be empty")
+	}
+
+	container := testworkloads.NewUnprivilegedContainer(ContainerName, w.image, w.resources)
+	container.Command = []string{"/bin/entrypoint.sh"}
+	container.VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      "entrypoint",
+			MountPath: "/bin/entrypoint.sh",
+			ReadOnly:  true,
+			SubPath:   "entrypoint.sh",
+		},
+	}
+
+	pod := testworkloads.NewUnprivilegedPod(
+		w.podName,
+		[]corev1.Container{container},
+		w.nodeSelector,
+		w.tolerations,
+		map[string]string{"app": PodLabelValue},
+	)
+
+	pod.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "entrypoint",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: workerConfigMapName},
+					DefaultMode:          ptr.To[int32](0777),
+				},
+			},
+		},
+	}
+
+	return pod, nil
+}
+
+// CheckSuccess validates that the worker ran all its iterations on the GPU.
+func (w *Workload) CheckSuccess(builder *testworkloads.Builder) error {
+	glog.V(gpuparams.GpuLogLevel).Infof("Checking MPS worker success criteria")
+
+	logs, err := builder.GetFullLogs(ContainerName)
+	if err != nil {
+		return fmt.Errorf("failed to get logs: %w", err)
+	}
+
+	if !strings.Contains(logs, SuccessIndicator) {
+		return fmt.Errorf("logs do not contain success indicator '%s'", SuccessIndicator)
+	}
+
+	return nil
+}
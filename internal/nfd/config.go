@@ -1,15 +1,27 @@
 package nfd
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/kelseyhightower/envconfig"
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
 )
 
-// NFDConfig contains only the fallback catalog source index image for NFD.
+// NFDConfig contains the fallback catalog source settings for NFD.
 type NFDConfig struct {
-	FallbackCatalogSourceIndexImage string `envconfig:"NFD_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	FallbackCatalogSourceIndexImage            string            `envconfig:"NFD_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	FallbackCatalogSourcePriority              int               `envconfig:"NFD_FALLBACK_CATALOGSOURCE_PRIORITY"`
+	FallbackCatalogSourceRegistryPollInterval  time.Duration     `envconfig:"NFD_FALLBACK_CATALOGSOURCE_REGISTRY_POLL_INTERVAL"`
+	FallbackCatalogSourceNodeSelector          map[string]string `envconfig:"NFD_FALLBACK_CATALOGSOURCE_NODE_SELECTOR"`
+	FallbackCatalogSourceTolerations           string            `envconfig:"NFD_FALLBACK_CATALOGSOURCE_TOLERATIONS"`
+	FallbackCatalogSourceSecurityContextConfig string            `envconfig:"NFD_FALLBACK_CATALOGSOURCE_SECURITY_CONTEXT_CONFIG"`
+	FallbackCatalogSourceFBCDir                string            `envconfig:"NFD_FALLBACK_CATALOGSOURCE_FBC_DIR"`
+	FallbackCatalogSourceFBCCacheDir           string            `envconfig:"NFD_FALLBACK_CATALOGSOURCE_FBC_CACHE_DIR"`
+	FallbackCatalogSourcePackageTimeout        time.Duration     `envconfig:"NFD_FALLBACK_CATALOGSOURCE_PACKAGE_TIMEOUT"`
 }
 
 // NewNFDConfig attempts to load NFDConfig from the environment.
@@ -25,3 +37,24 @@ func NewNFDConfig() (*NFDConfig, error) {
 	glog.V(100).Info("NFDConfig created successfully")
 	return cfg, nil
 }
+
+// FallbackCatalogSourceOptions returns the options of the fallback catalogsource. The tolerations are read as a JSON
+// list of corev1.Toleration.
+func (cfg *NFDConfig) FallbackCatalogSourceOptions() (olm.CatalogSourceOptions, error) {
+	options := olm.CatalogSourceOptions{
+		Priority:                 cfg.FallbackCatalogSourcePriority,
+		RegistryPollInterval:     cfg.FallbackCatalogSourceRegistryPollInterval,
+		NodeSelector:             cfg.FallbackCatalogSourceNodeSelector,
+		SecurityContextConfig:    oplmV1alpha1.SecurityConfig(cfg.FallbackCatalogSourceSecurityContextConfig),
+		FileBasedCatalogDir:      cfg.FallbackCatalogSourceFBCDir,
+		FileBasedCatalogCacheDir: cfg.FallbackCatalogSourceFBCCacheDir,
+	}
+
+	if cfg.FallbackCatalogSourceTolerations != "" {
+		if err := json.Unmarshal([]byte(cfg.FallbackCatalogSourceTolerations), &options.Tolerations); err != nil {
+			return options, fmt.Errorf("invalid NFD_FALLBACK_CATALOGSOURCE_TOLERATIONS: %w", err)
+		}
+	}
+
+	return options, nil
+}
@@ -1,21 +1,41 @@
 package nvidiagpuconfig
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"github.com/golang/glog"
 	"github.com/kelseyhightower/envconfig"
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuburn"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
 )
 
 // NvidiaGPUConfig contains environment information related to nvidiagpu tests.
 type NvidiaGPUConfig struct {
-	InstanceType                       string `envconfig:"NVIDIAGPU_GPU_MACHINESET_INSTANCE_TYPE"`
-	CatalogSource                      string `envconfig:"NVIDIAGPU_CATALOGSOURCE"`
-	SubscriptionChannel                string `envconfig:"NVIDIAGPU_SUBSCRIPTION_CHANNEL"`
-	CleanupAfterTest                   bool   `envconfig:"NVIDIAGPU_CLEANUP" default:"true"`
-	DeployFromBundle                   bool   `envconfig:"NVIDIAGPU_DEPLOY_FROM_BUNDLE" default:"false"`
-	BundleImage                        string `envconfig:"NVIDIAGPU_BUNDLE_IMAGE"`
-	OperatorUpgradeToChannel           string `envconfig:"NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL"`
-	GPUFallbackCatalogsourceIndexImage string `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
-	ClusterPolicyPatch                 string `envconfig:"NVIDIAGPU_GPU_CLUSTER_POLICY_PATCH"`
+	InstanceType                                  string             `envconfig:"NVIDIAGPU_GPU_MACHINESET_INSTANCE_TYPE"`
+	CatalogSource                                 string             `envconfig:"NVIDIAGPU_CATALOGSOURCE"`
+	SubscriptionChannel                           string             `envconfig:"NVIDIAGPU_SUBSCRIPTION_CHANNEL"`
+	CleanupAfterTest                              bool               `envconfig:"NVIDIAGPU_CLEANUP" default:"true"`
+	DeployFromBundle                              bool               `envconfig:"NVIDIAGPU_DEPLOY_FROM_BUNDLE" default:"false"`
+	BundleImage                                   string             `envconfig:"NVIDIAGPU_BUNDLE_IMAGE"`
+	BundleRegistryImage                           string             `envconfig:"NVIDIAGPU_BUNDLE_REGISTRY_IMAGE"`
+	InstallWithOLMv1                              bool               `envconfig:"NVIDIAGPU_INSTALL_WITH_OLMV1" default:"false"`
+	ClusterCatalog                                string             `envconfig:"NVIDIAGPU_CLUSTERCATALOG"`
+	OperatorUpgradeToChannel                      string             `envconfig:"NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL"`
+	GPUFallbackCatalogsourceIndexImage            string             `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	GPUFallbackCatalogsourcePriority              int                `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_PRIORITY"`
+	GPUFallbackCatalogsourceRegistryPollInterval  time.Duration      `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_REGISTRY_POLL_INTERVAL"`
+	GPUFallbackCatalogsourceNodeSelector          map[string]string  `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_NODE_SELECTOR"`
+	GPUFallbackCatalogsourceTolerations           string             `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_TOLERATIONS"`
+	GPUFallbackCatalogsourceSecurityContextConfig string             `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_SECURITY_CONTEXT_CONFIG"`
+	GPUFallbackCatalogsourceFBCDir                string             `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_FBC_DIR"`
+	GPUFallbackCatalogsourceFBCCacheDir           string             `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_FBC_CACHE_DIR"`
+	GPUFallbackCatalogsourcePackageTimeout        time.Duration      `envconfig:"NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_PACKAGE_TIMEOUT"`
+	ClusterPolicyPatch                            string             `envconfig:"NVIDIAGPU_GPU_CLUSTER_POLICY_PATCH"`
+	GPUBurnMinGflops                              map[string]float64 `envconfig:"NVIDIAGPU_GPU_BURN_MIN_GFLOPS"`
+	GPUBurnMaxTemperature                         int                `envconfig:"NVIDIAGPU_GPU_BURN_MAX_TEMPERATURE"`
 }
 
 // NewNvidiaGPUConfig returns an instance of NvidiaGPUConfig.
@@ -33,3 +53,32 @@ func NewNvidiaGPUConfig() *NvidiaGPUConfig {
 	log.Info("NvidiaGPUConfig created successfully")
 	return cfg
 }
+
+// GPUBurnThresholds returns the limits gpu-burn results are validated against.
+func (cfg *NvidiaGPUConfig) GPUBurnThresholds() gpuburn.Thresholds {
+	return gpuburn.Thresholds{
+		MinGflops:      cfg.GPUBurnMinGflops,
+		MaxTemperature: cfg.GPUBurnMaxTemperature,
+	}
+}
+
+// GPUFallbackCatalogSourceOptions returns the options of the fallback catalogsource. The tolerations are read as a JSON
+// list of corev1.Toleration.
+func (cfg *NvidiaGPUConfig) GPUFallbackCatalogSourceOptions() (olm.CatalogSourceOptions, error) {
+	options := olm.CatalogSourceOptions{
+		Priority:                 cfg.GPUFallbackCatalogsourcePriority,
+		RegistryPollInterval:     cfg.GPUFallbackCatalogsourceRegistryPollInterval,
+		NodeSelector:             cfg.GPUFallbackCatalogsourceNodeSelector,
+		SecurityContextConfig:    oplmV1alpha1.SecurityConfig(cfg.GPUFallbackCatalogsourceSecurityContextConfig),
+		FileBasedCatalogDir:      cfg.GPUFallbackCatalogsourceFBCDir,
+		FileBasedCatalogCacheDir: cfg.GPUFallbackCatalogsourceFBCCacheDir,
+	}
+
+	if cfg.GPUFallbackCatalogsourceTolerations != "" {
+		if err := json.Unmarshal([]byte(cfg.GPUFallbackCatalogsourceTolerations), &options.Tolerations); err != nil {
+			return options, fmt.Errorf("invalid NVIDIAGPU_GPU_FALLBACK_CATALOGSOURCE_TOLERATIONS: %w", err)
+		}
+	}
+
+	return options, nil
+}
@@ -1,40 +1,62 @@
 package nvidianetworkconfig
 
 import (
-	"github.com/golang/glog"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/golang/glog"
 	"github.com/kelseyhightower/envconfig"
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
 )
 
 // NvidiaNetworkConfig contains environment information related to nvidianetwork tests.
 type NvidiaNetworkConfig struct {
-	CatalogSource                      string `envconfig:"NVIDIANETWORK_CATALOGSOURCE"`
-	SubscriptionChannel                string `envconfig:"NVIDIANETWORK_SUBSCRIPTION_CHANNEL"`
-	CleanupAfterTest                   bool   `envconfig:"NVIDIANETWORK_CLEANUP" default:"true"`
-	DeployFromBundle                   bool   `envconfig:"NVIDIANETWORK_DEPLOY_FROM_BUNDLE" default:"false"`
-	BundleImage                        string `envconfig:"NVIDIANETWORK_BUNDLE_IMAGE"`
-	OfedDriverVersion                  string `envconfig:"NVIDIANETWORK_OFED_DRIVER_VERSION"`
-	OfedDriverRepository               string `envconfig:"NVIDIANETWORK_OFED_REPOSITORY"`
-	RdmaWorkloadNamespace              string `envconfig:"NVIDIANETWORK_RDMA_WORKLOAD_NAMESPACE"`
-	RdmaLinkType                       string `envconfig:"NVIDIANETWORK_RDMA_LINK_TYPE"`
-	RdmaClientHostname                 string `envconfig:"NVIDIANETWORK_RDMA_CLIENT_HOSTNAME"`
-	RdmaServerHostname                 string `envconfig:"NVIDIANETWORK_RDMA_SERVER_HOSTNAME"`
-	RdmaTestImage                      string `envconfig:"NVIDIANETWORK_RDMA_TEST_IMAGE"`
-	RdmaMlxDevice                      string `envconfig:"NVIDIANETWORK_RDMA_MLX_DEVICE"`
-	RdmaNetworkType                    string `envconfig:"NVIDIANETWORK_RDMA_NETWORK_TYPE"`
-	RdmaGPUDirect                      bool   `envconfig:"NVIDIANETWORK_RDMA_GPUDIRECT"`
-	SriovNetworkName                   string `envconfig:"NVIDIANETWORK_RDMA_SRIOV_NETWORK_NAME"`
-	MellanoxEthernetInterfaceName      string `envconfig:"NVIDIANETWORK_MELLANOX_ETH_INTERFACE_NAME"`
-	MellanoxInfinibandInterfaceName    string `envconfig:"NVIDIANETWORK_MELLANOX_IB_INTERFACE_NAME"`
-	MacvlanNetworkName                 string `envconfig:"NVIDIANETWORK_MACVLANNETWORK_NAME"`
-	MacvlanNetworkIPAMRange            string `envconfig:"NVIDIANETWORK_MACVLANNETWORK_IPAM_RANGE"`
-	MacvlanNetworkIPAMGateway          string `envconfig:"NVIDIANETWORK_MACVLANNETWORK_IPAM_GATEWAY"`
-	IPoIBNetworkName                   string `envconfig:"NVIDIANETWORK_IPOIBNETWORK_NAME"`
-	IPoIBNetworkIPAMRange              string `envconfig:"NVIDIANETWORK_IPOIBNETWORK_IPAM_RANGE"`
-	IPoIBNetworkIPAMExcludeIP1         string `envconfig:"NVIDIANETWORK_IPOIBNETWORK_IPAM_EXCLUDEIP1"`
-	IPoIBNetworkIPAMExcludeIP2         string `envconfig:"NVIDIANETWORK_IPOIBNETWORK_IPAM_EXCLUDEIP2"`
-	OperatorUpgradeToChannel           string `envconfig:"NVIDIANETWORK_SUBSCRIPTION_UPGRADE_TO_CHANNEL"`
-	NNOFallbackCatalogsourceIndexImage string `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	CatalogSource                                 string             `envconfig:"NVIDIANETWORK_CATALOGSOURCE"`
+	SubscriptionChannel                           string             `envconfig:"NVIDIANETWORK_SUBSCRIPTION_CHANNEL"`
+	CleanupAfterTest                              bool               `envconfig:"NVIDIANETWORK_CLEANUP" default:"true"`
+	DeployFromBundle                              bool               `envconfig:"NVIDIANETWORK_DEPLOY_FROM_BUNDLE" default:"false"`
+	BundleImage                                   string             `envconfig:"NVIDIANETWORK_BUNDLE_IMAGE"`
+	BundleRegistryImage                           string             `envconfig:"NVIDIANETWORK_BUNDLE_REGISTRY_IMAGE"`
+	InstallWithOLMv1                              bool               `envconfig:"NVIDIANETWORK_INSTALL_WITH_OLMV1" default:"false"`
+	ClusterCatalog                                string             `envconfig:"NVIDIANETWORK_CLUSTERCATALOG"`
+	OfedDriverVersion                             string             `envconfig:"NVIDIANETWORK_OFED_DRIVER_VERSION"`
+	OfedDriverRepository                          string             `envconfig:"NVIDIANETWORK_OFED_REPOSITORY"`
+	RdmaWorkloadNamespace                         string             `envconfig:"NVIDIANETWORK_RDMA_WORKLOAD_NAMESPACE"`
+	RdmaLinkType                                  string             `envconfig:"NVIDIANETWORK_RDMA_LINK_TYPE"`
+	RdmaClientHostname                            string             `envconfig:"NVIDIANETWORK_RDMA_CLIENT_HOSTNAME"`
+	RdmaServerHostname                            string             `envconfig:"NVIDIANETWORK_RDMA_SERVER_HOSTNAME"`
+	RdmaTestImage                                 string             `envconfig:"NVIDIANETWORK_RDMA_TEST_IMAGE"`
+	RdmaMlxDevice                                 string             `envconfig:"NVIDIANETWORK_RDMA_MLX_DEVICE"`
+	RdmaNetworkType                               string             `envconfig:"NVIDIANETWORK_RDMA_NETWORK_TYPE"`
+	RdmaGPUDirect                                 bool               `envconfig:"NVIDIANETWORK_RDMA_GPUDIRECT"`
+	RdmaBenchmarks                                []string           `envconfig:"NVIDIANETWORK_RDMA_BENCHMARKS" default:"ib_write_bw"`
+	RdmaMinBandwidth                              map[string]float64 `envconfig:"NVIDIANETWORK_RDMA_MIN_BANDWIDTH"`
+	RdmaMinMsgRate                                map[string]float64 `envconfig:"NVIDIANETWORK_RDMA_MIN_MSG_RATE"`
+	RdmaMaxLatency                                map[string]float64 `envconfig:"NVIDIANETWORK_RDMA_MAX_LATENCY"`
+	RdmaMaxLatencyP99                             map[string]float64 `envconfig:"NVIDIANETWORK_RDMA_MAX_LATENCY_P99"`
+	RdmaMaxLatencyP999                            map[string]float64 `envconfig:"NVIDIANETWORK_RDMA_MAX_LATENCY_P999"`
+	SriovNetworkName                              string             `envconfig:"NVIDIANETWORK_RDMA_SRIOV_NETWORK_NAME"`
+	MellanoxEthernetInterfaceName                 string             `envconfig:"NVIDIANETWORK_MELLANOX_ETH_INTERFACE_NAME"`
+	MellanoxInfinibandInterfaceName               string             `envconfig:"NVIDIANETWORK_MELLANOX_IB_INTERFACE_NAME"`
+	MacvlanNetworkName                            string             `envconfig:"NVIDIANETWORK_MACVLANNETWORK_NAME"`
+	MacvlanNetworkIPAMRange                       string             `envconfig:"NVIDIANETWORK_MACVLANNETWORK_IPAM_RANGE"`
+	MacvlanNetworkIPAMGateway                     string             `envconfig:"NVIDIANETWORK_MACVLANNETWORK_IPAM_GATEWAY"`
+	IPoIBNetworkName                              string             `envconfig:"NVIDIANETWORK_IPOIBNETWORK_NAME"`
+	IPoIBNetworkIPAMRange                         string             `envconfig:"NVIDIANETWORK_IPOIBNETWORK_IPAM_RANGE"`
+	IPoIBNetworkIPAMExcludeIP1                    string             `envconfig:"NVIDIANETWORK_IPOIBNETWORK_IPAM_EXCLUDEIP1"`
+	IPoIBNetworkIPAMExcludeIP2                    string             `envconfig:"NVIDIANETWORK_IPOIBNETWORK_IPAM_EXCLUDEIP2"`
+	OperatorUpgradeToChannel                      string             `envconfig:"NVIDIANETWORK_SUBSCRIPTION_UPGRADE_TO_CHANNEL"`
+	NNOFallbackCatalogsourceIndexImage            string             `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_INDEX_IMAGE"`
+	NNOFallbackCatalogsourcePriority              int                `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_PRIORITY"`
+	NNOFallbackCatalogsourceRegistryPollInterval  time.Duration      `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_REGISTRY_POLL_INTERVAL"`
+	NNOFallbackCatalogsourceNodeSelector          map[string]string  `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_NODE_SELECTOR"`
+	NNOFallbackCatalogsourceTolerations           string             `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_TOLERATIONS"`
+	NNOFallbackCatalogsourceSecurityContextConfig string             `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_SECURITY_CONTEXT_CONFIG"`
+	NNOFallbackCatalogsourceFBCDir                string             `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_FBC_DIR"`
+	NNOFallbackCatalogsourceFBCCacheDir           string             `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_FBC_CACHE_DIR"`
+	NNOFallbackCatalogsourcePackageTimeout        time.Duration      `envconfig:"NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_PACKAGE_TIMEOUT"`
 }
 
 // NewNvidiaNetworkConfig returns instance of NvidiaNetworkConfig type.
@@ -52,3 +74,24 @@ func NewNvidiaNetworkConfig() *NvidiaNetworkConfig {
 
 	return nvidiaNetworkConfig
 }
+
+// NNOFallbackCatalogSourceOptions returns the options of the fallback catalogsource. The tolerations are read as a JSON
+// list of corev1.Toleration.
+func (cfg *NvidiaNetworkConfig) NNOFallbackCatalogSourceOptions() (olm.CatalogSourceOptions, error) {
+	options := olm.CatalogSourceOptions{
+		Priority:                 cfg.NNOFallbackCatalogsourcePriority,
+		RegistryPollInterval:     cfg.NNOFallbackCatalogsourceRegistryPollInterval,
+		NodeSelector:             cfg.NNOFallbackCatalogsourceNodeSelector,
+		SecurityContextConfig:    oplmV1alpha1.SecurityConfig(cfg.NNOFallbackCatalogsourceSecurityContextConfig),
+		FileBasedCatalogDir:      cfg.NNOFallbackCatalogsourceFBCDir,
+		FileBasedCatalogCacheDir: cfg.NNOFallbackCatalogsourceFBCCacheDir,
+	}
+
+	if cfg.NNOFallbackCatalogsourceTolerations != "" {
+		if err := json.Unmarshal([]byte(cfg.NNOFallbackCatalogsourceTolerations), &options.Tolerations); err != nil {
+			return options, fmt.Errorf("invalid NVIDIANETWORK_NNO_FALLBACK_CATALOGSOURCE_TOLERATIONS: %w", err)
+		}
+	}
+
+	return options, nil
+}
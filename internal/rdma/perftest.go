@@ -0,0 +1,425 @@
+package rdma
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/networkparams"
+)
+
+const (
+	// BenchmarkWriteBW is the ib_write_bw perftest benchmark.
+	BenchmarkWriteBW = "ib_write_bw"
+	// BenchmarkReadBW is the ib_read_bw perftest benchmark.
+	BenchmarkReadBW = "ib_read_bw"
+	// BenchmarkSendBW is the ib_send_bw perftest benchmark.
+	BenchmarkSendBW = "ib_send_bw"
+	// BenchmarkWriteLat is the ib_write_lat perftest benchmark.
+	BenchmarkWriteLat = "ib_write_lat"
+	// BenchmarkReadLat is the ib_read_lat perftest benchmark.
+	BenchmarkReadLat = "ib_read_lat"
+	// BenchmarkSendLat is the ib_send_lat perftest benchmark.
+	BenchmarkSendLat = "ib_send_lat"
+
+	// bytes in the MB perftest reports bandwidth in when --report_gbits is not set.
+	perftestMegabyte = 1024 * 1024
+)
+
+var (
+	// RDMA_Write BW Test, RDMA_Read Latency Test, Send BW Test, ...
+	perftestTitleRegex = regexp.MustCompile(`^\s*(RDMA_Write|RDMA_Read|Send) (BW|Latency) Test\s*$`)
+	// Dual-port       : OFF		Device         : mlx5_0
+	perftestConfigRegex = regexp.MustCompile(`([A-Za-z][\w .*-]*?)\s*:\s*(.+?)(?:\t+|\s{2,}|$)`)
+	// #bytes     #iterations    BW peak[Gb/sec]    BW average[Gb/sec]   MsgRate[Mpps]
+	perftestColumnRegex = regexp.MustCompile(
+		`(#bytes|#iterations|BW peak|BW average|MsgRate|t_min|t_max|t_typical|t_avg|t_stdev|99% percentile|` +
+			`99\.9% percentile)(?:\[([^\]]+)\])?`)
+	perftestSeparatorRegex = regexp.MustCompile(`^\s*-{20,}\s*$`)
+
+	perftestBenchmarks = map[string]string{
+		"RDMA_Write BW":      BenchmarkWriteBW,
+		"RDMA_Read BW":       BenchmarkReadBW,
+		"Send BW":            BenchmarkSendBW,
+		"RDMA_Write Latency": BenchmarkWriteLat,
+		"RDMA_Read Latency":  BenchmarkReadLat,
+		"Send Latency":       BenchmarkSendLat,
+	}
+)
+
+// BandwidthSample is a row of the table printed by the perftest *_bw benchmarks.
+type BandwidthSample struct {
+	Bytes       int     `json:"bytes"`
+	Iterations  int     `json:"iterations"`
+	PeakGbps    float64 `json:"peakGbps"`
+	AverageGbps float64 `json:"averageGbps"`
+	MsgRateMpps float64 `json:"msgRateMpps"`
+}
+
+// LatencySample is a row of the table printed by the perftest *_lat benchmarks, latencies are in usec.
+type LatencySample struct {
+	Bytes      int     `json:"bytes"`
+	Iterations int     `json:"iterations"`
+	Min        float64 `json:"min"`
+	Max        float64 `json:"max"`
+	Typical    float64 `json:"typical"`
+	Average    float64 `json:"average,omitempty"`
+	Stdev      float64 `json:"stdev,omitempty"`
+	P99        float64 `json:"p99,omitempty"`
+	P999       float64 `json:"p999,omitempty"`
+}
+
+// PerftestResult is the typed representation of a single perftest benchmark run.
+type PerftestResult struct {
+	// Benchmark is the perftest binary that produced the result, e.g. BenchmarkWriteBW.
+	Benchmark string `json:"benchmark"`
+	// Title is the test title printed by perftest, e.g. "RDMA_Write BW Test".
+	Title string `json:"title"`
+	// Config holds the key value pairs of the test configuration block, e.g. "Link type": "IB".
+	Config    map[string]string `json:"config"`
+	Bandwidth []BandwidthSample `json:"bandwidth,omitempty"`
+	Latency   []LatencySample   `json:"latency,omitempty"`
+}
+
+// PerftestThresholds defines the limits a PerftestResult is validated against.
+// Every map is keyed by the link type reported by perftest, "IB" or "Ethernet", compared case-insensitively;
+// the empty string applies to link types without a dedicated entry.
+type PerftestThresholds struct {
+	// MinBandwidthGbps is the minimum average bandwidth of the best message size.
+	MinBandwidthGbps map[string]float64 `json:"minBandwidthGbps,omitempty"`
+	// MinMsgRateMpps is the minimum message rate of the best message size.
+	MinMsgRateMpps map[string]float64 `json:"minMsgRateMpps,omitempty"`
+	// MaxLatencyUsec is the maximum typical latency of the smallest message size.
+	MaxLatencyUsec map[string]float64 `json:"maxLatencyUsec,omitempty"`
+	// MaxP99LatencyUsec is the maximum 99th percentile latency of the smallest message size.
+	MaxP99LatencyUsec map[string]float64 `json:"maxP99LatencyUsec,omitempty"`
+	// MaxP999LatencyUsec is the maximum 99.9th percentile latency of the smallest message size.
+	MaxP999LatencyUsec map[string]float64 `json:"maxP999LatencyUsec,omitempty"`
+}
+
+// DefaultPerftestThresholds returns the thresholds historically applied to the ib_write_bw smoke test.
+func DefaultPerftestThresholds() PerftestThresholds {
+	return PerftestThresholds{
+		MinBandwidthGbps: map[string]float64{"": MinBandwidth},
+		MinMsgRateMpps:   map[string]float64{"": MinMsgRate},
+	}
+}
+
+// Merge returns the thresholds with the entries of overrides added, an override replaces the entry of the same
+// link type only, so overriding "IB" keeps the "" entry applied to Ethernet.
+func (thresholds PerftestThresholds) Merge(overrides PerftestThresholds) PerftestThresholds {
+	merge := func(base, override map[string]float64) map[string]float64 {
+		if len(base) == 0 && len(override) == 0 {
+			return nil
+		}
+
+		merged := maps.Clone(base)
+		if merged == nil {
+			merged = make(map[string]float64, len(override))
+		}
+
+		maps.Copy(merged, override)
+
+		return merged
+	}
+
+	return PerftestThresholds{
+		MinBandwidthGbps:   merge(thresholds.MinBandwidthGbps, overrides.MinBandwidthGbps),
+		MinMsgRateMpps:     merge(thresholds.MinMsgRateMpps, overrides.MinMsgRateMpps),
+		MaxLatencyUsec:     merge(thresholds.MaxLatencyUsec, overrides.MaxLatencyUsec),
+		MaxP99LatencyUsec:  merge(thresholds.MaxP99LatencyUsec, overrides.MaxP99LatencyUsec),
+		MaxP999LatencyUsec: merge(thresholds.MaxP999LatencyUsec, overrides.MaxP999LatencyUsec),
+	}
+}
+
+// ParsePerftestOutput parses the output of one or more perftest benchmarks, e.g. the logs of a pod
+// running ib_write_bw then ib_read_lat, into one PerftestResult per benchmark.
+func ParsePerftestOutput(output string) ([]PerftestResult, error) {
+	glog.V(networkparams.LogLevel).Infof("Parsing perftest output")
+
+	var (
+		results  []PerftestResult
+		current  *PerftestResult
+		columns  [][]string
+		inConfig bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if match := perftestTitleRegex.FindStringSubmatch(line); match != nil {
+			results = append(results, PerftestResult{
+				Benchmark: perftestBenchmarks[match[1]+" "+match[2]],
+				Title:     strings.TrimSpace(line),
+				Config:    make(map[string]string),
+			})
+			current = &results[len(results)-1]
+			columns = nil
+			inConfig = true
+
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		if perftestSeparatorRegex.MatchString(line) {
+			inConfig = false
+
+			continue
+		}
+
+		if inConfig {
+			for _, match := range perftestConfigRegex.FindAllStringSubmatch(line, -1) {
+				current.Config[strings.TrimSpace(match[1])] = strings.TrimSpace(match[2])
+			}
+
+			continue
+		}
+
+		if strings.Contains(line, "#bytes") {
+			columns = perftestColumnRegex.FindAllStringSubmatch(line, -1)
+
+			continue
+		}
+
+		if columns == nil {
+			continue
+		}
+
+		values, ok := parsePerftestRow(line, len(columns))
+		if !ok {
+			continue
+		}
+
+		if err := current.addSample(columns, values); err != nil {
+			return nil, fmt.Errorf("failed to parse perftest row %q: %w", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read perftest output: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, fmt.Errorf("no perftest benchmark found in output")
+	}
+
+	return results, nil
+}
+
+// LinkType returns the link type reported by perftest, "IB" or "Ethernet".
+func (result *PerftestResult) LinkType() string {
+	return result.Config["Link type"]
+}
+
+// IsLatency returns true for the results of the *_lat benchmarks.
+func (result *PerftestResult) IsLatency() bool {
+	return strings.HasSuffix(result.Benchmark, "_lat")
+}
+
+// BestBandwidth returns the highest average bandwidth and the highest message rate over all message sizes.
+func (result *PerftestResult) BestBandwidth() (averageGbps, msgRateMpps float64) {
+	for _, sample := range result.Bandwidth {
+		averageGbps = max(averageGbps, sample.AverageGbps)
+		msgRateMpps = max(msgRateMpps, sample.MsgRateMpps)
+	}
+
+	return averageGbps, msgRateMpps
+}
+
+// Validate checks the result against the thresholds of its link type and returns all violations joined in
+// a single error. A result must always report a valid link type and at least one measurement.
+func (result *PerftestResult) Validate(thresholds PerftestThresholds) error {
+	linkType := result.LinkType()
+	if !slices.Contains(strings.Split(ValidLinkTypes, ","), linkType) {
+		return fmt.Errorf("%s: invalid link type: %q (expected: %s)", result.Benchmark, linkType, ValidLinkTypes)
+	}
+
+	if result.IsLatency() {
+		return result.validateLatency(linkType, thresholds)
+	}
+
+	if len(result.Bandwidth) == 0 {
+		return fmt.Errorf("%s: no bandwidth measurements found", result.Benchmark)
+	}
+
+	var violations []error
+
+	averageGbps, msgRateMpps := result.BestBandwidth()
+
+	if minBandwidth, ok := thresholdFor(thresholds.MinBandwidthGbps, linkType); ok && averageGbps < minBandwidth {
+		violations = append(violations, fmt.Errorf("%s: bandwidth too low: %.2f Gbps (min: %.2f Gbps)",
+			result.Benchmark, averageGbps, minBandwidth))
+	}
+
+	if minMsgRate, ok := thresholdFor(thresholds.MinMsgRateMpps, linkType); ok && msgRateMpps < minMsgRate {
+		violations = append(violations, fmt.Errorf("%s: MsgRate too low: %.3f Mpps (min: %.3f Mpps)",
+			result.Benchmark, msgRateMpps, minMsgRate))
+	}
+
+	return errors.Join(violations...)
+}
+
+// ValidatePerftestResults validates every result and returns all violations joined in a single error.
+func ValidatePerftestResults(results []PerftestResult, thresholds PerftestThresholds) error {
+	if len(results) == 0 {
+		return fmt.Errorf("no perftest results to validate")
+	}
+
+	var violations []error
+
+	for index := range results {
+		violations = append(violations, results[index].Validate(thresholds))
+	}
+
+	return errors.Join(violations...)
+}
+
+// validateLatency checks the smallest message size latencies against the thresholds of the link type.
+func (result *PerftestResult) validateLatency(linkType string, thresholds PerftestThresholds) error {
+	if len(result.Latency) == 0 {
+		return fmt.Errorf("%s: no latency measurements found", result.Benchmark)
+	}
+
+	sample := slices.MinFunc(result.Latency, func(a, b LatencySample) int {
+		return a.Bytes - b.Bytes
+	})
+
+	var violations []error
+
+	for _, check := range []struct {
+		name       string
+		value      float64
+		thresholds map[string]float64
+	}{
+		{name: "typical latency", value: sample.Typical, thresholds: thresholds.MaxLatencyUsec},
+		{name: "99% latency", value: sample.P99, thresholds: thresholds.MaxP99LatencyUsec},
+		{name: "99.9% latency", value: sample.P999, thresholds: thresholds.MaxP999LatencyUsec},
+	} {
+		if maxLatency, ok := thresholdFor(check.thresholds, linkType); ok && check.value > maxLatency {
+			violations = append(violations, fmt.Errorf("%s: %s too high for %d bytes: %.2f usec (max: %.2f usec)",
+				result.Benchmark, check.name, sample.Bytes, check.value, maxLatency))
+		}
+	}
+
+	return errors.Join(violations...)
+}
+
+// addSample stores a table row, the columns are the perftestColumnRegex matches of the table header.
+func (result *PerftestResult) addSample(columns [][]string, values []float64) error {
+	if result.IsLatency() {
+		sample := LatencySample{}
+
+		for index, column := range columns {
+			switch column[1] {
+			case "#bytes":
+				sample.Bytes = int(values[index])
+			case "#iterations":
+				sample.Iterations = int(values[index])
+			case "t_min":
+				sample.Min = values[index]
+			case "t_max":
+				sample.Max = values[index]
+			case "t_typical":
+				sample.Typical = values[index]
+			case "t_avg":
+				sample.Average = values[index]
+			case "t_stdev":
+				sample.Stdev = values[index]
+			case "99% percentile":
+				sample.P99 = values[index]
+			case "99.9% percentile":
+				sample.P999 = values[index]
+			}
+		}
+
+		result.Latency = append(result.Latency, sample)
+
+		return nil
+	}
+
+	sample := BandwidthSample{}
+
+	for index, column := range columns {
+		switch column[1] {
+		case "#bytes":
+			sample.Bytes = int(values[index])
+		case "#iterations":
+			sample.Iterations = int(values[index])
+		case "BW peak", "BW average":
+			gbps, err := toGbps(values[index], column[2])
+			if err != nil {
+				return err
+			}
+
+			if column[1] == "BW peak" {
+				sample.PeakGbps = gbps
+			} else {
+				sample.AverageGbps = gbps
+			}
+		case "MsgRate":
+			sample.MsgRateMpps = values[index]
+		}
+	}
+
+	result.Bandwidth = append(result.Bandwidth, sample)
+
+	return nil
+}
+
+// parsePerftestRow returns the values of a table row, or false if the line is not a row of columnCount numbers.
+func parsePerftestRow(line string, columnCount int) ([]float64, bool) {
+	fields := strings.Fields(line)
+	if len(fields) != columnCount {
+		return nil, false
+	}
+
+	values := make([]float64, len(fields))
+
+	for index, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return nil, false
+		}
+
+		values[index] = value
+	}
+
+	return values, true
+}
+
+// toGbps converts a bandwidth reported in the given perftest unit to Gb/sec.
+func toGbps(value float64, unit string) (float64, error) {
+	switch unit {
+	case "Gb/sec":
+		return value, nil
+	case "MB/sec":
+		return value * perftestMegabyte * 8 / 1e9, nil
+	default:
+		return 0, fmt.Errorf("unsupported bandwidth unit %q", unit)
+	}
+}
+
+// thresholdFor returns the threshold of the link type, compared case-insensitively,
+// falling back to the empty string entry.
+func thresholdFor(thresholds map[string]float64, linkType string) (float64, bool) {
+	for thresholdLinkType, threshold := range thresholds {
+		if thresholdLinkType != "" && strings.EqualFold(thresholdLinkType, linkType) {
+			return threshold, true
+		}
+	}
+
+	threshold, ok := thresholds[""]
+
+	return threshold, ok
+}
@@ -0,0 +1,219 @@
+package rdma
+
+import (
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readTestLog(t *testing.T, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read test log %s: %v", name, err)
+	}
+
+	return string(content)
+}
+
+func TestParsePerftestOutputBandwidth(t *testing.T) {
+	results, err := ParsePerftestOutput(readTestLog(t, "ib_write_bw.log"))
+	if err != nil {
+		t.Fatalf("failed to parse perftest output: %v", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+
+	result := results[0]
+
+	if result.Benchmark != BenchmarkWriteBW || result.IsLatency() {
+		t.Errorf("unexpected benchmark %q", result.Benchmark)
+	}
+
+	if result.LinkType() != "Ethernet" || result.Config["Device"] != "mlx5_2" ||
+		result.Config["Transport type"] != "IB" || result.Config["rdma_cm QPs"] != "OFF" {
+		t.Errorf("unexpected config %v", result.Config)
+	}
+
+	if len(result.Bandwidth) != 4 {
+		t.Fatalf("expected the full table of 4 message sizes, got %d", len(result.Bandwidth))
+	}
+
+	expected := BandwidthSample{Bytes: 8388608, Iterations: 5000, PeakGbps: 97.86, AverageGbps: 97.85,
+		MsgRateMpps: 0.001458}
+	if result.Bandwidth[3] != expected {
+		t.Errorf("expected last sample %+v, got %+v", expected, result.Bandwidth[3])
+	}
+
+	averageGbps, msgRateMpps := result.BestBandwidth()
+	if averageGbps != 97.85 || msgRateMpps != 5.812345 {
+		t.Errorf("unexpected best bandwidth %.2f Gbps %.6f Mpps", averageGbps, msgRateMpps)
+	}
+}
+
+func TestParsePerftestOutputMultipleBenchmarks(t *testing.T) {
+	results, err := ParsePerftestOutput(readTestLog(t, "ib_read_lat_send_bw.log"))
+	if err != nil {
+		t.Fatalf("failed to parse perftest output: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	latency := results[0]
+	if latency.Benchmark != BenchmarkReadLat || !latency.IsLatency() || latency.LinkType() != "IB" {
+		t.Errorf("unexpected latency result %s %v", latency.Benchmark, latency.Config)
+	}
+
+	if len(latency.Latency) != 2 {
+		t.Fatalf("expected 2 latency samples, got %d", len(latency.Latency))
+	}
+
+	expected := LatencySample{Bytes: 2, Iterations: 1000, Min: 1.88, Max: 5.43, Typical: 1.93, Average: 1.94,
+		Stdev: 0.07, P99: 2.11, P999: 5.43}
+	if latency.Latency[0] != expected {
+		t.Errorf("expected first sample %+v, got %+v", expected, latency.Latency[0])
+	}
+
+	bandwidth := results[1]
+	if bandwidth.Benchmark != BenchmarkSendBW || len(bandwidth.Bandwidth) != 1 {
+		t.Fatalf("unexpected bandwidth result %s %+v", bandwidth.Benchmark, bandwidth.Bandwidth)
+	}
+
+	// 11730.12 MB/sec with 1 MB = 1048576 bytes.
+	if math.Abs(bandwidth.Bandwidth[0].AverageGbps-98.40) > 0.01 {
+		t.Errorf("expected MB/sec to be converted to ~98.40 Gb/sec, got %.2f", bandwidth.Bandwidth[0].AverageGbps)
+	}
+}
+
+func TestParsePerftestOutputErrors(t *testing.T) {
+	if _, err := ParsePerftestOutput("ib_write_bw: command not found\n"); err == nil {
+		t.Error("expected an error for output without a benchmark")
+	}
+
+	results, err := ParsePerftestOutput(readTestLog(t, "ib_write_bw-failed.log"))
+	if err != nil {
+		t.Fatalf("failed to parse perftest output: %v", err)
+	}
+
+	err = ValidatePerftestResults(results, DefaultPerftestThresholds())
+	if err == nil || !strings.Contains(err.Error(), "no bandwidth measurements found") {
+		t.Errorf("expected a missing measurements error, got %v", err)
+	}
+}
+
+func TestPerftestResultValidate(t *testing.T) {
+	writeResults, err := ParsePerftestOutput(readTestLog(t, "ib_write_bw.log"))
+	if err != nil {
+		t.Fatalf("failed to parse perftest output: %v", err)
+	}
+
+	latencyResults, err := ParsePerftestOutput(readTestLog(t, "ib_read_lat_send_bw.log"))
+	if err != nil {
+		t.Fatalf("failed to parse perftest output: %v", err)
+	}
+
+	testCases := []struct {
+		name           string
+		results        []PerftestResult
+		thresholds     PerftestThresholds
+		expectedErrors []string
+	}{
+		{
+			name:       "default thresholds",
+			results:    writeResults,
+			thresholds: DefaultPerftestThresholds(),
+		},
+		{
+			name:    "link type specific bandwidth",
+			results: writeResults,
+			thresholds: PerftestThresholds{
+				MinBandwidthGbps: map[string]float64{"": 10, "ethernet": 180, "IB": 50},
+				MinMsgRateMpps:   map[string]float64{"Ethernet": 6},
+			},
+			expectedErrors: []string{"bandwidth too low: 97.85 Gbps (min: 180.00 Gbps)", "MsgRate too low"},
+		},
+		{
+			name:    "threshold of another link type",
+			results: writeResults,
+			thresholds: PerftestThresholds{
+				MinBandwidthGbps: map[string]float64{"IB": 180},
+			},
+		},
+		{
+			name:    "latency percentiles",
+			results: latencyResults,
+			thresholds: PerftestThresholds{
+				MinBandwidthGbps:   map[string]float64{"IB": 90},
+				MaxLatencyUsec:     map[string]float64{"IB": 2},
+				MaxP99LatencyUsec:  map[string]float64{"IB": 2},
+				MaxP999LatencyUsec: map[string]float64{"": 6},
+			},
+			expectedErrors: []string{"ib_read_lat: 99% latency too high for 2 bytes: 2.11 usec (max: 2.00 usec)"},
+		},
+		{
+			name:    "invalid link type",
+			results: []PerftestResult{{Benchmark: BenchmarkWriteBW, Config: map[string]string{"Link type": "Loopback"}}},
+			expectedErrors: []string{
+				`ib_write_bw: invalid link type: "Loopback"`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := ValidatePerftestResults(testCase.results, testCase.thresholds)
+
+			if len(testCase.expectedErrors) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected errors %v, got nil", testCase.expectedErrors)
+			}
+
+			for _, expectedError := range testCase.expectedErrors {
+				if !strings.Contains(err.Error(), expectedError) {
+					t.Errorf("expected error to contain %q, got %v", expectedError, err)
+				}
+			}
+
+			if strings.Count(err.Error(), "\n")+1 != len(testCase.expectedErrors) {
+				t.Errorf("expected %d violations, got %v", len(testCase.expectedErrors), err)
+			}
+		})
+	}
+}
+
+func TestPerftestThresholdsMerge(t *testing.T) {
+	defaults := DefaultPerftestThresholds()
+	merged := defaults.Merge(PerftestThresholds{
+		MinBandwidthGbps: map[string]float64{"IB": 180},
+		MaxLatencyUsec:   map[string]float64{"": 5},
+	})
+
+	expectedBandwidth := map[string]float64{"": MinBandwidth, "IB": 180}
+	if !maps.Equal(merged.MinBandwidthGbps, expectedBandwidth) {
+		t.Errorf("expected bandwidth thresholds %v, got %v", expectedBandwidth, merged.MinBandwidthGbps)
+	}
+
+	if !maps.Equal(merged.MinMsgRateMpps, defaults.MinMsgRateMpps) || merged.MaxLatencyUsec[""] != 5 ||
+		merged.MaxP99LatencyUsec != nil {
+		t.Errorf("unexpected merged thresholds %+v", merged)
+	}
+
+	if _, ok := defaults.MinBandwidthGbps["IB"]; ok {
+		t.Error("expected the merge to leave the base thresholds unchanged")
+	}
+}
@@ -1,19 +1,15 @@
 package rdma
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
-	"k8s.io/apimachinery/pkg/api/resource"
-
 	"github.com/golang/glog"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -41,100 +37,28 @@ const (
 	gpuResourceName             corev1.ResourceName = "nvidia.com/gpu"
 )
 
-// CreateRdmaWorkloadPod create RDMA worker pod.
-func CreateRdmaWorkloadPod(name, namespace, withCuda, mode, hostname, device, crName, image, linkType, serverIP string, rdmaNetworkType string) *corev1.Pod {
-	var (
-		args          []string
-		rdmaResources corev1.ResourceRequirements
-	)
-
-	if mode == "server" {
-		args = []string{"-c", withCuda, "-m", mode, "-n", "net1", "-d", device}
-	} else {
-		args = []string{"-c", withCuda, "-m", mode, "-n", "net1", "-d", device, "-i", serverIP}
-	}
-
-	switch rdmaNetworkType {
-	case "sriov":
-		if withCuda == "yes" {
-			rdmaResources = corev1.ResourceRequirements{
-				Limits: corev1.ResourceList{
-					gpuResourceName:             resource.MustParse("1"),
-					RdmaLegacySriovResourceName: resource.MustParse("1"),
-				},
-				Requests: corev1.ResourceList{
-					gpuResourceName:             resource.MustParse("1"),
-					RdmaLegacySriovResourceName: resource.MustParse("1"),
-				},
-			}
-		} else {
-			rdmaResources = corev1.ResourceRequirements{
-				Limits: corev1.ResourceList{
-					RdmaLegacySriovResourceName: resource.MustParse("1"),
-				},
-				Requests: corev1.ResourceList{
-					RdmaLegacySriovResourceName: resource.MustParse("1"),
-				},
-			}
-		}
-	case "shared-device", "undefined":
-		if withCuda == "yes" {
-			rdmaResources = corev1.ResourceRequirements{
-				Limits: corev1.ResourceList{
-					gpuResourceName:                        resource.MustParse("1"),
-					RdmaSharedDeviceResourceName[linkType]: resource.MustParse("1"),
-				},
-				Requests: corev1.ResourceList{
-					gpuResourceName:                        resource.MustParse("1"),
-					RdmaSharedDeviceResourceName[linkType]: resource.MustParse("1"),
-				},
-			}
-		} else {
-			rdmaResources = corev1.ResourceRequirements{
-				Limits: corev1.ResourceList{
-					RdmaSharedDeviceResourceName[linkType]: resource.MustParse("1"),
-				},
-				Requests: corev1.ResourceList{
-					RdmaSharedDeviceResourceName[linkType]: resource.MustParse("1"),
-				},
-			}
-		}
+// CreateRdmaWorkloadPod returns a pod builder for an RDMA worker pod, the pod is not created.
+func CreateRdmaWorkloadPod(apiClient *clients.Settings, name, namespace, withCuda, mode, hostname, device, crName,
+	image, linkType, serverIP string, rdmaNetworkType string) (*pod.Builder, error) {
+	workload := NewWorkload(name, image, mode).
+		WithCuda(withCuda == "yes").
+		WithNode(hostname).
+		WithDevice(device).
+		WithNetwork(crName).
+		WithLinkType(linkType).
+		WithNetworkType(rdmaNetworkType).
+		WithServerIP(serverIP).
+		// legacy RDMA pods rely on the API server default restart policy
+		WithRestartPolicy("")
+
+	rdmaPod, err := workload.BuildPodSpec()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build RDMA workload pod %s: %w", name, err)
 	}
-	// Add case for HostDevice in future
 
-	pod := &corev1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			Annotations: map[string]string{
-				"k8s.v1.cni.cncf.io/networks": crName,
-			},
-		},
-		Spec: corev1.PodSpec{
-			NodeSelector: map[string]string{
-				"kubernetes.io/hostname": hostname,
-			},
-			ServiceAccountName: "rdma",
-			Containers: []corev1.Container{
-				{
-					Name:            name,
-					Image:           image,
-					ImagePullPolicy: corev1.PullAlways,
-					Command:         []string{"/root/entrypoint.sh"},
-					Args:            args,
-					SecurityContext: &corev1.SecurityContext{
-						Privileged: boolPtr(true),
-						Capabilities: &corev1.Capabilities{
-							Add: []corev1.Capability{"IPC_LOCK"},
-						},
-					},
-					Resources: rdmaResources,
-				},
-			},
-		},
-	}
+	rdmaPod.Namespace = namespace
 
-	return pod
+	return pod.NewBuilderFromDefinition(apiClient, rdmaPod), nil
 }
 
 func boolPtr(b bool) *bool {
@@ -178,62 +102,6 @@ func GetMyServerIP(clientset *clients.Settings, podName, podNamespace, podinterf
 	return "", fmt.Errorf("ipoib network IP not found")
 }
 
-// ParseRdmaOutput parse Rdma logs.
-func ParseRdmaOutput(output string) (map[string]string, error) {
-	results := make(map[string]string)
-
-	// Regex patterns
-	configRegex := regexp.MustCompile(`([\w-\s\*]+):\s+([\w\[\]\/.]+)`)
-	bwTableRegex := regexp.MustCompile(`\s*(\d+)\s+(\d+)\s+([\d.]+)\s+([\d.]+)\s+([\d.]+)`)
-
-	scanner := bufio.NewScanner(strings.NewReader(output))
-	isParsingConfig := false
-
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-
-		if strings.Contains(line, "RDMA_Write BW Test") {
-			isParsingConfig = true
-			results["Test_Type"] = "RDMA_Write BW Test"
-			continue
-		}
-
-		if strings.Contains(line, "---------------------------------------------------------------------------------------") {
-			isParsingConfig = false
-		}
-
-		// Parse RDMA configuration key-value pairs
-		if isParsingConfig {
-			matches := configRegex.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) > 2 {
-					key := strings.TrimSpace(match[1])
-					value := strings.TrimSpace(match[2])
-					results[key] = value
-				}
-			}
-		}
-
-		// Match the performance table
-		if matches := bwTableRegex.FindStringSubmatch(line); len(matches) > 4 {
-			glog.Infof("Matched Bandwidth Table:%v", matches)
-			results["Bytes"] = matches[1]
-			results["Iterations"] = matches[2]
-			results["BW_Peak_Gbps"] = matches[3]
-			results["BW_Avg_Gbps"] = matches[4]
-			results["MsgRate_Mpps"] = matches[5]
-			break // Stop after finding the first occurrence
-		}
-	}
-
-	// Check for errors
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	return results, nil
-}
-
 func GetPodLogs(clientset *clients.Settings, namespace, podName string) (string, error) {
 	req := clientset.Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{})
 	// req := apiClient.Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{})
@@ -260,36 +128,6 @@ func GetPodLogs(clientset *clients.Settings, namespace, podName string) (string,
 	return logs.String(), nil
 }
 
-// ValidateRDMAResults basic validation for rdma tests result.
-func ValidateRDMAResults(results map[string]string) (bool, error) {
-	// Check Test Type
-	testType, exists := results["Test_Type"]
-	if !exists || testType != "RDMA_Write BW Test" {
-		return false, fmt.Errorf("invalid test type: %s", testType)
-	}
-
-	// Check Link Type
-	linkType, exists := results["Link type"]
-	if !exists || (linkType != "Ethernet" && linkType != "IB") {
-		return false, fmt.Errorf("invalid link type: %s (expected: Ethernet or IB)", linkType)
-	}
-
-	// Check Bandwidth
-	bwAvg, err := strconv.ParseFloat(results["BW_Avg_Gbps"], 64)
-	if err != nil || bwAvg < MinBandwidth {
-		return false, fmt.Errorf("bandwidth too low: %.2f Gbps (min: %.2f Gbps)", bwAvg, MinBandwidth)
-	}
-
-	// Check Message Rate
-	msgRate, err := strconv.ParseFloat(results["MsgRate_Mpps"], 64)
-	if err != nil || msgRate < MinMsgRate {
-		return false, fmt.Errorf("MsgRate too low: %.3f Mpps (Min: %.1f Mpps)", msgRate, MinMsgRate)
-	}
-
-	// If everything is valid
-	return true, nil
-}
-
 // DeleteMofedRpmDir deletes mofed driver inventory dir on a specific node.
 func DeleteMofedRpmDir(clientset *clients.Settings, podName, namespace, clusterArch, nodeName string) (string, error) {
 	commands := []string{
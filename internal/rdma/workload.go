@@ -0,0 +1,297 @@
+package rdma
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/networkparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ModeServer runs the perftest benchmark as the server side of the test.
+	ModeServer = "server"
+	// ModeClient runs the perftest benchmark as the client side of the test, connecting to the server IP.
+	ModeClient = "client"
+
+	// NetworkTypeSriov requests a legacy SR-IOV RDMA device.
+	NetworkTypeSriov = "sriov"
+	// NetworkTypeSharedDevice requests an RDMA shared device.
+	NetworkTypeSharedDevice = "shared-device"
+
+	serviceAccountName = "rdma"
+	podInterfaceName   = "net1"
+	// entrypointPath builds perftest in the rdma-tools image and runs ib_write_bw, or the benchmark given with '-b'.
+	entrypointPath = "/root/entrypoint.sh"
+)
+
+// Workload implements the testworkloads.Workload interface for a perftest server or client pod.
+type Workload struct {
+	podName       string
+	image         string
+	mode          string
+	benchmark     string
+	withCuda      bool
+	hostname      string
+	device        string
+	networkName   string
+	linkType      string
+	serverIP      string
+	networkType   string
+	restartPolicy corev1.RestartPolicy
+	thresholds    PerftestThresholds
+	results       []PerftestResult
+}
+
+// NewWorkload creates an RDMA workload running BenchmarkWriteBW in the given mode, ModeServer or ModeClient.
+func NewWorkload(podName, image, mode string) *Workload {
+	glog.V(networkparams.LogLevel).Infof("Creating RDMA %s workload: %s", mode, podName)
+
+	return &Workload{
+		podName:       podName,
+		image:         image,
+		mode:          mode,
+		benchmark:     BenchmarkWriteBW,
+		networkType:   NetworkTypeSharedDevice,
+		restartPolicy: corev1.RestartPolicyNever,
+		thresholds:    DefaultPerftestThresholds(),
+	}
+}
+
+// WithBenchmark sets the perftest benchmark the workload runs, e.g. BenchmarkReadBW, BenchmarkWriteBW by default.
+func (w *Workload) WithBenchmark(benchmark string) *Workload {
+	w.benchmark = benchmark
+	return w
+}
+
+// WithCuda enables GPUDirect RDMA by also requesting a GPU and running the benchmark with CUDA buffers.
+func (w *Workload) WithCuda(withCuda bool) *Workload {
+	w.withCuda = withCuda
+	return w
+}
+
+// WithNode pins the workload to the node with the given hostname.
+func (w *Workload) WithNode(hostname string) *Workload {
+	w.hostname = hostname
+	return w
+}
+
+// WithDevice sets the mlx5 device used by the benchmark, e.g. "mlx5_0".
+func (w *Workload) WithDevice(device string) *Workload {
+	w.device = device
+	return w
+}
+
+// WithNetwork attaches the workload to the given secondary network.
+func (w *Workload) WithNetwork(networkName string) *Workload {
+	w.networkName = networkName
+	return w
+}
+
+// WithLinkType sets the link type, "ethernet" or "infiniband", used to select the shared device resource.
+func (w *Workload) WithLinkType(linkType string) *Workload {
+	w.linkType = linkType
+	return w
+}
+
+// WithNetworkType sets how the RDMA device is requested, NetworkTypeSriov or NetworkTypeSharedDevice.
+func (w *Workload) WithNetworkType(networkType string) *Workload {
+	w.networkType = networkType
+	return w
+}
+
+// WithServerIP sets the address a client workload connects to.
+func (w *Workload) WithServerIP(serverIP string) *Workload {
+	w.serverIP = serverIP
+	return w
+}
+
+// WithRestartPolicy overrides the pod restart policy, RestartPolicyNever by default.
+func (w *Workload) WithRestartPolicy(restartPolicy corev1.RestartPolicy) *Workload {
+	w.restartPolicy = restartPolicy
+	return w
+}
+
+// ServerIPHook returns a testworkloads.StartHook for a client workload added to a testworkloads.Group.
+// The hook reads the net1 IP of the server group member serverName and sets it as the client server IP.
+func (w *Workload) ServerIPHook(apiClient *clients.Settings, serverName string) testworkloads.StartHook {
+	return func(dependencies map[string]*testworkloads.Builder) error {
+		server, ok := dependencies[serverName]
+		if !ok {
+			return fmt.Errorf("rdma server %s is not a dependency of client %s", serverName, w.podName)
+		}
+
+		serverPodName, err := server.PodName()
+		if err != nil {
+			return err
+		}
+
+		serverIP, err := GetMyServerIP(apiClient, serverPodName, server.Namespace(), podInterfaceName)
+		if err != nil {
+			return fmt.Errorf("failed to get the %s IP of rdma server %s: %w", podInterfaceName, serverPodName, err)
+		}
+
+		glog.V(networkparams.LogLevel).Infof("RDMA client %s connects to server %s at %s", w.podName, serverPodName, serverIP)
+
+		w.serverIP = serverIP
+
+		return nil
+	}
+}
+
+// WithThresholds sets the limits the parsed perftest results are validated against,
+// DefaultPerftestThresholds by default.
+func (w *Workload) WithThresholds(thresholds PerftestThresholds) *Workload {
+	w.thresholds = thresholds
+	return w
+}
+
+// Results returns the perftest results parsed by CheckSuccess, or nil if it has not run yet.
+func (w *Workload) Results() []PerftestResult {
+	return w.results
+}
+
+// BuildPodSpec creates the pod specification for the RDMA workload.
+func (w *Workload) BuildPodSpec() (*corev1.Pod, error) {
+	glog.V(networkparams.LogLevel).Infof("Building pod spec for RDMA workload: %s", w.podName)
+
+	if w.podName == "" {
+		return nil, fmt.Errorf("pod name cannot be empty")
+	}
+
+	if w.image == "" {
+		return nil, fmt.Errorf("container image cannot be empty")
+	}
+
+	switch w.mode {
+	case ModeServer:
+	case ModeClient:
+		if w.serverIP == "" {
+			return nil, fmt.Errorf("server IP cannot be empty in %s mode", ModeClient)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported RDMA workload mode '%s', must be '%s' or '%s'",
+			w.mode, ModeServer, ModeClient)
+	}
+
+	command, args, err := w.command()
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: w.podName,
+			Annotations: map[string]string{
+				"k8s.v1.cni.cncf.io/networks": w.networkName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{
+				"kubernetes.io/hostname": w.hostname,
+			},
+			RestartPolicy:      w.restartPolicy,
+			ServiceAccountName: serviceAccountName,
+			Containers: []corev1.Container{
+				{
+					Name:            w.podName,
+					Image:           w.image,
+					ImagePullPolicy: corev1.PullAlways,
+					Command:         command,
+					Args:            args,
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: ptr.To(true),
+						Capabilities: &corev1.Capabilities{
+							Add: []corev1.Capability{"IPC_LOCK"},
+						},
+					},
+					Resources: w.resources(),
+				},
+			},
+		},
+	}, nil
+}
+
+// CheckSuccess parses the perftest output and validates it against the workload thresholds.
+// The server of a latency benchmark does not report measurements, only its pod phase is checked.
+func (w *Workload) CheckSuccess(builder *testworkloads.Builder) error {
+	glog.V(networkparams.LogLevel).Infof("Checking RDMA workload success criteria")
+
+	if w.mode == ModeServer && strings.HasSuffix(w.benchmark, "_lat") {
+		return nil
+	}
+
+	logs, err := builder.GetFullLogs(w.podName)
+	if err != nil {
+		return fmt.Errorf("failed to get logs: %w", err)
+	}
+
+	w.results, err = ParsePerftestOutput(logs)
+	if err != nil {
+		return fmt.Errorf("failed to parse perftest output: %w", err)
+	}
+
+	return ValidatePerftestResults(w.results, w.thresholds)
+}
+
+// command returns the container command and arguments running the workload benchmark. Every benchmark runs
+// through the image entrypoint, which builds perftest with CUDA support; benchmarks other than BenchmarkWriteBW are
+// passed to it with '-b'.
+func (w *Workload) command() ([]string, []string, error) {
+	if !slices.Contains(slices.Collect(maps.Values(perftestBenchmarks)), w.benchmark) {
+		return nil, nil, fmt.Errorf("unsupported perftest benchmark '%s'", w.benchmark)
+	}
+
+	args := []string{"-c", w.cudaSwitch(), "-m", w.mode, "-n", podInterfaceName, "-d", w.device}
+	if w.benchmark != BenchmarkWriteBW {
+		args = append(args, "-b", w.benchmark)
+	}
+
+	if w.mode == ModeClient {
+		args = append(args, "-i", w.serverIP)
+	}
+
+	return []string{entrypointPath}, args, nil
+}
+
+// resources returns the RDMA device, and optionally GPU, requests of the workload.
+func (w *Workload) resources() corev1.ResourceRequirements {
+	resourceList := corev1.ResourceList{}
+
+	switch w.networkType {
+	case NetworkTypeSriov:
+		resourceList[RdmaLegacySriovResourceName] = resource.MustParse("1")
+	case NetworkTypeSharedDevice, "undefined":
+		resourceList[RdmaSharedDeviceResourceName[w.linkType]] = resource.MustParse("1")
+	}
+	// Add case for HostDevice in future
+
+	if len(resourceList) == 0 {
+		return corev1.ResourceRequirements{}
+	}
+
+	if w.withCuda {
+		resourceList[gpuResourceName] = resource.MustParse("1")
+	}
+
+	return corev1.ResourceRequirements{
+		Limits:   resourceList,
+		Requests: resourceList.DeepCopy(),
+	}
+}
+
+func (w *Workload) cudaSwitch() string {
+	if w.withCuda {
+		return "yes"
+	}
+
+	return "no"
+}
@@ -0,0 +1,190 @@
+package rdma
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWorkloadBuildPodSpec(t *testing.T) {
+	testCases := []struct {
+		name              string
+		workload          *Workload
+		expectedCommand   []string
+		expectedArgs      []string
+		expectedResources []corev1.ResourceName
+		expectedError     bool
+	}{
+		{
+			name: "shared device server",
+			workload: NewWorkload("rdma-server", "rdma:latest", ModeServer).
+				WithDevice("mlx5_0").WithLinkType("infiniband"),
+			expectedCommand:   []string{"/root/entrypoint.sh"},
+			expectedArgs:      []string{"-c", "no", "-m", "server", "-n", "net1", "-d", "mlx5_0"},
+			expectedResources: []corev1.ResourceName{"rdma/rdma_shared_device_ib"},
+		},
+		{
+			name: "sriov client with cuda",
+			workload: NewWorkload("rdma-client", "rdma:latest", ModeClient).
+				WithDevice("mlx5_2").WithNetworkType(NetworkTypeSriov).WithCuda(true).WithServerIP("192.168.1.10"),
+			expectedCommand: []string{"/root/entrypoint.sh"},
+			expectedArgs: []string{"-c", "yes", "-m", "client", "-n", "net1", "-d", "mlx5_2",
+				"-i", "192.168.1.10"},
+			expectedResources: []corev1.ResourceName{RdmaLegacySriovResourceName, gpuResourceName},
+		},
+		{
+			name: "read bandwidth client",
+			workload: NewWorkload("rdma-client", "rdma:latest", ModeClient).WithBenchmark(BenchmarkReadBW).
+				WithDevice("mlx5_0").WithLinkType("ethernet").WithCuda(true).WithServerIP("192.168.1.10"),
+			expectedCommand: []string{"/root/entrypoint.sh"},
+			expectedArgs: []string{"-c", "yes", "-m", "client", "-n", "net1", "-d", "mlx5_0",
+				"-b", BenchmarkReadBW, "-i", "192.168.1.10"},
+			expectedResources: []corev1.ResourceName{RdmaSharedDeviceResourceName["ethernet"],
+				gpuResourceName},
+		},
+		{
+			name: "send latency server",
+			workload: NewWorkload("rdma-server", "rdma:latest", ModeServer).WithBenchmark(BenchmarkSendLat).
+				WithDevice("mlx5_0").WithLinkType("infiniband"),
+			expectedCommand:   []string{"/root/entrypoint.sh"},
+			expectedArgs:      []string{"-c", "no", "-m", "server", "-n", "net1", "-d", "mlx5_0", "-b", BenchmarkSendLat},
+			expectedResources: []corev1.ResourceName{"rdma/rdma_shared_device_ib"},
+		},
+		{
+			name: "unsupported benchmark",
+			workload: NewWorkload("rdma-server", "rdma:latest", ModeServer).WithBenchmark("ib_atomic_bw").
+				WithDevice("mlx5_0"),
+			expectedError: true,
+		},
+		{
+			name:          "client without server ip",
+			workload:      NewWorkload("rdma-client", "rdma:latest", ModeClient),
+			expectedError: true,
+		},
+		{
+			name:          "unsupported mode",
+			workload:      NewWorkload("rdma-client", "rdma:latest", "loopback"),
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pod, err := testCase.workload.BuildPodSpec()
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			container := pod.Spec.Containers[0]
+			if !slices.Equal(container.Command, testCase.expectedCommand) {
+				t.Errorf("expected command %v, got %v", testCase.expectedCommand, container.Command)
+			}
+
+			if !slices.Equal(container.Args, testCase.expectedArgs) {
+				t.Errorf("expected args %v, got %v", testCase.expectedArgs, container.Args)
+			}
+
+			if len(container.Resources.Limits) != len(testCase.expectedResources) {
+				t.Errorf("expected resources %v, got %v", testCase.expectedResources, container.Resources.Limits)
+			}
+
+			for _, resourceName := range testCase.expectedResources {
+				if _, ok := container.Resources.Requests[resourceName]; !ok {
+					t.Errorf("expected %s to be requested", resourceName)
+				}
+			}
+
+			if pod.Spec.RestartPolicy != corev1.RestartPolicyNever {
+				t.Errorf("expected restart policy %s, got %s", corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+			}
+		})
+	}
+}
+
+func TestCreateRdmaWorkloadPod(t *testing.T) {
+	podBuilder, err := CreateRdmaWorkloadPod(clients.GetTestClients(clients.TestClientParams{}), "rdma-server",
+		"rdma-test", "yes", "server", "worker-0", "mlx5_0", "rdmashared-net", "rdma:latest", "ethernet", "none",
+		"shared-device")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pod := podBuilder.Definition
+
+	if pod.Namespace != "rdma-test" || pod.Spec.NodeSelector["kubernetes.io/hostname"] != "worker-0" {
+		t.Errorf("unexpected pod metadata %v / %v", pod.ObjectMeta, pod.Spec.NodeSelector)
+	}
+
+	if pod.Spec.RestartPolicy != "" {
+		t.Errorf("expected the legacy pod to keep the default restart policy, got %s", pod.Spec.RestartPolicy)
+	}
+
+	if _, ok := pod.Spec.Containers[0].Resources.Limits[RdmaSharedDeviceResourceName["ethernet"]]; !ok {
+		t.Error("expected the ethernet shared device to be requested")
+	}
+
+	if _, err := CreateRdmaWorkloadPod(clients.GetTestClients(clients.TestClientParams{}), "rdma-client",
+		"rdma-test", "no", "client", "worker-1", "mlx5_0", "rdmashared-net", "rdma:latest", "ethernet", "",
+		"shared-device"); err == nil {
+		t.Error("expected an error for a client without server IP")
+	}
+}
+
+func TestWorkloadServerIPHook(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+	server := testworkloads.NewBuilder(apiClient, "rdma-test",
+		NewWorkload("rdma-server", "rdma:latest", ModeServer).WithDevice("mlx5_0")).Create()
+
+	if err := server.Error(); err != nil {
+		t.Fatalf("failed to create the server pod: %v", err)
+	}
+
+	client := NewWorkload("rdma-client", "rdma:latest", ModeClient).WithDevice("mlx5_0")
+	hook := client.ServerIPHook(apiClient, "server")
+
+	if err := hook(map[string]*testworkloads.Builder{"other": server}); err == nil {
+		t.Error("expected an error when the server is not a dependency")
+	}
+
+	if err := hook(map[string]*testworkloads.Builder{"server": server}); err == nil {
+		t.Error("expected an error when the server has no network status")
+	}
+
+	serverPod, err := apiClient.Pods("rdma-test").Get(context.TODO(), "rdma-server", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the server pod: %v", err)
+	}
+
+	serverPod.Annotations["k8s.v1.cni.cncf.io/network-status"] =
+		`[{"interface":"eth0","ips":["10.128.0.10"]},{"interface":"net1","ips":["192.168.1.10"]}]`
+
+	if _, err := apiClient.Pods("rdma-test").Update(context.TODO(), serverPod, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update the server pod: %v", err)
+	}
+
+	if err := hook(map[string]*testworkloads.Builder{"server": server}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pod, err := client.BuildPodSpec()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Contains(pod.Spec.Containers[0].Args, "192.168.1.10") {
+		t.Errorf("expected the client to connect to the server net1 IP, got %v", pod.Spec.Containers[0].Args)
+	}
+}
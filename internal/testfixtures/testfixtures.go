@@ -0,0 +1,100 @@
+package testfixtures
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// ClusterPolicy is a gpu-cluster-policy ClusterPolicy with the commonly used components enabled.
+	ClusterPolicy = "clusterpolicy.yaml"
+	// CSV is the GPU operator ClusterServiceVersion with ClusterPolicy and NVIDIADriver alm-examples.
+	CSV = "csv.yaml"
+	// Subscription is the GPU operator Subscription referencing the CSV fixture.
+	Subscription = "subscription.yaml"
+	// MachineSets holds worker MachineSets with AWS, GCP, Azure and vSphere providerSpecs and an AWS infra MachineSet.
+	MachineSets = "machinesets.yaml"
+	// Nodes holds a master, two GPU workers and a worker without GPU, labeled the way NFD labels them.
+	Nodes = "nodes.yaml"
+	// Pods holds a running and a pending GPU driver pod, the GPU operator pod and an NFD worker pod.
+	Pods = "pods.yaml"
+)
+
+//go:embed testdata/*.yaml
+var fixturesFS embed.FS
+
+// Load decodes the objects stored in the given fixture files. A fixture file may hold several YAML documents.
+func Load(fixtures ...string) ([]runtime.Object, error) {
+	testScheme, err := clients.GetTestScheme()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the test scheme: %w", err)
+	}
+
+	decoder := serializer.NewCodecFactory(testScheme).UniversalDeserializer()
+
+	var objects []runtime.Object
+
+	for _, fixture := range fixtures {
+		glog.V(100).Infof("Loading test fixture %s", fixture)
+
+		content, err := fixturesFS.ReadFile(path.Join("testdata", fixture))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read test fixture %s: %w", fixture, err)
+		}
+
+		reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+
+		for {
+			document, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to read a document from test fixture %s: %w", fixture, err)
+			}
+
+			if len(bytes.TrimSpace(document)) == 0 {
+				continue
+			}
+
+			object, _, err := decoder.Decode(document, nil, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode a document from test fixture %s: %w", fixture, err)
+			}
+
+			objects = append(objects, object)
+		}
+	}
+
+	return objects, nil
+}
+
+// NewTestClients returns fake clients seeded with the objects stored in the given fixture files
+// and any additional objects.
+func NewTestClients(fixtures []string, objects ...runtime.Object) (*clients.Settings, error) {
+	fixtureObjects, err := Load(fixtures...)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient := clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: append(fixtureObjects, objects...),
+	})
+	if apiClient == nil {
+		return nil, fmt.Errorf("failed to initialize fake clients")
+	}
+
+	return apiClient, nil
+}
@@ -0,0 +1,63 @@
+package testfixtures
+
+import (
+	"fmt"
+	"testing"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		fixture       string
+		expectedCount int
+		expectedType  runtime.Object
+	}{
+		{fixture: ClusterPolicy, expectedCount: 1, expectedType: &nvidiagpuv1.ClusterPolicy{}},
+		{fixture: CSV, expectedCount: 1, expectedType: &oplmV1alpha1.ClusterServiceVersion{}},
+		{fixture: Subscription, expectedCount: 1, expectedType: &oplmV1alpha1.Subscription{}},
+		{fixture: MachineSets, expectedCount: 5, expectedType: &machinev1beta1.MachineSet{}},
+		{fixture: Nodes, expectedCount: 4, expectedType: &corev1.Node{}},
+		{fixture: Pods, expectedCount: 4, expectedType: &corev1.Pod{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.fixture, func(t *testing.T) {
+			objects, err := Load(testCase.fixture)
+			if err != nil {
+				t.Fatalf("failed to load fixture: %v", err)
+			}
+
+			if len(objects) != testCase.expectedCount {
+				t.Fatalf("expected %d objects, got %d", testCase.expectedCount, len(objects))
+			}
+
+			for _, object := range objects {
+				if got, want := fmt.Sprintf("%T", object), fmt.Sprintf("%T", testCase.expectedType); got != want {
+					t.Errorf("expected object of type %s, got %s", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadMissingFixture(t *testing.T) {
+	if _, err := Load("missing.yaml"); err == nil {
+		t.Error("expected an error when loading a missing fixture")
+	}
+}
+
+func TestNewTestClients(t *testing.T) {
+	apiClient, err := NewTestClients([]string{ClusterPolicy, CSV, Subscription, MachineSets, Nodes, Pods})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	if apiClient == nil {
+		t.Fatal("NewTestClients returned nil clients")
+	}
+}
@@ -1,6 +1,7 @@
 package testworkloads
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -8,16 +9,25 @@ import (
 	"github.com/golang/glog"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/configmap"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiasmi"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
+// startPollInterval is how often waitUntilStarted checks the pod phase.
+const startPollInterval = 2 * time.Second
+
 // Builder provides lifecycle management for test workloads.
 // It wraps pod.Builder and adds workload-specific validation and success criteria.
 type Builder struct {
 	workload   Workload
 	podBuilder *pod.Builder
-	errorMsg   string
+	// ConfigMaps created for the workload, deleted together with the pod
+	configMapBuilders []*configmap.Builder
+	errorMsg          string
 	// Stored for lazy initialization
 	apiClient *clients.Settings
 	namespace string
@@ -82,6 +92,11 @@ func (b *Builder) Create() *Builder {
 		return b
 	}
 
+	if err := b.createConfigMaps(); err != nil {
+		b.errorMsg = fmt.Sprintf("failed to create configmaps: %v", err)
+		return b
+	}
+
 	glog.V(gpuparams.GpuLogLevel).Infof("Creating workload pod in namespace %s", b.podBuilder.Definition.Namespace)
 
 	// Delegate to pod.Builder
@@ -151,6 +166,59 @@ func (b *Builder) WaitUntilSuccess(timeout time.Duration) *Builder {
 	return b
 }
 
+// CheckGPUs checks that the running workload pod sees at least one GPU, using nvidia-smi in its first container.
+// Returns the Builder for method chaining.
+func (b *Builder) CheckGPUs() *Builder {
+	if valid, _ := b.validate(); !valid {
+		return b
+	}
+
+	gpuCount, err := nvidiasmi.NewClient(b.podBuilder).GPUCount()
+	if err != nil {
+		b.errorMsg = fmt.Sprintf("failed to count the GPUs of the pod: %v", err)
+		return b
+	}
+
+	if gpuCount == 0 {
+		b.errorMsg = "no GPUs found in the pod"
+		return b
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Found %d GPUs in pod %s", gpuCount, b.podBuilder.Definition.Name)
+
+	return b
+}
+
+// waitUntilStarted waits for the pod to reach Running or Succeeded phase and returns the phase it reached.
+// A pod that Failed ends the wait with an error.
+func (b *Builder) waitUntilStarted(timeout time.Duration) (corev1.PodPhase, error) {
+	if valid, err := b.validate(); !valid {
+		return "", err
+	}
+
+	var phase corev1.PodPhase
+
+	err := wait.PollUntilContextTimeout(context.TODO(), startPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			startedPod, err := b.apiClient.Pods(b.namespace).Get(ctx, b.podBuilder.Definition.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, nil
+			}
+
+			phase = startedPod.Status.Phase
+			if phase == corev1.PodFailed {
+				return false, fmt.Errorf("pod %s failed", startedPod.Name)
+			}
+
+			return phase == corev1.PodRunning || phase == corev1.PodSucceeded, nil
+		})
+	if err != nil {
+		return phase, fmt.Errorf("pod did not start, last phase %q: %w", phase, err)
+	}
+
+	return phase, nil
+}
+
 // GetLogs retrieves logs from the specified container in the workload pod.
 func (b *Builder) GetLogs(collectionPeriod time.Duration, containerName string) (string, error) {
 	if valid, err := b.validate(); !valid {
@@ -181,25 +249,91 @@ func (b *Builder) GetFullLogs(containerName string) (string, error) {
 	return logs, nil
 }
 
-// Delete removes the workload pod from the cluster.
+// Delete removes the workload pod and the ConfigMaps created for it from the cluster.
+// Every deletion is attempted even if some fail, the failures are returned together.
 func (b *Builder) Delete() error {
+	var errs []error
+
 	if b.podBuilder == nil {
 		// Check if there was a prior error that prevented initialization
 		if err := b.Error(); err != nil {
-			return fmt.Errorf("cannot delete: %w", err)
+			errs = append(errs, fmt.Errorf("cannot delete: %w", err))
+		}
+	} else if b.podBuilder.Object != nil {
+		// Delegate to pod.Builder, a pod that was never created has nothing to delete
+		if _, err := b.podBuilder.Delete(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete pod: %w", err))
+		}
+	}
+
+	var remaining []*configmap.Builder
+
+	for _, configMapBuilder := range b.configMapBuilders {
+		if err := configMapBuilder.Delete(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete configmap %s: %w", configMapBuilder.Definition.Name, err))
+			remaining = append(remaining, configMapBuilder)
 		}
+	}
+
+	b.configMapBuilders = remaining
+
+	return errors.Join(errs...)
+}
+
+// createConfigMaps creates the ConfigMaps of workloads implementing ConfigMapProvider.
+// ConfigMaps that already exist are updated to match the workload and left in place on Delete.
+func (b *Builder) createConfigMaps() error {
+	provider, ok := b.workload.(ConfigMapProvider)
+	if !ok {
 		return nil
 	}
 
-	// Delegate to pod.Builder
-	_, err := b.podBuilder.Delete()
+	configMaps, err := provider.BuildConfigMaps()
 	if err != nil {
-		return fmt.Errorf("failed to delete pod: %w", err)
+		return err
+	}
+
+	for _, configMap := range configMaps {
+		configMapBuilder := configmap.NewBuilder(b.apiClient, configMap.Name, b.namespace).WithData(configMap.Data)
+
+		if configMapBuilder.Exists() {
+			glog.V(gpuparams.GpuLogLevel).Infof("Updating existing configmap %s in namespace %s",
+				configMap.Name, b.namespace)
+
+			if _, err := configMapBuilder.Update(); err != nil {
+				return fmt.Errorf("failed to update configmap %s: %w", configMap.Name, err)
+			}
+
+			continue
+		}
+
+		glog.V(gpuparams.GpuLogLevel).Infof("Creating workload configmap %s in namespace %s",
+			configMap.Name, b.namespace)
+
+		if _, err := configMapBuilder.Create(); err != nil {
+			return fmt.Errorf("failed to create configmap %s: %w", configMap.Name, err)
+		}
+
+		b.configMapBuilders = append(b.configMapBuilders, configMapBuilder)
 	}
 
 	return nil
 }
 
+// PodName returns the name of the workload pod, building the pod spec if needed.
+func (b *Builder) PodName() (string, error) {
+	if valid, err := b.validate(); !valid {
+		return "", err
+	}
+
+	return b.podBuilder.Definition.Name, nil
+}
+
+// Namespace returns the namespace of the workload pod.
+func (b *Builder) Namespace() string {
+	return b.namespace
+}
+
 // Error returns any error that occurred during builder operations.
 func (b *Builder) Error() error {
 	if b.errorMsg == "" {
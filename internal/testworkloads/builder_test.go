@@ -0,0 +1,93 @@
+package testworkloads
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// configMapWorkload is a fakeWorkload with an entrypoint ConfigMap.
+type configMapWorkload struct {
+	fakeWorkload
+}
+
+func (w *configMapWorkload) BuildConfigMaps() ([]*corev1.ConfigMap, error) {
+	return []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: w.podName + "-entrypoint"},
+		Data:       map[string]string{"entrypoint.sh": "exit 0"},
+	}}, nil
+}
+
+func TestBuilderDelete(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+
+	notCreated := NewBuilder(apiClient, testGroupNamespace, &fakeWorkload{podName: "not-created"})
+	if _, err := notCreated.PodName(); err != nil {
+		t.Fatalf("failed to build the pod spec: %v", err)
+	}
+
+	if err := notCreated.Delete(); err != nil {
+		t.Errorf("expected a pod that was never created to be skipped, got %v", err)
+	}
+
+	created := NewBuilder(apiClient, testGroupNamespace,
+		&configMapWorkload{fakeWorkload{podName: "created", phase: corev1.PodSucceeded}}).Create()
+	if err := created.Error(); err != nil {
+		t.Fatalf("failed to create the pod: %v", err)
+	}
+
+	// The pod is deleted behind the builder, its deletion fails but the ConfigMap is deleted anyway.
+	if err := apiClient.Pods(testGroupNamespace).Delete(context.TODO(), "created", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete the pod: %v", err)
+	}
+
+	if err := created.Delete(); err == nil {
+		t.Error("expected an error deleting a pod that no longer exists")
+	}
+
+	_, err := apiClient.ConfigMaps(testGroupNamespace).Get(context.TODO(), "created-entrypoint", metav1.GetOptions{})
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the ConfigMap to be deleted, got %v", err)
+	}
+}
+
+func TestBuilderCreateUpdatesExistingConfigMap(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+
+	_, err := apiClient.ConfigMaps(testGroupNamespace).Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "stale-entrypoint", Namespace: testGroupNamespace},
+		Data:       map[string]string{"entrypoint.sh": "exit 1"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to create the stale ConfigMap: %v", err)
+	}
+
+	builder := NewBuilder(apiClient, testGroupNamespace,
+		&configMapWorkload{fakeWorkload{podName: "stale", phase: corev1.PodSucceeded}}).Create()
+	if err := builder.Error(); err != nil {
+		t.Fatalf("failed to create the pod: %v", err)
+	}
+
+	configMap, err := apiClient.ConfigMaps(testGroupNamespace).Get(context.TODO(), "stale-entrypoint",
+		metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the ConfigMap: %v", err)
+	}
+
+	if configMap.Data["entrypoint.sh"] != "exit 0" {
+		t.Errorf("expected the ConfigMap to match the workload, got %v", configMap.Data)
+	}
+
+	if err := builder.Delete(); err != nil {
+		t.Fatalf("unexpected error deleting the workload: %v", err)
+	}
+
+	if _, err := apiClient.ConfigMaps(testGroupNamespace).Get(context.TODO(), "stale-entrypoint",
+		metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the pre-existing ConfigMap to be left in place: %v", err)
+	}
+}
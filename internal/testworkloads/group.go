@@ -0,0 +1,347 @@
+package testworkloads
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// DefaultDependencyTimeout is how long a Group waits for the dependencies of a member to be Running.
+const DefaultDependencyTimeout = 5 * time.Minute
+
+// StartHook is called right before a group member is created, once all its dependencies are Running.
+// The dependencies map holds the Builders of those dependencies keyed by member name, so the hook can
+// read their state, e.g. the IP of a server pod, and configure the workload of the member accordingly.
+type StartHook func(dependencies map[string]*Builder) error
+
+// groupMember is a named workload of a Group.
+type groupMember struct {
+	name      string
+	builder   *Builder
+	dependsOn []string
+	startHook StartHook
+}
+
+// Group manages the lifecycle of several workloads started in order, e.g. an RDMA server and its client,
+// or N identical worker pods started with a delay between them.
+type Group struct {
+	members           []*groupMember
+	startDelay        time.Duration
+	dependencyTimeout time.Duration
+	errorMsg          string
+	apiClient         *clients.Settings
+	namespace         string
+}
+
+// NewGroup creates a new empty Group of workloads in the given namespace.
+func NewGroup(apiClient *clients.Settings, namespace string) *Group {
+	glog.V(100).Infof("Initializing new workload group in namespace: %s", namespace)
+
+	return &Group{
+		dependencyTimeout: DefaultDependencyTimeout,
+		apiClient:         apiClient,
+		namespace:         namespace,
+	}
+}
+
+// Add appends a workload without dependencies to the group.
+func (g *Group) Add(name string, workload Workload) *Group {
+	return g.AddWithDependencies(name, workload, nil, nil)
+}
+
+// AddWithDependencies appends a workload that is only created once every member listed in dependsOn
+// is Running. The optional startHook is called with the Builders of those dependencies right before
+// the workload is created. Dependencies must have been added to the group before the member.
+func (g *Group) AddWithDependencies(name string, workload Workload, dependsOn []string, startHook StartHook) *Group {
+	if g.errorMsg != "" {
+		return g
+	}
+
+	glog.V(100).Infof("Adding workload %s to group in namespace %s, depends on %v", name, g.namespace, dependsOn)
+
+	if name == "" {
+		g.errorMsg = "group member name cannot be empty"
+
+		return g
+	}
+
+	if g.member(name) != nil {
+		g.errorMsg = fmt.Sprintf("group member %s already exists", name)
+
+		return g
+	}
+
+	for _, dependency := range dependsOn {
+		if g.member(dependency) == nil {
+			g.errorMsg = fmt.Sprintf("dependency %s of group member %s must be added before it", dependency, name)
+
+			return g
+		}
+	}
+
+	g.members = append(g.members, &groupMember{
+		name:      name,
+		builder:   NewBuilder(g.apiClient, g.namespace, workload),
+		dependsOn: dependsOn,
+		startHook: startHook,
+	})
+
+	return g
+}
+
+// WithStartDelay sets the delay between the creation of two consecutive members.
+func (g *Group) WithStartDelay(delay time.Duration) *Group {
+	if g.errorMsg != "" {
+		return g
+	}
+
+	if delay < 0 {
+		g.errorMsg = "start delay cannot be negative"
+
+		return g
+	}
+
+	g.startDelay = delay
+
+	return g
+}
+
+// WithDependencyTimeout sets how long to wait for the dependencies of a member to be Running.
+func (g *Group) WithDependencyTimeout(timeout time.Duration) *Group {
+	if g.errorMsg != "" {
+		return g
+	}
+
+	if timeout <= 0 {
+		g.errorMsg = "dependency timeout must be positive"
+
+		return g
+	}
+
+	g.dependencyTimeout = timeout
+
+	return g
+}
+
+// Builder returns the Builder of the named member, or nil if the group has no such member.
+func (g *Group) Builder(name string) *Builder {
+	if member := g.member(name); member != nil {
+		return member.builder
+	}
+
+	return nil
+}
+
+// Create creates the members in the order they were added. A member with dependencies is created once
+// all of them are Running and its start hook succeeded. Creation stops at the first failure.
+func (g *Group) Create() *Group {
+	if valid, _ := g.validate(); !valid {
+		return g
+	}
+
+	running := make(map[string]bool)
+
+	for index, member := range g.members {
+		if index > 0 && g.startDelay > 0 {
+			glog.V(gpuparams.GpuLogLevel).Infof("Waiting %s before creating group member %s", g.startDelay, member.name)
+			time.Sleep(g.startDelay)
+		}
+
+		dependencies := make(map[string]*Builder)
+
+		for _, dependency := range member.dependsOn {
+			dependencyBuilder := g.Builder(dependency)
+
+			if !running[dependency] {
+				glog.V(gpuparams.GpuLogLevel).Infof("Waiting for group member %s to be running before creating %s",
+					dependency, member.name)
+
+				if err := dependencyBuilder.WaitUntilRunning(g.dependencyTimeout).Error(); err != nil {
+					g.errorMsg = fmt.Sprintf("dependency %s of group member %s is not running: %v",
+						dependency, member.name, err)
+
+					return g
+				}
+
+				running[dependency] = true
+			}
+
+			dependencies[dependency] = dependencyBuilder
+		}
+
+		if member.startHook != nil {
+			if err := member.startHook(dependencies); err != nil {
+				g.errorMsg = fmt.Sprintf("start hook of group member %s failed: %v", member.name, err)
+
+				return g
+			}
+		}
+
+		if err := member.builder.Create().Error(); err != nil {
+			g.errorMsg = fmt.Sprintf("failed to create group member %s: %v", member.name, err)
+
+			return g
+		}
+	}
+
+	return g
+}
+
+// WaitUntilRunning waits for every member to reach Running phase, all members share the timeout.
+func (g *Group) WaitUntilRunning(timeout time.Duration) *Group {
+	return g.forEachMember(timeout, func(builder *Builder, remaining time.Duration) error {
+		return builder.WaitUntilRunning(remaining).Error()
+	})
+}
+
+// WaitUntilSuccess waits for every member to reach Succeeded phase and validates its success criteria,
+// all members share the timeout. The failures of all members are reported, not only the first one.
+func (g *Group) WaitUntilSuccess(timeout time.Duration) *Group {
+	return g.forEachMember(timeout, func(builder *Builder, remaining time.Duration) error {
+		return builder.WaitUntilSuccess(remaining).Error()
+	})
+}
+
+// CheckGPUs waits for every member to start and checks that the running ones see at least one GPU, all members
+// share the timeout. Members that already Succeeded, e.g. when the start delay is longer than their run, are
+// skipped since nvidia-smi can no longer run in them.
+func (g *Group) CheckGPUs(timeout time.Duration) *Group {
+	return g.forEachMember(timeout, func(builder *Builder, remaining time.Duration) error {
+		phase, err := builder.waitUntilStarted(remaining)
+		if err != nil {
+			return err
+		}
+
+		if phase == corev1.PodSucceeded {
+			glog.V(gpuparams.GpuLogLevel).Infof("Pod %s already succeeded, skipping its GPU check",
+				builder.podBuilder.Definition.Name)
+
+			return nil
+		}
+
+		return builder.CheckGPUs().Error()
+	})
+}
+
+// CheckSuccess validates the success criteria of every member without waiting for a pod phase,
+// e.g. for long running workloads. The failures of all members are reported, not only the first one.
+func (g *Group) CheckSuccess() *Group {
+	return g.forEachMember(0, func(builder *Builder, _ time.Duration) error {
+		if valid, err := builder.validate(); !valid {
+			return err
+		}
+
+		return builder.workload.CheckSuccess(builder)
+	})
+}
+
+// GetFullLogs retrieves the logs of every container of every created member, keyed by member name then
+// container name. Prior wait or success check failures do not prevent log collection.
+// Logs that could be retrieved are returned together with the errors of the others.
+func (g *Group) GetFullLogs() (map[string]map[string]string, error) {
+	var errs []error
+
+	logs := make(map[string]map[string]string)
+
+	for _, member := range g.members {
+		podBuilder := member.builder.podBuilder
+		if podBuilder == nil || podBuilder.Object == nil {
+			continue
+		}
+
+		logs[member.name] = make(map[string]string)
+
+		for _, container := range podBuilder.Definition.Spec.Containers {
+			containerLogs, err := podBuilder.GetFullLog(container.Name)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("group member %s: %w", member.name, err))
+
+				continue
+			}
+
+			logs[member.name][container.Name] = containerLogs
+		}
+	}
+
+	return logs, errors.Join(errs...)
+}
+
+// Delete removes all members from the cluster in the reverse order of their creation.
+// All members are deleted even if some deletions fail.
+func (g *Group) Delete() error {
+	var errs []error
+
+	for index := len(g.members) - 1; index >= 0; index-- {
+		member := g.members[index]
+
+		if err := member.builder.Delete(); err != nil {
+			errs = append(errs, fmt.Errorf("group member %s: %w", member.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Error returns any error that occurred during group operations.
+func (g *Group) Error() error {
+	if g.errorMsg == "" {
+		return nil
+	}
+
+	return errors.New(g.errorMsg)
+}
+
+// validate checks if the group is in a valid state.
+func (g *Group) validate() (bool, error) {
+	if g.errorMsg != "" {
+		return false, errors.New(g.errorMsg)
+	}
+
+	if len(g.members) == 0 {
+		g.errorMsg = "workload group has no members"
+
+		return false, errors.New(g.errorMsg)
+	}
+
+	return true, nil
+}
+
+// forEachMember calls check for every member with the time left until the shared timeout expires
+// and records the failures of all members in the group error.
+func (g *Group) forEachMember(timeout time.Duration, check func(builder *Builder, remaining time.Duration) error) *Group {
+	if valid, _ := g.validate(); !valid {
+		return g
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	var errs []error
+
+	for _, member := range g.members {
+		if err := check(member.builder, max(time.Until(deadline), 0)); err != nil {
+			errs = append(errs, fmt.Errorf("group member %s: %w", member.name, err))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		g.errorMsg = err.Error()
+	}
+
+	return g
+}
+
+// member returns the named member, or nil if the group has no such member.
+func (g *Group) member(name string) *groupMember {
+	for _, member := range g.members {
+		if member.name == name {
+			return member
+		}
+	}
+
+	return nil
+}
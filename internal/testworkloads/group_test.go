@@ -0,0 +1,226 @@
+package testworkloads
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testGroupNamespace = "test-group"
+
+// fakeWorkload creates pods already in the given phase, the fake clients do not run pods.
+type fakeWorkload struct {
+	podName    string
+	phase      corev1.PodPhase
+	successErr error
+	serverName string
+}
+
+func (w *fakeWorkload) BuildPodSpec() (*corev1.Pod, error) {
+	pod := NewUnprivilegedPod(w.podName,
+		[]corev1.Container{NewUnprivilegedContainer("ctr", "image:latest", corev1.ResourceRequirements{})},
+		nil, nil, map[string]string{"app": "group-test"})
+	pod.Status.Phase = w.phase
+
+	if w.serverName != "" {
+		pod.Annotations = map[string]string{"server": w.serverName}
+	}
+
+	return pod, nil
+}
+
+func (w *fakeWorkload) CheckSuccess(_ *Builder) error {
+	return w.successErr
+}
+
+func TestGroupCreate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		serverPhase   corev1.PodPhase
+		dependsOn     []string
+		expectedPods  []string
+		expectedError string
+	}{
+		{
+			name:         "client starts after running server",
+			serverPhase:  corev1.PodRunning,
+			dependsOn:    []string{"server"},
+			expectedPods: []string{"server-pod", "client-pod"},
+		},
+		{
+			name:          "server never running",
+			serverPhase:   corev1.PodPending,
+			dependsOn:     []string{"server"},
+			expectedPods:  []string{"server-pod"},
+			expectedError: "dependency server of group member client is not running",
+		},
+		{
+			name:          "unknown dependency",
+			serverPhase:   corev1.PodRunning,
+			dependsOn:     []string{"other"},
+			expectedError: "dependency other of group member client must be added before it",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := clients.GetTestClients(clients.TestClientParams{})
+			client := &fakeWorkload{podName: "client-pod", phase: corev1.PodPending}
+
+			group := NewGroup(apiClient, testGroupNamespace).
+				WithDependencyTimeout(time.Second).
+				Add("server", &fakeWorkload{podName: "server-pod", phase: testCase.serverPhase}).
+				AddWithDependencies("client", client, testCase.dependsOn,
+					func(dependencies map[string]*Builder) error {
+						serverName, err := dependencies["server"].PodName()
+						client.serverName = serverName
+
+						return err
+					}).
+				Create()
+
+			if testCase.expectedError == "" && group.Error() != nil {
+				t.Fatalf("unexpected error: %v", group.Error())
+			}
+
+			if testCase.expectedError != "" &&
+				(group.Error() == nil || !strings.Contains(group.Error().Error(), testCase.expectedError)) {
+				t.Fatalf("expected error containing %q, got %v", testCase.expectedError, group.Error())
+			}
+
+			pods, err := apiClient.Pods(testGroupNamespace).List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("failed to list pods: %v", err)
+			}
+
+			if len(pods.Items) != len(testCase.expectedPods) {
+				t.Fatalf("expected pods %v, got %d pods", testCase.expectedPods, len(pods.Items))
+			}
+
+			for _, podName := range testCase.expectedPods {
+				if _, err := apiClient.Pods(testGroupNamespace).Get(context.TODO(), podName, metav1.GetOptions{}); err != nil {
+					t.Errorf("expected pod %s to be created: %v", podName, err)
+				}
+			}
+
+			if testCase.expectedError == "" {
+				clientPod, _ := apiClient.Pods(testGroupNamespace).Get(context.TODO(), "client-pod", metav1.GetOptions{})
+				if clientPod.Annotations["server"] != "server-pod" {
+					t.Errorf("expected the start hook to pass the server pod name, got %v", clientPod.Annotations)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupWaitUntilSuccess(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+
+	group := NewGroup(apiClient, testGroupNamespace).
+		Add("worker-0", &fakeWorkload{podName: "worker-0", phase: corev1.PodSucceeded}).
+		Add("worker-1", &fakeWorkload{podName: "worker-1", phase: corev1.PodSucceeded,
+			successErr: fmt.Errorf("workload output not found")}).
+		Add("worker-2", &fakeWorkload{podName: "worker-2", phase: corev1.PodSucceeded}).
+		Create()
+
+	if err := group.Error(); err != nil {
+		t.Fatalf("unexpected error creating the group: %v", err)
+	}
+
+	err := group.WaitUntilSuccess(time.Second).Error()
+	if err == nil {
+		t.Fatal("expected worker-1 to fail")
+	}
+
+	if !strings.Contains(err.Error(), "group member worker-1") || strings.Contains(err.Error(), "worker-0") ||
+		strings.Contains(err.Error(), "worker-2") {
+		t.Errorf("expected only worker-1 to be reported, got %v", err)
+	}
+
+	logs, err := group.GetFullLogs()
+	if err != nil {
+		t.Fatalf("unexpected error getting logs: %v", err)
+	}
+
+	if len(logs) != 3 || logs["worker-2"]["ctr"] == "" {
+		t.Errorf("expected the logs of every member, got %v", logs)
+	}
+
+	if err := group.Delete(); err != nil {
+		t.Fatalf("unexpected error deleting the group: %v", err)
+	}
+
+	pods, _ := apiClient.Pods(testGroupNamespace).List(context.TODO(), metav1.ListOptions{})
+	if len(pods.Items) != 0 {
+		t.Errorf("expected all pods to be deleted, %d left", len(pods.Items))
+	}
+}
+
+func TestGroupCheckGPUs(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+
+	group := NewGroup(apiClient, testGroupNamespace).
+		Add("completed", &fakeWorkload{podName: "completed", phase: corev1.PodSucceeded}).
+		Add("failed", &fakeWorkload{podName: "failed", phase: corev1.PodFailed}).
+		Add("pending", &fakeWorkload{podName: "pending", phase: corev1.PodPending}).
+		Create()
+
+	if err := group.Error(); err != nil {
+		t.Fatalf("unexpected error creating the group: %v", err)
+	}
+
+	err := group.CheckGPUs(time.Second).Error()
+	if err == nil {
+		t.Fatal("expected the failed and pending members to be reported")
+	}
+
+	if strings.Contains(err.Error(), "group member completed") || !strings.Contains(err.Error(), "group member failed") ||
+		!strings.Contains(err.Error(), "group member pending") {
+		t.Errorf("expected only the failed and pending members to be reported, got %v", err)
+	}
+}
+
+func TestGroupValidation(t *testing.T) {
+	apiClient := clients.GetTestClients(clients.TestClientParams{})
+
+	testCases := []struct {
+		name          string
+		group         *Group
+		expectedError string
+	}{
+		{
+			name:          "empty group",
+			group:         NewGroup(apiClient, testGroupNamespace),
+			expectedError: "workload group has no members",
+		},
+		{
+			name: "duplicate member",
+			group: NewGroup(apiClient, testGroupNamespace).
+				Add("worker", &fakeWorkload{podName: "worker-0"}).
+				Add("worker", &fakeWorkload{podName: "worker-1"}),
+			expectedError: "group member worker already exists",
+		},
+		{
+			name: "negative start delay",
+			group: NewGroup(apiClient, testGroupNamespace).
+				Add("worker", &fakeWorkload{podName: "worker-0"}).
+				WithStartDelay(-time.Second),
+			expectedError: "start delay cannot be negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.group.Create().Error()
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
@@ -16,3 +16,11 @@ type Workload interface {
 	// Return nil if the workload completed successfully.
 	CheckSuccess(builder *Builder) error
 }
+
+// ConfigMapProvider is implemented by workloads that need ConfigMaps, e.g. an entrypoint script,
+// in the workload namespace before the pod is created.
+type ConfigMapProvider interface {
+	// BuildConfigMaps returns the ConfigMaps consumed by the workload pod.
+	// The namespace is set by the Builder.
+	BuildConfigMaps() ([]*corev1.ConfigMap, error)
+}
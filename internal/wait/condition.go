@@ -0,0 +1,129 @@
+package wait
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Condition decides whether an object observed by ForObject satisfies a wait.
+type Condition interface {
+	// Check reports whether the object, nil while it does not exist, satisfies the condition and describes the
+	// state it observed. An error stops the wait.
+	Check(object *unstructured.Unstructured) (met bool, state string, err error)
+	// String describes the awaited state in progress lines and errors.
+	String() string
+}
+
+// JSONPath returns a Condition met when the JSONPath expression, e.g. {.status.state} or .status.state, evaluates
+// to a single value equal to the expected one, the same way "kubectl wait --for=jsonpath" does.
+func JSONPath(expression, value string) Condition {
+	if !strings.HasPrefix(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+
+	return &jsonPathCondition{expression: expression, value: value}
+}
+
+// Exists returns a Condition met as soon as the object exists.
+func Exists() Condition {
+	return existsCondition{}
+}
+
+// Func returns a Condition met when check returns true. The description names the awaited state and check is never
+// called with a nil object.
+func Func(description string, check func(object *unstructured.Unstructured) (bool, string, error)) Condition {
+	return &funcCondition{description: description, check: check}
+}
+
+// FromUnstructured converts an object observed by ForObject into its typed form for Func conditions.
+func FromUnstructured(object *unstructured.Unstructured, into any) error {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.UnstructuredContent(), into); err != nil {
+		return fmt.Errorf("failed to convert %s %s: %w", object.GetKind(), object.GetName(), err)
+	}
+
+	return nil
+}
+
+type jsonPathCondition struct {
+	expression string
+	value      string
+}
+
+// Check implements Condition.
+func (condition *jsonPathCondition) Check(object *unstructured.Unstructured) (bool, string, error) {
+	if object == nil {
+		return false, "not found", nil
+	}
+
+	parser := jsonpath.New("condition").AllowMissingKeys(true)
+	if err := parser.Parse(condition.expression); err != nil {
+		return false, "", fmt.Errorf("invalid jsonpath expression %s: %w", condition.expression, err)
+	}
+
+	results, err := parser.FindResults(object.UnstructuredContent())
+	if err != nil {
+		return false, "", fmt.Errorf("failed to evaluate jsonpath expression %s: %w", condition.expression, err)
+	}
+
+	var values []string
+
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, fmt.Sprint(value.Interface()))
+		}
+	}
+
+	switch len(values) {
+	case 0:
+		return false, condition.expression + " is not set", nil
+	case 1:
+		return values[0] == condition.value, condition.expression + "=" + values[0], nil
+	default:
+		return false, "", fmt.Errorf("jsonpath expression %s matches %d values, expected a single one",
+			condition.expression, len(values))
+	}
+}
+
+// String implements Condition.
+func (condition *jsonPathCondition) String() string {
+	return condition.expression + "=" + condition.value
+}
+
+type existsCondition struct{}
+
+// Check implements Condition.
+func (existsCondition) Check(object *unstructured.Unstructured) (bool, string, error) {
+	if object == nil {
+		return false, "not found", nil
+	}
+
+	return true, "found", nil
+}
+
+// String implements Condition.
+func (existsCondition) String() string {
+	return "exists"
+}
+
+type funcCondition struct {
+	description string
+	check       func(object *unstructured.Unstructured) (bool, string, error)
+}
+
+// Check implements Condition.
+func (condition *funcCondition) Check(object *unstructured.Unstructured) (bool, string, error) {
+	if object == nil {
+		return false, "not found", nil
+	}
+
+	return condition.check(object)
+}
+
+// String implements Condition.
+func (condition *funcCondition) String() string {
+	return condition.description
+}
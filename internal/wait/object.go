@@ -0,0 +1,195 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// ObjectRef identifies the object ForObject observes through the dynamic client.
+type ObjectRef struct {
+	GVR       schema.GroupVersionResource
+	Namespace string
+	Name      string
+}
+
+// String returns the resource and the namespaced name of the object.
+func (ref ObjectRef) String() string {
+	if ref.Namespace == "" {
+		return ref.GVR.Resource + " " + ref.Name
+	}
+
+	return ref.GVR.Resource + " " + ref.Namespace + "/" + ref.Name
+}
+
+// TimeoutError is returned by ForObject when the object does not satisfy the condition in time.
+type TimeoutError struct {
+	Object    ObjectRef
+	Condition string
+	Elapsed   time.Duration
+	// LastObserved is the object as last observed, nil when it was never found.
+	LastObserved *unstructured.Unstructured
+	// LastState is the state the condition described on the last poll.
+	LastState string
+	Err       error
+}
+
+// Error returns the awaited and the last observed state of the object.
+func (timeoutErr *TimeoutError) Error() string {
+	return fmt.Sprintf("%s did not satisfy %s within %s, last observed %s: %v", timeoutErr.Object,
+		timeoutErr.Condition, timeoutErr.Elapsed.Round(time.Second), timeoutErr.LastState, timeoutErr.Err)
+}
+
+// Unwrap returns the error of the poll, e.g. context.DeadlineExceeded.
+func (timeoutErr *TimeoutError) Unwrap() error {
+	return timeoutErr.Err
+}
+
+// Record is how long a ForObject wait took.
+type Record struct {
+	Object    ObjectRef
+	Condition string
+	Elapsed   time.Duration
+	Err       error
+}
+
+var (
+	recordsMu sync.Mutex
+	records   []Record
+
+	progressMu     sync.Mutex
+	progressWriter io.Writer
+)
+
+// SetProgressWriter sets where ForObject streams the observed state of the objects it waits for, e.g. the
+// GinkgoWriter. Without a writer the progress is only logged.
+func SetProgressWriter(writer io.Writer) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+
+	progressWriter = writer
+}
+
+// writeProgress streams a line of progress to the progress writer, or logs it when there is none.
+func writeProgress(format string, args ...any) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+
+	if progressWriter == nil {
+		glog.V(gpuparams.Gpu100LogLevel).Infof(format, args...)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(progressWriter, format+"\n", args...)
+}
+
+// Records returns the waits ForObject completed so far, in completion order.
+func Records() []Record {
+	recordsMu.Lock()
+	defer recordsMu.Unlock()
+
+	return slices.Clone(records)
+}
+
+// ForObject polls the object through the dynamic client until it satisfies the condition, streaming the observed
+// state to the progress writer. A missing object keeps the wait polling, any other error of the dynamic client or of
+// the condition stops it. It returns the last observed object, which is nil when it was never found; when the
+// condition is not met in time or the context ends the error is a *TimeoutError.
+func ForObject(ctx context.Context, apiClient *clients.Settings, object ObjectRef, condition Condition, pollInterval,
+	timeout time.Duration) (*unstructured.Unstructured, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("cannot wait for %s with nil apiClient", object)
+	}
+
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Waiting for %s to satisfy %s", object, condition)
+
+	var (
+		lastObserved *unstructured.Unstructured
+		lastState    string
+		stopErr      error
+	)
+
+	start := time.Now()
+
+	err := wait.PollUntilContextTimeout(ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		observed, err := getObject(ctx, apiClient, object)
+		if err != nil {
+			stopErr = fmt.Errorf("failed to get %s: %w", object, err)
+
+			return false, stopErr
+		}
+
+		lastObserved = observed
+
+		met, state, err := condition.Check(observed)
+		if err != nil {
+			stopErr = fmt.Errorf("failed to check %s: %w", object, err)
+
+			return false, stopErr
+		}
+
+		lastState = state
+
+		writeProgress("%s: %s, waiting for %s (%s elapsed)", object, state, condition,
+			time.Since(start).Round(time.Second))
+
+		return met, nil
+	})
+
+	elapsed := time.Since(start)
+
+	if err != nil && !errors.Is(err, stopErr) {
+		err = &TimeoutError{Object: object, Condition: condition.String(), Elapsed: elapsed,
+			LastObserved: lastObserved, LastState: lastState, Err: err}
+	}
+
+	recordsMu.Lock()
+	records = append(records, Record{Object: object, Condition: condition.String(), Elapsed: elapsed, Err: err})
+	recordsMu.Unlock()
+
+	if err != nil {
+		glog.V(gpuparams.GpuLogLevel).Infof("Waiting for %s failed after %s: %v", object, elapsed, err)
+
+		return lastObserved, err
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("%s satisfies %s after %s", object, condition, elapsed)
+
+	return lastObserved, nil
+}
+
+// getObject returns the object, or nil when it does not exist.
+func getObject(ctx context.Context, apiClient *clients.Settings, object ObjectRef) (*unstructured.Unstructured,
+	error) {
+	var (
+		observed *unstructured.Unstructured
+		err      error
+	)
+
+	if object.Namespace == "" {
+		observed, err = apiClient.Resource(object.GVR).Get(ctx, object.Name, metav1.GetOptions{})
+	} else {
+		observed, err = apiClient.Resource(object.GVR).Namespace(object.Namespace).Get(ctx, object.Name,
+			metav1.GetOptions{})
+	}
+
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	return observed, err
+}
@@ -0,0 +1,325 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	networkoperator "github.com/Mellanox/network-operator/api/v1alpha1"
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testClusterPolicy = "gpu-cluster-policy"
+	testNamespace     = "nvidia-gpu-operator"
+	testPollInterval  = time.Millisecond
+	testTimeout       = 10 * time.Millisecond
+)
+
+func newTestClusterPolicy(state nvidiagpuv1.State) *nvidiagpuv1.ClusterPolicy {
+	return &nvidiagpuv1.ClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterPolicy},
+		Status:     nvidiagpuv1.ClusterPolicyStatus{State: state},
+	}
+}
+
+func newTestDaemonSet(available int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-driver-daemonset", Namespace: testNamespace, Generation: 2},
+		Status: appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2,
+			NumberAvailable: available},
+	}
+}
+
+func newTestClients(t *testing.T, objects ...runtime.Object) *clients.Settings {
+	t.Helper()
+
+	apiClient, err := testfixtures.NewTestClients(nil, objects...)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	return apiClient
+}
+
+func TestJSONPath(t *testing.T) {
+	object := &unstructured.Unstructured{Object: map[string]any{
+		"status": map[string]any{
+			"state":      "ready",
+			"replicas":   int64(2),
+			"conditions": []any{map[string]any{"type": "Ready"}, map[string]any{"type": "Available"}},
+		},
+	}}
+
+	testCases := []struct {
+		name          string
+		condition     Condition
+		object        *unstructured.Unstructured
+		expectedMet   bool
+		expectedState string
+		expectedError string
+	}{
+		{
+			name:          "met",
+			condition:     JSONPath("{.status.state}", "ready"),
+			object:        object,
+			expectedMet:   true,
+			expectedState: "{.status.state}=ready",
+		},
+		{
+			name:          "relaxed expression with a number",
+			condition:     JSONPath(".status.replicas", "2"),
+			object:        object,
+			expectedMet:   true,
+			expectedState: "{.status.replicas}=2",
+		},
+		{
+			name:          "different value",
+			condition:     JSONPath("{.status.state}", "notReady"),
+			object:        object,
+			expectedState: "{.status.state}=ready",
+		},
+		{
+			name:          "missing key",
+			condition:     JSONPath("{.status.phase}", "Succeeded"),
+			object:        object,
+			expectedState: "{.status.phase} is not set",
+		},
+		{
+			name:          "missing object",
+			condition:     JSONPath("{.status.state}", "ready"),
+			expectedState: "not found",
+		},
+		{
+			name:          "several values",
+			condition:     JSONPath("{.status.conditions[*].type}", "Ready"),
+			object:        object,
+			expectedError: "jsonpath expression {.status.conditions[*].type} matches 2 values",
+		},
+		{
+			name:          "invalid expression",
+			condition:     JSONPath("{.status.state", "ready"),
+			object:        object,
+			expectedError: "invalid jsonpath expression {.status.state",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			met, state, err := testCase.condition.Check(testCase.object)
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Fatalf("expected error %q, got %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil || met != testCase.expectedMet || state != testCase.expectedState {
+				t.Errorf("expected met %t with state %q, got %t with state %q: %v", testCase.expectedMet,
+					testCase.expectedState, met, state, err)
+			}
+		})
+	}
+}
+
+func TestForObject(t *testing.T) {
+	apiClient := newTestClients(t, newTestClusterPolicy(nvidiagpuv1.NotReady))
+	ref := clusterPolicyRef(testClusterPolicy)
+
+	observed, err := ForObject(context.TODO(), apiClient, ref, JSONPath("{.status.state}", "notReady"),
+		testPollInterval, testTimeout)
+	if err != nil || observed.GetName() != testClusterPolicy {
+		t.Fatalf("expected the notReady ClusterPolicy to be returned, got %v: %v", observed, err)
+	}
+
+	observed, err = ForObject(context.TODO(), apiClient, ref, JSONPath("{.status.state}", "ready"),
+		testPollInterval, testTimeout)
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a TimeoutError, got %v", err)
+	}
+
+	if observed == nil || timeoutErr.LastObserved != observed || timeoutErr.LastState != "{.status.state}=notReady" {
+		t.Errorf("expected the last observed notReady ClusterPolicy, got %v in state %q", timeoutErr.LastObserved,
+			timeoutErr.LastState)
+	}
+
+	if !strings.HasPrefix(err.Error(), "clusterpolicies gpu-cluster-policy did not satisfy {.status.state}=ready") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+
+	_, err = ForObject(context.TODO(), apiClient, clusterPolicyRef("missing"), Exists(), testPollInterval,
+		testTimeout)
+	if !errors.As(err, &timeoutErr) || timeoutErr.LastObserved != nil || timeoutErr.LastState != "not found" {
+		t.Errorf("expected a TimeoutError for the missing ClusterPolicy, got %v", err)
+	}
+
+	_, err = ForObject(context.TODO(), apiClient, ref, JSONPath("{.status.state", "ready"), testPollInterval,
+		testTimeout)
+	if err == nil || errors.As(err, &timeoutErr) {
+		t.Errorf("expected an invalid condition to stop the wait, got %v", err)
+	}
+
+	records := Records()
+	if len(records) < 4 || records[len(records)-4].Object != ref || records[len(records)-4].Err != nil ||
+		records[len(records)-3].Err == nil {
+		t.Errorf("expected the waits to be recorded, got %+v", records)
+	}
+
+	if _, err := ForObject(context.TODO(), nil, ref, Exists(), testPollInterval, testTimeout); err == nil {
+		t.Errorf("expected an error with nil apiClient")
+	}
+}
+
+func TestForObjectProgress(t *testing.T) {
+	apiClient := newTestClients(t, newTestClusterPolicy(nvidiagpuv1.Ready))
+
+	var progress bytes.Buffer
+
+	SetProgressWriter(&progress)
+	defer SetProgressWriter(nil)
+
+	_, err := ForObject(context.TODO(), apiClient, clusterPolicyRef(testClusterPolicy),
+		JSONPath("{.status.state}", "ready"), testPollInterval, testTimeout)
+	if err != nil {
+		t.Fatalf("failed to wait for the ready ClusterPolicy: %v", err)
+	}
+
+	if !strings.HasPrefix(progress.String(), "clusterpolicies gpu-cluster-policy: {.status.state}=ready, waiting for") {
+		t.Errorf("expected the progress to be streamed to the writer, got %q", progress.String())
+	}
+}
+
+func TestReadyWrappers(t *testing.T) {
+	csv := &oplmV1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator-certified.v24.9.2", Namespace: testNamespace},
+		Status:     oplmV1alpha1.ClusterServiceVersionStatus{Phase: oplmV1alpha1.CSVPhaseSucceeded},
+	}
+
+	nicClusterPolicy := &networkoperator.NicClusterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "nic-cluster-policy"},
+		Status:     networkoperator.NicClusterPolicyStatus{State: networkoperator.StateReady},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator", Namespace: testNamespace},
+	}
+
+	apiClient := newTestClients(t, newTestClusterPolicy(nvidiagpuv1.Ready), csv, nicClusterPolicy, deployment,
+		newTestDaemonSet(2))
+
+	testCases := map[string]error{
+		"ClusterPolicyReady": ClusterPolicyReady(apiClient, testClusterPolicy, testPollInterval, testTimeout),
+		"CSVSucceeded":       CSVSucceeded(apiClient, csv.Name, testNamespace, testPollInterval, testTimeout),
+		"NicClusterPolicyReady": NicClusterPolicyReady(apiClient, nicClusterPolicy.Name, testPollInterval,
+			testTimeout),
+		"DaemonSetReady": DaemonSetReady(apiClient, "nvidia-driver-daemonset", testNamespace, testPollInterval,
+			testTimeout),
+	}
+
+	for name, err := range testCases {
+		if err != nil {
+			t.Errorf("expected %s to succeed, got %v", name, err)
+		}
+	}
+
+	if !DeploymentCreated(apiClient, deployment.Name, testNamespace, testPollInterval, testTimeout) {
+		t.Errorf("expected the deployment to be found")
+	}
+
+	if DeploymentCreated(apiClient, "missing", testNamespace, testPollInterval, testTimeout) {
+		t.Errorf("expected the missing deployment not to be found")
+	}
+}
+
+func TestReadyWrappersContextCanceled(t *testing.T) {
+	apiClient := newTestClients(t, newTestClusterPolicy(nvidiagpuv1.NotReady), newTestDaemonSet(1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := map[string]error{
+		"ClusterPolicyReadyContext": ClusterPolicyReadyContext(ctx, apiClient, testClusterPolicy, testPollInterval,
+			time.Hour),
+		"DaemonSetReadyContext": DaemonSetReadyContext(ctx, apiClient, "nvidia-driver-daemonset", testNamespace,
+			testPollInterval, time.Hour),
+		"NicClusterPolicyReadyContext": NicClusterPolicyReadyContext(ctx, apiClient, "nic-cluster-policy",
+			testPollInterval, time.Hour),
+	}
+
+	for name, err := range testCases {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected %s to stop with the canceled context, got %v", name, err)
+		}
+	}
+}
+
+func TestDaemonSetReadyTimeout(t *testing.T) {
+	apiClient := newTestClients(t, newTestDaemonSet(1))
+
+	err := DaemonSetReady(apiClient, "nvidia-driver-daemonset", testNamespace, testPollInterval, testTimeout)
+
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.LastState != "1/2 pods available" {
+		t.Errorf("expected a TimeoutError with 1/2 pods available, got %v", err)
+	}
+}
+
+func TestClusterPolicyRestored(t *testing.T) {
+	testCases := []struct {
+		name          string
+		state         nvidiagpuv1.State
+		expectedError bool
+	}{
+		{name: "ready", state: nvidiagpuv1.Ready},
+		{name: "never ready", state: nvidiagpuv1.NotReady, expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient := newTestClients(t, newTestClusterPolicy(testCase.state))
+
+			snapshot, err := nvidiagpu.TakeSnapshot(apiClient, testClusterPolicy, testNamespace, nil)
+			if err != nil {
+				t.Fatalf("failed to take snapshot: %v", err)
+			}
+
+			// Nothing to restore, the readiness is not waited for.
+			if err := ClusterPolicyRestored(apiClient, snapshot, testPollInterval, testTimeout); err != nil {
+				t.Fatalf("unexpected error restoring an unchanged snapshot: %v", err)
+			}
+
+			builder, err := nvidiagpu.Pull(apiClient, testClusterPolicy)
+			if err != nil {
+				t.Fatalf("failed to pull the clusterpolicy: %v", err)
+			}
+
+			if _, err := builder.WithMIGStrategy(nvidiagpuv1.MIGStrategyMixed).Update(false); err != nil {
+				t.Fatalf("failed to update the clusterpolicy: %v", err)
+			}
+
+			err = ClusterPolicyRestored(apiClient, snapshot, testPollInterval, testTimeout)
+			if (err != nil) != testCase.expectedError {
+				t.Errorf("expected error %v, got %v", testCase.expectedError, err)
+			}
+
+			restored, err := builder.Get()
+			if err != nil || restored.Spec.MIG.Strategy != snapshot.ClusterPolicy.Spec.MIG.Strategy {
+				t.Errorf("expected the MIG strategy to be restored, got %v, %v", restored, err)
+			}
+		})
+	}
+}
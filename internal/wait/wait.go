@@ -5,138 +5,119 @@ import (
 	"fmt"
 	"time"
 
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	"github.com/golang/glog"
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/deployment"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nodes"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // ClusterPolicyReady Waits until clusterPolicy is Ready.
+// On failure the error names the ClusterPolicy component blocking the readiness.
 func ClusterPolicyReady(apiClient *clients.Settings, clusterPolicyName string, pollInterval, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			clusterPolicy, err := nvidiagpu.Pull(apiClient, clusterPolicyName)
-
-			if err != nil {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy pull from cluster error: %s\n", err)
-
-				return false, err
-			}
-
-			if clusterPolicy.Object != nil && clusterPolicy.Object.Status.State == "ready" {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy %s in now in %s state",
-					clusterPolicy.Object.Name, clusterPolicy.Object.Status.State)
-
-				// this exits out of the PollUntilContextTimeout()
-				return true, nil
-			}
-			if clusterPolicy.Object == nil {
-				glog.V(gpuparams.GpuLogLevel).Info("ClusterPolicy object is nil")
-				return false, nil
-			}
+	return ClusterPolicyReadyContext(context.TODO(), apiClient, clusterPolicyName, pollInterval, timeout)
+}
 
-			glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy %s in now in %s state",
-				clusterPolicy.Object.Name, clusterPolicy.Object.Status.State)
+// ClusterPolicyReadyContext waits like ClusterPolicyReady, stopping early when ctx is done.
+func ClusterPolicyReadyContext(ctx context.Context, apiClient *clients.Settings, clusterPolicyName string,
+	pollInterval, timeout time.Duration) error {
+	_, err := ForObject(ctx, apiClient, clusterPolicyRef(clusterPolicyName),
+		JSONPath("{.status.state}", "ready"), pollInterval, timeout)
+	if err != nil {
+		return nvidiagpu.WrapNotReadyError(apiClient, clusterPolicyName, err)
+	}
 
-			return false, nil
-		})
+	return nil
 }
 
 // ClusterPolicyNotReady Waits until clusterPolicy is NotReady.
 func ClusterPolicyNotReady(apiClient *clients.Settings, clusterPolicyName string, pollInterval,
 	timeout time.Duration) error {
-	glog.V(gpuparams.Gpu10LogLevel).Infof("wait.ClusterPolicyNotReady: %s", clusterPolicyName)
-	return wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			clusterPolicy, err := nvidiagpu.Pull(apiClient, clusterPolicyName)
+	return ClusterPolicyNotReadyContext(context.TODO(), apiClient, clusterPolicyName, pollInterval, timeout)
+}
 
-			if err != nil {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy pull from cluster error: %s\n", err)
+// ClusterPolicyNotReadyContext waits like ClusterPolicyNotReady, stopping early when ctx is done.
+func ClusterPolicyNotReadyContext(ctx context.Context, apiClient *clients.Settings, clusterPolicyName string,
+	pollInterval, timeout time.Duration) error {
+	_, err := ForObject(ctx, apiClient, clusterPolicyRef(clusterPolicyName),
+		JSONPath("{.status.state}", "notReady"), pollInterval, timeout)
 
-				return false, err
-			}
+	return err
+}
 
-			if clusterPolicy.Object != nil && clusterPolicy.Object.Status.State == "notReady" {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy %s is now in %s state",
-					clusterPolicy.Object.Name, clusterPolicy.Object.Status.State)
+// ClusterPolicyRestored restores the snapshot and, if anything had to be restored, waits until the ClusterPolicy
+// is Ready again. The operator is first given up to ClusterPolicyNotReadyTimeout to notice the changes and go
+// notReady, a policy that stays ready is fine.
+func ClusterPolicyRestored(apiClient *clients.Settings, snapshot *nvidiagpu.Snapshot, pollInterval,
+	timeout time.Duration) error {
+	return ClusterPolicyRestoredContext(context.TODO(), apiClient, snapshot, pollInterval, timeout)
+}
 
-				// this exits out of the PollUntilContextTimeout()
-				return true, nil
-			}
-			if clusterPolicy.Object == nil {
-				glog.V(gpuparams.GpuLogLevel).Info("ClusterPolicy object is nil")
-				return false, nil
-			}
+// ClusterPolicyRestoredContext restores the snapshot like ClusterPolicyRestored, stopping the waits early when ctx
+// is done.
+func ClusterPolicyRestoredContext(ctx context.Context, apiClient *clients.Settings, snapshot *nvidiagpu.Snapshot,
+	pollInterval, timeout time.Duration) error {
+	restored, err := snapshot.Restore()
+	if err != nil {
+		return fmt.Errorf("failed to restore ClusterPolicy %s: %w", snapshot.ClusterPolicy.Name, err)
+	}
 
-			glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy %s is currently in %s state",
-				clusterPolicy.Object.Name, clusterPolicy.Object.Status.State)
+	if !restored {
+		return nil
+	}
 
-			return false, nil
-		})
+	_ = ClusterPolicyNotReadyContext(ctx, apiClient, snapshot.ClusterPolicy.Name, min(pollInterval,
+		nvidiagpu.ClusterPolicyNotReadyCheckInterval), min(timeout, nvidiagpu.ClusterPolicyNotReadyTimeout))
+
+	return ClusterPolicyReadyContext(ctx, apiClient, snapshot.ClusterPolicy.Name, pollInterval, timeout)
 }
 
 // CSVSucceeded waits for a defined period of time for CSV to be in Succeeded state.
+// On failure the error is an *olm.CSVNotSucceededError diagnosing why the CSV did not succeed.
 func CSVSucceeded(apiClient *clients.Settings, csvName, csvNamespace string, pollInterval,
 	timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			csvPulled, err := olm.PullClusterServiceVersion(apiClient, csvName, csvNamespace)
-
-			if err != nil {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion pull from cluster error: %s\n", err)
-
-				return false, err
-			}
-
-			if csvPulled.Object.Status.Phase == "Succeeded" {
-				glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion %s in now in %s state",
-					csvPulled.Object.Name, csvPulled.Object.Status.Phase)
-
-				// this exists out of the wait.PollImmediate().
-				return true, nil
-			}
-
-			glog.V(gpuparams.GpuLogLevel).Infof("clusterPolicy %s in now in %s state",
-				csvPulled.Object.Name, csvPulled.Object.Status.Phase)
+	return CSVSucceededContext(context.TODO(), apiClient, csvName, csvNamespace, pollInterval, timeout)
+}
 
-			return false, err
-		})
+// CSVSucceededContext waits like CSVSucceeded, stopping early when ctx is done.
+func CSVSucceededContext(ctx context.Context, apiClient *clients.Settings, csvName, csvNamespace string,
+	pollInterval, timeout time.Duration) error {
+	_, err := ForObject(ctx, apiClient, ObjectRef{
+		GVR:       oplmV1alpha1.SchemeGroupVersion.WithResource("clusterserviceversions"),
+		Namespace: csvNamespace,
+		Name:      csvName,
+	}, JSONPath("{.status.phase}", string(oplmV1alpha1.CSVPhaseSucceeded)), pollInterval, timeout)
+	if err != nil {
+		return olm.WrapCSVNotSucceededErrorContext(ctx, apiClient, csvName, csvNamespace, err)
+	}
+
+	return nil
 }
 
 // DeploymentCreated waits for a defined period of time for deployment to be created.
 func DeploymentCreated(apiClient *clients.Settings, deploymentName, deploymentNamespace string, pollInterval,
 	timeout time.Duration) bool {
-	// Note: the value for boolean variable "immediate" is false here, meaning check AFTER polling interval
-	//       on the very first try.  Otherwise the first check was causing an error and failing testcase.
-	err := wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, false, func(ctx context.Context) (bool, error) {
-			var err error
-			deploymentPulled, err := deployment.Pull(apiClient, deploymentName, deploymentNamespace)
-
-			if err != nil {
-				glog.V(gpuparams.GpuLogLevel).Infof("Deployment '%s' pull from cluster namespace '%s' error:"+
-					" %v", deploymentName, deploymentNamespace, err)
-
-				return false, err
-			}
-
-			if deploymentPulled.Exists() {
-				glog.V(gpuparams.GpuLogLevel).Infof("Deployment '%s' in namespace '%s' has been created",
-					deploymentPulled.Object.Name, deploymentNamespace)
-
-				// this exists out of the wait.PollImmediate().
-				return true, nil
-			}
+	return DeploymentCreatedContext(context.TODO(), apiClient, deploymentName, deploymentNamespace, pollInterval,
+		timeout)
+}
 
-			return false, nil
-		})
+// DeploymentCreatedContext waits like DeploymentCreated, stopping early when ctx is done.
+func DeploymentCreatedContext(ctx context.Context, apiClient *clients.Settings, deploymentName,
+	deploymentNamespace string, pollInterval, timeout time.Duration) bool {
+	_, err := ForObject(ctx, apiClient, ObjectRef{
+		GVR:       appsv1.SchemeGroupVersion.WithResource("deployments"),
+		Namespace: deploymentNamespace,
+		Name:      deploymentName,
+	}, Exists(), pollInterval, timeout)
 
 	return err == nil
 }
@@ -144,10 +125,17 @@ func DeploymentCreated(apiClient *clients.Settings, deploymentName, deploymentNa
 // NodeLabelExists waits for at least one node with the specified label selector to have a label with the given key and value.
 func NodeLabelExists(apiClient *clients.Settings, labelKey, labelValue string, nodeSelector labels.Set, pollInterval,
 	timeout time.Duration) error {
+	return NodeLabelExistsContext(context.TODO(), apiClient, labelKey, labelValue, nodeSelector, pollInterval,
+		timeout)
+}
+
+// NodeLabelExistsContext waits like NodeLabelExists, stopping early when ctx is done.
+func NodeLabelExistsContext(ctx context.Context, apiClient *clients.Settings, labelKey, labelValue string,
+	nodeSelector labels.Set, pollInterval, timeout time.Duration) error {
 	glog.V(gpuparams.Gpu10LogLevel).Infof("Waiting for node label '%s'='%s' on nodes with selector: %v", labelKey, labelValue, nodeSelector)
 	return wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			nodeBuilders, err := nodes.List(apiClient, metav1.ListOptions{LabelSelector: nodeSelector.String()})
+		ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			nodeBuilders, err := nodes.ListContext(ctx, apiClient, metav1.ListOptions{LabelSelector: nodeSelector.String()})
 
 			if err != nil {
 				glog.V(gpuparams.GpuLogLevel).Infof("Error listing nodes: %v", err)
@@ -176,11 +164,17 @@ func NodeLabelExists(apiClient *clients.Settings, labelKey, labelValue string, n
 
 // WaitForNodes waits for nodes matching the selector to satisfy the condition function.
 func WaitForNodes(apiClient *clients.Settings, nodeSelector labels.Set, condition func(*corev1.Node) (bool, error), pollInterval, timeout time.Duration) error {
+	return WaitForNodesContext(context.TODO(), apiClient, nodeSelector, condition, pollInterval, timeout)
+}
+
+// WaitForNodesContext waits like WaitForNodes, stopping early when ctx is done.
+func WaitForNodesContext(ctx context.Context, apiClient *clients.Settings, nodeSelector labels.Set,
+	condition func(*corev1.Node) (bool, error), pollInterval, timeout time.Duration) error {
 	glog.V(gpuparams.Gpu10LogLevel).Infof("Waiting for nodes with selector: %v", nodeSelector)
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			nodeBuilders, err := nodes.List(apiClient, metav1.ListOptions{
+		ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			nodeBuilders, err := nodes.ListContext(ctx, apiClient, metav1.ListOptions{
 				LabelSelector: nodeSelector.String(),
 			})
 
@@ -211,44 +205,49 @@ func WaitForNodes(apiClient *clients.Settings, nodeSelector labels.Set, conditio
 
 // DaemonSetReady waits for a specific DaemonSet to have all pods ready.
 func DaemonSetReady(apiClient *clients.Settings, daemonSetName, namespace string, pollInterval, timeout time.Duration) error {
-	glog.V(gpuparams.Gpu10LogLevel).Infof("Waiting for DaemonSet '%s' in namespace '%s' to be ready", daemonSetName, namespace)
-	return wait.PollUntilContextTimeout(
-		context.TODO(), pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
-			ds, err := apiClient.DaemonSets(namespace).Get(ctx, daemonSetName, metav1.GetOptions{})
-
-			if err != nil {
-				return false, fmt.Errorf("error getting DaemonSet '%s' in namespace '%s': %w", daemonSetName, namespace, err)
-			}
-
-			// Verify the generation observed by the DaemonSet controller matches the spec generation
-			if ds.Status.ObservedGeneration != ds.Generation {
-				glog.V(gpuparams.GpuLogLevel).Infof("DaemonSet '%s' in namespace '%s': ObservedGeneration %d != Generation %d",
-					daemonSetName, namespace, ds.Status.ObservedGeneration, ds.Generation)
-				return false, nil
-			}
-
-			// Make sure all the updated pods have been scheduled
-			if ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled {
-				glog.V(gpuparams.GpuLogLevel).Infof("DaemonSet '%s' in namespace '%s': %d/%d pods updated",
-					daemonSetName, namespace, ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled)
-				return false, nil
-			}
+	return DaemonSetReadyContext(context.TODO(), apiClient, daemonSetName, namespace, pollInterval, timeout)
+}
 
-			// Verify all nodes have available pods (ready for at least minReadySeconds)
-			// NumberAvailable only counts nodes with the current revision's pods that are available,
-			// unlike NumberReady which can include old revision pods during rolling updates
-			available := ds.Status.NumberAvailable
-			desired := ds.Status.DesiredNumberScheduled
+// DaemonSetReadyContext waits like DaemonSetReady, stopping early when ctx is done.
+func DaemonSetReadyContext(ctx context.Context, apiClient *clients.Settings, daemonSetName, namespace string,
+	pollInterval, timeout time.Duration) error {
+	_, err := ForObject(ctx, apiClient, ObjectRef{
+		GVR:       appsv1.SchemeGroupVersion.WithResource("daemonsets"),
+		Namespace: namespace,
+		Name:      daemonSetName,
+	}, Func("all pods updated and available", daemonSetAvailable), pollInterval, timeout)
 
-			glog.V(gpuparams.GpuLogLevel).Infof("DaemonSet '%s' in namespace '%s': %d/%d pods available",
-				daemonSetName, namespace, available, desired)
+	return err
+}
 
-			if desired > 0 && available == desired {
-				glog.V(gpuparams.GpuLogLevel).Infof("DaemonSet '%s' in namespace '%s' is now ready",
-					daemonSetName, namespace)
-				return true, nil
-			}
+// daemonSetAvailable checks that the DaemonSet controller observed the current generation and that every node runs
+// an available pod of the current revision.
+func daemonSetAvailable(object *unstructured.Unstructured) (bool, string, error) {
+	daemonSet := &appsv1.DaemonSet{}
+	if err := FromUnstructured(object, daemonSet); err != nil {
+		return false, "", err
+	}
+
+	if daemonSet.Status.ObservedGeneration != daemonSet.Generation {
+		return false, fmt.Sprintf("ObservedGeneration %d != Generation %d", daemonSet.Status.ObservedGeneration,
+			daemonSet.Generation), nil
+	}
+
+	// Make sure all the updated pods have been scheduled
+	if daemonSet.Status.UpdatedNumberScheduled != daemonSet.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d/%d pods updated", daemonSet.Status.UpdatedNumberScheduled,
+			daemonSet.Status.DesiredNumberScheduled), nil
+	}
+
+	// NumberAvailable only counts nodes with the current revision's pods that are available,
+	// unlike NumberReady which can include old revision pods during rolling updates
+	available := daemonSet.Status.NumberAvailable
+	desired := daemonSet.Status.DesiredNumberScheduled
+
+	return desired > 0 && available == desired, fmt.Sprintf("%d/%d pods available", available, desired), nil
+}
 
-			return false, nil
-		})
+// clusterPolicyRef returns the reference of the cluster scoped ClusterPolicy.
+func clusterPolicyRef(clusterPolicyName string) ObjectRef {
+	return ObjectRef{GVR: nvidiagpuv1.SchemeGroupVersion.WithResource("clusterpolicies"), Name: clusterPolicyName}
 }
@@ -0,0 +1,283 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8sTesting "k8s.io/client-go/testing"
+	runtimeFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configFake "github.com/openshift/client-go/config/clientset/versioned/fake"
+	machineFake "github.com/openshift/client-go/machine/clientset/versioned/fake"
+	operatorFake "github.com/openshift/client-go/operator/clientset/versioned/fake"
+	securityFake "github.com/openshift/client-go/security/clientset/versioned/fake"
+	olmFake "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/fake"
+	pkgManifestFake "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/client/clientset/versioned/fake"
+)
+
+// TestClientParams provides the parameters used to seed the fake clients returned by GetTestClients.
+type TestClientParams struct {
+	// K8sMockObjects are preloaded into the fake cluster. Any type known to the scheme built by SetScheme,
+	// as well as the OpenShift config, security, machine and operator types, is accepted.
+	K8sMockObjects []runtime.Object
+}
+
+// fakeReactor is implemented by all generated fake clientsets.
+type fakeReactor interface {
+	PrependReactor(verb, resource string, reaction k8sTesting.ReactionFunc)
+	PrependWatchReactor(resource string, reaction k8sTesting.WatchReactionFunc)
+}
+
+// GetTestClients returns a *Settings backed by fake clients instead of a live API server.
+//
+// The typed clientsets, the controller-runtime client and the dynamic client share a single object tracker, so an
+// object created through one interface, e.g. Pods(), is visible through all the others, e.g. Get() or Resource().
+// The dynamic client keeps its own unstructured store only for the kinds the scheme does not know.
+func GetTestClients(tcp TestClientParams) *Settings {
+	glog.V(100).Infof("Initializing fake clients with %d mock objects", len(tcp.K8sMockObjects))
+
+	fakeScheme, err := GetTestScheme()
+	if err != nil {
+		glog.V(100).Infof("Failed to build the fake client scheme: %v", err)
+
+		return nil
+	}
+
+	tracker := k8sTesting.NewObjectTracker(fakeScheme, serializer.NewCodecFactory(fakeScheme).UniversalDecoder())
+
+	for _, object := range tcp.K8sMockObjects {
+		if err := tracker.Add(object); err != nil {
+			glog.V(100).Infof("Failed to add mock object %T to the fake cluster: %v", object, err)
+
+			return nil
+		}
+	}
+
+	k8sClientSet := k8sFake.NewSimpleClientset()
+	olmClientSet := olmFake.NewSimpleClientset()
+	configClientSet := configFake.NewSimpleClientset()
+	securityClientSet := securityFake.NewSimpleClientset()
+	machineClientSet := machineFake.NewSimpleClientset()
+	operatorClientSet := operatorFake.NewSimpleClientset()
+	pkgManifestClientSet := pkgManifestFake.NewSimpleClientset()
+
+	for _, clientSet := range []fakeReactor{k8sClientSet, olmClientSet, configClientSet, securityClientSet,
+		machineClientSet, operatorClientSet, pkgManifestClientSet} {
+		attachTracker(clientSet, tracker)
+	}
+
+	clientSet := &Settings{}
+	clientSet.K8sClient = k8sClientSet
+	clientSet.CoreV1Interface = k8sClientSet.CoreV1()
+	clientSet.AppsV1Interface = k8sClientSet.AppsV1()
+	clientSet.ConfigV1Interface = configClientSet.ConfigV1()
+	clientSet.SecurityV1Interface = securityClientSet.SecurityV1()
+	clientSet.OperatorsV1alpha1Interface = olmClientSet.OperatorsV1alpha1()
+	clientSet.OperatorsV1Interface = olmClientSet.OperatorsV1()
+	clientSet.PackageManifestInterface = pkgManifestClientSet.PackagesV1()
+	clientSet.OperatorV1alpha1Interface = operatorClientSet.OperatorV1alpha1()
+	clientSet.MachineV1beta1Interface = machineClientSet.MachineV1beta1()
+	clientSet.Interface = newDynamicClient(fakeScheme, tracker, tcp.K8sMockObjects)
+	clientSet.Client = runtimeFake.NewClientBuilder().WithScheme(fakeScheme).WithObjectTracker(tracker).Build()
+	clientSet.Config = &rest.Config{}
+
+	return clientSet
+}
+
+// GetTestScheme returns the scheme used by the fake clients. It contains everything registered by SetScheme
+// and the OpenShift API groups served by the typed clients in Settings.
+func GetTestScheme() (*runtime.Scheme, error) {
+	fakeScheme := runtime.NewScheme()
+
+	if err := SetScheme(fakeScheme); err != nil {
+		return nil, err
+	}
+
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		configFake.AddToScheme,
+		securityFake.AddToScheme,
+		machineFake.AddToScheme,
+		operatorFake.AddToScheme,
+	} {
+		if err := addToScheme(fakeScheme); err != nil {
+			return nil, err
+		}
+	}
+
+	return fakeScheme, nil
+}
+
+// attachTracker routes all requests of a fake clientset to the shared object tracker.
+func attachTracker(clientSet fakeReactor, tracker k8sTesting.ObjectTracker) {
+	clientSet.PrependReactor("*", "*", k8sTesting.ObjectReaction(tracker))
+	clientSet.PrependWatchReactor("*", func(action k8sTesting.Action) (bool, watch.Interface, error) {
+		watcher, err := tracker.Watch(action.GetResource(), action.GetNamespace())
+		if err != nil {
+			return false, nil, err
+		}
+
+		return true, watcher, nil
+	})
+}
+
+// newDynamicClient returns a fake dynamic client whose requests for the kinds known to the scheme are served by the
+// shared object tracker, which stores them typed. The objects are converted from and to unstructured on the way.
+// The mock objects of unknown kinds are seeded into the own store of the dynamic client.
+func newDynamicClient(fakeScheme *runtime.Scheme, tracker k8sTesting.ObjectTracker,
+	mockObjects []runtime.Object) *dynamicFake.FakeDynamicClient {
+	kinds := map[schema.GroupVersionResource]schema.GroupVersionKind{}
+
+	for gvk := range fakeScheme.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+
+		plural, _ := meta.UnsafeGuessKindToResource(gvk)
+		kinds[plural] = gvk
+	}
+
+	var unknownObjects []runtime.Object
+
+	for _, object := range mockObjects {
+		_, isUnstructured := object.(runtime.Unstructured)
+		if isUnstructured && !fakeScheme.Recognizes(object.GetObjectKind().GroupVersionKind()) {
+			unknownObjects = append(unknownObjects, object)
+		}
+	}
+
+	dynamicClient := dynamicFake.NewSimpleDynamicClient(fakeScheme, unknownObjects...)
+	typedReaction := k8sTesting.ObjectReaction(tracker)
+
+	dynamicClient.PrependReactor("*", "*", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		gvk, known := kinds[action.GetResource()]
+		if !known {
+			return false, nil, nil
+		}
+
+		action, err := toTypedAction(fakeScheme, gvk, action)
+		if err != nil {
+			return true, nil, err
+		}
+
+		handled, object, err := typedReaction(action)
+		if err != nil || object == nil {
+			return handled, object, err
+		}
+
+		object, err = toUnstructured(fakeScheme, object)
+
+		return handled, object, err
+	})
+
+	dynamicClient.PrependWatchReactor("*", func(action k8sTesting.Action) (bool, watch.Interface, error) {
+		if _, known := kinds[action.GetResource()]; !known {
+			return false, nil, nil
+		}
+
+		watcher, err := tracker.Watch(action.GetResource(), action.GetNamespace())
+		if err != nil {
+			return false, nil, err
+		}
+
+		return true, watch.Filter(watcher, func(event watch.Event) (watch.Event, bool) {
+			if object, err := toUnstructured(fakeScheme, event.Object); err == nil {
+				event.Object = object
+			}
+
+			return event, true
+		}), nil
+	})
+
+	return dynamicClient
+}
+
+// toTypedAction returns the create or update action with its unstructured object converted to the typed gvk.
+// Other actions carry no object and are returned unchanged.
+func toTypedAction(fakeScheme *runtime.Scheme, gvk schema.GroupVersionKind,
+	action k8sTesting.Action) (k8sTesting.Action, error) {
+	var object runtime.Object
+
+	switch typedAction := action.(type) {
+	case k8sTesting.CreateActionImpl:
+		object = typedAction.Object
+	case k8sTesting.UpdateActionImpl:
+		object = typedAction.Object
+	default:
+		return action, nil
+	}
+
+	unstructuredObject, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		return action, nil
+	}
+
+	typedObject, err := fakeScheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObject.Object, typedObject); err != nil {
+		return nil, fmt.Errorf("failed to convert %s to %T: %w", gvk.Kind, typedObject, err)
+	}
+
+	switch typedAction := action.(type) {
+	case k8sTesting.CreateActionImpl:
+		typedAction.Object = typedObject
+
+		return typedAction, nil
+	case k8sTesting.UpdateActionImpl:
+		typedAction.Object = typedObject
+
+		return typedAction, nil
+	}
+
+	return action, nil
+}
+
+// toUnstructured converts a typed object, or every item of a typed list, to unstructured.
+func toUnstructured(fakeScheme *runtime.Scheme, object runtime.Object) (runtime.Object, error) {
+	if _, ok := object.(runtime.Unstructured); ok {
+		return object, nil
+	}
+
+	if !meta.IsListType(object) {
+		unstructuredObject := &unstructured.Unstructured{}
+		if err := fakeScheme.Convert(object, unstructuredObject, nil); err != nil {
+			return nil, err
+		}
+
+		return unstructuredObject, nil
+	}
+
+	items, err := meta.ExtractList(object)
+	if err != nil {
+		return nil, err
+	}
+
+	unstructuredList := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+
+	if listMeta, err := meta.ListAccessor(object); err == nil {
+		unstructuredList.SetResourceVersion(listMeta.GetResourceVersion())
+	}
+
+	for _, item := range items {
+		unstructuredItem, err := toUnstructured(fakeScheme, item)
+		if err != nil {
+			return nil, err
+		}
+
+		unstructuredList.Items = append(unstructuredList.Items, *unstructuredItem.(*unstructured.Unstructured))
+	}
+
+	return unstructuredList, nil
+}
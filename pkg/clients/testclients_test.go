@@ -0,0 +1,133 @@
+package clients
+
+import (
+	"context"
+	"testing"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetTestClients(t *testing.T) {
+	testNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}}
+	testClusterPolicy := &nvidiagpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "gpu-cluster-policy"}}
+	testMachineSet := &machinev1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-a", Namespace: "openshift-machine-api"}}
+
+	apiClient := GetTestClients(TestClientParams{
+		K8sMockObjects: []runtime.Object{testNode, testClusterPolicy, testMachineSet},
+	})
+	if apiClient == nil {
+		t.Fatal("GetTestClients returned nil")
+	}
+
+	if _, err := apiClient.CoreV1Interface.Nodes().Get(context.TODO(), testNode.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("failed to get node through the typed client: %v", err)
+	}
+
+	if err := apiClient.Get(context.TODO(), runtimeClient.ObjectKeyFromObject(testNode), &corev1.Node{}); err != nil {
+		t.Errorf("failed to get node through the runtime client: %v", err)
+	}
+
+	if err := apiClient.Get(context.TODO(), runtimeClient.ObjectKeyFromObject(testClusterPolicy),
+		&nvidiagpuv1.ClusterPolicy{}); err != nil {
+		t.Errorf("failed to get clusterpolicy through the runtime client: %v", err)
+	}
+
+	if _, err := apiClient.MachineSets(testMachineSet.Namespace).Get(
+		context.TODO(), testMachineSet.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("failed to get machineset through the typed client: %v", err)
+	}
+
+	clusterPolicyGVR := schema.GroupVersionResource{Group: "nvidia.com", Version: "v1", Resource: "clusterpolicies"}
+	if _, err := apiClient.Resource(clusterPolicyGVR).Get(
+		context.TODO(), testClusterPolicy.Name, metav1.GetOptions{}); err != nil {
+		t.Errorf("failed to get clusterpolicy through the dynamic client: %v", err)
+	}
+}
+
+func TestGetTestClientsSharedTracker(t *testing.T) {
+	apiClient := GetTestClients(TestClientParams{})
+	if apiClient == nil {
+		t.Fatal("GetTestClients returned nil")
+	}
+
+	testPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	if _, err := apiClient.Pods(testPod.Namespace).Create(context.TODO(), testPod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create pod through the typed client: %v", err)
+	}
+
+	podList := &corev1.PodList{}
+	if err := apiClient.List(context.TODO(), podList, runtimeClient.InNamespace(testPod.Namespace)); err != nil {
+		t.Fatalf("failed to list pods through the runtime client: %v", err)
+	}
+
+	if len(podList.Items) != 1 {
+		t.Errorf("expected 1 pod through the runtime client, got %d", len(podList.Items))
+	}
+}
+
+func TestGetTestClientsDynamicSharedTracker(t *testing.T) {
+	apiClient := GetTestClients(TestClientParams{})
+	if apiClient == nil {
+		t.Fatal("GetTestClients returned nil")
+	}
+
+	testPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default",
+		Labels: map[string]string{"app": "test"}}}
+
+	if _, err := apiClient.Pods(testPod.Namespace).Create(context.TODO(), testPod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create pod through the typed client: %v", err)
+	}
+
+	podGVR := corev1.SchemeGroupVersion.WithResource("pods")
+
+	unstructuredPod, err := apiClient.Resource(podGVR).Namespace(testPod.Namespace).Get(
+		context.TODO(), testPod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the typed pod through the dynamic client: %v", err)
+	}
+
+	if unstructuredPod.GetKind() != "Pod" || unstructuredPod.GetLabels()["app"] != "test" {
+		t.Errorf("expected the pod with its labels, got %v", unstructuredPod.Object)
+	}
+
+	podList, err := apiClient.Resource(podGVR).Namespace(testPod.Namespace).List(
+		context.TODO(), metav1.ListOptions{LabelSelector: "app=test"})
+	if err != nil || len(podList.Items) != 1 {
+		t.Fatalf("expected 1 pod through the dynamic client: %v", err)
+	}
+
+	clusterPolicy := &unstructured.Unstructured{}
+	clusterPolicy.SetGroupVersionKind(nvidiagpuv1.SchemeGroupVersion.WithKind("ClusterPolicy"))
+	clusterPolicy.SetName("gpu-cluster-policy")
+
+	clusterPolicyGVR := nvidiagpuv1.SchemeGroupVersion.WithResource("clusterpolicies")
+	if _, err := apiClient.Resource(clusterPolicyGVR).Create(
+		context.TODO(), clusterPolicy, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create clusterpolicy through the dynamic client: %v", err)
+	}
+
+	if _, err := apiClient.Resource(clusterPolicyGVR).Patch(context.TODO(), clusterPolicy.GetName(),
+		types.MergePatchType, []byte(`{"status":{"state":"ready"}}`), metav1.PatchOptions{}); err != nil {
+		t.Fatalf("failed to patch clusterpolicy through the dynamic client: %v", err)
+	}
+
+	typedClusterPolicy := &nvidiagpuv1.ClusterPolicy{}
+	if err := apiClient.Get(context.TODO(), runtimeClient.ObjectKey{Name: clusterPolicy.GetName()},
+		typedClusterPolicy); err != nil {
+		t.Fatalf("failed to get the dynamic clusterpolicy through the runtime client: %v", err)
+	}
+
+	if typedClusterPolicy.Status.State != "ready" {
+		t.Errorf("expected the patched state through the runtime client, got %q", typedClusterPolicy.Status.State)
+	}
+}
@@ -34,6 +34,11 @@ type AdditionalOptions func(builder *Builder) (*Builder, error)
 
 // Pull retrieves an existing configmap object from the cluster.
 func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
+	return PullContext(context.TODO(), apiClient, name, nsname)
+}
+
+// PullContext retrieves an existing configmap object from the cluster.
+func PullContext(ctx context.Context, apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	builder := Builder{
 		apiClient: apiClient.CoreV1Interface,
 		Definition: &corev1.ConfigMap{
@@ -59,7 +64,7 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	glog.V(100).Infof(
 		"Pulling configmap object name:%s in namespace: %s", name, nsname)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("configmap object %s doesn't exist in namespace %s", name, nsname)
 	}
 
@@ -104,6 +109,11 @@ func NewBuilder(apiClient *clients.Settings, name, nsname string) *Builder {
 
 // Create makes a configmap in cluster and stores the created object in struct.
 func (builder *Builder) Create() (*Builder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a configmap in cluster and stores the created object in struct.
+func (builder *Builder) CreateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -111,28 +121,53 @@ func (builder *Builder) Create() (*Builder, error) {
 	glog.V(100).Infof("Creating the configmap %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
 
 	var err error
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		builder.Object, err = builder.apiClient.ConfigMaps(builder.Definition.Namespace).Create(
-			context.TODO(), builder.Definition, metav1.CreateOptions{})
+			ctx, builder.Definition, metav1.CreateOptions{})
+	}
+
+	return builder, err
+}
+
+// Update renovates the existing configmap object with the configmap definition in builder.
+func (builder *Builder) Update() (*Builder, error) {
+	return builder.UpdateContext(context.TODO())
+}
+
+// UpdateContext renovates the existing configmap object with the configmap definition in builder.
+func (builder *Builder) UpdateContext(ctx context.Context) (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
 	}
 
+	glog.V(100).Infof("Updating the configmap %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.apiClient.ConfigMaps(builder.Definition.Namespace).Update(
+		ctx, builder.Definition, metav1.UpdateOptions{})
+
 	return builder, err
 }
 
 // Delete removes a configmap.
 func (builder *Builder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a configmap.
+func (builder *Builder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
 
 	glog.V(100).Infof("Deleting the configmap %s from namespace %s", builder.Definition.Name, builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil
 	}
 
 	err := builder.apiClient.ConfigMaps(builder.Definition.Namespace).Delete(
-		context.TODO(), builder.Object.Name, metav1.DeleteOptions{})
+		ctx, builder.Object.Name, metav1.DeleteOptions{})
 	if err != nil {
 		return err
 	}
@@ -144,6 +179,11 @@ func (builder *Builder) Delete() error {
 
 // WaitUntilDeleted waits for the duration of the defined timeout or until the configmap is deleted.
 func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
+	return builder.WaitUntilDeletedContext(context.TODO(), timeout)
+}
+
+// WaitUntilDeletedContext waits for the duration of the defined timeout or until the configmap is deleted.
+func (builder *Builder) WaitUntilDeletedContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -152,7 +192,7 @@ func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
 		builder.Definition.Name, builder.Definition.Namespace)
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, false, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, false, func(ctx context.Context) (bool, error) {
 			_, err := builder.apiClient.ConfigMaps(builder.Definition.Namespace).Get(
 				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if err == nil {
@@ -177,6 +217,11 @@ func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
 
 // Exists checks whether the given configmap exists.
 func (builder *Builder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given configmap exists.
+func (builder *Builder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -187,7 +232,7 @@ func (builder *Builder) Exists() bool {
 
 	var err error
 	builder.Object, err = builder.apiClient.ConfigMaps(builder.Definition.Namespace).Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
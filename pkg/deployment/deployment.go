@@ -88,6 +88,11 @@ func NewBuilder(apiClient *clients.Settings, name, nsname string, labels map[str
 
 // Pull loads an existing deployment into Builder struct.
 func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
+	return PullContext(context.TODO(), apiClient, name, nsname)
+}
+
+// PullContext loads an existing deployment into Builder struct.
+func PullContext(ctx context.Context, apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	// Safeguard against nil apiClient interfaces.
 	if apiClient == nil {
 		glog.V(100).Infof("The apiClient is nil")
@@ -119,7 +124,7 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 		return nil, fmt.Errorf("deployment 'namespace' cannot be empty")
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("deployment object %s doesn't exist in namespace %s", name, nsname)
 	}
 
@@ -382,6 +387,11 @@ func (builder *Builder) WithOptions(options ...AdditionalOptions) *Builder {
 
 // Create generates a deployment in cluster and stores the created object in struct.
 func (builder *Builder) Create() (*Builder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext generates a deployment in cluster and stores the created object in struct.
+func (builder *Builder) CreateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -389,9 +399,9 @@ func (builder *Builder) Create() (*Builder, error) {
 	glog.V(100).Infof("Creating deployment %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
 
 	var err error
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		builder.Object, err = builder.apiClient.Deployments(builder.Definition.Namespace).Create(
-			context.TODO(), builder.Definition, metav1.CreateOptions{})
+			ctx, builder.Definition, metav1.CreateOptions{})
 	}
 
 	return builder, err
@@ -399,6 +409,11 @@ func (builder *Builder) Create() (*Builder, error) {
 
 // Update renovates the existing deployment object with the deployment definition in builder.
 func (builder *Builder) Update() (*Builder, error) {
+	return builder.UpdateContext(context.TODO())
+}
+
+// UpdateContext renovates the existing deployment object with the deployment definition in builder.
+func (builder *Builder) UpdateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -407,13 +422,18 @@ func (builder *Builder) Update() (*Builder, error) {
 
 	var err error
 	builder.Object, err = builder.apiClient.Deployments(builder.Definition.Namespace).Update(
-		context.TODO(), builder.Definition, metav1.UpdateOptions{})
+		ctx, builder.Definition, metav1.UpdateOptions{})
 
 	return builder, err
 }
 
 // Delete removes a deployment.
 func (builder *Builder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a deployment.
+func (builder *Builder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -421,14 +441,14 @@ func (builder *Builder) Delete() error {
 	glog.V(100).Infof("Deleting deployment %s in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		builder.Object = nil
 
 		return nil
 	}
 
 	err := builder.apiClient.Deployments(builder.Definition.Namespace).Delete(
-		context.TODO(), builder.Object.Name, metav1.DeleteOptions{})
+		ctx, builder.Object.Name, metav1.DeleteOptions{})
 
 	if err != nil {
 		return err
@@ -441,6 +461,11 @@ func (builder *Builder) Delete() error {
 
 // CreateAndWaitUntilReady creates a deployment in the cluster and waits until the deployment is available.
 func (builder *Builder) CreateAndWaitUntilReady(timeout time.Duration) (*Builder, error) {
+	return builder.CreateAndWaitUntilReadyContext(context.TODO(), timeout)
+}
+
+// CreateAndWaitUntilReadyContext creates a deployment in the cluster and waits until the deployment is available.
+func (builder *Builder) CreateAndWaitUntilReadyContext(ctx context.Context, timeout time.Duration) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -448,11 +473,11 @@ func (builder *Builder) CreateAndWaitUntilReady(timeout time.Duration) (*Builder
 	glog.V(100).Infof("Creating deployment %s in namespace %s and waiting for the defined period until it's ready",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	if _, err := builder.Create(); err != nil {
+	if _, err := builder.CreateContext(ctx); err != nil {
 		return nil, err
 	}
 
-	if builder.IsReady(timeout) {
+	if builder.IsReadyContext(ctx, timeout) {
 		return builder, nil
 	}
 
@@ -463,6 +488,11 @@ func (builder *Builder) CreateAndWaitUntilReady(timeout time.Duration) (*Builder
 
 // IsReady periodically checks if deployment is in ready status.
 func (builder *Builder) IsReady(timeout time.Duration) bool {
+	return builder.IsReadyContext(context.TODO(), timeout)
+}
+
+// IsReadyContext periodically checks if deployment is in ready status.
+func (builder *Builder) IsReadyContext(ctx context.Context, timeout time.Duration) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -470,15 +500,15 @@ func (builder *Builder) IsReady(timeout time.Duration) bool {
 	glog.V(100).Infof("Running periodic check until deployment %s in namespace %s is ready",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return false
 	}
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
 			builder.Object, err = builder.apiClient.Deployments(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 
 			if err != nil {
 				return false, err
@@ -496,6 +526,11 @@ func (builder *Builder) IsReady(timeout time.Duration) bool {
 
 // DeleteAndWait deletes a deployment and waits until it is removed from the cluster.
 func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
+	return builder.DeleteAndWaitContext(context.TODO(), timeout)
+}
+
+// DeleteAndWaitContext deletes a deployment and waits until it is removed from the cluster.
+func (builder *Builder) DeleteAndWaitContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -503,15 +538,15 @@ func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
 	glog.V(100).Infof("Deleting deployment %s in namespace %s and waiting for the defined period until it's removed",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	if err := builder.Delete(); err != nil {
+	if err := builder.DeleteContext(ctx); err != nil {
 		return err
 	}
 
 	// Polls the deployment every second until it's removed.
 	return wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			_, err := builder.apiClient.Deployments(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if k8serrors.IsNotFound(err) {
 				return true, nil
 			}
@@ -522,6 +557,11 @@ func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
 
 // Exists checks whether the given deployment exists.
 func (builder *Builder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given deployment exists.
+func (builder *Builder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -531,7 +571,7 @@ func (builder *Builder) Exists() bool {
 
 	var err error
 	builder.Object, err = builder.apiClient.Deployments(builder.Definition.Namespace).Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
@@ -539,6 +579,13 @@ func (builder *Builder) Exists() bool {
 // WaitUntilCondition waits for the duration of the defined timeout or until the
 // deployment gets to a specific condition.
 func (builder *Builder) WaitUntilCondition(condition appsv1.DeploymentConditionType, timeout time.Duration) error {
+	return builder.WaitUntilConditionContext(context.TODO(), condition, timeout)
+}
+
+// WaitUntilConditionContext waits for the duration of the defined timeout or until the
+// deployment gets to a specific condition.
+func (builder *Builder) WaitUntilConditionContext(ctx context.Context,
+	condition appsv1.DeploymentConditionType, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -546,14 +593,14 @@ func (builder *Builder) WaitUntilCondition(condition appsv1.DeploymentConditionT
 	glog.V(100).Infof("Waiting for the defined period until deployment %s in namespace %s has condition %v",
 		builder.Definition.Name, builder.Definition.Namespace, condition)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return fmt.Errorf("cannot wait for deployment condition because it does not exist")
 	}
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			updateDeployment, err := builder.apiClient.Deployments(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if err != nil {
 				return false, nil
 			}
@@ -11,6 +11,12 @@ import (
 
 // List returns deployment inventory in the given namespace.
 func List(apiClient *clients.Settings, nsname string, options ...metav1.ListOptions) ([]*Builder, error) {
+	return ListContext(context.TODO(), apiClient, nsname, options...)
+}
+
+// ListContext returns deployment inventory in the given namespace.
+func ListContext(ctx context.Context, apiClient *clients.Settings, nsname string,
+	options ...metav1.ListOptions) ([]*Builder, error) {
 	if nsname == "" {
 		glog.V(100).Infof("deployment 'nsname' parameter can not be empty")
 
@@ -33,7 +39,7 @@ func List(apiClient *clients.Settings, nsname string, options ...metav1.ListOpti
 
 	glog.V(100).Infof(logMessage)
 
-	deploymentList, err := apiClient.Deployments(nsname).List(context.TODO(), passedOptions)
+	deploymentList, err := apiClient.Deployments(nsname).List(ctx, passedOptions)
 	if err != nil {
 		glog.V(100).Infof("Failed to list deployments in the namespace %s due to %s", nsname, err.Error())
 
@@ -58,6 +64,12 @@ func List(apiClient *clients.Settings, nsname string, options ...metav1.ListOpti
 
 // ListInAllNamespaces returns deployment inventory in the all the namespaces.
 func ListInAllNamespaces(apiClient *clients.Settings, options ...metav1.ListOptions) ([]*Builder, error) {
+	return ListInAllNamespacesContext(context.TODO(), apiClient, options...)
+}
+
+// ListInAllNamespacesContext returns deployment inventory in the all the namespaces.
+func ListInAllNamespacesContext(ctx context.Context, apiClient *clients.Settings,
+	options ...metav1.ListOptions) ([]*Builder, error) {
 	passedOptions := metav1.ListOptions{}
 	logMessage := "Listing deployments in all namespaces"
 
@@ -74,7 +86,7 @@ func ListInAllNamespaces(apiClient *clients.Settings, options ...metav1.ListOpti
 
 	glog.V(100).Infof(logMessage)
 
-	deploymentList, err := apiClient.Deployments("").List(context.TODO(), passedOptions)
+	deploymentList, err := apiClient.Deployments("").List(ctx, passedOptions)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list deployments in all namespaces due to %s", err.Error())
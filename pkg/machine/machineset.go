@@ -112,6 +112,11 @@ func NewSetBuilderFromCopy(apiClient *clients.Settings, nsName string, instanceT
 
 // PullSet loads an existing MachineSet into Builder struct.
 func PullSet(apiClient *clients.Settings, name, namespace string) (*SetBuilder, error) {
+	return PullSetContext(context.TODO(), apiClient, name, namespace)
+}
+
+// PullSetContext loads an existing MachineSet into Builder struct.
+func PullSetContext(ctx context.Context, apiClient *clients.Settings, name, namespace string) (*SetBuilder, error) {
 	glog.V(100).Infof("Pulling existing machineSet name %s in namespace %s", name, namespace)
 
 	builder := SetBuilder{
@@ -132,7 +137,7 @@ func PullSet(apiClient *clients.Settings, name, namespace string) (*SetBuilder,
 		builder.errorMsg = "MachineSet 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("machineSet object %s doesn't exist in namespace %s", name, namespace)
 	}
 
@@ -143,6 +148,11 @@ func PullSet(apiClient *clients.Settings, name, namespace string) (*SetBuilder,
 
 // Exists checks whether the given MachineSet exists.
 func (builder *SetBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given MachineSet exists.
+func (builder *SetBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -152,7 +162,7 @@ func (builder *SetBuilder) Exists() bool {
 		builder.Definition.Namespace)
 
 	var err error
-	builder.Object, err = builder.apiClient.MachineSets(builder.Definition.Namespace).Get(context.TODO(),
+	builder.Object, err = builder.apiClient.MachineSets(builder.Definition.Namespace).Get(ctx,
 		builder.Definition.Name, metav1.GetOptions{})
 
 	if err != nil {
@@ -164,6 +174,11 @@ func (builder *SetBuilder) Exists() bool {
 
 // Create makes a MachineSet in cluster and stores the created object in struct.
 func (builder *SetBuilder) Create() (*SetBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a MachineSet in cluster and stores the created object in struct.
+func (builder *SetBuilder) CreateContext(ctx context.Context) (*SetBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -171,9 +186,9 @@ func (builder *SetBuilder) Create() (*SetBuilder, error) {
 	glog.V(100).Infof("Creating the MachineSet %s", builder.Definition.Name)
 
 	var err error
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		builder.Object, err = builder.apiClient.MachineSets(builder.Definition.Namespace).Create(
-			context.TODO(), builder.Definition, metav1.CreateOptions{})
+			ctx, builder.Definition, metav1.CreateOptions{})
 	}
 
 	return builder, err
@@ -181,6 +196,11 @@ func (builder *SetBuilder) Create() (*SetBuilder, error) {
 
 // Delete removes a MachineSet object from a cluster.
 func (builder *SetBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a MachineSet object from a cluster.
+func (builder *SetBuilder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -188,12 +208,12 @@ func (builder *SetBuilder) Delete() error {
 	glog.V(100).Infof("Deleting the MachineSet object %s",
 		builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return fmt.Errorf("machineSet cannot be deleted because it does not exist")
 	}
 
 	err := builder.apiClient.MachineSets(builder.Object.Namespace).Delete(
-		context.TODO(), builder.Object.Name, metav1.DeleteOptions{})
+		ctx, builder.Object.Name, metav1.DeleteOptions{})
 
 	if err != nil {
 		return fmt.Errorf("cannot delete MachineSet: %w", err)
@@ -203,14 +223,16 @@ func (builder *SetBuilder) Delete() error {
 }
 
 // WaitForMachineSetReady waits until MachineSet first replica is Ready.
-func WaitForMachineSetReady(
-	apiClient *clients.Settings,
-	namespace,
-	machineSetName string,
-	timeout time.Duration) error {
+func WaitForMachineSetReady(apiClient *clients.Settings, namespace, machineSetName string, timeout time.Duration) error {
+	return WaitForMachineSetReadyContext(context.TODO(), apiClient, namespace, machineSetName, timeout)
+}
+
+// WaitForMachineSetReadyContext waits until MachineSet first replica is Ready.
+func WaitForMachineSetReadyContext(ctx context.Context, apiClient *clients.Settings, namespace,
+	machineSetName string, timeout time.Duration) error {
 	return wait.PollUntilContextTimeout(
-		context.TODO(), 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-			machineSetPulled, err := PullSet(apiClient, machineSetName, namespace)
+		ctx, 30*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			machineSetPulled, err := PullSetContext(ctx, apiClient, machineSetName, namespace)
 
 			if err != nil {
 				glog.V(100).Infof("MachineSet pull from cluster error: %v\n", err)
@@ -0,0 +1,155 @@
+package machine
+
+import (
+	"testing"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testMachineSetNamespace = "openshift-machine-api"
+	testWorkerLabel         = "machine.openshift.io/cluster-api-machine-role"
+)
+
+func TestSetBuilderGetPublicCloudKind(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.MachineSets})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	testCases := []struct {
+		name                string
+		machineSetName      string
+		expectedPublicCloud string
+		expectedError       bool
+	}{
+		{
+			name:                "aws",
+			machineSetName:      "ci-cluster-worker-us-east-1a",
+			expectedPublicCloud: AwsCloud,
+		},
+		{
+			name:                "gcp",
+			machineSetName:      "ci-cluster-worker-us-central1-a",
+			expectedPublicCloud: GcpCloud,
+		},
+		{
+			name:                "azure",
+			machineSetName:      "ci-cluster-worker-eastus1",
+			expectedPublicCloud: AzureCloud,
+		},
+		{
+			name:           "unsupported vsphere",
+			machineSetName: "ci-cluster-worker-vsphere",
+			expectedError:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder, err := PullSet(apiClient, testCase.machineSetName, testMachineSetNamespace)
+			if err != nil {
+				t.Fatalf("failed to pull MachineSet: %v", err)
+			}
+
+			err = builder.getPublicCloudKind()
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+
+				if builder.errorMsg == "" {
+					t.Error("expected the builder errorMsg to be set")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if builder.publicCloud != testCase.expectedPublicCloud {
+				t.Errorf("expected public cloud %q, got %q", testCase.expectedPublicCloud, builder.publicCloud)
+			}
+		})
+	}
+}
+
+func TestSetBuilderGetPublicCloudKindInvalidProviderSpec(t *testing.T) {
+	testCases := []struct {
+		name          string
+		providerSpec  *runtime.RawExtension
+		apiClient     *clients.Settings
+		expectedError string
+	}{
+		{
+			name:          "missing kind",
+			providerSpec:  &runtime.RawExtension{Raw: []byte(`{"instanceType":"m6i.xlarge"}`)},
+			apiClient:     clients.GetTestClients(clients.TestClientParams{}),
+			expectedError: "failed to detect public cloud kind",
+		},
+		{
+			name:          "non-string kind",
+			providerSpec:  &runtime.RawExtension{Raw: []byte(`{"kind":42}`)},
+			apiClient:     clients.GetTestClients(clients.TestClientParams{}),
+			expectedError: "failed to detect public cloud kind",
+		},
+		{
+			name:          "nil apiClient",
+			providerSpec:  &runtime.RawExtension{Raw: []byte(`{"kind":"AWSMachineProviderConfig"}`)},
+			expectedError: "MachineSet builder cannot have nil apiClient",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := &SetBuilder{
+				apiClient: testCase.apiClient,
+				Definition: &machinev1beta1.MachineSet{
+					Spec: machinev1beta1.MachineSetSpec{
+						Template: machinev1beta1.MachineTemplateSpec{
+							Spec: machinev1beta1.MachineSpec{
+								ProviderSpec: machinev1beta1.ProviderSpec{Value: testCase.providerSpec},
+							},
+						},
+					},
+				},
+			}
+
+			err := builder.getPublicCloudKind()
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+
+			if err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %q", testCase.expectedError, err.Error())
+			}
+		})
+	}
+}
+
+func TestListWorkerMachineSets(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.MachineSets})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	workerSetBuilders, err := ListWorkerMachineSets(apiClient, testMachineSetNamespace, testWorkerLabel)
+	if err != nil {
+		t.Fatalf("failed to list worker MachineSets: %v", err)
+	}
+
+	if len(workerSetBuilders) != 4 {
+		t.Errorf("expected 4 worker MachineSets, got %d", len(workerSetBuilders))
+	}
+
+	for _, workerSetBuilder := range workerSetBuilders {
+		if workerSetBuilder.Definition.Name == "ci-cluster-infra-us-east-1a" {
+			t.Errorf("infra MachineSet %s must not be listed as a worker", workerSetBuilder.Definition.Name)
+		}
+	}
+}
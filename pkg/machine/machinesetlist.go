@@ -10,11 +10,13 @@ import (
 )
 
 // ListWorkerMachineSets returns a slice of SetBuilder objects in a namespace on a cluster.
-func ListWorkerMachineSets(
-	apiClient *clients.Settings,
-	namespace string,
-	workerLabel string,
-	options ...metav1.ListOptions) ([]*SetBuilder, error) {
+func ListWorkerMachineSets(apiClient *clients.Settings, namespace string, workerLabel string, options ...metav1.ListOptions) ([]*SetBuilder, error) {
+	return ListWorkerMachineSetsContext(context.TODO(), apiClient, namespace, workerLabel, options...)
+}
+
+// ListWorkerMachineSetsContext returns a slice of SetBuilder objects in a namespace on a cluster.
+func ListWorkerMachineSetsContext(ctx context.Context, apiClient *clients.Settings, namespace string,
+	workerLabel string, options ...metav1.ListOptions) ([]*SetBuilder, error) {
 	if namespace == "" {
 		glog.V(100).Infof("machineSet 'namespace' parameter can not be empty")
 
@@ -43,7 +45,7 @@ func ListWorkerMachineSets(
 
 	glog.V(100).Infof(logMessage)
 
-	machineSetList, err := apiClient.MachineSets(namespace).List(context.TODO(), passedOptions)
+	machineSetList, err := apiClient.MachineSets(namespace).List(ctx, passedOptions)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list MachineSets in the namespace %s due to %s",
@@ -0,0 +1,133 @@
+package mig
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiasmi"
+)
+
+func readTestOutput(t *testing.T, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read test output %s: %v", name, err)
+	}
+
+	return string(content)
+}
+
+// readTestProfiles returns the MIG profiles of the nvidia-smi mig -lgip output of the GPU, shared with the
+// nvidiasmi package.
+func readTestProfiles(t *testing.T, gpu string) []MIGProfileInfo {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("..", "nvidiasmi", "testdata", "nvidia-smi-mig-lgip-"+gpu+".txt"))
+	if err != nil {
+		t.Fatalf("failed to read test output for %s: %v", gpu, err)
+	}
+
+	gpuInstanceProfiles, err := nvidiasmi.ParseGPUInstanceProfiles(string(content))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi output: %v", err)
+	}
+
+	profiles, err := migProfiles(gpuInstanceProfiles)
+	if err != nil {
+		t.Fatalf("failed to convert the MIG profiles: %v", err)
+	}
+
+	return profiles
+}
+
+func TestMIGProfiles(t *testing.T) {
+	profiles := readTestProfiles(t, "a100")
+
+	var names []string
+	for _, profile := range profiles {
+		names = append(names, profile.MigName)
+	}
+
+	expectedNames := []string{"1g.5gb", "1g.10gb", "2g.10gb", "3g.20gb", "4g.20gb", "7g.40gb"}
+	if !slices.Equal(names, expectedNames) {
+		t.Fatalf("expected profiles %v without the +me one, got %v", expectedNames, names)
+	}
+
+	expected := MIGProfileInfo{GpuID: 0, MigType: "MIG", MigName: "2g.10gb", MigID: 14, Available: 3, Total: 3,
+		Memory: "9.62", P2P: "No", SM: 28, DEC: 1, ENC: 0, CE: 2, JPEG: 0, OFA: 0, Flavor: "gpu", SliceUsage: 2,
+		MemUsage: 10}
+	if profiles[2] != expected {
+		t.Errorf("expected %+v, got %+v", expected, profiles[2])
+	}
+
+	// Leaving out the +me profile must keep the engines of the 1g.5gb profile before it.
+	if profiles[0].JPEG != 0 || profiles[0].OFA != 0 {
+		t.Errorf("expected the 1g.5gb engines to be kept, got %+v", profiles[0])
+	}
+
+	if last := profiles[len(profiles)-1]; last.Available != 0 || last.Total != 1 || last.JPEG != 1 ||
+		last.OFA != 1 {
+		t.Errorf("unexpected 7g.40gb profile %+v", last)
+	}
+
+	if _, err := migProfiles([]nvidiasmi.GPUInstanceProfile{{Name: "1g.5gb+me"}}); err == nil {
+		t.Error("expected an error without profiles")
+	}
+}
+
+func TestSelectMigIndex(t *testing.T) {
+	profiles := make([]MIGProfileInfo, 3)
+
+	testCases := []struct {
+		name          string
+		useMigIndex   int
+		expectedIndex int
+	}{
+		{name: "in range", useMigIndex: 1, expectedIndex: 1},
+		{name: "out of range", useMigIndex: 7, expectedIndex: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			index, err := selectMigIndex(profiles, testCase.useMigIndex)
+			if err != nil || index != testCase.expectedIndex {
+				t.Errorf("expected index %d, got %d: %v", testCase.expectedIndex, index, err)
+			}
+		})
+	}
+
+	for range 10 {
+		if index, err := selectMigIndex(profiles, -1); err != nil || index < 0 || index >= len(profiles) {
+			t.Fatalf("expected a random index within range, got %d: %v", index, err)
+		}
+	}
+
+	if _, err := selectMigIndex(nil, 0); err == nil {
+		t.Error("expected an error without MIG profiles")
+	}
+}
+
+func TestUpdateMIGCapabilities(t *testing.T) {
+	profiles := readTestProfiles(t, "a100")
+
+	if sum := UpdateMIGCapabilities(profiles, []int{2, 0, 1, 1}, MIGStrategyMixed); sum != 4 {
+		t.Errorf("expected 4 instances in total, got %d", sum)
+	}
+
+	if profiles[0].MixedCnt != 2 || profiles[3].MixedCnt != 1 || profiles[5].MixedCnt != 0 {
+		t.Errorf("unexpected instance counts %+v", profiles)
+	}
+}
+
+func TestParseMigInstances(t *testing.T) {
+	if instances := ParseMigInstances("[2, 0,1,1]", "-1"); !slices.Equal(instances, []int{2, 0, 1, 1}) {
+		t.Errorf("expected [2 0 1 1], got %v", instances)
+	}
+
+	if instances := ParseMigInstances("none", "3"); !slices.Equal(instances, []int{3}) {
+		t.Errorf("expected the defaults [3], got %v", instances)
+	}
+}
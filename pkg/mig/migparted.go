@@ -0,0 +1,321 @@
+package mig
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/wait"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/configmap"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nodes"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// MigPartedConfigKey is the key of the mig-parted configuration in the MIG manager ConfigMap.
+	MigPartedConfigKey = "config.yaml"
+	// MigConfigDisabled is the mig-parted configuration disabling MIG on all GPUs, the ClusterPolicy default.
+	MigConfigDisabled = "all-disabled"
+	// MigConfigLabel is the node label selecting the mig-parted configuration applied by the MIG manager.
+	MigConfigLabel = "nvidia.com/mig.config"
+	// MigStrategyLabel is the node label holding the MIG strategy of the node.
+	MigStrategyLabel = "nvidia.com/mig.strategy"
+)
+
+// MigPartedDevices are the indexes of the GPUs an entry of a mig-parted configuration applies to, all GPUs when empty.
+type MigPartedDevices []int
+
+// MarshalJSON renders the devices as "all" when empty, as mig-parted expects.
+func (devices MigPartedDevices) MarshalJSON() ([]byte, error) {
+	if len(devices) == 0 {
+		return json.Marshal("all")
+	}
+
+	return json.Marshal([]int(devices))
+}
+
+// UnmarshalJSON reads the devices of a mig-parted configuration, "all" is read as empty devices.
+func (devices *MigPartedDevices) UnmarshalJSON(data []byte) error {
+	var all string
+	if err := json.Unmarshal(data, &all); err == nil {
+		if all != "all" {
+			return fmt.Errorf("invalid mig-parted devices %q, expected 'all' or a list of GPU indexes", all)
+		}
+
+		*devices = nil
+
+		return nil
+	}
+
+	var indexes []int
+	if err := json.Unmarshal(data, &indexes); err != nil {
+		return fmt.Errorf("invalid mig-parted devices %s: %w", data, err)
+	}
+
+	*devices = indexes
+
+	return nil
+}
+
+// MigPartedDeviceConfig is an entry of a mig-parted configuration. It applies to the GPUs of Devices whose PCI
+// device ID, e.g. 0x20B010DE for an A100, matches DeviceFilter when set. MigDevices are the number of MIG devices
+// of each profile, e.g. 1g.5gb, created on each of those GPUs.
+type MigPartedDeviceConfig struct {
+	DeviceFilter []string         `json:"device-filter,omitempty"`
+	Devices      MigPartedDevices `json:"devices"`
+	MigEnabled   bool             `json:"mig-enabled"`
+	MigDevices   map[string]int   `json:"mig-devices,omitempty"`
+}
+
+// MigEnabledDevices returns an entry enabling MIG with migDevices on the GPUs of indexes, all GPUs when empty.
+func MigEnabledDevices(migDevices map[string]int, indexes ...int) MigPartedDeviceConfig {
+	return MigPartedDeviceConfig{Devices: indexes, MigEnabled: true, MigDevices: migDevices}
+}
+
+// MigDisabledDevices returns an entry disabling MIG on the GPUs of indexes, all GPUs when empty.
+func MigDisabledDevices(indexes ...int) MigPartedDeviceConfig {
+	return MigPartedDeviceConfig{Devices: indexes, MigEnabled: false}
+}
+
+// WithDeviceFilter restricts the entry to the GPUs with one of the PCI device IDs.
+func (deviceConfig MigPartedDeviceConfig) WithDeviceFilter(deviceIDs ...string) MigPartedDeviceConfig {
+	deviceConfig.DeviceFilter = deviceIDs
+
+	return deviceConfig
+}
+
+// HalfMIGDevices returns the entries of a heterogeneous node with gpuCount GPUs: the first half of the GPUs in MIG
+// mode with migDevices, the other half as full GPUs.
+func HalfMIGDevices(gpuCount int, migDevices map[string]int) []MigPartedDeviceConfig {
+	var migIndexes, fullIndexes []int
+
+	for index := range gpuCount {
+		if index < (gpuCount+1)/2 {
+			migIndexes = append(migIndexes, index)
+		} else {
+			fullIndexes = append(fullIndexes, index)
+		}
+	}
+
+	deviceConfigs := []MigPartedDeviceConfig{MigEnabledDevices(migDevices, migIndexes...)}
+	if len(fullIndexes) > 0 {
+		deviceConfigs = append(deviceConfigs, MigDisabledDevices(fullIndexes...))
+	}
+
+	return deviceConfigs
+}
+
+// migPartedConfig is the content of the mig-parted config.yaml.
+type migPartedConfig struct {
+	Version    string                             `json:"version"`
+	MigConfigs map[string][]MigPartedDeviceConfig `json:"mig-configs"`
+}
+
+// MigPartedConfigBuilder provides struct for the ConfigMap of custom mig-parted configurations used by the MIG
+// manager through ClusterPolicy.spec.migManager.config.
+type MigPartedConfigBuilder struct {
+	// Configs are the named mig-parted configurations, selected on the nodes by the nvidia.com/mig.config label.
+	Configs map[string][]MigPartedDeviceConfig
+	// ConfigMap is the builder of the ConfigMap once created.
+	ConfigMap *configmap.Builder
+
+	apiClient *clients.Settings
+	name      string
+	namespace string
+	errorMsg  string
+}
+
+// NewMigPartedConfigBuilder creates a new instance of MigPartedConfigBuilder for the ConfigMap name in nsname. It
+// holds the all-disabled configuration, which the MIG manager falls back to, until it is redefined.
+func NewMigPartedConfigBuilder(apiClient *clients.Settings, name, nsname string) *MigPartedConfigBuilder {
+	glog.V(gpuparams.GpuLogLevel).Infof("Initializing new mig-parted config builder for configmap %s in namespace %s",
+		name, nsname)
+
+	builder := &MigPartedConfigBuilder{
+		Configs: map[string][]MigPartedDeviceConfig{
+			MigConfigDisabled: {MigDisabledDevices()},
+		},
+		apiClient: apiClient,
+		name:      name,
+		namespace: nsname,
+	}
+
+	if name == "" {
+		builder.errorMsg = "mig-parted configmap 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		builder.errorMsg = "mig-parted configmap 'nsname' cannot be empty"
+	}
+
+	return builder
+}
+
+// WithConfig defines the named mig-parted configuration made of the device entries.
+func (builder *MigPartedConfigBuilder) WithConfig(
+	name string, deviceConfigs ...MigPartedDeviceConfig) *MigPartedConfigBuilder {
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Setting mig-parted config %s to %+v", name, deviceConfigs)
+
+	if name == "" {
+		builder.errorMsg = "mig-parted config name cannot be empty"
+
+		return builder
+	}
+
+	if len(deviceConfigs) == 0 {
+		builder.errorMsg = fmt.Sprintf("mig-parted config %s needs at least one device entry", name)
+
+		return builder
+	}
+
+	for _, deviceConfig := range deviceConfigs {
+		if deviceConfig.MigEnabled && len(deviceConfig.MigDevices) == 0 {
+			builder.errorMsg = fmt.Sprintf("mig-parted config %s enables MIG without mig-devices", name)
+
+			return builder
+		}
+
+		if !deviceConfig.MigEnabled && len(deviceConfig.MigDevices) > 0 {
+			builder.errorMsg = fmt.Sprintf("mig-parted config %s has mig-devices on GPUs with MIG disabled", name)
+
+			return builder
+		}
+
+		if slices.ContainsFunc(deviceConfig.Devices, func(index int) bool { return index < 0 }) {
+			builder.errorMsg = fmt.Sprintf("mig-parted config %s has negative GPU indexes %v", name,
+				deviceConfig.Devices)
+
+			return builder
+		}
+	}
+
+	builder.Configs[name] = deviceConfigs
+
+	return builder
+}
+
+// Render returns the mig-parted config.yaml of the configurations.
+func (builder *MigPartedConfigBuilder) Render() (string, error) {
+	if builder.errorMsg != "" {
+		return "", fmt.Errorf("%s", builder.errorMsg)
+	}
+
+	content, err := yaml.Marshal(migPartedConfig{Version: "v1", MigConfigs: builder.Configs})
+	if err != nil {
+		return "", fmt.Errorf("error rendering mig-parted config: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// Create creates the ConfigMap with the mig-parted config.yaml, replacing an existing one.
+func (builder *MigPartedConfigBuilder) Create() (*configmap.Builder, error) {
+	if builder.apiClient == nil {
+		return nil, fmt.Errorf("cannot create mig-parted configmap with nil apiClient")
+	}
+
+	content, err := builder.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	configMapBuilder := configmap.NewBuilder(builder.apiClient, builder.name, builder.namespace).
+		WithData(map[string]string{MigPartedConfigKey: content})
+
+	// The configmap builder does not update existing configmaps, so an outdated one is replaced.
+	if configMapBuilder.Exists() {
+		glog.V(gpuparams.GpuLogLevel).Infof("Replacing mig-parted configmap %s in namespace %s",
+			builder.name, builder.namespace)
+
+		if err = configMapBuilder.Delete(); err != nil {
+			return nil, fmt.Errorf("error deleting mig-parted configmap %s: %w", builder.name, err)
+		}
+	}
+
+	builder.ConfigMap, err = configMapBuilder.Create()
+	if err != nil {
+		return nil, fmt.Errorf("error creating mig-parted configmap %s: %w", builder.name, err)
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Created mig-parted configmap %s in namespace %s with configs:\n%s",
+		builder.name, builder.namespace, content)
+
+	return builder.ConfigMap, nil
+}
+
+// Delete removes the ConfigMap of the mig-parted configurations.
+func (builder *MigPartedConfigBuilder) Delete() error {
+	if builder.apiClient == nil {
+		return fmt.Errorf("cannot delete mig-parted configmap with nil apiClient")
+	}
+
+	return configmap.NewBuilder(builder.apiClient, builder.name, builder.namespace).Delete()
+}
+
+// ConfigureMigPartedConfig creates the ConfigMap of the mig-parted configurations and points the MIG manager of the
+// ClusterPolicy to it, with all-disabled as default configuration. It waits for the ClusterPolicy to be ready.
+func ConfigureMigPartedConfig(apiClient *clients.Settings, clusterPolicyBuilder *nvidiagpu.Builder,
+	configBuilder *MigPartedConfigBuilder) error {
+	glog.V(gpuparams.Gpu10LogLevel).Infof("%s", colorLog(colorCyan+colorBold, "Configure custom mig-parted config"))
+
+	if _, err := configBuilder.Create(); err != nil {
+		return err
+	}
+
+	_, err := clusterPolicyBuilder.WithMIGManagerConfig(configBuilder.name, MigConfigDisabled).Update(true)
+	if err != nil {
+		return fmt.Errorf("error updating ClusterPolicy with mig-parted configmap %s: %w", configBuilder.name, err)
+	}
+
+	err = wait.ClusterPolicyReady(apiClient, nvidiagpu.ClusterPolicyName,
+		nvidiagpu.ClusterPolicyReadyCheckInterval, nvidiagpu.ClusterPolicyReadyTimeout)
+	if err != nil {
+		return fmt.Errorf("error waiting for ClusterPolicy to be ready with mig-parted configmap %s: %w",
+			configBuilder.name, err)
+	}
+
+	return nil
+}
+
+// SetMIGConfigLabelsPerNode sets the mixed MIG strategy and the nvidia.com/mig.config label of each node of
+// nodeConfigs to its named mig-parted configuration, so different nodes can run different MIG layouts.
+func SetMIGConfigLabelsPerNode(apiClient *clients.Settings, nodeConfigs map[string]string) error {
+	glog.V(gpuparams.Gpu10LogLevel).Infof("%s", colorLog(colorCyan+colorBold, "Set MIG config labels per node"))
+
+	if apiClient == nil {
+		return fmt.Errorf("cannot label nodes with nil apiClient")
+	}
+
+	nodeNames := make([]string, 0, len(nodeConfigs))
+	for nodeName := range nodeConfigs {
+		nodeNames = append(nodeNames, nodeName)
+	}
+
+	slices.Sort(nodeNames)
+
+	for _, nodeName := range nodeNames {
+		nodeBuilder, err := nodes.Pull(apiClient, nodeName)
+		if err != nil {
+			return fmt.Errorf("error pulling node '%s': %w", nodeName, err)
+		}
+
+		glog.V(gpuparams.GpuLogLevel).Infof("Setting MIG configuration label %s on node '%s' (overwrite=true)",
+			nodeConfigs[nodeName], nodeName)
+
+		nodeBuilder = nodeBuilder.WithLabel(MigStrategyLabel, MIGStrategyMixed).
+			WithLabel(MigConfigLabel, nodeConfigs[nodeName])
+		if _, err = nodeBuilder.Update(); err != nil {
+			return fmt.Errorf("error updating node '%s' with MIG labels: %w", nodeName, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package mig
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/configmap"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nodes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestMigPartedConfigRender(t *testing.T) {
+	builder := NewMigPartedConfigBuilder(nil, "custom-mig-parted-config", "nvidia-gpu-operator").
+		WithConfig("half-balanced", HalfMIGDevices(4, map[string]int{"1g.5gb": 2, "2g.10gb": 1})...).
+		WithConfig("a100-all-3g", MigEnabledDevices(map[string]int{"3g.20gb": 2}).WithDeviceFilter("0x20B010DE"))
+
+	content, err := builder.Render()
+	if err != nil {
+		t.Fatalf("failed to render mig-parted config: %v", err)
+	}
+
+	expected := `mig-configs:
+  a100-all-3g:
+  - device-filter:
+    - "0x20B010DE"
+    devices: all
+    mig-devices:
+      3g.20gb: 2
+    mig-enabled: true
+  all-disabled:
+  - devices: all
+    mig-enabled: false
+  half-balanced:
+  - devices:
+    - 0
+    - 1
+    mig-devices:
+      1g.5gb: 2
+      2g.10gb: 1
+    mig-enabled: true
+  - devices:
+    - 2
+    - 3
+    mig-enabled: false
+version: v1
+`
+	if content != expected {
+		t.Fatalf("expected mig-parted config:\n%s\ngot:\n%s", expected, content)
+	}
+
+	var parsed migPartedConfig
+	if err = yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("failed to parse the rendered mig-parted config: %v", err)
+	}
+
+	if devices := parsed.MigConfigs["half-balanced"][1].Devices; len(devices) != 2 || devices[0] != 2 {
+		t.Errorf("expected the full GPUs 2 and 3, got %v", devices)
+	}
+
+	if devices := parsed.MigConfigs[MigConfigDisabled][0].Devices; devices != nil {
+		t.Errorf("expected all devices, got %v", devices)
+	}
+}
+
+func TestMigPartedConfigWithConfigErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configName    string
+		deviceConfigs []MigPartedDeviceConfig
+		expectedError string
+	}{
+		{
+			name:          "empty name",
+			deviceConfigs: []MigPartedDeviceConfig{MigDisabledDevices()},
+			expectedError: "name cannot be empty",
+		},
+		{
+			name:          "no device entries",
+			configName:    "empty",
+			expectedError: "at least one device entry",
+		},
+		{
+			name:          "MIG enabled without mig-devices",
+			configName:    "no-devices",
+			deviceConfigs: []MigPartedDeviceConfig{MigEnabledDevices(nil, 0)},
+			expectedError: "without mig-devices",
+		},
+		{
+			name:       "mig-devices with MIG disabled",
+			configName: "disabled-devices",
+			deviceConfigs: []MigPartedDeviceConfig{
+				{Devices: MigPartedDevices{0}, MigDevices: map[string]int{"1g.5gb": 7}},
+			},
+			expectedError: "MIG disabled",
+		},
+		{
+			name:          "negative GPU index",
+			configName:    "negative",
+			deviceConfigs: []MigPartedDeviceConfig{MigDisabledDevices(-1)},
+			expectedError: "negative GPU indexes",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := NewMigPartedConfigBuilder(nil, "custom-mig-parted-config", "nvidia-gpu-operator").
+				WithConfig(testCase.configName, testCase.deviceConfigs...).Render()
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestMigPartedConfigCreate(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients(nil)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	_, err = configmap.NewBuilder(apiClient, "custom-mig-parted-config", "nvidia-gpu-operator").
+		WithData(map[string]string{MigPartedConfigKey: "outdated"}).Create()
+	if err != nil {
+		t.Fatalf("failed to create the outdated configmap: %v", err)
+	}
+
+	builder := NewMigPartedConfigBuilder(apiClient, "custom-mig-parted-config", "nvidia-gpu-operator").
+		WithConfig("all-1g.5gb", MigEnabledDevices(map[string]int{"1g.5gb": 7}))
+	if _, err = builder.Create(); err != nil {
+		t.Fatalf("failed to create mig-parted configmap: %v", err)
+	}
+
+	configMap, err := apiClient.ConfigMaps("nvidia-gpu-operator").Get(context.TODO(), "custom-mig-parted-config",
+		metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get mig-parted configmap: %v", err)
+	}
+
+	if !strings.Contains(configMap.Data[MigPartedConfigKey], "all-1g.5gb:") {
+		t.Errorf("expected the outdated configmap to be replaced, got %q", configMap.Data[MigPartedConfigKey])
+	}
+}
+
+func TestSetMIGConfigLabelsPerNode(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Nodes})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	nodeConfigs := map[string]string{"worker-gpu-0": "half-balanced", "worker-gpu-1": "all-1g.5gb"}
+	if err = SetMIGConfigLabelsPerNode(apiClient, nodeConfigs); err != nil {
+		t.Fatalf("failed to set MIG config labels: %v", err)
+	}
+
+	for nodeName, config := range nodeConfigs {
+		nodeBuilder, err := nodes.Pull(apiClient, nodeName)
+		if err != nil {
+			t.Fatalf("failed to pull node %s: %v", nodeName, err)
+		}
+
+		labels := nodeBuilder.Object.Labels
+		if labels[MigConfigLabel] != config || labels[MigStrategyLabel] != MIGStrategyMixed {
+			t.Errorf("expected node %s to use MIG config %s with the mixed strategy, got %v", nodeName, config,
+				labels)
+		}
+	}
+
+	if err = SetMIGConfigLabelsPerNode(apiClient, map[string]string{"missing-node": "all-disabled"}); err == nil {
+		t.Error("expected an error for a missing node")
+	}
+}
@@ -1,11 +1,8 @@
 package mig
 
 import (
-	"flag"
 	"fmt"
 	"os"
-
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
 )
 
 // MIGProfileInfo represents information about a MIG profile
@@ -30,20 +27,12 @@ type MIGProfileInfo struct {
 	MemUsage   int    // memory usage in GB per instance
 }
 
-type MigPodInfo struct {
-	PodName        string         // name of the pod
-	Namespace      string         // namespace of the pod
-	Pod            *pod.Builder   // pod object
-	MigProfileInfo MIGProfileInfo // MIG profile information
-}
-
 // ANSI color constants for console output highlighting
 // colors are \033[31m - red through \033[37m - white
 const (
 	colorReset = "\033[0m"
 	colorRed   = "\033[31m"
 	colorCyan  = "\033[36m"
-	colorGreen = "\033[32m"
 	colorBold  = "\033[1m"
 )
 
@@ -57,29 +46,10 @@ func colorLog(color, message string) string {
 	return fmt.Sprintf("%s%s%s", color, message, colorReset)
 }
 
-// Global variables for ginkgo CLI parameters and values derived from them
-var (
-	PodDelay          int
-	SingleMigProfile  int
-	MigInstances      string
-	NoColor           bool
-	MixedMigInstances []int
-)
-
-const (
-	defaultMigInstances     int = -1 // parameter not provided
-	defaultSingleMigProfile int = -2 // parameter not provided
-)
+// NoColor disables the color output of colorLog, like the NO_COLOR environment variable does.
+var NoColor bool
 
 const (
 	MIGStrategySingle = "single"
 	MIGStrategyMixed  = "mixed"
 )
-
-func init() {
-	// Register flags before Ginkgo parses them
-	flag.IntVar(&PodDelay, "mixed.mig.pod-delay", 0, "delay in seconds between pod creation on mixed-mig testcase")
-	flag.IntVar(&SingleMigProfile, "single.mig.profile", -2, "index of the MIG profile to be used for single-mig testcase")
-	flag.StringVar(&MigInstances, "mixed.mig.instances", "-1", "comma-separated number of instances for mixed-mig testcase, defaults are for A100 GPU [2,0,1,1,0,0]")
-	flag.BoolVar(&NoColor, "no-color", false, "disable color output")
-}
@@ -0,0 +1,236 @@
+package mig
+
+import (
+	"fmt"
+	"math/rand"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+)
+
+// Placement is a range of memory slices a GPU instance can occupy, as listed by nvidia-smi mig -lgipp.
+type Placement struct {
+	Start int
+	Size  int
+}
+
+// ProfilePlacements are the placements of a GPU instance profile on a GPU.
+type ProfilePlacements struct {
+	GpuID      int
+	MigID      int
+	Placements []Placement
+}
+
+// PlacedInstance is a GPU instance of a mix placed on the GPU by the Planner.
+type PlacedInstance struct {
+	MigName string
+	MigID   int
+	Placement
+}
+
+// Planner validates and generates mixes of MIG instances for one GPU. A mix has the same layout as the instance
+// counts of the mixed-mig testcase: the number of instances of each profile, in the order of the profiles.
+type Planner struct {
+	profiles     []MIGProfileInfo
+	placements   [][]Placement
+	memorySlices int
+}
+
+// ParseMIGPlacements parses the output of nvidia-smi mig -lgipp, e.g.:
+// GPU  0 Profile ID 19 Placements: {0,1,2,3,4,5,6}:1
+// GPU  0 Profile ID  0 Placement : {0}:8
+func ParseMIGPlacements(output string) ([]ProfilePlacements, error) {
+	placementRegex := regexp.MustCompile(`GPU\s+(\d+)\s+Profile ID\s+(\d+)\s+Placements?\s*:\s*\{([\d,\s]+)\}:(\d+)`)
+
+	var profilePlacements []ProfilePlacements
+
+	for _, line := range strings.Split(output, "\n") {
+		matches := placementRegex.FindStringSubmatch(line)
+		if len(matches) == 0 {
+			continue
+		}
+
+		gpuID, _ := strconv.Atoi(matches[1])
+		migID, _ := strconv.Atoi(matches[2])
+		size, _ := strconv.Atoi(matches[4])
+
+		placements := ProfilePlacements{GpuID: gpuID, MigID: migID}
+
+		for _, start := range strings.Split(matches[3], ",") {
+			startSlice, err := strconv.Atoi(strings.TrimSpace(start))
+			if err != nil {
+				return nil, fmt.Errorf("invalid placement start %q in line %q", start, line)
+			}
+
+			placements.Placements = append(placements.Placements, Placement{Start: startSlice, Size: size})
+		}
+
+		profilePlacements = append(profilePlacements, placements)
+	}
+
+	if len(profilePlacements) == 0 {
+		return nil, fmt.Errorf("no MIG placements found in nvidia-smi output")
+	}
+
+	return profilePlacements, nil
+}
+
+// NewPlanner returns a Planner for the GPU of the profiles, which all GPUs of a node share in practice. Every
+// profile needs placements on that GPU.
+func NewPlanner(profiles []MIGProfileInfo, profilePlacements []ProfilePlacements) (*Planner, error) {
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("cannot plan MIG mixes without MIG profiles")
+	}
+
+	gpuID := profiles[0].GpuID
+	planner := &Planner{profiles: profiles, placements: make([][]Placement, len(profiles))}
+
+	for index, profile := range profiles {
+		for _, placements := range profilePlacements {
+			if placements.GpuID == gpuID && placements.MigID == profile.MigID {
+				planner.placements[index] = placements.Placements
+			}
+		}
+
+		if len(planner.placements[index]) == 0 {
+			return nil, fmt.Errorf("no placements found for MIG profile %s (ID %d) on GPU %d", profile.MigName,
+				profile.MigID, gpuID)
+		}
+
+		for _, placement := range planner.placements[index] {
+			planner.memorySlices = max(planner.memorySlices, placement.Start+placement.Size)
+		}
+	}
+
+	if planner.memorySlices > 64 {
+		return nil, fmt.Errorf("GPU %d has %d memory slices, at most 64 are supported", gpuID,
+			planner.memorySlices)
+	}
+
+	glog.V(gpuparams.Gpu100LogLevel).Infof("MIG planner for GPU %d with %d profiles and %d memory slices", gpuID,
+		len(profiles), planner.memorySlices)
+
+	return planner, nil
+}
+
+// MemorySlices returns the number of memory slices of the GPU.
+func (planner *Planner) MemorySlices() int {
+	return planner.memorySlices
+}
+
+// Place returns a placement of the instances of the mix on the GPU, or an error if the mix does not fit.
+func (planner *Planner) Place(mix []int) ([]PlacedInstance, error) {
+	if len(mix) > len(planner.profiles) {
+		return nil, fmt.Errorf("mix %v has %d instance counts but there are only %d MIG profiles", mix, len(mix),
+			len(planner.profiles))
+	}
+
+	// The largest instances are placed first, they have the fewest placements.
+	var instances []int
+
+	for index, count := range mix {
+		if count < 0 || count > planner.profiles[index].Total {
+			return nil, fmt.Errorf("mix %v requests %d instances of MIG profile %s, which allows 0-%d", mix, count,
+				planner.profiles[index].MigName, planner.profiles[index].Total)
+		}
+
+		for range count {
+			instances = append(instances, index)
+		}
+	}
+
+	slices.SortStableFunc(instances, func(a, b int) int {
+		return planner.placements[b][0].Size - planner.placements[a][0].Size
+	})
+
+	placed := make([]PlacedInstance, len(instances))
+	if !planner.place(instances, placed, 0, 0) {
+		return nil, fmt.Errorf("mix %v does not fit the %d memory slices of the GPU", mix, planner.memorySlices)
+	}
+
+	return placed, nil
+}
+
+// Validate returns an error if the mix does not fit the GPU.
+func (planner *Planner) Validate(mix []int) error {
+	_, err := planner.Place(mix)
+
+	return err
+}
+
+// Mixes returns all the mixes with at least one instance that fit the GPU.
+func (planner *Planner) Mixes() [][]int {
+	var mixes [][]int
+
+	mix := make([]int, len(planner.profiles))
+
+	var enumerate func(index int)
+	enumerate = func(index int) {
+		if index == len(mix) {
+			if slices.ContainsFunc(mix, func(count int) bool { return count > 0 }) {
+				mixes = append(mixes, slices.Clone(mix))
+			}
+
+			return
+		}
+
+		for count := 0; count <= planner.profiles[index].Total; count++ {
+			mix[index] = count
+			// A mix that does not fit does not fit with more instances either.
+			if count > 0 && planner.Validate(mix[:index+1]) != nil {
+				break
+			}
+
+			enumerate(index + 1)
+		}
+
+		mix[index] = 0
+	}
+
+	enumerate(0)
+
+	return mixes
+}
+
+// RandomMix returns one of the Mixes picked with rng.
+func (planner *Planner) RandomMix(rng *rand.Rand) []int {
+	mixes := planner.Mixes()
+	if len(mixes) == 0 {
+		return make([]int, len(planner.profiles))
+	}
+
+	return mixes[rng.Intn(len(mixes))]
+}
+
+// place backtracks over the placements of the instances from next on, given the memory slices already occupied.
+func (planner *Planner) place(instances []int, placed []PlacedInstance, next int, occupied uint64) bool {
+	if next == len(instances) {
+		return true
+	}
+
+	profile := planner.profiles[instances[next]]
+
+	for _, placement := range planner.placements[instances[next]] {
+		// Instances of the same profile are interchangeable, placing them in increasing order avoids retrying
+		// their permutations.
+		if next > 0 && instances[next-1] == instances[next] && placement.Start <= placed[next-1].Start {
+			continue
+		}
+
+		mask := (uint64(1)<<placement.Size - 1) << placement.Start
+		if occupied&mask != 0 {
+			continue
+		}
+
+		placed[next] = PlacedInstance{MigName: profile.MigName, MigID: profile.MigID, Placement: placement}
+		if planner.place(instances, placed, next+1, occupied|mask) {
+			return true
+		}
+	}
+
+	return false
+}
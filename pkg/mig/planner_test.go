@@ -0,0 +1,141 @@
+package mig
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestPlanner(t *testing.T, gpu string) *Planner {
+	t.Helper()
+
+	profiles := readTestProfiles(t, gpu)
+
+	placements, err := ParseMIGPlacements(readTestOutput(t, "nvidia-smi-mig-lgipp-"+gpu+".txt"))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi placements: %v", err)
+	}
+
+	planner, err := NewPlanner(profiles, placements)
+	if err != nil {
+		t.Fatalf("failed to create planner: %v", err)
+	}
+
+	return planner
+}
+
+func TestParseMIGPlacements(t *testing.T) {
+	placements, err := ParseMIGPlacements(readTestOutput(t, "nvidia-smi-mig-lgipp-a100.txt"))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi output: %v", err)
+	}
+
+	if len(placements) != 7 {
+		t.Fatalf("expected 7 profiles, got %d", len(placements))
+	}
+
+	if placements[4].MigID != 9 || !slices.Equal(placements[4].Placements, []Placement{{0, 4}, {4, 4}}) {
+		t.Errorf("unexpected 3g.20gb placements %+v", placements[4])
+	}
+
+	if placements[6].MigID != 0 || !slices.Equal(placements[6].Placements, []Placement{{0, 8}}) {
+		t.Errorf("unexpected 7g.40gb placement %+v", placements[6])
+	}
+
+	if _, err := ParseMIGPlacements("No MIG-supported devices found."); err == nil {
+		t.Error("expected an error for an output without placements")
+	}
+}
+
+func TestPlannerValidate(t *testing.T) {
+	planner := newTestPlanner(t, "a100")
+
+	if planner.MemorySlices() != 8 {
+		t.Errorf("expected 8 memory slices, got %d", planner.MemorySlices())
+	}
+
+	// The profiles are 1g.5gb, 1g.10gb, 2g.10gb, 3g.20gb, 4g.20gb and 7g.40gb.
+	testCases := []struct {
+		name          string
+		mix           []int
+		expectedError string
+	}{
+		{name: "default mix", mix: []int{2, 0, 1, 1, 0, 0}},
+		{name: "seven 1g.5gb", mix: []int{7}},
+		{name: "two 3g.20gb", mix: []int{0, 0, 0, 2}},
+		{name: "4g.20gb with 2g.10gb and 1g.5gb", mix: []int{1, 0, 1, 0, 1}},
+		{name: "three 2g.10gb and a 1g.5gb", mix: []int{1, 0, 3}},
+		{name: "no room left by four 1g.10gb", mix: []int{1, 4}, expectedError: "does not fit"},
+		{name: "4g.20gb with 7g.40gb", mix: []int{0, 0, 0, 0, 1, 1}, expectedError: "does not fit"},
+		{name: "more instances than the profile allows", mix: []int{8}, expectedError: "allows 0-7"},
+		{name: "more counts than profiles", mix: []int{0, 0, 0, 0, 0, 0, 1}, expectedError: "only 6 MIG profiles"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := planner.Validate(testCase.mix)
+			if testCase.expectedError == "" && err != nil {
+				t.Errorf("expected mix %v to fit, got %v", testCase.mix, err)
+			}
+
+			if testCase.expectedError != "" && (err == nil || !strings.Contains(err.Error(), testCase.expectedError)) {
+				t.Errorf("expected error %q for mix %v, got %v", testCase.expectedError, testCase.mix, err)
+			}
+		})
+	}
+}
+
+func TestPlannerPlace(t *testing.T) {
+	// The 1g.5gb instances cannot use the last memory slice, so the 3g.20gb instance has to take the second half.
+	placed, err := newTestPlanner(t, "a100").Place([]int{2, 0, 1, 1})
+	if err != nil {
+		t.Fatalf("failed to place the default mix: %v", err)
+	}
+
+	expected := []PlacedInstance{
+		{MigName: "3g.20gb", MigID: 9, Placement: Placement{Start: 4, Size: 4}},
+		{MigName: "2g.10gb", MigID: 14, Placement: Placement{Start: 0, Size: 2}},
+		{MigName: "1g.5gb", MigID: 19, Placement: Placement{Start: 2, Size: 1}},
+		{MigName: "1g.5gb", MigID: 19, Placement: Placement{Start: 3, Size: 1}},
+	}
+	if !slices.Equal(placed, expected) {
+		t.Errorf("expected placement %+v, got %+v", expected, placed)
+	}
+}
+
+func TestPlannerMixes(t *testing.T) {
+	planner := newTestPlanner(t, "a30")
+
+	// The profiles are 1g.6gb, 2g.12gb and 4g.24gb.
+	expected := [][]int{
+		{0, 0, 1}, {0, 1, 0}, {0, 2, 0}, {1, 0, 0}, {1, 1, 0}, {2, 0, 0}, {2, 1, 0}, {3, 0, 0}, {4, 0, 0},
+	}
+
+	mixes := planner.Mixes()
+	if !slices.EqualFunc(mixes, expected, slices.Equal) {
+		t.Errorf("expected mixes %v, got %v", expected, mixes)
+	}
+
+	a100Planner := newTestPlanner(t, "a100")
+
+	rng := rand.New(rand.NewSource(1))
+	for range 20 {
+		if mix := a100Planner.RandomMix(rng); a100Planner.Validate(mix) != nil {
+			t.Fatalf("expected the random mix %v to fit", mix)
+		}
+	}
+}
+
+func TestNewPlannerMissingPlacements(t *testing.T) {
+	profiles := readTestProfiles(t, "a100")
+
+	placements, err := ParseMIGPlacements(readTestOutput(t, "nvidia-smi-mig-lgipp-a30.txt"))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi placements: %v", err)
+	}
+
+	if _, err := NewPlanner(profiles, placements); err == nil {
+		t.Error("expected an error for profiles without placements")
+	}
+}
@@ -118,6 +118,11 @@ func (builder *Builder) WithOptions(options ...AdditionalOptions) *Builder {
 
 // Create makes a namespace in the cluster and stores the created object in struct.
 func (builder *Builder) Create() (*Builder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a namespace in the cluster and stores the created object in struct.
+func (builder *Builder) CreateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -125,9 +130,9 @@ func (builder *Builder) Create() (*Builder, error) {
 	glog.V(100).Infof("Creating namespace %s", builder.Definition.Name)
 
 	var err error
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		builder.Object, err = builder.apiClient.Namespaces().Create(
-			context.TODO(), builder.Definition, metav1.CreateOptions{})
+			ctx, builder.Definition, metav1.CreateOptions{})
 	}
 
 	return builder, err
@@ -135,6 +140,11 @@ func (builder *Builder) Create() (*Builder, error) {
 
 // Update renovates the existing namespace object with the namespace definition in builder.
 func (builder *Builder) Update() (*Builder, error) {
+	return builder.UpdateContext(context.TODO())
+}
+
+// UpdateContext renovates the existing namespace object with the namespace definition in builder.
+func (builder *Builder) UpdateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -143,24 +153,29 @@ func (builder *Builder) Update() (*Builder, error) {
 
 	var err error
 	builder.Object, err = builder.apiClient.Namespaces().Update(
-		context.TODO(), builder.Definition, metav1.UpdateOptions{})
+		ctx, builder.Definition, metav1.UpdateOptions{})
 
 	return builder, err
 }
 
 // Delete removes a namespace.
 func (builder *Builder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a namespace.
+func (builder *Builder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
 
 	glog.V(100).Infof("Deleting namespace %s", builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil
 	}
 
-	err := builder.apiClient.Namespaces().Delete(context.TODO(), builder.Object.Name, metav1.DeleteOptions{})
+	err := builder.apiClient.Namespaces().Delete(ctx, builder.Object.Name, metav1.DeleteOptions{})
 
 	if err != nil {
 		return err
@@ -173,19 +188,24 @@ func (builder *Builder) Delete() error {
 
 // DeleteAndWait deletes a namespace and waits until it's removed from the cluster.
 func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
+	return builder.DeleteAndWaitContext(context.TODO(), timeout)
+}
+
+// DeleteAndWaitContext deletes a namespace and waits until it's removed from the cluster.
+func (builder *Builder) DeleteAndWaitContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
 
 	glog.V(100).Infof("Deleting namespace %s and waiting for the removal to complete", builder.Definition.Name)
 
-	if err := builder.Delete(); err != nil {
+	if err := builder.DeleteContext(ctx); err != nil {
 		return err
 	}
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-			_, err := builder.apiClient.Namespaces().Get(context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			_, err := builder.apiClient.Namespaces().Get(ctx, builder.Definition.Name, metav1.GetOptions{})
 			if k8serrors.IsNotFound(err) {
 				return true, nil
 			}
@@ -196,6 +216,11 @@ func (builder *Builder) DeleteAndWait(timeout time.Duration) error {
 
 // Exists checks whether the given namespace exists.
 func (builder *Builder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given namespace exists.
+func (builder *Builder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -204,13 +229,18 @@ func (builder *Builder) Exists() bool {
 
 	var err error
 	builder.Object, err = builder.apiClient.Namespaces().Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
 // Pull loads existing namespace in to Builder struct.
 func Pull(apiClient *clients.Settings, nsname string) (*Builder, error) {
+	return PullContext(context.TODO(), apiClient, nsname)
+}
+
+// PullContext loads existing namespace in to Builder struct.
+func PullContext(ctx context.Context, apiClient *clients.Settings, nsname string) (*Builder, error) {
 	glog.V(100).Infof("Pulling existing namespace: %s from cluster", nsname)
 
 	builder := Builder{
@@ -226,7 +256,7 @@ func Pull(apiClient *clients.Settings, nsname string) (*Builder, error) {
 		builder.errorMsg = "'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("namespace object %s doesn't exist", nsname)
 	}
 
@@ -237,6 +267,12 @@ func Pull(apiClient *clients.Settings, nsname string) (*Builder, error) {
 
 // CleanObjects removes given objects from the namespace.
 func (builder *Builder) CleanObjects(cleanTimeout time.Duration, objects ...schema.GroupVersionResource) error {
+	return builder.CleanObjectsContext(context.TODO(), cleanTimeout, objects...)
+}
+
+// CleanObjectsContext removes given objects from the namespace.
+func (builder *Builder) CleanObjectsContext(ctx context.Context, cleanTimeout time.Duration,
+	objects ...schema.GroupVersionResource) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -248,7 +284,7 @@ func (builder *Builder) CleanObjects(cleanTimeout time.Duration, objects ...sche
 			builder.Definition.Name)
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return fmt.Errorf("failed to remove resources from non-existent namespace %s",
 			builder.Definition.Name)
 	}
@@ -258,7 +294,7 @@ func (builder *Builder) CleanObjects(cleanTimeout time.Duration, objects ...sche
 			resource.Resource, builder.Definition.Name)
 
 		err := builder.apiClient.Resource(resource).Namespace(builder.Definition.Name).DeleteCollection(
-			context.TODO(), metav1.DeleteOptions{
+			ctx, metav1.DeleteOptions{
 				GracePeriodSeconds: ptr.To(int64(0)),
 			}, metav1.ListOptions{})
 
@@ -270,9 +306,9 @@ func (builder *Builder) CleanObjects(cleanTimeout time.Duration, objects ...sche
 		}
 
 		err = wait.PollUntilContextTimeout(
-			context.TODO(), 3*time.Second, cleanTimeout, true, func(ctx context.Context) (bool, error) {
+			ctx, 3*time.Second, cleanTimeout, true, func(ctx context.Context) (bool, error) {
 				objList, err := builder.apiClient.Resource(resource).Namespace(builder.Definition.Name).List(
-					context.TODO(), metav1.ListOptions{})
+					ctx, metav1.ListOptions{})
 
 				if err != nil || len(objList.Items) > 1 {
 					// avoid timeout due to default automatically created openshift
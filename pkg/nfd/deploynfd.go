@@ -6,10 +6,7 @@ import (
 	"fmt"
 	"time"
 
-	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/logging"
-
 	"github.com/golang/glog"
-	. "github.com/onsi/gomega" //nolint:staticcheck
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/get"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
@@ -18,7 +15,6 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/deployment"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -27,206 +23,12 @@ const (
 	nfdOperatorNamespace                        = "openshift-nfd"
 	nfdOperatorGroupName                        = "nfd-og"
 	nfdSubscriptionName                         = "nfd-subscription"
-	nfdCatalogSourceNamespace                   = "openshift-marketplace"
 	nfdOperatorDeploymentName                   = "nfd-controller-manager"
-	nfdPackage                                  = "nfd"
 	nfdChannel                                  = "stable"
 	nfdInstallPlanApproval    v1alpha1.Approval = "Automatic"
 	nfdCRDeploymentName                         = "nfd-master"
 )
 
-// CreateNFDNamespace creates and labels NFD namespace.
-func CreateNFDNamespace(apiClient *clients.Settings) error {
-	glog.V(gpuparams.GpuLogLevel).Infof("Check if NFD Operator namespace exists, otherwise created it")
-
-	nfdNsBuilder := namespace.NewBuilder(apiClient, nfdOperatorNamespace)
-
-	glog.V(gpuparams.GpuLogLevel).Infof("Creating the namespace:  %v", nfdOperatorNamespace)
-
-	createdNfdNsBuilder, err := nfdNsBuilder.Create()
-
-	if err != nil {
-		glog.V(gpuparams.GpuLogLevel).Infof("error creating NFD namespace '%s' :  %v ", createdNfdNsBuilder.Definition.Name, err)
-
-		return err
-	}
-
-	glog.V(gpuparams.GpuLogLevel).Infof("Successfully created NFD namespace '%s'",
-		createdNfdNsBuilder.Object.Name)
-
-	glog.V(gpuparams.GpuLogLevel).Infof("Labeling the newly created NFD namespace '%s'",
-		nfdNsBuilder.Object.Name)
-
-	labeledNfdNsBuilder := createdNfdNsBuilder.WithMultipleLabels(map[string]string{
-		"openshift.io/cluster-monitoring":    "true",
-		"pod-security.kubernetes.io/enforce": "privileged",
-	})
-
-	newLabeledNfdNsBuilder, err := labeledNfdNsBuilder.Update()
-
-	if err != nil {
-		glog.V(gpuparams.GpuLogLevel).Infof("error labeling NFD namespace %s: %v", newLabeledNfdNsBuilder.Definition.Name, err)
-
-		return err
-	}
-
-	glog.V(gpuparams.GpuLogLevel).Infof("The NFD labeled namespace has "+
-		"labels:  %v", newLabeledNfdNsBuilder.Object.Labels)
-
-	return nil
-}
-
-// CreateNFDOperatorGroup creates NFD OperatorGroup in NFD namespace.
-func CreateNFDOperatorGroup(apiClient *clients.Settings) error {
-	glog.V(gpuparams.GpuLogLevel).Infof("Create the NFD operatorgroup")
-
-	nfdOgBuilder := olm.NewOperatorGroupBuilder(apiClient, nfdOperatorGroupName, nfdOperatorNamespace)
-
-	if nfdOgBuilder.Exists() {
-		glog.V(gpuparams.GpuLogLevel).Infof("The nfdOgBuilder that exists has name:  %v",
-			nfdOgBuilder.Object.Name)
-	} else {
-		glog.V(gpuparams.GpuLogLevel).Infof("Create a new NFD OperatorGroup with name:  %s",
-			nfdOperatorGroupName)
-
-		nfdOgBuilderCreated, err := nfdOgBuilder.Create()
-
-		if err != nil {
-			glog.V(gpuparams.GpuLogLevel).Infof("error creating NFD operatorgroup %v :  %v ",
-				nfdOgBuilderCreated.Definition.Name, err)
-
-			return err
-		}
-	}
-
-	return nil
-}
-
-// CreateNFDSubscription creates NFD Subscription in NFD namespace.
-func CreateNFDSubscription(apiClient *clients.Settings, nfdCatalogSource string) error {
-	glog.V(gpuparams.GpuLogLevel).Info("Create Subscription in NFD Operator Namespace")
-
-	nfdSubBuilder := olm.NewSubscriptionBuilder(apiClient, nfdSubscriptionName, nfdOperatorNamespace,
-		nfdCatalogSource, nfdCatalogSourceNamespace, nfdPackage)
-
-	nfdSubBuilder.WithChannel(nfdChannel)
-	nfdSubBuilder.WithInstallPlanApproval(nfdInstallPlanApproval)
-
-	glog.V(gpuparams.GpuLogLevel).Infof("Creating the NFD subscription, i.e Deploy the NFD operator")
-
-	createdNfdSub, err := nfdSubBuilder.Create()
-
-	if err != nil {
-		glog.V(gpuparams.GpuLogLevel).Infof("error creating NFD subscription %v :  %v ",
-			createdNfdSub.Definition.Name, err)
-
-		return err
-	}
-
-	if createdNfdSub.Exists() {
-		glog.V(gpuparams.GpuLogLevel).Infof("Newly created NFD subscription: %s was successfully created",
-			createdNfdSub.Object.Name)
-		glog.V(gpuparams.GpuLogLevel).Infof("The newly created subscription: %s in namespace: %v "+
-			"has current CSV:  %v", createdNfdSub.Object.Name, createdNfdSub.Object.Namespace,
-			createdNfdSub.Object.Status.CurrentCSV)
-	} else {
-		return fmt.Errorf("could not determine the current CSV from newly created subscription: %s in"+
-			" namespace %s", createdNfdSub.Object.Name, createdNfdSub.Object.Namespace)
-	}
-
-	return nil
-}
-
-// CheckNFDOperatorDeployed checks that NFD Operator is successfully deployed in NFD namespace.
-func CheckNFDOperatorDeployed(apiClient *clients.Settings, waitTime time.Duration) (bool, error) {
-	glog.V(gpuparams.GpuLogLevel).Infof("Check if the NFD operator deployment is ready")
-
-	nfdOperatorDeployment, err := deployment.Pull(apiClient, nfdOperatorDeploymentName, nfdOperatorNamespace)
-
-	if err != nil {
-		glog.V(gpuparams.GpuLogLevel).Infof("Error trying to pull NFD operator "+
-			"deployment is: %v", err)
-
-		return false, err
-	}
-
-	glog.V(gpuparams.GpuLogLevel).Infof("Pulled NFD operator deployment is:  %v ",
-		nfdOperatorDeployment.Definition.Name)
-
-	if nfdOperatorDeployment.IsReady(waitTime) {
-		glog.V(gpuparams.GpuLogLevel).Infof("Pulled NFD operator deployment is:  %v is Ready ",
-			nfdOperatorDeployment.Definition.Name)
-	} else {
-		return false, fmt.Errorf("NFD operator deployment:  %v is still not Ready "+
-			"after waiting %v time duration", nfdOperatorDeployment.Definition.Name, waitTime)
-	}
-
-	glog.V(gpuparams.GpuLogLevel).Infof("Get currentCSV from NFD subscription")
-
-	nfdCurrentCSVFromSub, err := get.CurrentCSVFromSubscription(apiClient, nfdSubscriptionName,
-		nfdOperatorNamespace)
-
-	if err != nil {
-		glog.V(gpuparams.GpuLogLevel).Infof("error pulling NFD currentCSV from cluster:  %v", err)
-
-		return false, err
-	}
-
-	if nfdCurrentCSVFromSub == "" {
-		glog.V(gpuparams.GpuLogLevel).Infof("NFD currentCSV from subscription is null:  %s",
-			nfdCurrentCSVFromSub)
-
-		return false, err
-	}
-
-	glog.V(gpuparams.GpuLogLevel).Infof("currentCSV %s extracted from NFD Subscription %s",
-		nfdCurrentCSVFromSub, nfdSubscriptionName)
-
-	glog.V(gpuparams.GpuLogLevel).Infof("Wait for NFD ClusterServiceVersion to be in " +
-		"Succeeded phase")
-	glog.V(gpuparams.GpuLogLevel).Infof("Waiting for NFD ClusterServiceVersion to be Succeeded phase")
-
-	err = nvidiagpuwait.CSVSucceeded(
-		apiClient, nfdCurrentCSVFromSub, nfdOperatorNamespace, 60*time.Second, 5*time.Minute)
-
-	glog.V(gpuparams.GpuLogLevel).Infof("error waiting for NFD ClusterServiceVersion to be "+
-		"in Succeeded phase:  %v ", err)
-
-	if err != nil {
-		glog.V(gpuparams.GpuLogLevel).Infof("error waiting for NFD ClusterServiceVersion"+
-			" to be in Succeeded phase:  %v ", err)
-
-		return false, err
-	}
-
-	glog.V(gpuparams.GpuLogLevel).Infof("Pull existing CSV in NFD Operator Namespace")
-
-	clusterNfdCSV, err := olm.PullClusterServiceVersion(apiClient, nfdCurrentCSVFromSub, nfdOperatorNamespace)
-
-	if err != nil {
-		glog.V(gpuparams.GpuLogLevel).Infof("error pulling CSV %v from cluster:  %v",
-			nfdCurrentCSVFromSub, err)
-
-		return false, err
-	}
-
-	glog.V(gpuparams.GpuLogLevel).Infof("NFD clusterCSV from cluster lastUpdatedTime is : %v ",
-		clusterNfdCSV.Definition.Status.LastUpdateTime)
-
-	glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
-		clusterNfdCSV.Definition.Status.Phase)
-
-	succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
-
-	if clusterNfdCSV.Definition.Status.Phase != succeeded {
-		glog.V(gpuparams.GpuLogLevel).Infof("CSV Phase is not succeeded")
-
-		return false, fmt.Errorf("CSV Phase is not 'succeeded'")
-	}
-
-	return true, nil
-}
-
 // DeployCRInstance deploys NodeFeatureDiscovery instance from current CSV almExamples.
 func DeployCRInstance(apiClient *clients.Settings) error {
 	glog.V(gpuparams.GpuLogLevel).Infof("Get ALM examples block form NFD CSV")
@@ -449,46 +251,3 @@ func DeleteNFDCSV(apiClient *clients.Settings) error {
 
 	return err
 }
-
-// DeleteAnyNFDCSV Deletes all CSVs that belong to a the NFD subscription (by name) in the NFD subscription namespace.
-// Equivalent to `oc delete csv -n <namespace> -l operators.coreos.com/<name>.<namespace>`
-func DeleteAnyNFDCSV(apiClient *clients.Settings) error {
-	csvList, err := apiClient.ClusterServiceVersions(nfdOperatorNamespace).List(context.TODO(),
-		metav1.ListOptions{
-			LabelSelector: fmt.Sprintf("operators.coreos.com/%s.%s", nfdPackage, nfdOperatorNamespace),
-		})
-
-	if err != nil {
-		return err
-	}
-
-	for _, csv := range csvList.Items {
-		glog.V(gpuparams.GpuLogLevel).Infof("Attempt deleting NFD CSV %s in namespace %s", csv.Name,
-			nfdOperatorNamespace)
-		if err := apiClient.ClusterServiceVersions(nfdOperatorNamespace).Delete(context.TODO(), csv.Name,
-			metav1.DeleteOptions{}); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-func CreateNFDDeployment(apiClient *clients.Settings, catalogSource string, logLevel logging.Level) bool {
-	glog.V(glog.Level(logLevel)).Info("Deploying NFD Subscription")
-	err := CreateNFDSubscription(apiClient, catalogSource)
-	Expect(err).ToNot(HaveOccurred(), "error creating NFD Subscription: %v", err)
-
-	glog.V(glog.Level(logLevel)).Info("Sleeping for 2 minutes to allow the NFD Operator deployment to stabilize")
-	time.Sleep(2 * time.Minute)
-
-	glog.V(glog.Level(logLevel)).Infof("Waiting up to %v for NFD Operator deployment to be fully created", NFDOperatorTimeout)
-	nfdDeploymentCreated := nvidiagpuwait.DeploymentCreated(apiClient, OperatorDeploymentName, OperatorNamespace, NFDOperatorCheckInterval, NFDOperatorTimeout)
-	Expect(nfdDeploymentCreated).ToNot(BeFalse(), "timed out waiting for NFD operator deployment")
-
-	glog.V(glog.Level(logLevel)).Info("Checking if NFD Operator deployment is active")
-	nfdDeployed, err := CheckNFDOperatorDeployed(apiClient, 4*time.Minute)
-	Expect(err).ToNot(HaveOccurred(), "error deploying NFD Operator in NFD namespace: %v", err)
-
-	return nfdDeployed
-}
@@ -65,6 +65,11 @@ func NewBuilderFromObjectString(apiClient *clients.Settings, almExample string)
 
 // Get returns NodeFeatureDiscovery object if found.
 func (builder *Builder) Get() (*nfdv1.NodeFeatureDiscovery, error) {
+	return builder.GetContext(context.TODO())
+}
+
+// GetContext returns NodeFeatureDiscovery object if found.
+func (builder *Builder) GetContext(ctx context.Context) (*nfdv1.NodeFeatureDiscovery, error) {
 	if valid, err := builder.validate(); !valid {
 		return nil, err
 	}
@@ -73,7 +78,7 @@ func (builder *Builder) Get() (*nfdv1.NodeFeatureDiscovery, error) {
 		builder.Definition.Name, builder.Definition.Namespace)
 
 	nodeFeatureDiscovery := &nfdv1.NodeFeatureDiscovery{}
-	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+	err := builder.apiClient.Get(ctx, goclient.ObjectKey{
 		Name:      builder.Definition.Name,
 		Namespace: builder.Definition.Namespace,
 	}, nodeFeatureDiscovery)
@@ -90,6 +95,11 @@ func (builder *Builder) Get() (*nfdv1.NodeFeatureDiscovery, error) {
 
 // Pull loads an existing NodeFeatureDiscovery into Builder struct.
 func Pull(apiClient *clients.Settings, name, namespace string) (*Builder, error) {
+	return PullContext(context.TODO(), apiClient, name, namespace)
+}
+
+// PullContext loads an existing NodeFeatureDiscovery into Builder struct.
+func PullContext(ctx context.Context, apiClient *clients.Settings, name, namespace string) (*Builder, error) {
 	glog.V(LogLevel).Infof("Pulling existing nodeFeatureDiscovery name: %s in namespace: %s", name, namespace)
 
 	builder := Builder{
@@ -114,7 +124,7 @@ func Pull(apiClient *clients.Settings, name, namespace string) (*Builder, error)
 		builder.errorMsg = "NodeFeatureDiscovery 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("NodeFeatureDiscovery object %s doesn't exist in namespace %s", name, namespace)
 	}
 
@@ -125,6 +135,11 @@ func Pull(apiClient *clients.Settings, name, namespace string) (*Builder, error)
 
 // Exists checks whether the given NodeFeatureDiscovery exists.
 func (builder *Builder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given NodeFeatureDiscovery exists.
+func (builder *Builder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -134,7 +149,7 @@ func (builder *Builder) Exists() bool {
 		builder.Definition.Namespace)
 
 	var err error
-	builder.Object, err = builder.Get()
+	builder.Object, err = builder.GetContext(ctx)
 
 	if err != nil {
 		glog.V(LogLevel).Infof("Failed to collect NodeFeatureDiscovery object due to %s", err.Error())
@@ -145,6 +160,11 @@ func (builder *Builder) Exists() bool {
 
 // Delete removes a NodeFeatureDiscovery.
 func (builder *Builder) Delete() (*Builder, error) {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a NodeFeatureDiscovery.
+func (builder *Builder) DeleteContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -152,11 +172,11 @@ func (builder *Builder) Delete() (*Builder, error) {
 	glog.V(LogLevel).Infof("Deleting NodeFeatureDiscovery %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return builder, fmt.Errorf("NodeFeatureDiscovery cannot be deleted because it does not exist")
 	}
 
-	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+	err := builder.apiClient.Delete(ctx, builder.Definition)
 
 	if err != nil {
 		return builder, fmt.Errorf("cannot delete NodeFeaturediscovery: %w", err)
@@ -169,6 +189,11 @@ func (builder *Builder) Delete() (*Builder, error) {
 
 // Create makes a NodeFeatureDiscovery in the cluster and stores the created object in struct.
 func (builder *Builder) Create() (*Builder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a NodeFeatureDiscovery in the cluster and stores the created object in struct.
+func (builder *Builder) CreateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -177,8 +202,8 @@ func (builder *Builder) Create() (*Builder, error) {
 		builder.Definition.Namespace)
 
 	var err error
-	if !builder.Exists() {
-		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+	if !builder.ExistsContext(ctx) {
+		err = builder.apiClient.Create(ctx, builder.Definition)
 
 		if err == nil {
 			builder.Object = builder.Definition
@@ -190,6 +215,11 @@ func (builder *Builder) Create() (*Builder, error) {
 
 // Update renovates the existing NodeFeatureDiscovery object with the definition in builder.
 func (builder *Builder) Update(force bool) (*Builder, error) {
+	return builder.UpdateContext(context.TODO(), force)
+}
+
+// UpdateContext renovates the existing NodeFeatureDiscovery object with the definition in builder.
+func (builder *Builder) UpdateContext(ctx context.Context, force bool) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -197,14 +227,14 @@ func (builder *Builder) Update(force bool) (*Builder, error) {
 	glog.V(LogLevel).Infof("Updating the NodeFeatureDiscovery object named: %s in namespace: %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	err := builder.apiClient.Update(ctx, builder.Definition)
 
 	if err != nil {
 		if force {
 			glog.V(LogLevel).Infof(
 				msg.FailToUpdateNotification("NodeFeatureDiscovery", builder.Definition.Name, builder.Definition.Namespace))
 
-			builder, err := builder.Delete()
+			builder, err := builder.DeleteContext(ctx)
 
 			if err != nil {
 				glog.V(LogLevel).Infof(
@@ -213,7 +243,7 @@ func (builder *Builder) Update(force bool) (*Builder, error) {
 				return nil, err
 			}
 
-			return builder.Create()
+			return builder.CreateContext(ctx)
 		}
 	}
 
@@ -0,0 +1,40 @@
+package nfd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	nfdv1 "github.com/openshift/cluster-nfd-operator/api/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewOperatorUninstaller returns an OperatorUninstaller removing the NFD Operator, its NodeFeatureDiscovery
+// instance, the nfd.openshift.io CustomResourceDefinitions and the feature.node.kubernetes.io node labels.
+func NewOperatorUninstaller(apiClient *clients.Settings) *olm.OperatorUninstaller {
+	return olm.NewOperatorUninstaller(apiClient, Package, OperatorNamespace).
+		WithSubscriptionName(nfdSubscriptionName).
+		WithOperatorGroupName(nfdOperatorGroupName).
+		WithCustomResources(&nfdv1.NodeFeatureDiscovery{
+			ObjectMeta: metav1.ObjectMeta{Name: CRName, Namespace: OperatorNamespace}}).
+		WithCRDGroups(nfdv1.GroupVersion.Group).
+		WithNamePrefixes("nfd-").
+		WithNodeLabelPrefixes("feature.node.kubernetes.io/")
+}
+
+// UninstallNFD removes the NFD Operator with NewOperatorUninstaller and logs the uninstall report. In strict mode,
+// resources left behind on the cluster are returned as an error. The uninstall stops early when ctx is done.
+func UninstallNFD(ctx context.Context, apiClient *clients.Settings, strict bool) error {
+	report, err := NewOperatorUninstaller(apiClient).WithStrict(strict).UninstallContext(ctx)
+	if report != nil {
+		glog.V(LogLevel).Infof("NFD Operator uninstall report: %s", report)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error uninstalling NFD Operator: %w", err)
+	}
+
+	return nil
+}
@@ -4,20 +4,36 @@ import (
 	"fmt"
 
 	"github.com/golang/glog"
-	. "github.com/onsi/ginkgo/v2" //nolint:staticcheck
-	. "github.com/onsi/gomega"    //nolint:staticcheck
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/check"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 )
 
-func CheckNfdInstallation(apiClient *clients.Settings, label string, allowedLabelValues []string, workerLabelMap map[string]string, logLevel int) {
-	By(fmt.Sprintf("Check if NFD is installed using label: %s", label))
+// CheckNfdInstallation checks that the NFD label is set to one of the allowed values on all worker nodes and that
+// the NFD deployments are ready.
+func CheckNfdInstallation(apiClient *clients.Settings, label string, allowedLabelValues []string,
+	workerLabelMap map[string]string, logLevel int) error {
+	if apiClient == nil {
+		return fmt.Errorf("cannot check the NFD installation with nil apiClient")
+	}
+
 	nfdLabelDetected, err := check.AllNodeLabel(apiClient, label, allowedLabelValues, workerLabelMap)
-	Expect(err).ToNot(HaveOccurred(), "error calling check.NodeLabel: %v", err)
-	Expect(nfdLabelDetected).NotTo(BeFalse(), "NFD node label check failed to match label %s and label values %v on all nodes", label, allowedLabelValues)
+	if err != nil {
+		return fmt.Errorf("error checking NFD node label %s: %w", label, err)
+	}
+
+	if !nfdLabelDetected {
+		return fmt.Errorf("NFD node label check failed to match label %s and label values %v on all nodes",
+			label, allowedLabelValues)
+	}
+
 	glog.V(glog.Level(logLevel)).Infof("The check for NFD label returned: %v", nfdLabelDetected)
 
 	isNfdInstalled, err := check.NFDDeploymentsReady(apiClient)
-	Expect(err).ToNot(HaveOccurred(), "error checking if NFD deployments are ready: %v", err)
+	if err != nil {
+		return fmt.Errorf("error checking if NFD deployments are ready: %w", err)
+	}
+
 	glog.V(glog.Level(logLevel)).Infof("The check for NFD deployments ready returned: %v", isNfdInstalled)
+
+	return nil
 }
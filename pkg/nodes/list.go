@@ -18,6 +18,11 @@ const (
 
 // List returns node inventory.
 func List(apiClient *clients.Settings, options ...v1.ListOptions) ([]*Builder, error) {
+	return ListContext(context.TODO(), apiClient, options...)
+}
+
+// ListContext returns node inventory.
+func ListContext(ctx context.Context, apiClient *clients.Settings, options ...v1.ListOptions) ([]*Builder, error) {
 	passedOptions := v1.ListOptions{}
 	logMessage := "Listing all node resources"
 
@@ -34,7 +39,7 @@ func List(apiClient *clients.Settings, options ...v1.ListOptions) ([]*Builder, e
 
 	glog.V(100).Infof(logMessage)
 
-	nodeList, err := apiClient.CoreV1Interface.Nodes().List(context.TODO(), passedOptions)
+	nodeList, err := apiClient.CoreV1Interface.Nodes().List(ctx, passedOptions)
 	if err != nil {
 		glog.V(100).Infof("Failed to list nodes due to %s", err.Error())
 
@@ -84,10 +89,16 @@ func ListExternalIPv4Networks(apiClient *clients.Settings, options ...v1.ListOpt
 
 // WaitForAllNodesAreReady waits for all nodes to be Ready for a time duration up to the timeout.
 func WaitForAllNodesAreReady(apiClient *clients.Settings, timeout time.Duration, options ...v1.ListOptions) (bool, error) {
+	return WaitForAllNodesAreReadyContext(context.TODO(), apiClient, timeout, options...)
+}
+
+// WaitForAllNodesAreReadyContext waits for all nodes to be Ready for a time duration up to the timeout.
+func WaitForAllNodesAreReadyContext(ctx context.Context, apiClient *clients.Settings, timeout time.Duration,
+	options ...v1.ListOptions) (bool, error) {
 	glog.V(100).Infof("Waiting for all nodes to be in the Ready state for up to a duration of %v",
 		timeout)
 
-	nodesList, err := List(apiClient, options...)
+	nodesList, err := ListContext(ctx, apiClient, options...)
 	if err != nil {
 		glog.V(100).Infof("Failed to list all nodes due to %s", err.Error())
 
@@ -95,9 +106,9 @@ func WaitForAllNodesAreReady(apiClient *clients.Settings, timeout time.Duration,
 	}
 
 	err = wait.PollUntilContextTimeout(
-		context.TODO(), backoff, timeout, true, func(ctx context.Context) (done bool, err error) {
+		ctx, backoff, timeout, true, func(ctx context.Context) (done bool, err error) {
 			for _, node := range nodesList {
-				ready, err := node.IsReady()
+				ready, err := node.IsReadyContext(ctx)
 				if err != nil {
 					glog.V(100).Infof("Node %v has error %w", node.Object.Name, err)
 
@@ -130,9 +141,15 @@ func WaitForAllNodesAreReady(apiClient *clients.Settings, timeout time.Duration,
 
 // WaitForAllNodesToReboot waits for all nodes to start and finish reboot up to the timeout.
 func WaitForAllNodesToReboot(apiClient *clients.Settings, globalRebootTimeout time.Duration, options ...v1.ListOptions) (bool, error) {
+	return WaitForAllNodesToRebootContext(context.TODO(), apiClient, globalRebootTimeout, options...)
+}
+
+// WaitForAllNodesToRebootContext waits for all nodes to start and finish reboot up to the timeout.
+func WaitForAllNodesToRebootContext(ctx context.Context, apiClient *clients.Settings,
+	globalRebootTimeout time.Duration, options ...v1.ListOptions) (bool, error) {
 	glog.V(100).Infof("Waiting for all nodes in the list to reboot and return to the Ready condition")
 
-	nodesList, err := List(apiClient, options...)
+	nodesList, err := ListContext(ctx, apiClient, options...)
 	if err != nil {
 		glog.V(100).Infof("Failed to list all nodes due to %s", err.Error())
 
@@ -143,10 +160,10 @@ func WaitForAllNodesToReboot(apiClient *clients.Settings, globalRebootTimeout ti
 	readyNodes := []string{}
 	rebootedNodes := []string{}
 	err = wait.PollUntilContextTimeout(
-		context.TODO(), backoff, globalRebootTimeout, true, func(ctx context.Context) (done bool, err error) {
+		ctx, backoff, globalRebootTimeout, true, func(ctx context.Context) (done bool, err error) {
 			for _, node := range nodesList {
 				if !slices.Contains(readyNodes, node.Object.Name) {
-					ready, err := node.IsReady()
+					ready, err := node.IsReadyContext(ctx)
 					if err != nil {
 						return false, err
 					}
@@ -0,0 +1,81 @@
+package nodes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestList(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Nodes})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		options       []v1.ListOptions
+		expectedCount int
+		expectedError bool
+	}{
+		{
+			name:          "all nodes",
+			expectedCount: 4,
+		},
+		{
+			name:          "workers",
+			options:       []v1.ListOptions{{LabelSelector: "node-role.kubernetes.io/worker"}},
+			expectedCount: 3,
+		},
+		{
+			name:          "nfd gpu label",
+			options:       []v1.ListOptions{{LabelSelector: "feature.node.kubernetes.io/pci-10de.present=true"}},
+			expectedCount: 2,
+		},
+		{
+			name:          "too many options",
+			options:       []v1.ListOptions{{}, {}},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			nodeBuilders, err := List(apiClient, testCase.options...)
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(nodeBuilders) != testCase.expectedCount {
+				t.Errorf("expected %d nodes, got %d", testCase.expectedCount, len(nodeBuilders))
+			}
+		})
+	}
+}
+
+func TestWaitForAllNodesToRebootContext(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Nodes})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	rebooted, err := WaitForAllNodesToRebootContext(ctx, apiClient, time.Hour)
+	if rebooted || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the wait to end with its cancelled context, got %t: %v", rebooted, err)
+	}
+}
@@ -117,6 +117,11 @@ type AdditionalOptions func(builder *Builder) (*Builder, error)
 
 // Pull gathers existing node from cluster.
 func Pull(apiClient *clients.Settings, nodeName string) (*Builder, error) {
+	return PullContext(context.TODO(), apiClient, nodeName)
+}
+
+// PullContext gathers existing node from cluster.
+func PullContext(ctx context.Context, apiClient *clients.Settings, nodeName string) (*Builder, error) {
 	glog.V(100).Infof("Pulling existing node object: %s", nodeName)
 
 	builder := Builder{
@@ -128,7 +133,7 @@ func Pull(apiClient *clients.Settings, nodeName string) (*Builder, error) {
 		},
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("node object %s doesn't exist", nodeName)
 	}
 
@@ -139,13 +144,18 @@ func Pull(apiClient *clients.Settings, nodeName string) (*Builder, error) {
 
 // Update renovates the existing node object with the node definition in builder.
 func (builder *Builder) Update() (*Builder, error) {
+	return builder.UpdateContext(context.TODO())
+}
+
+// UpdateContext renovates the existing node object with the node definition in builder.
+func (builder *Builder) UpdateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Updating configuration of node %s", builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("node %s object doesn't exist", builder.Definition.Name)
 	}
 
@@ -154,13 +164,18 @@ func (builder *Builder) Update() (*Builder, error) {
 
 	var err error
 	builder.Object, err = builder.apiClient.CoreV1().Nodes().Update(
-		context.TODO(), builder.Definition, metav1.UpdateOptions{})
+		ctx, builder.Definition, metav1.UpdateOptions{})
 
 	return builder, err
 }
 
 // Exists checks whether the given node exists.
 func (builder *Builder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given node exists.
+func (builder *Builder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -169,25 +184,30 @@ func (builder *Builder) Exists() bool {
 
 	var err error
 	builder.Object, err = builder.apiClient.CoreV1().Nodes().Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
 // Delete removes node from the cluster.
 func (builder *Builder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes node from the cluster.
+func (builder *Builder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
 
 	glog.V(100).Infof("Deleting the node %s", builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return fmt.Errorf("node cannot be deleted because it does not exist")
 	}
 
 	err := builder.apiClient.CoreV1().Nodes().Delete(
-		context.TODO(),
+		ctx,
 		builder.Definition.Name,
 		metav1.DeleteOptions{})
 
@@ -332,13 +352,18 @@ func (builder *Builder) ExternalIPv4Network() (string, error) {
 
 // IsReady check if the Node is Ready.
 func (builder *Builder) IsReady() (bool, error) {
+	return builder.IsReadyContext(context.TODO())
+}
+
+// IsReadyContext check if the Node is Ready.
+func (builder *Builder) IsReadyContext(ctx context.Context) (bool, error) {
 	if valid, err := builder.validate(); !valid {
 		return false, err
 	}
 
 	glog.V(100).Infof("Verify %s node availability", builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return false, fmt.Errorf("%s node object doesn't exist", builder.Definition.Name)
 	}
 
@@ -352,15 +377,20 @@ func (builder *Builder) IsReady() (bool, error) {
 }
 
 // WaitUntilConditionTrue waits for timeout duration or until node gets to a specific status.
-func (builder *Builder) WaitUntilConditionTrue(
+func (builder *Builder) WaitUntilConditionTrue(conditionType corev1.NodeConditionType, timeout time.Duration) error {
+	return builder.WaitUntilConditionTrueContext(context.TODO(), conditionType, timeout)
+}
+
+// WaitUntilConditionTrueContext waits for timeout duration or until node gets to a specific status.
+func (builder *Builder) WaitUntilConditionTrueContext(ctx context.Context,
 	conditionType corev1.NodeConditionType, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-			if !builder.Exists() {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.ExistsContext(ctx) {
 				return false, fmt.Errorf("node %s object doesn't exist", builder.Definition.Name)
 			}
 
@@ -383,15 +413,20 @@ func (builder *Builder) WaitUntilConditionTrue(
 }
 
 // WaitUntilConditionUnknown waits for timeout duration or until node change specific status.
-func (builder *Builder) WaitUntilConditionUnknown(
+func (builder *Builder) WaitUntilConditionUnknown(conditionType corev1.NodeConditionType, timeout time.Duration) error {
+	return builder.WaitUntilConditionUnknownContext(context.TODO(), conditionType, timeout)
+}
+
+// WaitUntilConditionUnknownContext waits for timeout duration or until node change specific status.
+func (builder *Builder) WaitUntilConditionUnknownContext(ctx context.Context,
 	conditionType corev1.NodeConditionType, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-			if !builder.Exists() {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.ExistsContext(ctx) {
 				return false, fmt.Errorf("node %s object doesn't exist", builder.Definition.Name)
 			}
 
@@ -415,12 +450,22 @@ func (builder *Builder) WaitUntilConditionUnknown(
 
 // WaitUntilReady waits for timeout duration or until node is Ready.
 func (builder *Builder) WaitUntilReady(timeout time.Duration) error {
-	return builder.WaitUntilConditionTrue(corev1.NodeReady, timeout)
+	return builder.WaitUntilReadyContext(context.TODO(), timeout)
+}
+
+// WaitUntilReadyContext waits for timeout duration or until node is Ready.
+func (builder *Builder) WaitUntilReadyContext(ctx context.Context, timeout time.Duration) error {
+	return builder.WaitUntilConditionTrueContext(ctx, corev1.NodeReady, timeout)
 }
 
 // WaitUntilNotReady waits for timeout duration or until node is NotReady.
 func (builder *Builder) WaitUntilNotReady(timeout time.Duration) error {
-	return builder.WaitUntilConditionUnknown(corev1.NodeReady, timeout)
+	return builder.WaitUntilNotReadyContext(context.TODO(), timeout)
+}
+
+// WaitUntilNotReadyContext waits for timeout duration or until node is NotReady.
+func (builder *Builder) WaitUntilNotReadyContext(ctx context.Context, timeout time.Duration) error {
+	return builder.WaitUntilConditionUnknownContext(ctx, corev1.NodeReady, timeout)
 }
 
 // validate will check that the builder and builder definition are properly initialized before
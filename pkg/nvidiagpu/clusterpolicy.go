@@ -101,6 +101,11 @@ func newBuilder(apiClient *clients.Settings, clusterPolicy *nvidiagpuv1.ClusterP
 
 // Get returns clusterPolicy object if found.
 func (builder *Builder) Get() (*nvidiagpuv1.ClusterPolicy, error) {
+	return builder.GetContext(context.TODO())
+}
+
+// GetContext returns clusterPolicy object if found.
+func (builder *Builder) GetContext(ctx context.Context) (*nvidiagpuv1.ClusterPolicy, error) {
 	if valid, err := builder.validate(); !valid {
 		return nil, err
 	}
@@ -109,7 +114,7 @@ func (builder *Builder) Get() (*nvidiagpuv1.ClusterPolicy, error) {
 		"Collecting ClusterPolicy object %s", builder.Definition.Name)
 
 	clusterPolicy := &nvidiagpuv1.ClusterPolicy{}
-	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+	err := builder.apiClient.Get(ctx, goclient.ObjectKey{
 		Name: builder.Definition.Name,
 	}, clusterPolicy)
 
@@ -125,6 +130,11 @@ func (builder *Builder) Get() (*nvidiagpuv1.ClusterPolicy, error) {
 
 // Pull loads an existing clusterPolicy into Builder struct.
 func Pull(apiClient *clients.Settings, name string) (*Builder, error) {
+	return PullContext(context.TODO(), apiClient, name)
+}
+
+// PullContext loads an existing clusterPolicy into Builder struct.
+func PullContext(ctx context.Context, apiClient *clients.Settings, name string) (*Builder, error) {
 	glog.V(100).Infof("Pulling existing clusterPolicy name: %s", name)
 
 	builder := Builder{
@@ -142,7 +152,7 @@ func Pull(apiClient *clients.Settings, name string) (*Builder, error) {
 		builder.errorMsg = "ClusterPolicy 'name' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("ClusterPolicy object %s doesn't exist", name)
 	}
 
@@ -153,6 +163,11 @@ func Pull(apiClient *clients.Settings, name string) (*Builder, error) {
 
 // Exists checks whether the given ClusterPolicy exists.
 func (builder *Builder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given ClusterPolicy exists.
+func (builder *Builder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -161,7 +176,7 @@ func (builder *Builder) Exists() bool {
 		"Checking if ClusterPolicy %s exists", builder.Definition.Name)
 
 	var err error
-	builder.Object, err = builder.Get()
+	builder.Object, err = builder.GetContext(ctx)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to collect ClusterPolicy object due to %s", err.Error())
@@ -172,17 +187,22 @@ func (builder *Builder) Exists() bool {
 
 // Delete removes a ClusterPolicy.
 func (builder *Builder) Delete() (*Builder, error) {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a ClusterPolicy.
+func (builder *Builder) DeleteContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Deleting ClusterPolicy %s", builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return builder, fmt.Errorf("clusterpolicy cannot be deleted because it does not exist")
 	}
 
-	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+	err := builder.apiClient.Delete(ctx, builder.Definition)
 
 	if err != nil {
 		return builder, fmt.Errorf("cannot delete clusterpolicy: %w", err)
@@ -195,6 +215,11 @@ func (builder *Builder) Delete() (*Builder, error) {
 
 // Create makes a ClusterPolicy in the cluster and stores the created object in struct.
 func (builder *Builder) Create() (*Builder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a ClusterPolicy in the cluster and stores the created object in struct.
+func (builder *Builder) CreateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -202,8 +227,8 @@ func (builder *Builder) Create() (*Builder, error) {
 	glog.V(100).Infof("Creating the ClusterPolicy %s", builder.Definition.Name)
 
 	var err error
-	if !builder.Exists() {
-		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+	if !builder.ExistsContext(ctx) {
+		err = builder.apiClient.Create(ctx, builder.Definition)
 
 		if err == nil {
 			builder.Object = builder.Definition
@@ -215,19 +240,24 @@ func (builder *Builder) Create() (*Builder, error) {
 
 // Update renovates the existing ClusterPolicy object with the definition in builder.
 func (builder *Builder) Update(force bool) (*Builder, error) {
+	return builder.UpdateContext(context.TODO(), force)
+}
+
+// UpdateContext renovates the existing ClusterPolicy object with the definition in builder.
+func (builder *Builder) UpdateContext(ctx context.Context, force bool) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Updating the ClusterPolicy object named:  %s", builder.Definition.Name)
 
-	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	err := builder.apiClient.Update(ctx, builder.Definition)
 
 	if err != nil {
 		if force {
 			glog.V(100).Infof(msg.FailToUpdateNotification("clusterpolicy", builder.Definition.Name))
 
-			builder, err := builder.Delete()
+			builder, err := builder.DeleteContext(ctx)
 
 			if err != nil {
 				glog.V(100).Infof(
@@ -236,7 +266,7 @@ func (builder *Builder) Update(force bool) (*Builder, error) {
 				return nil, err
 			}
 
-			return builder.Create()
+			return builder.CreateContext(ctx)
 		}
 	}
 
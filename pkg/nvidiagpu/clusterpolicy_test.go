@@ -0,0 +1,122 @@
+package nvidiagpu
+
+import (
+	"testing"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBuilderUpdate(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		fixtures             []string
+		force                bool
+		staleResourceVersion bool
+		expectedError        bool
+		expectedNilBuilder   bool
+		expectedNotFound     bool
+		expectedConflict     bool
+	}{
+		{
+			name:     "existing clusterpolicy",
+			fixtures: []string{testfixtures.ClusterPolicy},
+		},
+		{
+			name:     "existing clusterpolicy with force",
+			fixtures: []string{testfixtures.ClusterPolicy},
+			force:    true,
+		},
+		{
+			name:             "missing clusterpolicy",
+			expectedError:    true,
+			expectedNotFound: true,
+		},
+		{
+			name:               "missing clusterpolicy with force",
+			force:              true,
+			expectedError:      true,
+			expectedNilBuilder: true,
+		},
+		{
+			name:                 "stale clusterpolicy",
+			fixtures:             []string{testfixtures.ClusterPolicy},
+			staleResourceVersion: true,
+			expectedError:        true,
+			expectedConflict:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient, err := testfixtures.NewTestClients(testCase.fixtures)
+			if err != nil {
+				t.Fatalf("failed to create test clients: %v", err)
+			}
+
+			builder := newBuilder(apiClient, &nvidiagpuv1.ClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: ClusterPolicyName},
+			}, nil)
+
+			if builder.Exists() {
+				builder.Definition = builder.Object
+			}
+
+			if testCase.staleResourceVersion {
+				builder.Definition.ResourceVersion = "1"
+			}
+
+			builder.Definition.Spec.Driver.UsePrecompiled = newTrue()
+
+			updatedBuilder, err := builder.Update(testCase.force)
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+
+				if testCase.expectedNilBuilder != (updatedBuilder == nil) {
+					t.Errorf("expected nil builder to be %v", testCase.expectedNilBuilder)
+				}
+
+				if testCase.expectedNotFound && !k8serrors.IsNotFound(err) {
+					t.Errorf("expected a NotFound error, got %v", err)
+				}
+
+				if testCase.expectedConflict && !k8serrors.IsConflict(err) {
+					t.Errorf("expected a Conflict error, got %v", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			clusterPolicy, err := updatedBuilder.Get()
+			if err != nil {
+				t.Fatalf("failed to get the updated clusterpolicy: %v", err)
+			}
+
+			if clusterPolicy.Spec.Driver.UsePrecompiled == nil || !*clusterPolicy.Spec.Driver.UsePrecompiled {
+				t.Error("expected the clusterpolicy update to be stored in the cluster")
+			}
+		})
+	}
+}
+
+func TestBuilderUpdateInvalidBuilder(t *testing.T) {
+	builder := newBuilder(nil, &nvidiagpuv1.ClusterPolicy{}, nil)
+
+	if _, err := builder.Update(true); err == nil {
+		t.Error("expected an error for a builder without apiClient")
+	}
+}
+
+func newTrue() *bool {
+	value := true
+
+	return &value
+}
@@ -0,0 +1,310 @@
+package nvidiagpu
+
+import (
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	upgradev1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/golang/glog"
+	"k8s.io/utils/ptr"
+)
+
+// WithDriverEnabled enables or disables the driver daemonset.
+func (builder *Builder) WithDriverEnabled(enabled bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s driver enabled to %v", builder.Definition.Name, enabled)
+
+	builder.Definition.Spec.Driver.Enabled = ptr.To(enabled)
+
+	return builder
+}
+
+// WithDriverImage sets the driver image. Empty values keep the current ones.
+func (builder *Builder) WithDriverImage(repository, image, version string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s driver image to %s/%s:%s",
+		builder.Definition.Name, repository, image, version)
+
+	if repository == "" && image == "" && version == "" {
+		builder.errorMsg = "ClusterPolicy driver image cannot be redefined with empty values"
+
+		return builder
+	}
+
+	setImage(&builder.Definition.Spec.Driver.Repository, &builder.Definition.Spec.Driver.Image,
+		&builder.Definition.Spec.Driver.Version, repository, image, version)
+
+	return builder
+}
+
+// WithDriverUpgradePolicy sets the driver upgrade policy.
+func (builder *Builder) WithDriverUpgradePolicy(upgradePolicy upgradev1alpha1.DriverUpgradePolicySpec) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s driver upgrade policy to %+v", builder.Definition.Name, upgradePolicy)
+
+	builder.Definition.Spec.Driver.UpgradePolicy = &upgradePolicy
+
+	return builder
+}
+
+// WithDriverGPUDirectRDMA enables or disables GPUDirect RDMA in the driver, optionally using the host MOFED.
+func (builder *Builder) WithDriverGPUDirectRDMA(enabled, useHostMOFED bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s driver GPUDirect RDMA enabled to %v, useHostMofed to %v",
+		builder.Definition.Name, enabled, useHostMOFED)
+
+	builder.Definition.Spec.Driver.GPUDirectRDMA = &nvidiagpuv1.GPUDirectRDMASpec{
+		Enabled:      ptr.To(enabled),
+		UseHostMOFED: ptr.To(useHostMOFED),
+	}
+
+	return builder
+}
+
+// WithToolkitEnabled enables or disables the container toolkit daemonset.
+func (builder *Builder) WithToolkitEnabled(enabled bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s toolkit enabled to %v", builder.Definition.Name, enabled)
+
+	builder.Definition.Spec.Toolkit.Enabled = ptr.To(enabled)
+
+	return builder
+}
+
+// WithDevicePluginEnabled enables or disables the device plugin daemonset.
+func (builder *Builder) WithDevicePluginEnabled(enabled bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s device plugin enabled to %v", builder.Definition.Name, enabled)
+
+	builder.Definition.Spec.DevicePlugin.Enabled = ptr.To(enabled)
+
+	return builder
+}
+
+// WithDevicePluginConfig sets the ConfigMap holding the device plugin configurations and the default one.
+func (builder *Builder) WithDevicePluginConfig(configMapName, defaultConfig string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s device plugin config to configmap %s with default %s",
+		builder.Definition.Name, configMapName, defaultConfig)
+
+	if configMapName == "" {
+		builder.errorMsg = "ClusterPolicy device plugin config cannot have an empty configmap name"
+
+		return builder
+	}
+
+	builder.Definition.Spec.DevicePlugin.Config = &nvidiagpuv1.DevicePluginConfig{
+		Name:    configMapName,
+		Default: defaultConfig,
+	}
+
+	return builder
+}
+
+// WithDCGMEnabled enables or disables the standalone DCGM daemonset.
+func (builder *Builder) WithDCGMEnabled(enabled bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s DCGM enabled to %v", builder.Definition.Name, enabled)
+
+	builder.Definition.Spec.DCGM.Enabled = ptr.To(enabled)
+
+	return builder
+}
+
+// WithDCGMExporterEnabled enables or disables the DCGM exporter daemonset.
+func (builder *Builder) WithDCGMExporterEnabled(enabled bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s DCGM exporter enabled to %v", builder.Definition.Name, enabled)
+
+	builder.Definition.Spec.DCGMExporter.Enabled = ptr.To(enabled)
+
+	return builder
+}
+
+// WithMIGStrategy sets the MIG strategy, single or mixed.
+func (builder *Builder) WithMIGStrategy(strategy nvidiagpuv1.MIGStrategy) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s MIG strategy to %s", builder.Definition.Name, strategy)
+
+	if strategy != nvidiagpuv1.MIGStrategySingle && strategy != nvidiagpuv1.MIGStrategyMixed {
+		builder.errorMsg = "ClusterPolicy MIG strategy must be 'single' or 'mixed'"
+
+		return builder
+	}
+
+	builder.Definition.Spec.MIG.Strategy = strategy
+
+	return builder
+}
+
+// WithMIGManagerEnabled enables or disables the MIG manager daemonset.
+func (builder *Builder) WithMIGManagerEnabled(enabled bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s MIG manager enabled to %v", builder.Definition.Name, enabled)
+
+	builder.Definition.Spec.MIGManager.Enabled = ptr.To(enabled)
+
+	return builder
+}
+
+// WithMIGManagerConfig sets the ConfigMap holding the mig-parted configurations and the default one.
+func (builder *Builder) WithMIGManagerConfig(configMapName, defaultConfig string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s MIG manager config to configmap %s with default %s",
+		builder.Definition.Name, configMapName, defaultConfig)
+
+	if configMapName == "" {
+		builder.errorMsg = "ClusterPolicy MIG manager config cannot have an empty configmap name"
+
+		return builder
+	}
+
+	builder.Definition.Spec.MIGManager.Config = &nvidiagpuv1.MIGPartedConfigSpec{
+		Name:    configMapName,
+		Default: defaultConfig,
+	}
+
+	return builder
+}
+
+// WithSandboxWorkloads enables or disables sandbox workloads, defaultWorkload is one of container,
+// vm-passthrough or vm-vgpu. An empty defaultWorkload keeps the current one.
+func (builder *Builder) WithSandboxWorkloads(enabled bool, defaultWorkload string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s sandbox workloads enabled to %v with default workload %s",
+		builder.Definition.Name, enabled, defaultWorkload)
+
+	builder.Definition.Spec.SandboxWorkloads.Enabled = ptr.To(enabled)
+
+	if defaultWorkload != "" {
+		builder.Definition.Spec.SandboxWorkloads.DefaultWorkload = defaultWorkload
+	}
+
+	return builder
+}
+
+// WithVGPUManagerEnabled enables or disables the vGPU manager daemonset.
+func (builder *Builder) WithVGPUManagerEnabled(enabled bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s vGPU manager enabled to %v", builder.Definition.Name, enabled)
+
+	builder.Definition.Spec.VGPUManager.Enabled = ptr.To(enabled)
+
+	return builder
+}
+
+// WithVGPUManagerImage sets the vGPU manager image. Empty values keep the current ones.
+func (builder *Builder) WithVGPUManagerImage(repository, image, version string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s vGPU manager image to %s/%s:%s",
+		builder.Definition.Name, repository, image, version)
+
+	if repository == "" && image == "" && version == "" {
+		builder.errorMsg = "ClusterPolicy vGPU manager image cannot be redefined with empty values"
+
+		return builder
+	}
+
+	setImage(&builder.Definition.Spec.VGPUManager.Repository, &builder.Definition.Spec.VGPUManager.Image,
+		&builder.Definition.Spec.VGPUManager.Version, repository, image, version)
+
+	return builder
+}
+
+// WithGDSEnabled enables or disables GPUDirect Storage.
+func (builder *Builder) WithGDSEnabled(enabled bool) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s GPUDirect Storage enabled to %v", builder.Definition.Name, enabled)
+
+	if builder.Definition.Spec.GPUDirectStorage == nil {
+		builder.Definition.Spec.GPUDirectStorage = &nvidiagpuv1.GPUDirectStorageSpec{}
+	}
+
+	builder.Definition.Spec.GPUDirectStorage.Enabled = ptr.To(enabled)
+
+	return builder
+}
+
+// WithDaemonsetsRollingUpdate sets the maximum number of unavailable operand pods during a rolling update,
+// e.g. "1" or "25%".
+func (builder *Builder) WithDaemonsetsRollingUpdate(maxUnavailable string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting ClusterPolicy %s daemonsets rollingUpdate maxUnavailable to %s",
+		builder.Definition.Name, maxUnavailable)
+
+	if maxUnavailable == "" {
+		builder.errorMsg = "ClusterPolicy daemonsets rollingUpdate maxUnavailable cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Daemonsets.RollingUpdate = &nvidiagpuv1.RollingUpdateSpec{
+		MaxUnavailable: maxUnavailable,
+	}
+
+	return builder
+}
+
+// setImage overrides the non-empty image fields.
+func setImage(repositoryField, imageField, versionField *string, repository, image, version string) {
+	if repository != "" {
+		*repositoryField = repository
+	}
+
+	if image != "" {
+		*imageField = image
+	}
+
+	if version != "" {
+		*versionField = version
+	}
+}
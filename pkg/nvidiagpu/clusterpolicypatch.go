@@ -0,0 +1,240 @@
+package nvidiagpu
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+// FieldChange is a ClusterPolicy field whose value differs between two versions of the policy.
+type FieldChange struct {
+	// Path of the field, e.g. "spec.devicePlugin.enabled".
+	Path string
+	// Old value of the field, nil if the field was not set.
+	Old interface{}
+	// New value of the field, nil if the field was removed.
+	New interface{}
+}
+
+// String returns the change formatted as "path: old -> new".
+func (change FieldChange) String() string {
+	return fmt.Sprintf("%s: %s -> %s", change.Path, formatFieldValue(change.Old), formatFieldValue(change.New))
+}
+
+// Apply patches the ClusterPolicy on the cluster and returns the fields the patch changed.
+// The patch is applied to the latest version of the policy on the client side, so strategic merge patches
+// use the ClusterPolicy Go types even though the API server does not support them for custom resources,
+// and the result is written with an update guarded by the resourceVersion of that latest version.
+// Supported patch types are types.JSONPatchType, types.MergePatchType and types.StrategicMergePatchType.
+// Nothing is written if the patch does not change the policy.
+func (builder *Builder) Apply(patchType types.PatchType, patch []byte) ([]FieldChange, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Applying %s patch to ClusterPolicy %s: %s", patchType, builder.Definition.Name, string(patch))
+
+	current, err := builder.Get()
+	if err != nil {
+		return nil, fmt.Errorf("cannot patch ClusterPolicy %s: %w", builder.Definition.Name, err)
+	}
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var patchedJSON []byte
+
+	switch patchType {
+	case types.JSONPatchType:
+		var decodedPatch jsonpatch.Patch
+
+		decodedPatch, err = jsonpatch.DecodePatch(patch)
+		if err == nil {
+			patchedJSON, err = decodedPatch.Apply(currentJSON)
+		}
+	case types.MergePatchType:
+		patchedJSON, err = jsonpatch.MergePatch(currentJSON, patch)
+	case types.StrategicMergePatchType:
+		patchedJSON, err = strategicpatch.StrategicMergePatch(currentJSON, patch, nvidiagpuv1.ClusterPolicy{})
+	default:
+		return nil, fmt.Errorf("unsupported ClusterPolicy patch type %q", patchType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply %s patch to ClusterPolicy %s: %w", patchType, current.Name, err)
+	}
+
+	patched := &nvidiagpuv1.ClusterPolicy{}
+	if err := json.Unmarshal(patchedJSON, patched); err != nil {
+		return nil, fmt.Errorf("patched ClusterPolicy %s is invalid: %w", current.Name, err)
+	}
+
+	if patched.Name != current.Name {
+		return nil, fmt.Errorf("patch cannot rename ClusterPolicy %s to %s", current.Name, patched.Name)
+	}
+
+	patched.ResourceVersion = current.ResourceVersion
+
+	changes, err := DiffClusterPolicies(current, patched)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.Definition = current
+	builder.Object = current
+
+	if len(changes) == 0 {
+		glog.V(100).Infof("Patch does not change ClusterPolicy %s", current.Name)
+
+		return nil, nil
+	}
+
+	if err := builder.apiClient.Update(context.TODO(), patched); err != nil {
+		return nil, fmt.Errorf("failed to update patched ClusterPolicy %s: %w", current.Name, err)
+	}
+
+	glog.V(100).Infof("Patched ClusterPolicy %s, changed fields: %v", current.Name, changes)
+
+	builder.Definition = patched
+	builder.Object = patched
+
+	return changes, nil
+}
+
+// Diff returns the fields the builder definition changes compared to the ClusterPolicy on the cluster,
+// e.g. the changes the pending With* mutations will make on Update.
+func (builder *Builder) Diff() ([]FieldChange, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	current, err := builder.Get()
+	if err != nil {
+		return nil, fmt.Errorf("cannot diff ClusterPolicy %s: %w", builder.Definition.Name, err)
+	}
+
+	return DiffClusterPolicies(current, builder.Definition)
+}
+
+// DiffClusterPolicies returns the spec, label and annotation fields that differ between two ClusterPolicies,
+// sorted by path. Lists are compared as a whole.
+func DiffClusterPolicies(oldPolicy, newPolicy *nvidiagpuv1.ClusterPolicy) ([]FieldChange, error) {
+	if oldPolicy == nil || newPolicy == nil {
+		return nil, fmt.Errorf("cannot diff a nil ClusterPolicy")
+	}
+
+	var changes []FieldChange
+
+	for _, field := range []struct {
+		path     string
+		old, new interface{}
+	}{
+		{path: "metadata.labels", old: oldPolicy.Labels, new: newPolicy.Labels},
+		{path: "metadata.annotations", old: oldPolicy.Annotations, new: newPolicy.Annotations},
+		{path: "spec", old: &oldPolicy.Spec, new: &newPolicy.Spec},
+	} {
+		oldValue, err := toUnstructuredValue(field.old)
+		if err != nil {
+			return nil, err
+		}
+
+		newValue, err := toUnstructuredValue(field.new)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = diffValues(field.path, oldValue, newValue, changes)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes, nil
+}
+
+// toUnstructuredValue converts a typed value to its JSON representation made of maps, slices and scalars.
+func toUnstructuredValue(value interface{}) (interface{}, error) {
+	if reflect.ValueOf(value).Kind() == reflect.Map {
+		content, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		var result interface{}
+
+		return result, json.Unmarshal(content, &result)
+	}
+
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(value)
+}
+
+// diffValues appends the differences between oldValue and newValue under path to changes.
+func diffValues(path string, oldValue, newValue interface{}, changes []FieldChange) []FieldChange {
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+
+	// an unset object is diffed field by field against the object replacing it
+	if (oldIsMap || oldValue == nil) && (newIsMap || newValue == nil) && (oldIsMap || newIsMap) {
+		for key, oldField := range oldMap {
+			changes = diffValues(path+"."+key, oldField, newMap[key], changes)
+		}
+
+		for key, newField := range newMap {
+			if _, ok := oldMap[key]; !ok {
+				changes = diffValues(path+"."+key, nil, newField, changes)
+			}
+		}
+
+		return changes
+	}
+
+	if isEmptyValue(oldValue) && isEmptyValue(newValue) {
+		return changes
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		changes = append(changes, FieldChange{Path: path, Old: oldValue, New: newValue})
+	}
+
+	return changes
+}
+
+// isEmptyValue returns true for unset fields and empty maps, which are equivalent once serialized.
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	if mapValue, ok := value.(map[string]interface{}); ok {
+		return len(mapValue) == 0
+	}
+
+	return false
+}
+
+// formatFieldValue formats a changed value as compact JSON, or <unset> for missing values.
+func formatFieldValue(value interface{}) string {
+	if value == nil {
+		return "<unset>"
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return strings.TrimSpace(string(content))
+}
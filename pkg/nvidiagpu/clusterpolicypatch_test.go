@@ -0,0 +1,222 @@
+package nvidiagpu
+
+import (
+	"strings"
+	"testing"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	upgradev1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestBuilderWithOptions(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.ClusterPolicy})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	builder, err := Pull(apiClient, ClusterPolicyName)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterpolicy: %v", err)
+	}
+
+	builder.WithDevicePluginEnabled(false).
+		WithDevicePluginConfig("device-plugin-config", "mps").
+		WithMIGStrategy(nvidiagpuv1.MIGStrategyMixed).
+		WithDriverImage("", "", "550.90.07").
+		WithDriverUpgradePolicy(upgradev1alpha1.DriverUpgradePolicySpec{AutoUpgrade: true}).
+		WithDaemonsetsRollingUpdate("1").
+		WithGDSEnabled(true)
+
+	changes, err := builder.Diff()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedChanges := []string{
+		`spec.daemonsets.rollingUpdate.maxUnavailable: <unset> -> "1"`,
+		`spec.devicePlugin.config.default: <unset> -> "mps"`,
+		`spec.devicePlugin.config.name: <unset> -> "device-plugin-config"`,
+		`spec.devicePlugin.enabled: true -> false`,
+		`spec.driver.upgradePolicy.autoUpgrade: <unset> -> true`,
+		`spec.driver.version: <unset> -> "550.90.07"`,
+		`spec.gds.enabled: <unset> -> true`,
+		`spec.mig.strategy: <unset> -> "mixed"`,
+	}
+
+	assertChanges(t, changes, expectedChanges)
+
+	if _, err := builder.Update(false); err != nil {
+		t.Fatalf("failed to update the clusterpolicy: %v", err)
+	}
+
+	changes, err = builder.Diff()
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected no pending changes after update, got %v, %v", changes, err)
+	}
+}
+
+func TestBuilderWithOptionsInvalid(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.ClusterPolicy})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		mutate        func(builder *Builder) *Builder
+		expectedError string
+	}{
+		{
+			name: "invalid mig strategy",
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithMIGStrategy("none")
+			},
+			expectedError: "ClusterPolicy MIG strategy must be 'single' or 'mixed'",
+		},
+		{
+			name: "empty driver image",
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithDriverImage("", "", "")
+			},
+			expectedError: "ClusterPolicy driver image cannot be redefined with empty values",
+		},
+		{
+			name: "empty device plugin configmap",
+			mutate: func(builder *Builder) *Builder {
+				return builder.WithDevicePluginConfig("", "default").WithDevicePluginEnabled(false)
+			},
+			expectedError: "ClusterPolicy device plugin config cannot have an empty configmap name",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder, err := Pull(apiClient, ClusterPolicyName)
+			if err != nil {
+				t.Fatalf("failed to pull the clusterpolicy: %v", err)
+			}
+
+			_, err = testCase.mutate(builder).Update(false)
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestBuilderApply(t *testing.T) {
+	testCases := []struct {
+		name            string
+		patchType       types.PatchType
+		patch           string
+		expectedChanges []string
+		expectedError   string
+	}{
+		{
+			name:      "merge patch",
+			patchType: types.MergePatchType,
+			patch:     `{"spec":{"devicePlugin":{"enabled":false},"dcgm":{"enabled":null},"sandboxWorkloads":{"enabled":true}}}`,
+			expectedChanges: []string{
+				`spec.dcgm.enabled: true -> <unset>`,
+				`spec.devicePlugin.enabled: true -> false`,
+				`spec.sandboxWorkloads.enabled: <unset> -> true`,
+			},
+		},
+		{
+			name:      "strategic merge patch",
+			patchType: types.StrategicMergePatchType,
+			patch:     `{"metadata":{"labels":{"ci":"true"}},"spec":{"driver":{"licensingConfig":{"$patch":"delete"}}}}`,
+			expectedChanges: []string{
+				`metadata.labels.ci: <unset> -> "true"`,
+				`spec.driver.licensingConfig.nlsEnabled: true -> <unset>`,
+			},
+		},
+		{
+			name:      "json patch",
+			patchType: types.JSONPatchType,
+			patch:     `[{"op":"replace","path":"/spec/validator/plugin/env/0/value","value":"true"}]`,
+			expectedChanges: []string{
+				`spec.validator.plugin.env: [{"name":"WITH_WORKLOAD","value":"false"}] -> ` +
+					`[{"name":"WITH_WORKLOAD","value":"true"}]`,
+			},
+		},
+		{
+			name:      "no-op patch",
+			patchType: types.MergePatchType,
+			patch:     `{"spec":{"toolkit":{"enabled":true}}}`,
+		},
+		{
+			name:          "unsupported patch type",
+			patchType:     types.ApplyPatchType,
+			patch:         `{}`,
+			expectedError: `unsupported ClusterPolicy patch type`,
+		},
+		{
+			name:          "rename",
+			patchType:     types.MergePatchType,
+			patch:         `{"metadata":{"name":"other-policy"}}`,
+			expectedError: `patch cannot rename ClusterPolicy`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient, err := testfixtures.NewTestClients([]string{testfixtures.ClusterPolicy})
+			if err != nil {
+				t.Fatalf("failed to create test clients: %v", err)
+			}
+
+			builder, err := Pull(apiClient, ClusterPolicyName)
+			if err != nil {
+				t.Fatalf("failed to pull the clusterpolicy: %v", err)
+			}
+
+			initialResourceVersion := builder.Object.ResourceVersion
+
+			changes, err := builder.Apply(testCase.patchType, []byte(testCase.patch))
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error containing %q, got %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertChanges(t, changes, testCase.expectedChanges)
+
+			clusterPolicy, err := builder.Get()
+			if err != nil {
+				t.Fatalf("failed to get the patched clusterpolicy: %v", err)
+			}
+
+			if (clusterPolicy.ResourceVersion != initialResourceVersion) != (len(testCase.expectedChanges) > 0) {
+				t.Errorf("expected the clusterpolicy to be updated only when the patch changes it")
+			}
+
+			remainingChanges, err := DiffClusterPolicies(clusterPolicy, builder.Object)
+			if err != nil || len(remainingChanges) != 0 {
+				t.Errorf("expected the builder to hold the patched clusterpolicy, got %v, %v", remainingChanges, err)
+			}
+		})
+	}
+}
+
+func assertChanges(t *testing.T, changes []FieldChange, expectedChanges []string) {
+	t.Helper()
+
+	if len(changes) != len(expectedChanges) {
+		t.Fatalf("expected changes %v, got %v", expectedChanges, changes)
+	}
+
+	for index, change := range changes {
+		if change.String() != expectedChanges[index] {
+			t.Errorf("expected change %q, got %q", expectedChanges[index], change.String())
+		}
+	}
+}
@@ -9,6 +9,7 @@ const (
 	GPUPresentLabel                  = "nvidia.com/gpu.present"
 	GPUCapacityKey                   = "nvidia.com/gpu"
 	DevicePluginLabel                = "app=nvidia-device-plugin-daemonset"
+	DriverPodLabel                   = "app.kubernetes.io/component=nvidia-driver"
 	OperatorGroupName                = "gpu-og"
 	OperatorDeployment               = "gpu-operator"
 	SubscriptionName                 = "gpu-subscription"
@@ -16,6 +17,7 @@ const (
 	CatalogSourceDefault             = "certified-operators"
 	CatalogSourceNamespace           = "openshift-marketplace"
 	Package                          = "gpu-operator-certified"
+	ClusterExtensionName             = "gpu-operator-certified"
 	ClusterPolicyName                = "gpu-cluster-policy"
 	OperatorDefaultMasterBundleImage = "ghcr.io/nvidia/gpu-operator/gpu-operator-bundle:main-latest"
 
@@ -23,8 +25,6 @@ const (
 
 	CustomCatalogSourceDisplayName = "Certified Operators Custom"
 
-	SleepDuration = 30 * time.Second
-
 	WaitDuration = 4 * time.Minute
 
 	DeletionPollInterval     = 30 * time.Second
@@ -33,13 +33,14 @@ const (
 
 	NodeLabelingDelay = 2 * time.Minute
 
-	CatalogSourceCreationDelay   = 30 * time.Second
+	DriverUpgradeTrackerPollInterval = 5 * time.Second
+	DriverUpgradeTimeout             = 30 * time.Minute
+
 	CatalogSourceReadyTimeout    = 4 * time.Minute
 	PackageManifestCheckInterval = 30 * time.Second
 	PackageManifestTimeout       = 5 * time.Minute
 	GpuBundleDeploymentTimeout   = 5 * time.Minute
 
-	OperatorDeploymentCreationDelay = 2 * time.Minute
 	DeploymentCreationCheckInterval = 30 * time.Second
 	DeploymentCreationTimeout       = 4 * time.Minute
 
@@ -58,8 +59,6 @@ const (
 
 	BurnLogCollectionPeriod = 500 * time.Second
 
-	CsvDeploymentSleepInterval = 2 * time.Minute
-
 	BurnPodPostUpgradeCreationTimeout = 5 * time.Minute
 
 	RedeployedBurnPodRunningTimeout   = 3 * time.Minute
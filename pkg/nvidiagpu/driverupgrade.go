@@ -0,0 +1,478 @@
+package nvidiagpu
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DriverUpgradeStateLabel is the node label holding the state of the driver upgrade state machine.
+const DriverUpgradeStateLabel = "nvidia.com/gpu-driver-upgrade-state"
+
+// DriverUpgradeState is a state of the driver upgrade state machine.
+type DriverUpgradeState string
+
+// Driver upgrade states, in the order a node goes through them.
+const (
+	DriverUpgradeStateUnknown            DriverUpgradeState = ""
+	DriverUpgradeStateRequired           DriverUpgradeState = "upgrade-required"
+	DriverUpgradeStateCordonRequired     DriverUpgradeState = "cordon-required"
+	DriverUpgradeStateWaitForJobs        DriverUpgradeState = "wait-for-jobs-required"
+	DriverUpgradeStatePodDeletion        DriverUpgradeState = "pod-deletion-required"
+	DriverUpgradeStateDrainRequired      DriverUpgradeState = "drain-required"
+	DriverUpgradeStatePodRestartRequired DriverUpgradeState = "pod-restart-required"
+	DriverUpgradeStateValidation         DriverUpgradeState = "validation-required"
+	DriverUpgradeStateUncordonRequired   DriverUpgradeState = "uncordon-required"
+	DriverUpgradeStateDone               DriverUpgradeState = "upgrade-done"
+	DriverUpgradeStateFailed             DriverUpgradeState = "upgrade-failed"
+)
+
+// defaultDriverUpgradeMaxUnavailable is the maxUnavailable default of the upgrade policy CRD.
+const defaultDriverUpgradeMaxUnavailable = "25%"
+
+// driverPodRevisionLabel is the label the DaemonSet controller sets to the revision of the driver pods.
+const driverPodRevisionLabel = "controller-revision-hash"
+
+// IsInProgress returns true if a node in this state counts towards maxParallelUpgrades.
+func (state DriverUpgradeState) IsInProgress() bool {
+	switch state {
+	case DriverUpgradeStateUnknown, DriverUpgradeStateRequired, DriverUpgradeStateDone:
+		return false
+	default:
+		return true
+	}
+}
+
+// DriverUpgradeTransition is a change of the upgrade state of a node.
+type DriverUpgradeTransition struct {
+	Node string
+	From DriverUpgradeState
+	To   DriverUpgradeState
+	// Unschedulable is true if the node was cordoned when the transition was observed.
+	Unschedulable bool
+	Time          time.Time
+}
+
+// String returns the transition formatted as "time node: from -> to".
+func (transition DriverUpgradeTransition) String() string {
+	from := string(transition.From)
+	if from == "" {
+		from = "<unset>"
+	}
+
+	to := string(transition.To)
+	if to == "" {
+		to = "<unset>"
+	}
+
+	cordoned := ""
+	if transition.Unschedulable {
+		cordoned = " (cordoned)"
+	}
+
+	return fmt.Sprintf("%s %s: %s -> %s%s", transition.Time.Format(time.RFC3339), transition.Node, from, to, cordoned)
+}
+
+// DriverUpgradeTracker follows the GPU nodes through the driver upgrade state machine by polling their
+// DriverUpgradeStateLabel, records the timeline of the transitions and checks that the number of nodes
+// upgraded in parallel and the number of unavailable nodes stay within the ClusterPolicy upgrade policy.
+// Transitions faster than the poll interval are not observed, a node also counts as upgraded once it is in the
+// upgrade-done state with a driver pod of another revision or image than when the tracker was created.
+type DriverUpgradeTracker struct {
+	// MaxParallelUpgrades is the maximum number of nodes upgraded in parallel, 0 means no limit.
+	MaxParallelUpgrades int
+	// MaxUnavailable is the maximum number of unavailable GPU nodes.
+	MaxUnavailable int
+	// PeakParallelUpgrades is the highest number of nodes observed upgrading in parallel.
+	PeakParallelUpgrades int
+	// PeakUnavailable is the highest number of unavailable GPU nodes observed.
+	PeakUnavailable int
+
+	apiClient        *clients.Settings
+	nodeSelector     map[string]string
+	states           map[string]DriverUpgradeState
+	initialRevisions map[string]string
+	upgraded         map[string]bool
+	timeline         []DriverUpgradeTransition
+	violations       []string
+	// parallelBreached and unavailableBreached are set while a limit is exceeded, so that a breach is
+	// recorded once when it starts rather than on every observation.
+	parallelBreached    bool
+	unavailableBreached bool
+	mutex               sync.Mutex
+}
+
+// NewDriverUpgradeTracker returns a tracker for the nodes matching nodeSelector, with the limits of the driver
+// upgrade policy of the ClusterPolicy. A percentage maxUnavailable is scaled on the current number of nodes,
+// rounding up. The current upgrade state of the nodes is recorded as the start of the timeline.
+func NewDriverUpgradeTracker(apiClient *clients.Settings, clusterPolicyName string,
+	nodeSelector map[string]string) (*DriverUpgradeTracker, error) {
+	return NewDriverUpgradeTrackerContext(context.TODO(), apiClient, clusterPolicyName, nodeSelector)
+}
+
+// NewDriverUpgradeTrackerContext returns a tracker like NewDriverUpgradeTracker, using ctx for the API calls.
+func NewDriverUpgradeTrackerContext(ctx context.Context, apiClient *clients.Settings, clusterPolicyName string,
+	nodeSelector map[string]string) (*DriverUpgradeTracker, error) {
+	glog.V(100).Infof("Creating driver upgrade tracker for ClusterPolicy %s and nodes %v",
+		clusterPolicyName, nodeSelector)
+
+	builder, err := PullContext(ctx, apiClient, clusterPolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	upgradePolicy := builder.Object.Spec.Driver.UpgradePolicy
+	if upgradePolicy == nil || !upgradePolicy.AutoUpgrade {
+		return nil, fmt.Errorf("ClusterPolicy %s driver auto upgrade is not enabled", clusterPolicyName)
+	}
+
+	tracker := &DriverUpgradeTracker{
+		MaxParallelUpgrades: upgradePolicy.MaxParallelUpgrades,
+		apiClient:           apiClient,
+		nodeSelector:        nodeSelector,
+		states:              make(map[string]DriverUpgradeState),
+		upgraded:            make(map[string]bool),
+	}
+
+	nodeList, err := tracker.listNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracker.initialRevisions, err = tracker.listDriverRevisions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	maxUnavailable := intstr.FromString(defaultDriverUpgradeMaxUnavailable)
+	if upgradePolicy.MaxUnavailable != nil {
+		maxUnavailable = *upgradePolicy.MaxUnavailable
+	}
+
+	tracker.MaxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, len(nodeList), true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ClusterPolicy %s driver upgrade maxUnavailable: %w", clusterPolicyName, err)
+	}
+
+	tracker.record(nodeList, tracker.initialRevisions, time.Now())
+
+	glog.V(100).Infof("Tracking driver upgrade of %d nodes with maxParallelUpgrades %d and maxUnavailable %d",
+		len(nodeList), tracker.MaxParallelUpgrades, tracker.MaxUnavailable)
+
+	return tracker, nil
+}
+
+// Observe polls the nodes once, records their transitions and checks the upgrade policy limits.
+func (tracker *DriverUpgradeTracker) Observe() error {
+	return tracker.ObserveContext(context.TODO())
+}
+
+// ObserveContext polls the nodes once like Observe, using ctx for the API calls.
+func (tracker *DriverUpgradeTracker) ObserveContext(ctx context.Context) error {
+	if err := tracker.observe(ctx); err != nil {
+		return err
+	}
+
+	return tracker.Err()
+}
+
+// Start observes the nodes every pollInterval in the background until the returned function is called.
+// Errors listing the nodes are logged and retried, policy violations are available from Err.
+//
+// Start uses context.Background internally; to specify the context, use StartContext.
+func (tracker *DriverUpgradeTracker) Start(pollInterval time.Duration) (stop func()) {
+	return tracker.StartContext(context.Background(), pollInterval)
+}
+
+// StartContext observes the nodes in the background like Start, until the returned function is called or ctx is
+// done.
+func (tracker *DriverUpgradeTracker) StartContext(ctx context.Context, pollInterval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		wait.UntilWithContext(ctx, func(ctx context.Context) {
+			if err := tracker.observe(ctx); err != nil {
+				glog.V(100).Infof("Failed to observe the driver upgrade state of the nodes: %v", err)
+			}
+		}, pollInterval)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// WaitUntilUpgraded observes the nodes until every one of them went through the upgrade and is back in the
+// upgrade-done state. It fails as soon as a node is in the upgrade-failed state or a limit is exceeded.
+func (tracker *DriverUpgradeTracker) WaitUntilUpgraded(pollInterval, timeout time.Duration) error {
+	return tracker.WaitUntilUpgradedContext(context.TODO(), pollInterval, timeout)
+}
+
+// WaitUntilUpgradedContext waits for the driver upgrade like WaitUntilUpgraded, stopping early when ctx is done.
+func (tracker *DriverUpgradeTracker) WaitUntilUpgradedContext(ctx context.Context, pollInterval,
+	timeout time.Duration) error {
+	glog.V(100).Infof("Waiting up to %s for the driver upgrade of nodes %v", timeout, tracker.nodeSelector)
+
+	err := wait.PollUntilContextTimeout(
+		ctx, pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+			if err := tracker.ObserveContext(ctx); err != nil {
+				return false, err
+			}
+
+			tracker.mutex.Lock()
+			defer tracker.mutex.Unlock()
+
+			var failed, pending []string
+
+			for node, state := range tracker.states {
+				switch {
+				case state == DriverUpgradeStateFailed:
+					failed = append(failed, node)
+				case !tracker.upgraded[node]:
+					pending = append(pending, node)
+				}
+			}
+
+			if len(failed) > 0 {
+				sort.Strings(failed)
+
+				return false, fmt.Errorf("driver upgrade failed on nodes %v", failed)
+			}
+
+			glog.V(100).Infof("Nodes pending driver upgrade: %v", pending)
+
+			return len(pending) == 0, nil
+		})
+	if err != nil {
+		return fmt.Errorf("driver upgrade did not complete: %w\n%s", err, tracker.Report())
+	}
+
+	return nil
+}
+
+// Err returns the upgrade policy violations observed so far, joined.
+func (tracker *DriverUpgradeTracker) Err() error {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	var errs []error
+
+	for _, violation := range tracker.violations {
+		errs = append(errs, errors.New(violation))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Timeline returns the transitions observed for all the nodes, in order.
+func (tracker *DriverUpgradeTracker) Timeline() []DriverUpgradeTransition {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	return append([]DriverUpgradeTransition{}, tracker.timeline...)
+}
+
+// NodeTimeline returns the transitions observed for a node, in order.
+func (tracker *DriverUpgradeTracker) NodeTimeline(nodeName string) []DriverUpgradeTransition {
+	var transitions []DriverUpgradeTransition
+
+	for _, transition := range tracker.Timeline() {
+		if transition.Node == nodeName {
+			transitions = append(transitions, transition)
+		}
+	}
+
+	return transitions
+}
+
+// Upgraded returns the names of the nodes that reached the upgrade-done state since the tracker was created, or that
+// are in it with a driver pod of another revision than when the tracker was created.
+func (tracker *DriverUpgradeTracker) Upgraded() []string {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	var nodeNames []string
+
+	for nodeName, upgraded := range tracker.upgraded {
+		if upgraded {
+			nodeNames = append(nodeNames, nodeName)
+		}
+	}
+
+	sort.Strings(nodeNames)
+
+	return nodeNames
+}
+
+// Drained returns true if the node was observed cordoned and going through the drain-required state.
+func (tracker *DriverUpgradeTracker) Drained(nodeName string) bool {
+	drained, cordoned := false, false
+
+	for _, transition := range tracker.NodeTimeline(nodeName) {
+		drained = drained || transition.To == DriverUpgradeStateDrainRequired
+		cordoned = cordoned || transition.Unschedulable
+	}
+
+	return drained && cordoned
+}
+
+// Report returns the limits, the peaks, the timeline and the violations, one per line.
+func (tracker *DriverUpgradeTracker) Report() string {
+	timeline := tracker.Timeline()
+	violations := tracker.Err()
+
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "driver upgrade: maxParallelUpgrades %d (peak %d), maxUnavailable %d (peak %d)",
+		tracker.MaxParallelUpgrades, tracker.PeakParallelUpgrades, tracker.MaxUnavailable, tracker.PeakUnavailable)
+
+	for _, transition := range timeline {
+		fmt.Fprintf(&builder, "\n  %s", transition)
+	}
+
+	if violations != nil {
+		fmt.Fprintf(&builder, "\nviolations:\n%s", violations)
+	}
+
+	return builder.String()
+}
+
+// observe polls the nodes and their driver pods once and records them.
+func (tracker *DriverUpgradeTracker) observe(ctx context.Context) error {
+	nodeList, err := tracker.listNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := tracker.listDriverRevisions(ctx)
+	if err != nil {
+		return err
+	}
+
+	tracker.record(nodeList, revisions, time.Now())
+
+	return nil
+}
+
+// listDriverRevisions returns the revision and image of the driver pod of each node, keyed by node name.
+// Driver pods being deleted are left out.
+func (tracker *DriverUpgradeTracker) listDriverRevisions(ctx context.Context) (map[string]string, error) {
+	podList, err := tracker.apiClient.Pods(NvidiaGPUNamespace).List(ctx,
+		metav1.ListOptions{LabelSelector: DriverPodLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list driver pods in namespace %s: %w", NvidiaGPUNamespace, err)
+	}
+
+	revisions := make(map[string]string)
+
+	for _, driverPod := range podList.Items {
+		if driverPod.Spec.NodeName == "" || driverPod.DeletionTimestamp != nil || len(driverPod.Spec.Containers) == 0 {
+			continue
+		}
+
+		revisions[driverPod.Spec.NodeName] = fmt.Sprintf("%s@%s",
+			driverPod.Labels[driverPodRevisionLabel], driverPod.Spec.Containers[0].Image)
+	}
+
+	return revisions, nil
+}
+
+// listNodes returns the nodes matching the tracker node selector.
+func (tracker *DriverUpgradeTracker) listNodes(ctx context.Context) ([]corev1.Node, error) {
+	nodeList, err := tracker.apiClient.CoreV1Interface.Nodes().List(ctx,
+		metav1.ListOptions{LabelSelector: labels.Set(tracker.nodeSelector).String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes matching %v: %w", tracker.nodeSelector, err)
+	}
+
+	return nodeList.Items, nil
+}
+
+// record appends the state changes of the nodes to the timeline, marks the upgraded nodes and checks the upgrade
+// policy limits. A limit breach is recorded as a violation when it starts.
+func (tracker *DriverUpgradeTracker) record(nodeList []corev1.Node, revisions map[string]string,
+	observedAt time.Time) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+
+	inProgress, unavailable := 0, 0
+
+	for _, node := range nodeList {
+		state := DriverUpgradeState(node.Labels[DriverUpgradeStateLabel])
+		previous, known := tracker.states[node.Name]
+
+		if !known || previous != state {
+			tracker.timeline = append(tracker.timeline, DriverUpgradeTransition{
+				Node: node.Name, From: previous, To: state, Unschedulable: node.Spec.Unschedulable, Time: observedAt,
+			})
+			tracker.states[node.Name] = state
+
+			if known && state == DriverUpgradeStateDone {
+				tracker.upgraded[node.Name] = true
+			}
+		}
+
+		// The transitions of a node upgraded between two polls are missed, its driver pod revision is not.
+		initialRevision, revision := tracker.initialRevisions[node.Name], revisions[node.Name]
+		if state == DriverUpgradeStateDone && initialRevision != "" && revision != "" && revision != initialRevision {
+			tracker.upgraded[node.Name] = true
+		}
+
+		if state.IsInProgress() {
+			inProgress++
+		}
+
+		if node.Spec.Unschedulable || !isNodeReady(&node) {
+			unavailable++
+		}
+	}
+
+	tracker.PeakParallelUpgrades = max(tracker.PeakParallelUpgrades, inProgress)
+	tracker.PeakUnavailable = max(tracker.PeakUnavailable, unavailable)
+
+	parallelBreached := tracker.MaxParallelUpgrades > 0 && inProgress > tracker.MaxParallelUpgrades
+	if parallelBreached && !tracker.parallelBreached {
+		tracker.violations = append(tracker.violations, fmt.Sprintf(
+			"%s: %d nodes upgrading in parallel, maxParallelUpgrades is %d",
+			observedAt.Format(time.RFC3339), inProgress, tracker.MaxParallelUpgrades))
+	}
+
+	unavailableBreached := unavailable > tracker.MaxUnavailable
+	if unavailableBreached && !tracker.unavailableBreached {
+		tracker.violations = append(tracker.violations, fmt.Sprintf(
+			"%s: %d nodes unavailable, maxUnavailable is %d",
+			observedAt.Format(time.RFC3339), unavailable, tracker.MaxUnavailable))
+	}
+
+	tracker.parallelBreached, tracker.unavailableBreached = parallelBreached, unavailableBreached
+}
+
+// isNodeReady returns true if the node Ready condition is true.
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,286 @@
+package nvidiagpu
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	upgradev1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+var driverUpgradeNodeSelector = map[string]string{NvidiaGPULabel: "true"}
+
+func newDriverUpgradeTestClients(t *testing.T, upgradePolicy *upgradev1alpha1.DriverUpgradePolicySpec) *clients.Settings {
+	t.Helper()
+
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.ClusterPolicy, testfixtures.Nodes,
+		testfixtures.Pods})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	builder, err := Pull(apiClient, ClusterPolicyName)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterpolicy: %v", err)
+	}
+
+	if upgradePolicy != nil {
+		builder.WithDriverUpgradePolicy(*upgradePolicy)
+	}
+
+	if _, err := builder.Update(false); err != nil {
+		t.Fatalf("failed to update the clusterpolicy: %v", err)
+	}
+
+	for _, nodeName := range []string{"worker-gpu-0", "worker-gpu-1"} {
+		setDriverUpgradeState(t, apiClient, nodeName, DriverUpgradeStateDone, false)
+	}
+
+	return apiClient
+}
+
+func setDriverUpgradeState(t *testing.T, apiClient *clients.Settings, nodeName string,
+	state DriverUpgradeState, unschedulable bool) {
+	t.Helper()
+
+	node, err := apiClient.CoreV1Interface.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get node %s: %v", nodeName, err)
+	}
+
+	node.Labels[DriverUpgradeStateLabel] = string(state)
+	node.Spec.Unschedulable = unschedulable
+
+	if _, err := apiClient.CoreV1Interface.Nodes().Update(context.TODO(), node, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update node %s: %v", nodeName, err)
+	}
+}
+
+// upgradeNode moves a node through the whole driver upgrade state machine, observing every state.
+func upgradeNode(t *testing.T, apiClient *clients.Settings, tracker *DriverUpgradeTracker, nodeName string) {
+	t.Helper()
+
+	for _, step := range []struct {
+		state         DriverUpgradeState
+		unschedulable bool
+	}{
+		{DriverUpgradeStateRequired, false},
+		{DriverUpgradeStateCordonRequired, false},
+		{DriverUpgradeStateWaitForJobs, true},
+		{DriverUpgradeStateDrainRequired, true},
+		{DriverUpgradeStatePodRestartRequired, true},
+		{DriverUpgradeStateUncordonRequired, true},
+		{DriverUpgradeStateDone, false},
+	} {
+		setDriverUpgradeState(t, apiClient, nodeName, step.state, step.unschedulable)
+
+		if err := tracker.Observe(); err != nil {
+			t.Fatalf("unexpected violation on node %s in state %s: %v", nodeName, step.state, err)
+		}
+	}
+}
+
+func TestDriverUpgradeTrackerRollingUpgrade(t *testing.T) {
+	apiClient := newDriverUpgradeTestClients(t, &upgradev1alpha1.DriverUpgradePolicySpec{
+		AutoUpgrade:         true,
+		MaxParallelUpgrades: 1,
+		MaxUnavailable:      ptr.To(intstr.FromString("50%")),
+	})
+
+	tracker, err := NewDriverUpgradeTracker(apiClient, ClusterPolicyName, driverUpgradeNodeSelector)
+	if err != nil {
+		t.Fatalf("failed to create the tracker: %v", err)
+	}
+
+	if tracker.MaxParallelUpgrades != 1 || tracker.MaxUnavailable != 1 {
+		t.Errorf("expected limits 1 and 1, got %d and %d", tracker.MaxParallelUpgrades, tracker.MaxUnavailable)
+	}
+
+	upgradeNode(t, apiClient, tracker, "worker-gpu-0")
+	upgradeNode(t, apiClient, tracker, "worker-gpu-1")
+
+	if err := tracker.WaitUntilUpgraded(time.Millisecond, time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if upgraded := tracker.Upgraded(); len(upgraded) != 2 {
+		t.Errorf("expected both GPU nodes to be upgraded, got %v", upgraded)
+	}
+
+	for _, nodeName := range []string{"worker-gpu-0", "worker-gpu-1"} {
+		if !tracker.Drained(nodeName) {
+			t.Errorf("expected node %s to be drained", nodeName)
+		}
+
+		// Initial state plus the 7 transitions of the state machine.
+		if transitions := tracker.NodeTimeline(nodeName); len(transitions) != 8 {
+			t.Errorf("expected 8 transitions for node %s, got %v", nodeName, transitions)
+		}
+	}
+
+	if tracker.PeakParallelUpgrades != 1 || tracker.PeakUnavailable != 1 {
+		t.Errorf("expected peaks of 1, got %d and %d", tracker.PeakParallelUpgrades, tracker.PeakUnavailable)
+	}
+
+	report := tracker.Report()
+	if !strings.Contains(report, "worker-gpu-1: drain-required -> pod-restart-required (cordoned)") ||
+		strings.Contains(report, "violations") {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
+
+func TestDriverUpgradeTrackerViolations(t *testing.T) {
+	apiClient := newDriverUpgradeTestClients(t, &upgradev1alpha1.DriverUpgradePolicySpec{
+		AutoUpgrade:         true,
+		MaxParallelUpgrades: 1,
+		MaxUnavailable:      ptr.To(intstr.FromInt32(1)),
+	})
+
+	tracker, err := NewDriverUpgradeTracker(apiClient, ClusterPolicyName, driverUpgradeNodeSelector)
+	if err != nil {
+		t.Fatalf("failed to create the tracker: %v", err)
+	}
+
+	setDriverUpgradeState(t, apiClient, "worker-gpu-0", DriverUpgradeStateDrainRequired, true)
+	setDriverUpgradeState(t, apiClient, "worker-gpu-1", DriverUpgradeStateCordonRequired, true)
+
+	err = tracker.Observe()
+	if err == nil {
+		t.Fatal("expected the parallel upgrade of both nodes to be reported")
+	}
+
+	for _, expected := range []string{
+		"2 nodes upgrading in parallel, maxParallelUpgrades is 1",
+		"2 nodes unavailable, maxUnavailable is 1",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected violation %q, got %v", expected, err)
+		}
+	}
+
+	if err := tracker.WaitUntilUpgraded(time.Millisecond, time.Second); err == nil {
+		t.Error("expected the wait to fail on violations")
+	}
+}
+
+func TestDriverUpgradeTrackerViolationRecordedOnce(t *testing.T) {
+	apiClient := newDriverUpgradeTestClients(t, &upgradev1alpha1.DriverUpgradePolicySpec{
+		AutoUpgrade:         true,
+		MaxParallelUpgrades: 1,
+		MaxUnavailable:      ptr.To(intstr.FromInt32(2)),
+	})
+
+	tracker, err := NewDriverUpgradeTracker(apiClient, ClusterPolicyName, driverUpgradeNodeSelector)
+	if err != nil {
+		t.Fatalf("failed to create the tracker: %v", err)
+	}
+
+	setDriverUpgradeState(t, apiClient, "worker-gpu-0", DriverUpgradeStateDrainRequired, true)
+	setDriverUpgradeState(t, apiClient, "worker-gpu-1", DriverUpgradeStateCordonRequired, true)
+
+	// The breach lasts two observations, ends, then starts again.
+	_ = tracker.Observe()
+	_ = tracker.Observe()
+
+	setDriverUpgradeState(t, apiClient, "worker-gpu-1", DriverUpgradeStateDone, false)
+	_ = tracker.Observe()
+
+	setDriverUpgradeState(t, apiClient, "worker-gpu-1", DriverUpgradeStateCordonRequired, true)
+
+	err = tracker.Observe()
+	if err == nil {
+		t.Fatal("expected the parallel upgrade of both nodes to be reported")
+	}
+
+	if count := strings.Count(err.Error(), "nodes upgrading in parallel"); count != 2 {
+		t.Errorf("expected each of the 2 breaches to be recorded once, got %d:\n%v", count, err)
+	}
+}
+
+func TestDriverUpgradeTrackerRevisionChange(t *testing.T) {
+	apiClient := newDriverUpgradeTestClients(t, &upgradev1alpha1.DriverUpgradePolicySpec{AutoUpgrade: true})
+
+	tracker, err := NewDriverUpgradeTracker(apiClient, ClusterPolicyName, driverUpgradeNodeSelector)
+	if err != nil {
+		t.Fatalf("failed to create the tracker: %v", err)
+	}
+
+	// worker-gpu-0 goes through the whole upgrade between two polls, only its driver pod revision changes.
+	driverPod, err := apiClient.Pods(NvidiaGPUNamespace).Get(context.TODO(), "nvidia-driver-daemonset-417.94-abcde",
+		metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the driver pod: %v", err)
+	}
+
+	driverPod.Labels[driverPodRevisionLabel] = "7c4b9d8f5"
+
+	if _, err := apiClient.Pods(NvidiaGPUNamespace).Update(context.TODO(), driverPod, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update the driver pod: %v", err)
+	}
+
+	if err := tracker.Observe(); err != nil {
+		t.Fatalf("unexpected violation: %v", err)
+	}
+
+	if upgraded := tracker.Upgraded(); len(upgraded) != 1 || upgraded[0] != "worker-gpu-0" {
+		t.Errorf("expected only worker-gpu-0 to be upgraded, got %v", upgraded)
+	}
+}
+
+func TestDriverUpgradeTrackerFailedUpgrade(t *testing.T) {
+	apiClient := newDriverUpgradeTestClients(t, &upgradev1alpha1.DriverUpgradePolicySpec{AutoUpgrade: true})
+
+	tracker, err := NewDriverUpgradeTracker(apiClient, ClusterPolicyName, driverUpgradeNodeSelector)
+	if err != nil {
+		t.Fatalf("failed to create the tracker: %v", err)
+	}
+
+	// 25% of 2 nodes rounded up.
+	if tracker.MaxParallelUpgrades != 0 || tracker.MaxUnavailable != 1 {
+		t.Errorf("expected default limits 0 and 1, got %d and %d", tracker.MaxParallelUpgrades, tracker.MaxUnavailable)
+	}
+
+	setDriverUpgradeState(t, apiClient, "worker-gpu-0", DriverUpgradeStateFailed, false)
+
+	err = tracker.WaitUntilUpgraded(time.Millisecond, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "driver upgrade failed on nodes [worker-gpu-0]") {
+		t.Errorf("expected the failed node to be reported, got %v", err)
+	}
+}
+
+func TestDriverUpgradeTrackerContextCanceled(t *testing.T) {
+	apiClient := newDriverUpgradeTestClients(t, &upgradev1alpha1.DriverUpgradePolicySpec{AutoUpgrade: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tracker, err := NewDriverUpgradeTrackerContext(ctx, apiClient, ClusterPolicyName, driverUpgradeNodeSelector)
+	if err != nil {
+		t.Fatalf("failed to create the tracker: %v", err)
+	}
+
+	stop := tracker.StartContext(ctx, time.Millisecond)
+
+	cancel()
+	stop()
+
+	err = tracker.WaitUntilUpgradedContext(ctx, time.Millisecond, time.Hour)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the wait to stop with the canceled context, got %v", err)
+	}
+}
+
+func TestNewDriverUpgradeTrackerAutoUpgradeDisabled(t *testing.T) {
+	apiClient := newDriverUpgradeTestClients(t, nil)
+
+	_, err := NewDriverUpgradeTracker(apiClient, ClusterPolicyName, driverUpgradeNodeSelector)
+	if err == nil || !strings.Contains(err.Error(), "driver auto upgrade is not enabled") {
+		t.Errorf("expected an auto upgrade error, got %v", err)
+	}
+}
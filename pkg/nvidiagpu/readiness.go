@@ -0,0 +1,384 @@
+package nvidiagpu
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ComponentEventsLimit is the number of most recent events kept per ClusterPolicy component.
+const ComponentEventsLimit = 5
+
+// Component is a ClusterPolicy operand deployed as one or more DaemonSets.
+type Component struct {
+	// Name of the component, e.g. "driver".
+	Name string
+	// DaemonSetName is the name of the operand DaemonSet.
+	DaemonSetName string
+	// MatchPrefix also matches the DaemonSets named DaemonSetName-<suffix>,
+	// e.g. the per-RHCOS-version driver DaemonSets on OpenShift.
+	MatchPrefix bool
+	// Enabled returns true if the ClusterPolicy spec deploys the component.
+	Enabled func(spec *nvidiagpuv1.ClusterPolicySpec) bool
+}
+
+// Components are the ClusterPolicy operands inspected by InspectReadiness, in deployment order.
+var Components = []Component{
+	{
+		Name: "driver", DaemonSetName: "nvidia-driver-daemonset", MatchPrefix: true,
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return spec.Driver.IsEnabled() },
+	},
+	{
+		Name: "toolkit", DaemonSetName: "nvidia-container-toolkit-daemonset",
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return spec.Toolkit.IsEnabled() },
+	},
+	{
+		Name: "validator", DaemonSetName: "nvidia-operator-validator",
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return true },
+	},
+	{
+		Name: "device-plugin", DaemonSetName: "nvidia-device-plugin-daemonset",
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return spec.DevicePlugin.IsEnabled() },
+	},
+	{
+		Name: "gfd", DaemonSetName: "gpu-feature-discovery",
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return spec.GPUFeatureDiscovery.IsEnabled() },
+	},
+	{
+		Name: "dcgm", DaemonSetName: "nvidia-dcgm",
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return spec.DCGM.IsEnabled() },
+	},
+	{
+		Name: "dcgm-exporter", DaemonSetName: "nvidia-dcgm-exporter",
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return spec.DCGMExporter.IsEnabled() },
+	},
+	{
+		Name: "mig-manager", DaemonSetName: "nvidia-mig-manager",
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return spec.MIGManager.IsEnabled() },
+	},
+	{
+		Name: "node-status-exporter", DaemonSetName: "nvidia-node-status-exporter",
+		Enabled: func(spec *nvidiagpuv1.ClusterPolicySpec) bool { return spec.NodeStatusExporter.IsEnabled() },
+	},
+}
+
+// Matches returns true if the DaemonSet name belongs to the component.
+func (component Component) Matches(daemonSetName string) bool {
+	return daemonSetName == component.DaemonSetName ||
+		component.MatchPrefix && strings.HasPrefix(daemonSetName, component.DaemonSetName+"-")
+}
+
+// FailingPod is an operand pod that is not running and ready.
+type FailingPod struct {
+	Name     string
+	NodeName string
+	Phase    corev1.PodPhase
+	// Reason is the waiting or termination reason of the first failing container, e.g. CrashLoopBackOff.
+	Reason string
+}
+
+// String returns the pod formatted as "name on node: reason".
+func (pod FailingPod) String() string {
+	reason := pod.Reason
+	if reason == "" {
+		reason = string(pod.Phase)
+	}
+
+	return fmt.Sprintf("%s on %s: %s", pod.Name, pod.NodeName, reason)
+}
+
+// ComponentStatus is the readiness of an enabled ClusterPolicy component, aggregated over its DaemonSets.
+type ComponentStatus struct {
+	Name        string
+	DaemonSets  []string
+	Desired     int32
+	Ready       int32
+	Updated     int32
+	Available   int32
+	FailingPods []FailingPod
+	// Events are the most recent events of the DaemonSets and failing pods, oldest first.
+	Events []string
+}
+
+// IsReady returns true if the component DaemonSets exist and all their scheduled pods are updated and available.
+func (status ComponentStatus) IsReady() bool {
+	return len(status.DaemonSets) > 0 && status.Updated == status.Desired && status.Available == status.Desired &&
+		len(status.FailingPods) == 0
+}
+
+// String returns a one line summary of the component readiness.
+func (status ComponentStatus) String() string {
+	if len(status.DaemonSets) == 0 {
+		return fmt.Sprintf("%s: no DaemonSet found", status.Name)
+	}
+
+	summary := fmt.Sprintf("%s: %d/%d ready, %d/%d updated, %d/%d available", status.Name,
+		status.Ready, status.Desired, status.Updated, status.Desired, status.Available, status.Desired)
+
+	if len(status.FailingPods) > 0 {
+		var pods []string
+		for _, pod := range status.FailingPods {
+			pods = append(pods, pod.String())
+		}
+
+		summary += fmt.Sprintf(", failing pods: %s", strings.Join(pods, "; "))
+	}
+
+	return summary
+}
+
+// ReadinessReport is the per-component readiness of a ClusterPolicy.
+type ReadinessReport struct {
+	ClusterPolicyName string
+	State             nvidiagpuv1.State
+	Namespace         string
+	Components        []ComponentStatus
+}
+
+// NotReady returns the components that are not ready, in deployment order.
+func (report *ReadinessReport) NotReady() []ComponentStatus {
+	var notReady []ComponentStatus
+
+	for _, component := range report.Components {
+		if !component.IsReady() {
+			notReady = append(notReady, component)
+		}
+	}
+
+	return notReady
+}
+
+// BlockingComponent returns the first component in deployment order that is not ready, as later operands
+// usually wait for the earlier ones, or nil if all the components are ready.
+func (report *ReadinessReport) BlockingComponent() *ComponentStatus {
+	notReady := report.NotReady()
+	if len(notReady) == 0 {
+		return nil
+	}
+
+	return &notReady[0]
+}
+
+// String returns the ClusterPolicy state followed by one line per component and the events of
+// the components that are not ready.
+func (report *ReadinessReport) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "ClusterPolicy %s is %s in namespace %s", report.ClusterPolicyName,
+		report.State, report.Namespace)
+
+	for _, component := range report.Components {
+		fmt.Fprintf(&builder, "\n  %s", component)
+
+		if component.IsReady() {
+			continue
+		}
+
+		for _, event := range component.Events {
+			fmt.Fprintf(&builder, "\n    %s", event)
+		}
+	}
+
+	return builder.String()
+}
+
+// InspectReadiness maps each enabled component of the ClusterPolicy to its DaemonSets and reports their
+// desired, ready, updated and available pod counts, the failing pods and the last events.
+// The DaemonSets are looked up in the namespace from the ClusterPolicy status, or NvidiaGPUNamespace.
+func InspectReadiness(apiClient *clients.Settings, clusterPolicyName string) (*ReadinessReport, error) {
+	builder, err := Pull(apiClient, clusterPolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReadinessReport{
+		ClusterPolicyName: clusterPolicyName,
+		State:             builder.Object.Status.State,
+		Namespace:         builder.Object.Status.Namespace,
+	}
+
+	if report.Namespace == "" {
+		report.Namespace = NvidiaGPUNamespace
+	}
+
+	glog.V(100).Infof("Inspecting readiness of ClusterPolicy %s components in namespace %s",
+		clusterPolicyName, report.Namespace)
+
+	daemonSets, err := apiClient.DaemonSets(report.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DaemonSets in namespace %s: %w", report.Namespace, err)
+	}
+
+	pods, err := apiClient.Pods(report.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", report.Namespace, err)
+	}
+
+	events, err := apiClient.Events(report.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events in namespace %s: %w", report.Namespace, err)
+	}
+
+	for _, component := range Components {
+		if !component.Enabled(&builder.Object.Spec) {
+			continue
+		}
+
+		report.Components = append(report.Components,
+			inspectComponent(component, daemonSets.Items, pods.Items, events.Items))
+	}
+
+	return report, nil
+}
+
+// WrapNotReadyError annotates err, returned while waiting for the ClusterPolicy to be ready, with the component
+// blocking the readiness and the report of all the components. err is returned as is if the inspection fails.
+func WrapNotReadyError(apiClient *clients.Settings, clusterPolicyName string, err error) error {
+	report, inspectErr := InspectReadiness(apiClient, clusterPolicyName)
+	if inspectErr != nil {
+		glog.V(100).Infof("Failed to inspect ClusterPolicy %s readiness: %v", clusterPolicyName, inspectErr)
+
+		return err
+	}
+
+	blocking := report.BlockingComponent()
+	if blocking == nil {
+		return fmt.Errorf("ClusterPolicy %s is %s with all components ready: %w\n%s",
+			clusterPolicyName, report.State, err, report)
+	}
+
+	return fmt.Errorf("ClusterPolicy %s is %s, blocked by component %s: %w\n%s",
+		clusterPolicyName, report.State, blocking.Name, err, report)
+}
+
+// inspectComponent aggregates the status of the component DaemonSets and their pods.
+func inspectComponent(component Component, daemonSets []appsv1.DaemonSet, pods []corev1.Pod,
+	events []corev1.Event) ComponentStatus {
+	status := ComponentStatus{Name: component.Name}
+	involvedObjects := make(map[string]bool)
+
+	for _, daemonSet := range daemonSets {
+		if !component.Matches(daemonSet.Name) {
+			continue
+		}
+
+		status.DaemonSets = append(status.DaemonSets, daemonSet.Name)
+		status.Desired += daemonSet.Status.DesiredNumberScheduled
+		status.Ready += daemonSet.Status.NumberReady
+		status.Updated += daemonSet.Status.UpdatedNumberScheduled
+		status.Available += daemonSet.Status.NumberAvailable
+		involvedObjects["DaemonSet/"+daemonSet.Name] = true
+
+		for _, pod := range pods {
+			if !isOwnedByDaemonSet(&pod, daemonSet.Name) {
+				continue
+			}
+
+			if failingPod, failing := checkPod(&pod); failing {
+				status.FailingPods = append(status.FailingPods, failingPod)
+				involvedObjects["Pod/"+pod.Name] = true
+			}
+		}
+	}
+
+	status.Events = componentEvents(events, involvedObjects)
+
+	return status
+}
+
+// isOwnedByDaemonSet returns true if the pod is controlled by the named DaemonSet.
+func isOwnedByDaemonSet(pod *corev1.Pod, daemonSetName string) bool {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" && owner.Name == daemonSetName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPod returns the pod as a FailingPod if it is not running with all its containers ready.
+func checkPod(pod *corev1.Pod) (FailingPod, bool) {
+	failingPod := FailingPod{Name: pod.Name, NodeName: pod.Spec.NodeName, Phase: pod.Status.Phase}
+
+	containerStatuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+		pod.Status.ContainerStatuses...)
+
+	for _, containerStatus := range containerStatuses {
+		switch {
+		case containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason != "PodInitializing":
+			failingPod.Reason = fmt.Sprintf("container %s %s", containerStatus.Name, containerStatus.State.Waiting.Reason)
+		case containerStatus.State.Terminated != nil && containerStatus.State.Terminated.ExitCode != 0:
+			failingPod.Reason = fmt.Sprintf("container %s %s", containerStatus.Name,
+				containerStatus.State.Terminated.Reason)
+		default:
+			continue
+		}
+
+		return failingPod, true
+	}
+
+	if pod.Status.Phase != corev1.PodRunning {
+		return failingPod, true
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+			failingPod.Reason = "not ready"
+
+			return failingPod, true
+		}
+	}
+
+	return failingPod, false
+}
+
+// componentEvents returns the last ComponentEventsLimit events of the involved objects, formatted as
+// "Kind/name: reason: message", oldest first.
+func componentEvents(events []corev1.Event, involvedObjects map[string]bool) []string {
+	var matching []corev1.Event
+
+	for _, event := range events {
+		if involvedObjects[event.InvolvedObject.Kind+"/"+event.InvolvedObject.Name] {
+			matching = append(matching, event)
+		}
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return eventTime(&matching[i]).Before(eventTime(&matching[j]))
+	})
+
+	if len(matching) > ComponentEventsLimit {
+		matching = matching[len(matching)-ComponentEventsLimit:]
+	}
+
+	var formatted []string
+
+	for _, event := range matching {
+		formatted = append(formatted, fmt.Sprintf("%s/%s: %s: %s", event.InvolvedObject.Kind,
+			event.InvolvedObject.Name, event.Reason, event.Message))
+	}
+
+	return formatted
+}
+
+// eventTime returns the last time the event was seen.
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
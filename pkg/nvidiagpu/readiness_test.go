@@ -0,0 +1,144 @@
+package nvidiagpu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newOperandDaemonSet(name string, desired, ready int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: NvidiaGPUNamespace},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: desired,
+			NumberReady:            ready,
+			UpdatedNumberScheduled: desired,
+			NumberAvailable:        ready,
+		},
+	}
+}
+
+func newOperandPod(name, daemonSetName string, phase corev1.PodPhase, waitingReason string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       NvidiaGPUNamespace,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: daemonSetName}},
+		},
+		Spec:   corev1.PodSpec{NodeName: "worker-gpu-0"},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "main",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason}},
+		}}
+	}
+
+	return pod
+}
+
+func newOperandEvent(name, kind, objectName, reason string, age time.Duration) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: NvidiaGPUNamespace},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: objectName},
+		Reason:         reason,
+		Message:        reason + " message",
+		LastTimestamp:  metav1.NewTime(time.Now().Add(-age)),
+	}
+}
+
+func TestInspectReadiness(t *testing.T) {
+	objects := []runtime.Object{
+		newOperandDaemonSet("nvidia-driver-daemonset-416.94.202405231234-0", 2, 2),
+		newOperandDaemonSet("nvidia-container-toolkit-daemonset", 2, 2),
+		newOperandDaemonSet("nvidia-operator-validator", 2, 2),
+		newOperandDaemonSet("nvidia-device-plugin-daemonset", 2, 1),
+		newOperandDaemonSet("gpu-feature-discovery", 2, 2),
+		newOperandDaemonSet("nvidia-dcgm", 2, 2),
+		newOperandDaemonSet("nvidia-dcgm-exporter", 2, 2),
+		newOperandDaemonSet("nvidia-mig-manager", 0, 0),
+		newOperandPod("nvidia-device-plugin-daemonset-abcde", "nvidia-device-plugin-daemonset",
+			corev1.PodPending, "ImagePullBackOff"),
+		newOperandPod("nvidia-device-plugin-daemonset-fghij", "nvidia-device-plugin-daemonset",
+			corev1.PodRunning, ""),
+		newOperandEvent("event-1", "Pod", "nvidia-device-plugin-daemonset-abcde", "Failed", time.Minute),
+		newOperandEvent("event-2", "Pod", "nvidia-device-plugin-daemonset-abcde", "BackOff", time.Second),
+		newOperandEvent("event-3", "Pod", "nvidia-device-plugin-daemonset-fghij", "Started", time.Second),
+	}
+
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.ClusterPolicy}, objects...)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	report, err := InspectReadiness(apiClient, ClusterPolicyName)
+	if err != nil {
+		t.Fatalf("failed to inspect readiness: %v", err)
+	}
+
+	if len(report.Components) != 9 {
+		t.Fatalf("expected the 9 enabled components, got %d", len(report.Components))
+	}
+
+	notReady := report.NotReady()
+	if len(notReady) != 2 || notReady[0].Name != "device-plugin" || notReady[1].Name != "node-status-exporter" {
+		t.Fatalf("expected device-plugin and node-status-exporter not to be ready, got %v", notReady)
+	}
+
+	devicePlugin := notReady[0]
+	if devicePlugin.Desired != 2 || devicePlugin.Ready != 1 || len(devicePlugin.FailingPods) != 1 {
+		t.Errorf("unexpected device-plugin status %s", devicePlugin)
+	}
+
+	expectedEvents := []string{
+		"Pod/nvidia-device-plugin-daemonset-abcde: Failed: Failed message",
+		"Pod/nvidia-device-plugin-daemonset-abcde: BackOff: BackOff message",
+	}
+	if strings.Join(devicePlugin.Events, "\n") != strings.Join(expectedEvents, "\n") {
+		t.Errorf("expected events %v, got %v", expectedEvents, devicePlugin.Events)
+	}
+
+	expectedSummary := "device-plugin: 1/2 ready, 2/2 updated, 1/2 available, failing pods: " +
+		"nvidia-device-plugin-daemonset-abcde on worker-gpu-0: container main ImagePullBackOff"
+	if devicePlugin.String() != expectedSummary {
+		t.Errorf("expected summary %q, got %q", expectedSummary, devicePlugin.String())
+	}
+
+	for _, component := range report.Components {
+		if component.Name == "driver" && (!component.IsReady() || component.Desired != 2) {
+			t.Errorf("expected the OpenShift driver daemonset to be matched by prefix, got %s", component)
+		}
+
+		if component.Name == "dcgm" && len(component.DaemonSets) != 1 {
+			t.Errorf("expected dcgm not to match the dcgm-exporter daemonset, got %v", component.DaemonSets)
+		}
+	}
+
+	err = WrapNotReadyError(apiClient, ClusterPolicyName, errors.New("context deadline exceeded"))
+	if err == nil || !strings.Contains(err.Error(), "blocked by component device-plugin: context deadline exceeded") {
+		t.Errorf("expected the wait error to name the blocking component, got %v", err)
+	}
+}
+
+func TestWrapNotReadyErrorInspectionFailure(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients(nil)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	waitErr := errors.New("context deadline exceeded")
+
+	if err := WrapNotReadyError(apiClient, ClusterPolicyName, waitErr); !errors.Is(err, waitErr) ||
+		err.Error() != waitErr.Error() {
+		t.Errorf("expected the wait error to be returned as is, got %v", err)
+	}
+}
@@ -0,0 +1,359 @@
+package nvidiagpu
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nodes"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SnapshotNodeLabels are the node labels tests set to drive the GPU operator, recorded and restored by Snapshot.
+// Labels managed by the operator itself, e.g. nvidia.com/mig.config.state, are not restored.
+var SnapshotNodeLabels = []string{
+	"nvidia.com/mig.config",
+	"nvidia.com/mig.strategy",
+	"nvidia.com/device-plugin.config",
+	"nvidia.com/gpu.workload.config",
+}
+
+// Snapshot records a ClusterPolicy, the SnapshotNodeLabels of the GPU nodes and the ConfigMaps the policy uses,
+// so that a suite can put the cluster back in that state whatever its specs changed.
+type Snapshot struct {
+	// ClusterPolicy as it was when the snapshot was taken.
+	ClusterPolicy *nvidiagpuv1.ClusterPolicy
+	// NodeLabels maps node names to the SnapshotNodeLabels they had, missing keys were not set.
+	NodeLabels map[string]map[string]string
+	// ConfigMaps maps ConfigMap names to their content, nil if the ConfigMap did not exist.
+	ConfigMaps map[string]*corev1.ConfigMap
+
+	apiClient          *clients.Settings
+	nodeSelector       map[string]string
+	configMapNamespace string
+}
+
+// TakeSnapshot records the named ClusterPolicy, the SnapshotNodeLabels of the nodes matching nodeSelector
+// and, in configMapNamespace, the ConfigMaps referenced by the policy together with the extra configMapNames.
+func TakeSnapshot(apiClient *clients.Settings, clusterPolicyName, configMapNamespace string,
+	nodeSelector map[string]string, configMapNames ...string) (*Snapshot, error) {
+	glog.V(100).Infof("Taking snapshot of ClusterPolicy %s, nodes %v and configmaps in namespace %s",
+		clusterPolicyName, nodeSelector, configMapNamespace)
+
+	if apiClient == nil {
+		return nil, fmt.Errorf("snapshot cannot have nil apiClient")
+	}
+
+	if configMapNamespace == "" {
+		return nil, fmt.Errorf("snapshot configmap namespace cannot be empty")
+	}
+
+	builder, err := Pull(apiClient, clusterPolicyName)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		ClusterPolicy:      builder.Object.DeepCopy(),
+		NodeLabels:         make(map[string]map[string]string),
+		ConfigMaps:         make(map[string]*corev1.ConfigMap),
+		apiClient:          apiClient,
+		nodeSelector:       nodeSelector,
+		configMapNamespace: configMapNamespace,
+	}
+
+	nodeBuilders, err := nodes.List(apiClient, metav1.ListOptions{LabelSelector: labels.Set(nodeSelector).String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes matching %v: %w", nodeSelector, err)
+	}
+
+	for _, nodeBuilder := range nodeBuilders {
+		snapshot.NodeLabels[nodeBuilder.Object.Name] = filterSnapshotNodeLabels(nodeBuilder.Object.Labels)
+	}
+
+	for _, name := range append(ReferencedConfigMaps(snapshot.ClusterPolicy), configMapNames...) {
+		configMap, err := apiClient.ConfigMaps(configMapNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+
+		switch {
+		case k8serrors.IsNotFound(err):
+			snapshot.ConfigMaps[name] = nil
+		case err != nil:
+			return nil, fmt.Errorf("failed to get configmap %s: %w", name, err)
+		default:
+			snapshot.ConfigMaps[name] = configMap
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Restore puts the recorded ConfigMaps, ClusterPolicy and node labels back, deleting the ConfigMaps referenced by
+// the current policy that did not exist when the snapshot was taken, and returns true if anything had to be
+// restored. Every step is attempted, the errors are joined. A ClusterPolicy deleted in the meantime is recreated.
+// Restore does not wait for the ClusterPolicy to be ready, see wait.ClusterPolicyRestored.
+func (snapshot *Snapshot) Restore() (bool, error) {
+	glog.V(100).Infof("Restoring snapshot of ClusterPolicy %s", snapshot.ClusterPolicy.Name)
+
+	var errs []error
+
+	currentPolicy := &nvidiagpuv1.ClusterPolicy{}
+	err := snapshot.apiClient.Get(context.TODO(), goclient.ObjectKey{Name: snapshot.ClusterPolicy.Name}, currentPolicy)
+
+	switch {
+	case k8serrors.IsNotFound(err):
+		currentPolicy = nil
+	case err != nil:
+		return false, fmt.Errorf("failed to get ClusterPolicy %s: %w", snapshot.ClusterPolicy.Name, err)
+	}
+
+	configMapsRestored, err := snapshot.restoreConfigMaps(currentPolicy)
+	errs = append(errs, err)
+
+	policyRestored, err := snapshot.restoreClusterPolicy(currentPolicy)
+	errs = append(errs, err)
+
+	labelsRestored, err := snapshot.restoreNodeLabels()
+	errs = append(errs, err)
+
+	restored := configMapsRestored || policyRestored || labelsRestored
+	if !restored {
+		glog.V(100).Infof("Nothing to restore for ClusterPolicy %s", snapshot.ClusterPolicy.Name)
+	}
+
+	return restored, errors.Join(errs...)
+}
+
+// ReferencedConfigMaps returns the names of the ConfigMaps the ClusterPolicy uses for its operands.
+func ReferencedConfigMaps(clusterPolicy *nvidiagpuv1.ClusterPolicy) []string {
+	spec := clusterPolicy.Spec
+	names := make(map[string]bool)
+
+	if spec.DevicePlugin.Config != nil {
+		names[spec.DevicePlugin.Config.Name] = true
+	}
+
+	if spec.MIGManager.Config != nil {
+		names[spec.MIGManager.Config.Name] = true
+	}
+
+	if spec.MIGManager.GPUClientsConfig != nil {
+		names[spec.MIGManager.GPUClientsConfig.Name] = true
+	}
+
+	if spec.DCGMExporter.MetricsConfig != nil {
+		names[spec.DCGMExporter.MetricsConfig.Name] = true
+	}
+
+	if spec.Driver.LicensingConfig != nil {
+		names[spec.Driver.LicensingConfig.ConfigMapName] = true
+	}
+
+	if spec.Driver.RepoConfig != nil {
+		names[spec.Driver.RepoConfig.ConfigMapName] = true
+	}
+
+	if spec.Driver.CertConfig != nil {
+		names[spec.Driver.CertConfig.Name] = true
+	}
+
+	if spec.Driver.KernelModuleConfig != nil {
+		names[spec.Driver.KernelModuleConfig.Name] = true
+	}
+
+	delete(names, "")
+
+	var result []string
+
+	for name := range names {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// restoreConfigMaps restores the recorded ConfigMaps and deletes the ones the current policy references
+// that did not exist when the snapshot was taken.
+func (snapshot *Snapshot) restoreConfigMaps(currentPolicy *nvidiagpuv1.ClusterPolicy) (bool, error) {
+	recorded := make(map[string]*corev1.ConfigMap, len(snapshot.ConfigMaps))
+	for name, configMap := range snapshot.ConfigMaps {
+		recorded[name] = configMap
+	}
+
+	if currentPolicy != nil {
+		for _, name := range ReferencedConfigMaps(currentPolicy) {
+			if _, ok := recorded[name]; !ok {
+				recorded[name] = nil
+			}
+		}
+	}
+
+	var (
+		errs     []error
+		restored bool
+	)
+
+	configMapClient := snapshot.apiClient.ConfigMaps(snapshot.configMapNamespace)
+
+	for name, configMap := range recorded {
+		current, err := configMapClient.Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to get configmap %s: %w", name, err))
+
+			continue
+		}
+
+		exists := err == nil
+
+		switch {
+		case configMap == nil && exists:
+			glog.V(100).Infof("Deleting configmap %s created after the snapshot", name)
+
+			err = configMapClient.Delete(context.TODO(), name, metav1.DeleteOptions{})
+		case configMap != nil && !exists:
+			glog.V(100).Infof("Recreating configmap %s deleted after the snapshot", name)
+
+			restoredConfigMap := configMap.DeepCopy()
+			restoredConfigMap.ResourceVersion = ""
+			restoredConfigMap.UID = ""
+			_, err = configMapClient.Create(context.TODO(), restoredConfigMap, metav1.CreateOptions{})
+		case configMap != nil && !configMapDataEqual(configMap, current):
+			glog.V(100).Infof("Restoring content of configmap %s", name)
+
+			current.Data = configMap.Data
+			current.BinaryData = configMap.BinaryData
+			_, err = configMapClient.Update(context.TODO(), current, metav1.UpdateOptions{})
+		default:
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore configmap %s: %w", name, err))
+
+			continue
+		}
+
+		restored = true
+	}
+
+	return restored, errors.Join(errs...)
+}
+
+// restoreClusterPolicy restores the recorded spec, labels and annotations of the ClusterPolicy.
+func (snapshot *Snapshot) restoreClusterPolicy(currentPolicy *nvidiagpuv1.ClusterPolicy) (bool, error) {
+	if currentPolicy == nil {
+		glog.V(100).Infof("Recreating ClusterPolicy %s deleted after the snapshot", snapshot.ClusterPolicy.Name)
+
+		restoredPolicy := snapshot.ClusterPolicy.DeepCopy()
+		restoredPolicy.ResourceVersion = ""
+		restoredPolicy.UID = ""
+		restoredPolicy.Status = nvidiagpuv1.ClusterPolicyStatus{}
+
+		if err := snapshot.apiClient.Create(context.TODO(), restoredPolicy); err != nil {
+			return false, fmt.Errorf("failed to recreate ClusterPolicy %s: %w", restoredPolicy.Name, err)
+		}
+
+		return true, nil
+	}
+
+	changes, err := DiffClusterPolicies(currentPolicy, snapshot.ClusterPolicy)
+	if err != nil {
+		return false, err
+	}
+
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	glog.V(100).Infof("Restoring ClusterPolicy %s fields: %v", currentPolicy.Name, changes)
+
+	currentPolicy.Spec = *snapshot.ClusterPolicy.Spec.DeepCopy()
+	currentPolicy.Labels = snapshot.ClusterPolicy.Labels
+	currentPolicy.Annotations = snapshot.ClusterPolicy.Annotations
+
+	if err := snapshot.apiClient.Update(context.TODO(), currentPolicy); err != nil {
+		return false, fmt.Errorf("failed to restore ClusterPolicy %s: %w", currentPolicy.Name, err)
+	}
+
+	return true, nil
+}
+
+// restoreNodeLabels restores the recorded SnapshotNodeLabels of the nodes that still exist.
+func (snapshot *Snapshot) restoreNodeLabels() (bool, error) {
+	var (
+		errs     []error
+		restored bool
+	)
+
+	for nodeName, recordedLabels := range snapshot.NodeLabels {
+		nodeBuilder, err := nodes.Pull(snapshot.apiClient, nodeName)
+		if err != nil {
+			glog.V(100).Infof("Skipping labels of node %s: %v", nodeName, err)
+
+			continue
+		}
+
+		changed := false
+
+		for _, key := range SnapshotNodeLabels {
+			value, wasSet := recordedLabels[key]
+			currentValue, isSet := nodeBuilder.Definition.Labels[key]
+
+			switch {
+			case wasSet && (!isSet || currentValue != value):
+				nodeBuilder.WithLabel(key, value)
+			case !wasSet && isSet:
+				nodeBuilder.RemoveLabel(key, currentValue)
+			default:
+				continue
+			}
+
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+
+		glog.V(100).Infof("Restoring labels %v of node %s", recordedLabels, nodeName)
+
+		if _, err := nodeBuilder.Update(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore labels of node %s: %w", nodeName, err))
+
+			continue
+		}
+
+		restored = true
+	}
+
+	return restored, errors.Join(errs...)
+}
+
+// filterSnapshotNodeLabels returns the SnapshotNodeLabels present in nodeLabels.
+func filterSnapshotNodeLabels(nodeLabels map[string]string) map[string]string {
+	filtered := make(map[string]string)
+
+	for _, key := range SnapshotNodeLabels {
+		if value, ok := nodeLabels[key]; ok {
+			filtered[key] = value
+		}
+	}
+
+	return filtered
+}
+
+// configMapDataEqual returns true if both ConfigMaps hold the same data.
+func configMapDataEqual(configMap, other *corev1.ConfigMap) bool {
+	return maps.Equal(configMap.Data, other.Data) && maps.EqualFunc(configMap.BinaryData, other.BinaryData, bytes.Equal)
+}
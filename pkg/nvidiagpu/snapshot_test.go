@@ -0,0 +1,258 @@
+package nvidiagpu
+
+import (
+	"context"
+	"testing"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const snapshotTestNamespace = "nvidia-gpu-operator"
+
+func newSnapshotTestClients(t *testing.T) *clients.Settings {
+	t.Helper()
+
+	licensingConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "licensing-config", Namespace: snapshotTestNamespace},
+		Data:       map[string]string{"gridd.conf": "FeatureType=1"},
+	}
+
+	apiClient, err := testfixtures.NewTestClients(
+		[]string{testfixtures.ClusterPolicy, testfixtures.Nodes}, licensingConfig)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	builder, err := Pull(apiClient, ClusterPolicyName)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterpolicy: %v", err)
+	}
+
+	builder.Object.Spec.Driver.LicensingConfig.ConfigMapName = "licensing-config"
+	builder.Object.Status.State = nvidiagpuv1.Ready
+
+	if err := apiClient.Update(context.TODO(), builder.Object); err != nil {
+		t.Fatalf("failed to update the clusterpolicy: %v", err)
+	}
+
+	node, err := apiClient.CoreV1Interface.Nodes().Get(context.TODO(), "worker-gpu-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get node: %v", err)
+	}
+
+	node.Labels["nvidia.com/mig.config"] = "all-disabled"
+
+	if _, err := apiClient.CoreV1Interface.Nodes().Update(context.TODO(), node, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update node: %v", err)
+	}
+
+	return apiClient
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	apiClient := newSnapshotTestClients(t)
+
+	snapshot, err := TakeSnapshot(apiClient, ClusterPolicyName, snapshotTestNamespace,
+		map[string]string{"feature.node.kubernetes.io/pci-10de.present": "true"}, "extra-config")
+	if err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+
+	if len(snapshot.NodeLabels) != 2 {
+		t.Errorf("expected the labels of the 2 GPU nodes, got %v", snapshot.NodeLabels)
+	}
+
+	if snapshot.NodeLabels["worker-gpu-0"]["nvidia.com/mig.config"] != "all-disabled" ||
+		len(snapshot.NodeLabels["worker-gpu-1"]) != 0 {
+		t.Errorf("unexpected node labels %v", snapshot.NodeLabels)
+	}
+
+	if configMap, ok := snapshot.ConfigMaps["licensing-config"]; !ok || configMap == nil {
+		t.Errorf("expected the referenced licensing configmap to be recorded, got %v", snapshot.ConfigMaps)
+	}
+
+	if configMap, ok := snapshot.ConfigMaps["extra-config"]; !ok || configMap != nil {
+		t.Errorf("expected the missing extra configmap to be recorded as absent, got %v", snapshot.ConfigMaps)
+	}
+
+	if _, err := TakeSnapshot(apiClient, "missing-policy", snapshotTestNamespace, nil); err == nil {
+		t.Error("expected an error for a missing clusterpolicy")
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	apiClient := newSnapshotTestClients(t)
+	nodeSelector := map[string]string{"feature.node.kubernetes.io/pci-10de.present": "true"}
+
+	snapshot, err := TakeSnapshot(apiClient, ClusterPolicyName, snapshotTestNamespace, nodeSelector)
+	if err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+
+	// Nothing changed yet.
+	if restored, err := snapshot.Restore(); err != nil || restored {
+		t.Fatalf("expected nothing to restore for an unchanged snapshot, got %v, %v", restored, err)
+	}
+
+	builder, err := Pull(apiClient, ClusterPolicyName)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterpolicy: %v", err)
+	}
+
+	if _, err := builder.WithDevicePluginEnabled(false).
+		WithDevicePluginConfig("device-plugin-config", "mps").
+		Update(false); err != nil {
+		t.Fatalf("failed to update the clusterpolicy: %v", err)
+	}
+
+	configMapClient := apiClient.ConfigMaps(snapshotTestNamespace)
+
+	if err := configMapClient.Delete(context.TODO(), "licensing-config", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete configmap: %v", err)
+	}
+
+	if _, err := configMapClient.Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "device-plugin-config", Namespace: snapshotTestNamespace},
+		Data:       map[string]string{"mps": "sharing: {}"},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create configmap: %v", err)
+	}
+
+	for nodeName, value := range map[string]string{"worker-gpu-0": "all-1g.5gb", "worker-gpu-1": "all-2g.10gb"} {
+		node, err := apiClient.CoreV1Interface.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get node: %v", err)
+		}
+
+		node.Labels["nvidia.com/mig.config"] = value
+
+		if _, err := apiClient.CoreV1Interface.Nodes().Update(context.TODO(), node, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update node: %v", err)
+		}
+	}
+
+	if restored, err := snapshot.Restore(); err != nil || !restored {
+		t.Fatalf("failed to restore snapshot: %v, %v", restored, err)
+	}
+
+	restored, err := builder.Get()
+	if err != nil {
+		t.Fatalf("failed to get the restored clusterpolicy: %v", err)
+	}
+
+	changes, err := DiffClusterPolicies(snapshot.ClusterPolicy, restored)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected the clusterpolicy to be restored, got changes %v, %v", changes, err)
+	}
+
+	configMap, err := configMapClient.Get(context.TODO(), "licensing-config", metav1.GetOptions{})
+	if err != nil || configMap.Data["gridd.conf"] != "FeatureType=1" {
+		t.Errorf("expected the licensing configmap to be recreated, got %v, %v", configMap, err)
+	}
+
+	if _, err := configMapClient.Get(context.TODO(), "device-plugin-config", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the configmap created after the snapshot to be deleted, got %v", err)
+	}
+
+	for nodeName, expectedValue := range map[string]string{"worker-gpu-0": "all-disabled", "worker-gpu-1": ""} {
+		node, err := apiClient.CoreV1Interface.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get node: %v", err)
+		}
+
+		if value, ok := node.Labels["nvidia.com/mig.config"]; value != expectedValue || ok != (expectedValue != "") {
+			t.Errorf("expected node %s mig.config label %q, got %q", nodeName, expectedValue, value)
+		}
+	}
+}
+
+func TestSnapshotRestoreMIGManagerConfig(t *testing.T) {
+	apiClient := newSnapshotTestClients(t)
+	configMapClient := apiClient.ConfigMaps(snapshotTestNamespace)
+
+	if _, err := configMapClient.Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom-mig-parted-config", Namespace: snapshotTestNamespace},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create configmap: %v", err)
+	}
+
+	builder, err := Pull(apiClient, ClusterPolicyName)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterpolicy: %v", err)
+	}
+
+	if _, err := builder.WithMIGManagerConfig("custom-mig-parted-config", "").Update(false); err != nil {
+		t.Fatalf("failed to update the clusterpolicy: %v", err)
+	}
+
+	snapshot, err := TakeSnapshot(apiClient, ClusterPolicyName, snapshotTestNamespace, nil)
+	if err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+
+	// Like the heterogeneous MIG test, switch the MIG manager to a configmap created after the snapshot.
+	if _, err := configMapClient.Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "heterogeneous-mig-parted-config", Namespace: snapshotTestNamespace},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("failed to create configmap: %v", err)
+	}
+
+	if _, err := builder.WithMIGManagerConfig("heterogeneous-mig-parted-config", "all-disabled").
+		Update(false); err != nil {
+		t.Fatalf("failed to update the clusterpolicy: %v", err)
+	}
+
+	if restored, err := snapshot.Restore(); err != nil || !restored {
+		t.Fatalf("failed to restore snapshot: %v, %v", restored, err)
+	}
+
+	restored, err := builder.Get()
+	if err != nil {
+		t.Fatalf("failed to get the restored clusterpolicy: %v", err)
+	}
+
+	if config := restored.Spec.MIGManager.Config; config == nil || config.Name != "custom-mig-parted-config" ||
+		config.Default != "" {
+		t.Errorf("expected the original MIG manager config to be restored, got %+v", config)
+	}
+
+	if _, err := configMapClient.Get(context.TODO(), "custom-mig-parted-config", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the original mig-parted configmap to be kept, got %v", err)
+	}
+
+	if _, err := configMapClient.Get(context.TODO(), "heterogeneous-mig-parted-config",
+		metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the custom mig-parted configmap to be deleted, got %v", err)
+	}
+}
+
+func TestSnapshotRestoreDeletedClusterPolicy(t *testing.T) {
+	apiClient := newSnapshotTestClients(t)
+
+	snapshot, err := TakeSnapshot(apiClient, ClusterPolicyName, snapshotTestNamespace, nil)
+	if err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+
+	builder, err := Pull(apiClient, ClusterPolicyName)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterpolicy: %v", err)
+	}
+
+	if _, err := builder.Delete(); err != nil {
+		t.Fatalf("failed to delete the clusterpolicy: %v", err)
+	}
+
+	if restored, err := snapshot.Restore(); err != nil || !restored {
+		t.Errorf("expected the deleted clusterpolicy to be restored, got %v, %v", restored, err)
+	}
+
+	if !builder.Exists() {
+		t.Error("expected the deleted clusterpolicy to be recreated")
+	}
+}
@@ -0,0 +1,44 @@
+package nvidiagpu
+
+import (
+	"context"
+	"fmt"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewOperatorUninstaller returns an OperatorUninstaller removing the GPU Operator, installed through a
+// Subscription or an OLM v1 ClusterExtension, its ClusterPolicy, the nvidia.com CustomResourceDefinitions and the
+// nvidia.com node labels.
+func NewOperatorUninstaller(apiClient *clients.Settings) *olm.OperatorUninstaller {
+	return olm.NewOperatorUninstaller(apiClient, Package, SubscriptionNamespace).
+		WithSubscriptionName(SubscriptionName).
+		WithOperatorGroupName(OperatorGroupName).
+		WithClusterExtensionName(ClusterExtensionName).
+		WithCustomResources(&nvidiagpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: ClusterPolicyName}}).
+		WithCRDGroups("nvidia.com").
+		WithNamePrefixes(OperatorDeployment).
+		WithNodeLabelPrefixes("nvidia.com/")
+}
+
+// UninstallGPUOperator removes the GPU Operator with NewOperatorUninstaller and logs the uninstall report. The
+// bundleInstaller is the one the operator was deployed from a bundle with, nil otherwise. In strict mode, resources
+// left behind on the cluster are returned as an error. The uninstall stops early when ctx is done.
+func UninstallGPUOperator(ctx context.Context, apiClient *clients.Settings, bundleInstaller *olm.BundleInstaller,
+	strict bool) error {
+	report, err := NewOperatorUninstaller(apiClient).WithBundleInstaller(bundleInstaller).WithStrict(strict).
+		UninstallContext(ctx)
+	if report != nil {
+		glog.V(100).Infof("GPU Operator uninstall report: %s", report)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error uninstalling GPU Operator: %w", err)
+	}
+
+	return nil
+}
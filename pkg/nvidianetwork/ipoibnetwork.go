@@ -76,6 +76,11 @@ func NewIPoIBNetworkBuilderFromObjectString(apiClient *clients.Settings, almExam
 
 // Get returns IPoIBNetwork object if found.
 func (builder *IPoIBNetworkBuilder) Get() (*nvidianetworkv1alpha1.IPoIBNetwork, error) {
+	return builder.GetContext(context.TODO())
+}
+
+// GetContext returns IPoIBNetwork object if found.
+func (builder *IPoIBNetworkBuilder) GetContext(ctx context.Context) (*nvidianetworkv1alpha1.IPoIBNetwork, error) {
 	if valid, err := builder.validate(); !valid {
 		return nil, err
 	}
@@ -84,7 +89,7 @@ func (builder *IPoIBNetworkBuilder) Get() (*nvidianetworkv1alpha1.IPoIBNetwork,
 		"Collecting IPoIBNetwork object %s", builder.Definition.Name)
 
 	IPoIBNetwork := &nvidianetworkv1alpha1.IPoIBNetwork{}
-	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+	err := builder.apiClient.Get(ctx, goclient.ObjectKey{
 		Name: builder.Definition.Name,
 	}, IPoIBNetwork)
 
@@ -100,6 +105,12 @@ func (builder *IPoIBNetworkBuilder) Get() (*nvidianetworkv1alpha1.IPoIBNetwork,
 
 // PullIPoIBNetwork loads an existing IPoIBNetwork into IPoIBNetworkBuilder  struct.
 func PullIPoIBNetwork(apiClient *clients.Settings, name string) (*IPoIBNetworkBuilder, error) {
+	return PullIPoIBNetworkContext(context.TODO(), apiClient, name)
+}
+
+// PullIPoIBNetworkContext loads an existing IPoIBNetwork into IPoIBNetworkBuilder  struct.
+func PullIPoIBNetworkContext(ctx context.Context, apiClient *clients.Settings,
+	name string) (*IPoIBNetworkBuilder, error) {
 	glog.V(100).Infof("Pulling existing IPoIBNetwork name: %s", name)
 
 	builder := IPoIBNetworkBuilder{
@@ -118,7 +129,7 @@ func PullIPoIBNetwork(apiClient *clients.Settings, name string) (*IPoIBNetworkBu
 		return nil, errors.New(builder.errorMsg)
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("IPoIBNetwork object %s doesn't exist", name)
 	}
 
@@ -129,6 +140,11 @@ func PullIPoIBNetwork(apiClient *clients.Settings, name string) (*IPoIBNetworkBu
 
 // Exists checks whether the given IPoIBNetwork exists.
 func (builder *IPoIBNetworkBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given IPoIBNetwork exists.
+func (builder *IPoIBNetworkBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -137,7 +153,7 @@ func (builder *IPoIBNetworkBuilder) Exists() bool {
 		"Checking if IPoIBNetwork %s exists", builder.Definition.Name)
 
 	var err error
-	builder.Object, err = builder.Get()
+	builder.Object, err = builder.GetContext(ctx)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to collect IPoIBNetwork object due to %s", err.Error())
@@ -148,17 +164,22 @@ func (builder *IPoIBNetworkBuilder) Exists() bool {
 
 // Delete removes a IPoIBNetwork.
 func (builder *IPoIBNetworkBuilder) Delete() (*IPoIBNetworkBuilder, error) {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a IPoIBNetwork.
+func (builder *IPoIBNetworkBuilder) DeleteContext(ctx context.Context) (*IPoIBNetworkBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Deleting IPoIBNetwork %s", builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return builder, fmt.Errorf("IPoIBNetwork cannot be deleted because it does not exist")
 	}
 
-	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+	err := builder.apiClient.Delete(ctx, builder.Definition)
 
 	if err != nil {
 		return builder, fmt.Errorf("cannot delete IPoIBNetwork: %w", err)
@@ -171,6 +192,11 @@ func (builder *IPoIBNetworkBuilder) Delete() (*IPoIBNetworkBuilder, error) {
 
 // Create makes a IPoIBNetwork in the cluster and stores the created object in struct.
 func (builder *IPoIBNetworkBuilder) Create() (*IPoIBNetworkBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a IPoIBNetwork in the cluster and stores the created object in struct.
+func (builder *IPoIBNetworkBuilder) CreateContext(ctx context.Context) (*IPoIBNetworkBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -178,8 +204,8 @@ func (builder *IPoIBNetworkBuilder) Create() (*IPoIBNetworkBuilder, error) {
 	glog.V(100).Infof("Creating the IPoIBNetwork %s", builder.Definition.Name)
 
 	var err error
-	if !builder.Exists() {
-		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+	if !builder.ExistsContext(ctx) {
+		err = builder.apiClient.Create(ctx, builder.Definition)
 
 		if err == nil {
 			builder.Object = builder.Definition
@@ -194,19 +220,24 @@ func (builder *IPoIBNetworkBuilder) Create() (*IPoIBNetworkBuilder, error) {
 
 // Update renovates the existing IPoIBNetwork object with the definition in builder.
 func (builder *IPoIBNetworkBuilder) Update(force bool) (*IPoIBNetworkBuilder, error) {
+	return builder.UpdateContext(context.TODO(), force)
+}
+
+// UpdateContext renovates the existing IPoIBNetwork object with the definition in builder.
+func (builder *IPoIBNetworkBuilder) UpdateContext(ctx context.Context, force bool) (*IPoIBNetworkBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Updating the IPoIBNetwork object named:  %s", builder.Definition.Name)
 
-	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	err := builder.apiClient.Update(ctx, builder.Definition)
 
 	if err != nil {
 		if force {
 			glog.V(100).Infof(msg.FailToUpdateNotification("IPoIBNetwork", builder.Definition.Name))
 
-			builder, err := builder.Delete()
+			builder, err := builder.DeleteContext(ctx)
 
 			if err != nil {
 				glog.V(100).Infof(
@@ -215,7 +246,7 @@ func (builder *IPoIBNetworkBuilder) Update(force bool) (*IPoIBNetworkBuilder, er
 				return nil, err
 			}
 
-			return builder.Create()
+			return builder.CreateContext(ctx)
 		}
 	}
 
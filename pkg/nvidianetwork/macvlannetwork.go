@@ -76,6 +76,11 @@ func NewMacvlanNetworkBuilderFromObjectString(apiClient *clients.Settings, almEx
 
 // Get returns MacvlanNetwork object if found.
 func (builder *MacvlanNetworkBuilder) Get() (*nvidianetworkv1alpha1.MacvlanNetwork, error) {
+	return builder.GetContext(context.TODO())
+}
+
+// GetContext returns MacvlanNetwork object if found.
+func (builder *MacvlanNetworkBuilder) GetContext(ctx context.Context) (*nvidianetworkv1alpha1.MacvlanNetwork, error) {
 	if valid, err := builder.validate(); !valid {
 		return nil, err
 	}
@@ -84,7 +89,7 @@ func (builder *MacvlanNetworkBuilder) Get() (*nvidianetworkv1alpha1.MacvlanNetwo
 		"Collecting MacvlanNetwork object %s", builder.Definition.Name)
 
 	MacvlanNetwork := &nvidianetworkv1alpha1.MacvlanNetwork{}
-	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+	err := builder.apiClient.Get(ctx, goclient.ObjectKey{
 		Name: builder.Definition.Name,
 	}, MacvlanNetwork)
 
@@ -100,6 +105,12 @@ func (builder *MacvlanNetworkBuilder) Get() (*nvidianetworkv1alpha1.MacvlanNetwo
 
 // PullMacvlanNetwork loads an existing MacvlanNetwork into MacvlanNetworkBuilder  struct.
 func PullMacvlanNetwork(apiClient *clients.Settings, name string) (*MacvlanNetworkBuilder, error) {
+	return PullMacvlanNetworkContext(context.TODO(), apiClient, name)
+}
+
+// PullMacvlanNetworkContext loads an existing MacvlanNetwork into MacvlanNetworkBuilder  struct.
+func PullMacvlanNetworkContext(ctx context.Context, apiClient *clients.Settings,
+	name string) (*MacvlanNetworkBuilder, error) {
 	glog.V(100).Infof("Pulling existing MacvlanNetwork name: %s", name)
 
 	builder := MacvlanNetworkBuilder{
@@ -118,7 +129,7 @@ func PullMacvlanNetwork(apiClient *clients.Settings, name string) (*MacvlanNetwo
 		return nil, errors.New(builder.errorMsg)
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("MacvlanNetwork object %s doesn't exist", name)
 	}
 
@@ -129,6 +140,11 @@ func PullMacvlanNetwork(apiClient *clients.Settings, name string) (*MacvlanNetwo
 
 // Exists checks whether the given MacvlanNetwork exists.
 func (builder *MacvlanNetworkBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given MacvlanNetwork exists.
+func (builder *MacvlanNetworkBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -137,7 +153,7 @@ func (builder *MacvlanNetworkBuilder) Exists() bool {
 		"Checking if MacvlanNetwork %s exists", builder.Definition.Name)
 
 	var err error
-	builder.Object, err = builder.Get()
+	builder.Object, err = builder.GetContext(ctx)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to collect MacvlanNetwork object due to %s", err.Error())
@@ -148,17 +164,22 @@ func (builder *MacvlanNetworkBuilder) Exists() bool {
 
 // Delete removes a MacvlanNetwork.
 func (builder *MacvlanNetworkBuilder) Delete() (*MacvlanNetworkBuilder, error) {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a MacvlanNetwork.
+func (builder *MacvlanNetworkBuilder) DeleteContext(ctx context.Context) (*MacvlanNetworkBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Deleting MacvlanNetwork %s", builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return builder, errors.New("MacvlanNetwork cannot be deleted because it does not exist")
 	}
 
-	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+	err := builder.apiClient.Delete(ctx, builder.Definition)
 
 	if err != nil {
 		return builder, fmt.Errorf("cannot delete MacvlanNetwork: %w", err)
@@ -171,6 +192,11 @@ func (builder *MacvlanNetworkBuilder) Delete() (*MacvlanNetworkBuilder, error) {
 
 // Create makes a MacvlanNetwork in the cluster and stores the created object in struct.
 func (builder *MacvlanNetworkBuilder) Create() (*MacvlanNetworkBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a MacvlanNetwork in the cluster and stores the created object in struct.
+func (builder *MacvlanNetworkBuilder) CreateContext(ctx context.Context) (*MacvlanNetworkBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -178,8 +204,8 @@ func (builder *MacvlanNetworkBuilder) Create() (*MacvlanNetworkBuilder, error) {
 	glog.V(100).Infof("Creating the MacvlanNetwork %s", builder.Definition.Name)
 
 	var err error
-	if !builder.Exists() {
-		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+	if !builder.ExistsContext(ctx) {
+		err = builder.apiClient.Create(ctx, builder.Definition)
 
 		if err == nil {
 			builder.Object = builder.Definition
@@ -194,19 +220,24 @@ func (builder *MacvlanNetworkBuilder) Create() (*MacvlanNetworkBuilder, error) {
 
 // Update renovates the existing MacvlanNetwork object with the definition in builder.
 func (builder *MacvlanNetworkBuilder) Update(force bool) (*MacvlanNetworkBuilder, error) {
+	return builder.UpdateContext(context.TODO(), force)
+}
+
+// UpdateContext renovates the existing MacvlanNetwork object with the definition in builder.
+func (builder *MacvlanNetworkBuilder) UpdateContext(ctx context.Context, force bool) (*MacvlanNetworkBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Updating the MacvlanNetwork object named:  %s", builder.Definition.Name)
 
-	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	err := builder.apiClient.Update(ctx, builder.Definition)
 
 	if err != nil {
 		if force {
 			glog.V(100).Infof(msg.FailToUpdateNotification("MacvlanNetwork", builder.Definition.Name))
 
-			builder, err := builder.Delete()
+			builder, err := builder.DeleteContext(ctx)
 
 			if err != nil {
 				glog.V(100).Infof(
@@ -215,7 +246,7 @@ func (builder *MacvlanNetworkBuilder) Update(force bool) (*MacvlanNetworkBuilder
 				return nil, err
 			}
 
-			return builder.Create()
+			return builder.CreateContext(ctx)
 		}
 	}
 
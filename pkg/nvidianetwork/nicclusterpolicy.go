@@ -77,6 +77,12 @@ func NewNicClusterPolicyBuilderFromObjectString(apiClient *clients.Settings, alm
 
 // Get returns nicclusterPolicy object if found.
 func (builder *NicClusterPolicyBuilder) Get() (*nvidianetworkv1alpha1.NicClusterPolicy, error) {
+	return builder.GetContext(context.TODO())
+}
+
+// GetContext returns nicclusterPolicy object if found.
+func (builder *NicClusterPolicyBuilder) GetContext(
+	ctx context.Context) (*nvidianetworkv1alpha1.NicClusterPolicy, error) {
 	if valid, err := builder.validate(); !valid {
 		return nil, err
 	}
@@ -85,7 +91,7 @@ func (builder *NicClusterPolicyBuilder) Get() (*nvidianetworkv1alpha1.NicCluster
 		"Collecting NicClusterPolicy object %s", builder.Definition.Name)
 
 	nicClusterPolicy := &nvidianetworkv1alpha1.NicClusterPolicy{}
-	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+	err := builder.apiClient.Get(ctx, goclient.ObjectKey{
 		Name: builder.Definition.Name,
 	}, nicClusterPolicy)
 
@@ -101,6 +107,12 @@ func (builder *NicClusterPolicyBuilder) Get() (*nvidianetworkv1alpha1.NicCluster
 
 // PullNicClusterPolicy loads an existing NicClusterPolicy into NicClusterPolicyBuilder struct.
 func PullNicClusterPolicy(apiClient *clients.Settings, name string) (*NicClusterPolicyBuilder, error) {
+	return PullNicClusterPolicyContext(context.TODO(), apiClient, name)
+}
+
+// PullNicClusterPolicyContext loads an existing NicClusterPolicy into NicClusterPolicyBuilder struct.
+func PullNicClusterPolicyContext(ctx context.Context, apiClient *clients.Settings,
+	name string) (*NicClusterPolicyBuilder, error) {
 	glog.V(100).Infof("Pulling existing nicClusterPolicy name: %s", name)
 
 	builder := NicClusterPolicyBuilder{
@@ -119,7 +131,7 @@ func PullNicClusterPolicy(apiClient *clients.Settings, name string) (*NicCluster
 		return nil, errors.New(builder.errorMsg)
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("NicClusterPolicy object %s doesn't exist", name)
 	}
 
@@ -130,6 +142,11 @@ func PullNicClusterPolicy(apiClient *clients.Settings, name string) (*NicCluster
 
 // Exists checks whether the given NicClusterPolicy exists.
 func (builder *NicClusterPolicyBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given NicClusterPolicy exists.
+func (builder *NicClusterPolicyBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -138,7 +155,7 @@ func (builder *NicClusterPolicyBuilder) Exists() bool {
 		"Checking if NicClusterPolicy %s exists", builder.Definition.Name)
 
 	var err error
-	builder.Object, err = builder.Get()
+	builder.Object, err = builder.GetContext(ctx)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to collect NicClusterPolicy object due to %s", err.Error())
@@ -149,17 +166,22 @@ func (builder *NicClusterPolicyBuilder) Exists() bool {
 
 // Delete removes a NicClusterPolicy.
 func (builder *NicClusterPolicyBuilder) Delete() (*NicClusterPolicyBuilder, error) {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a NicClusterPolicy.
+func (builder *NicClusterPolicyBuilder) DeleteContext(ctx context.Context) (*NicClusterPolicyBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Deleting NicClusterPolicy %s", builder.Definition.Name)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return builder, fmt.Errorf("nicclusterpolicy cannot be deleted because it does not exist")
 	}
 
-	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+	err := builder.apiClient.Delete(ctx, builder.Definition)
 
 	if err != nil {
 		return builder, fmt.Errorf("cannot delete nicclusterpolicy: %w", err)
@@ -172,6 +194,11 @@ func (builder *NicClusterPolicyBuilder) Delete() (*NicClusterPolicyBuilder, erro
 
 // Create makes a NicClusterPolicy in the cluster and stores the created object in struct.
 func (builder *NicClusterPolicyBuilder) Create() (*NicClusterPolicyBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a NicClusterPolicy in the cluster and stores the created object in struct.
+func (builder *NicClusterPolicyBuilder) CreateContext(ctx context.Context) (*NicClusterPolicyBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -179,8 +206,8 @@ func (builder *NicClusterPolicyBuilder) Create() (*NicClusterPolicyBuilder, erro
 	glog.V(100).Infof("Creating the NicClusterPolicy %s", builder.Definition.Name)
 
 	var err error
-	if !builder.Exists() {
-		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+	if !builder.ExistsContext(ctx) {
+		err = builder.apiClient.Create(ctx, builder.Definition)
 
 		if err == nil {
 			builder.Object = builder.Definition
@@ -195,19 +222,25 @@ func (builder *NicClusterPolicyBuilder) Create() (*NicClusterPolicyBuilder, erro
 
 // Update renovates the existing NicClusterPolicy object with the definition in builder.
 func (builder *NicClusterPolicyBuilder) Update(force bool) (*NicClusterPolicyBuilder, error) {
+	return builder.UpdateContext(context.TODO(), force)
+}
+
+// UpdateContext renovates the existing NicClusterPolicy object with the definition in builder.
+func (builder *NicClusterPolicyBuilder) UpdateContext(ctx context.Context,
+	force bool) (*NicClusterPolicyBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
 
 	glog.V(100).Infof("Updating the NicClusterPolicy object named:  %s", builder.Definition.Name)
 
-	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	err := builder.apiClient.Update(ctx, builder.Definition)
 
 	if err != nil {
 		if force {
 			glog.V(100).Infof(msg.FailToUpdateNotification("nicclusterpolicy", builder.Definition.Name))
 
-			builder, err := builder.Delete()
+			builder, err := builder.DeleteContext(ctx)
 
 			if err != nil {
 				glog.V(100).Infof(
@@ -216,7 +249,7 @@ func (builder *NicClusterPolicyBuilder) Update(force bool) (*NicClusterPolicyBui
 				return nil, err
 			}
 
-			return builder.Create()
+			return builder.CreateContext(ctx)
 		}
 	}
 
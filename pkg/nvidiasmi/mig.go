@@ -0,0 +1,250 @@
+package nvidiasmi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// InstancePlacement is the range of memory slices a GPU instance, or the range of compute slices a compute instance,
+// occupies.
+type InstancePlacement struct {
+	Start int
+	Size  int
+}
+
+// GPUInstance is a MIG GPU instance listed by nvidia-smi mig -lgi.
+type GPUInstance struct {
+	GpuID      int
+	Name       string
+	ProfileID  int
+	InstanceID int
+	Placement  InstancePlacement
+}
+
+// ComputeInstance is a MIG compute instance listed by nvidia-smi mig -lci.
+type ComputeInstance struct {
+	GpuID         int
+	GPUInstanceID int
+	Name          string
+	ProfileID     int
+	InstanceID    int
+	Placement     InstancePlacement
+}
+
+// GPUInstanceProfile is a MIG GPU instance profile listed by nvidia-smi mig -lgip.
+type GPUInstanceProfile struct {
+	GpuID  int
+	Name   string
+	ID     int
+	Free   int
+	Total  int
+	Memory string
+	P2P    string
+	SM     int
+	DEC    int
+	ENC    int
+	CE     int
+	JPEG   int
+	OFA    int
+}
+
+// ParseGPUInstanceProfiles parses the output of nvidia-smi mig -lgip, whose profiles span two rows, e.g.:
+// |   0  MIG 1g.5gb          19     7/7        4.75       No     14     0     0   |
+// |                                                               1     0     0   |
+// An output without any profile is an error.
+func ParseGPUInstanceProfiles(output string) ([]GPUInstanceProfile, error) {
+	var profiles []GPUInstanceProfile
+
+	// The second row belongs to the profile of the row right before it.
+	var current *GPUInstanceProfile
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			current = nil
+
+			continue
+		}
+
+		fields := strings.Fields(strings.Trim(line, "|"))
+
+		switch {
+		case len(fields) == 10 && fields[1] == "MIG":
+			profile, err := parseGPUInstanceProfileRow(fields)
+			if err != nil {
+				return nil, fmt.Errorf("invalid GPU instance profile row %q: %w", line, err)
+			}
+
+			profiles = append(profiles, profile)
+			current = &profiles[len(profiles)-1]
+		case len(fields) == 3 && current != nil:
+			if err := parseInts(fields, &current.CE, &current.JPEG, &current.OFA); err != nil {
+				return nil, fmt.Errorf("invalid GPU instance profile row %q: %w", line, err)
+			}
+
+			current = nil
+		default:
+			current = nil
+		}
+	}
+
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("no MIG profiles found in nvidia-smi output")
+	}
+
+	return profiles, nil
+}
+
+// parseGPUInstanceProfileRow parses the fields of the first row of a profile,
+// GPU, MIG, Name, ID, Free/Total, Memory, P2P, SM, DEC and ENC.
+func parseGPUInstanceProfileRow(fields []string) (GPUInstanceProfile, error) {
+	profile := GPUInstanceProfile{Name: fields[2], Memory: fields[5], P2P: fields[6]}
+
+	free, total, found := strings.Cut(fields[4], "/")
+	if !found {
+		return GPUInstanceProfile{}, fmt.Errorf("expected Free/Total, got %q", fields[4])
+	}
+
+	if err := parseInts([]string{fields[0], fields[3], free, total, fields[7], fields[8], fields[9]},
+		&profile.GpuID, &profile.ID, &profile.Free, &profile.Total, &profile.SM, &profile.DEC,
+		&profile.ENC); err != nil {
+		return GPUInstanceProfile{}, err
+	}
+
+	return profile, nil
+}
+
+// ParseGPUInstances parses the output of nvidia-smi mig -lgi, e.g.:
+// |   0  MIG 1g.5gb          19        9          6:1     |
+func ParseGPUInstances(output string) ([]GPUInstance, error) {
+	rows, err := parseInstanceRows(output, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	gpuInstances := make([]GPUInstance, 0, len(rows))
+
+	for _, row := range rows {
+		gpuInstance := GPUInstance{Name: row.name}
+		if err := parseInts(row.fields, &gpuInstance.GpuID, &gpuInstance.ProfileID, &gpuInstance.InstanceID); err != nil {
+			return nil, fmt.Errorf("invalid GPU instance row %q: %w", row.line, err)
+		}
+
+		gpuInstance.Placement = row.placement
+		gpuInstances = append(gpuInstances, gpuInstance)
+	}
+
+	return gpuInstances, nil
+}
+
+// ParseComputeInstances parses the output of nvidia-smi mig -lci, e.g.:
+// |   0      9       MIG 1g.5gb           0         0          0:1     |
+func ParseComputeInstances(output string) ([]ComputeInstance, error) {
+	rows, err := parseInstanceRows(output, 6)
+	if err != nil {
+		return nil, err
+	}
+
+	computeInstances := make([]ComputeInstance, 0, len(rows))
+
+	for _, row := range rows {
+		computeInstance := ComputeInstance{Name: row.name}
+		if err := parseInts(row.fields, &computeInstance.GpuID, &computeInstance.GPUInstanceID,
+			&computeInstance.ProfileID, &computeInstance.InstanceID); err != nil {
+			return nil, fmt.Errorf("invalid compute instance row %q: %w", row.line, err)
+		}
+
+		computeInstance.Placement = row.placement
+		computeInstances = append(computeInstances, computeInstance)
+	}
+
+	return computeInstances, nil
+}
+
+// instanceRow is a row of the nvidia-smi mig -lgi or -lci table, with the fields around the profile name.
+type instanceRow struct {
+	line      string
+	name      string
+	fields    []string
+	placement InstancePlacement
+}
+
+// parseInstanceRows returns the rows of an nvidia-smi MIG instance table that have fieldCount fields, counting the
+// profile name, e.g. "MIG 1g.5gb", as one field. An output without instances has no rows.
+func parseInstanceRows(output string, fieldCount int) ([]instanceRow, error) {
+	if strings.Contains(output, "No GPU instances found") || strings.Contains(output, "No compute instances found") {
+		return nil, nil
+	}
+
+	var rows []instanceRow
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		fields := strings.Fields(strings.Trim(line, "|"))
+		// The rows of the instances start with the GPU index and name the profile in two words, e.g. "MIG 1g.5gb".
+		nameIndex := fieldCount - 4
+		if len(fields) != fieldCount+1 || fields[nameIndex] != "MIG" {
+			continue
+		}
+
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			continue
+		}
+
+		placement, err := parsePlacement(fields[len(fields)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid placement in row %q: %w", line, err)
+		}
+
+		rows = append(rows, instanceRow{
+			line:      line,
+			name:      fields[nameIndex+1],
+			fields:    append(fields[:nameIndex:nameIndex], fields[nameIndex+2:len(fields)-1]...),
+			placement: placement,
+		})
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no MIG instances found in nvidia-smi output")
+	}
+
+	return rows, nil
+}
+
+// parsePlacement parses a Start:Size placement.
+func parsePlacement(field string) (InstancePlacement, error) {
+	start, size, found := strings.Cut(field, ":")
+	if !found {
+		return InstancePlacement{}, fmt.Errorf("expected Start:Size, got %q", field)
+	}
+
+	var placement InstancePlacement
+	if err := parseInts([]string{start, size}, &placement.Start, &placement.Size); err != nil {
+		return InstancePlacement{}, err
+	}
+
+	return placement, nil
+}
+
+// parseInts parses the fields into the targets, in order.
+func parseInts(fields []string, targets ...*int) error {
+	if len(fields) != len(targets) {
+		return fmt.Errorf("expected %d numbers, got %v", len(targets), fields)
+	}
+
+	for index, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			return fmt.Errorf("invalid number %q: %w", field, err)
+		}
+
+		*targets[index] = number
+	}
+
+	return nil
+}
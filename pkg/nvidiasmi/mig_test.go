@@ -0,0 +1,85 @@
+package nvidiasmi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseGPUInstances(t *testing.T) {
+	gpuInstances, err := ParseGPUInstances(readTestOutput(t, "nvidia-smi-mig-lgi-a100.txt"))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi output: %v", err)
+	}
+
+	expected := []GPUInstance{
+		{GpuID: 0, Name: "1g.5gb", ProfileID: 19, InstanceID: 9, Placement: InstancePlacement{Start: 6, Size: 1}},
+		{GpuID: 0, Name: "2g.10gb", ProfileID: 14, InstanceID: 3, Placement: InstancePlacement{Start: 4, Size: 2}},
+		{GpuID: 0, Name: "3g.20gb", ProfileID: 9, InstanceID: 2, Placement: InstancePlacement{Start: 0, Size: 4}},
+	}
+	if !slices.Equal(gpuInstances, expected) {
+		t.Errorf("expected GPU instances %+v, got %+v", expected, gpuInstances)
+	}
+
+	if gpuInstances, err = ParseGPUInstances("No GPU instances found: Not Found\n"); err != nil || len(gpuInstances) != 0 {
+		t.Errorf("expected no GPU instances without MIG, got %v: %v", gpuInstances, err)
+	}
+
+	if _, err = ParseGPUInstances("Failed to initialize NVML: Driver/library version mismatch"); err == nil {
+		t.Error("expected an error for an output without instances")
+	}
+}
+
+func TestParseComputeInstances(t *testing.T) {
+	computeInstances, err := ParseComputeInstances(readTestOutput(t, "nvidia-smi-mig-lci-a100.txt"))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi output: %v", err)
+	}
+
+	expected := ComputeInstance{GpuID: 0, GPUInstanceID: 3, Name: "2g.10gb", ProfileID: 1, InstanceID: 0,
+		Placement: InstancePlacement{Start: 0, Size: 2}}
+	if len(computeInstances) != 3 || computeInstances[1] != expected {
+		t.Errorf("expected the second compute instance %+v, got %+v", expected, computeInstances)
+	}
+
+	// The GPU instance table has one field less, its rows are not compute instances.
+	if _, err = ParseComputeInstances(readTestOutput(t, "nvidia-smi-mig-lgi-a100.txt")); err == nil {
+		t.Error("expected an error for a GPU instance table")
+	}
+}
+
+func TestParseGPUInstanceProfiles(t *testing.T) {
+	profiles, err := ParseGPUInstanceProfiles(readTestOutput(t, "nvidia-smi-mig-lgip-a100.txt"))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi output: %v", err)
+	}
+
+	var names []string
+	for _, profile := range profiles {
+		names = append(names, profile.Name)
+	}
+
+	expectedNames := []string{"1g.5gb", "1g.5gb+me", "1g.10gb", "2g.10gb", "3g.20gb", "4g.20gb", "7g.40gb"}
+	if !slices.Equal(names, expectedNames) {
+		t.Fatalf("expected profiles %v, got %v", expectedNames, names)
+	}
+
+	expected := GPUInstanceProfile{GpuID: 0, Name: "2g.10gb", ID: 14, Free: 3, Total: 3, Memory: "9.62", P2P: "No",
+		SM: 28, DEC: 1, ENC: 0, CE: 2, JPEG: 0, OFA: 0}
+	if profiles[3] != expected {
+		t.Errorf("expected %+v, got %+v", expected, profiles[3])
+	}
+
+	// The second row of each profile must fill the engines of that profile only.
+	if profiles[0].JPEG != 0 || profiles[1].JPEG != 1 || profiles[1].OFA != 1 {
+		t.Errorf("expected the engines of each profile from its own second row, got %+v and %+v", profiles[0],
+			profiles[1])
+	}
+
+	if last := profiles[len(profiles)-1]; last.Free != 0 || last.Total != 1 {
+		t.Errorf("unexpected 7g.40gb profile %+v", last)
+	}
+
+	if _, err := ParseGPUInstanceProfiles("No MIG-supported devices found."); err == nil {
+		t.Error("expected an error for an output without profiles")
+	}
+}
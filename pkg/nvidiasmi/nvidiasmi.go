@@ -0,0 +1,199 @@
+package nvidiasmi
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Client runs nvidia-smi in a container of a pod with GPU access, the driver pod or a GPU workload pod.
+type Client struct {
+	podBuilder    *pod.Builder
+	containerName []string
+}
+
+// NewClient returns a Client running nvidia-smi in the container of the pod, its first container by default.
+func NewClient(podBuilder *pod.Builder, containerName ...string) *Client {
+	return &Client{podBuilder: podBuilder, containerName: containerName}
+}
+
+// Run runs nvidia-smi with the args and returns its output, with the error messages of nvidia-smi.
+func (client *Client) Run(args ...string) (string, error) {
+	return client.RunContext(context.TODO(), args...)
+}
+
+// RunContext runs nvidia-smi with the args and returns its output, with the error messages of nvidia-smi.
+func (client *Client) RunContext(ctx context.Context, args ...string) (string, error) {
+	if client.podBuilder == nil || client.podBuilder.Object == nil {
+		return "", fmt.Errorf("cannot run nvidia-smi without a pod")
+	}
+
+	// The pod exec streams stderr to the test output, so it is redirected to keep the nvidia-smi errors.
+	command := strings.Join(append([]string{"nvidia-smi"}, args...), " ")
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Running '%s' in pod %s/%s", command, client.podBuilder.Object.Namespace,
+		client.podBuilder.Object.Name)
+
+	buffer, err := client.podBuilder.ExecCommandContext(ctx, []string{"sh", "-c", command + " 2>&1"},
+		client.containerName...)
+	// The pod exec allocates a TTY, which ends the lines with \r\n.
+	output := strings.ReplaceAll(buffer.String(), "\r", "")
+
+	if err != nil {
+		return output, fmt.Errorf("error running '%s' in pod %s/%s: %w: %s", command,
+			client.podBuilder.Object.Namespace, client.podBuilder.Object.Name, err, strings.TrimSpace(output))
+	}
+
+	return output, nil
+}
+
+// Query runs nvidia-smi -q -x and returns the parsed GPUs.
+func (client *Client) Query() (*Log, error) {
+	return client.QueryContext(context.TODO())
+}
+
+// QueryContext runs nvidia-smi -q -x and returns the parsed GPUs.
+func (client *Client) QueryContext(ctx context.Context) (*Log, error) {
+	output, err := client.RunContext(ctx, "-q", "-x")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseQuery(output)
+}
+
+// GPUCount runs nvidia-smi -q -x and returns the number of GPUs the container sees.
+func (client *Client) GPUCount() (int, error) {
+	return client.GPUCountContext(context.TODO())
+}
+
+// GPUCountContext runs nvidia-smi -q -x and returns the number of GPUs the container sees.
+func (client *Client) GPUCountContext(ctx context.Context) (int, error) {
+	log, err := client.QueryContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(log.GPUs), nil
+}
+
+// GPUInstanceProfiles runs nvidia-smi mig -lgip and returns the MIG GPU instance profiles of the GPUs.
+func (client *Client) GPUInstanceProfiles() ([]GPUInstanceProfile, error) {
+	return client.GPUInstanceProfilesContext(context.TODO())
+}
+
+// GPUInstanceProfilesContext runs nvidia-smi mig -lgip and returns the MIG GPU instance profiles of the GPUs.
+func (client *Client) GPUInstanceProfilesContext(ctx context.Context) ([]GPUInstanceProfile, error) {
+	output, err := client.RunContext(ctx, "mig", "-lgip")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGPUInstanceProfiles(output)
+}
+
+// GPUInstances runs nvidia-smi mig -lgi and returns the MIG GPU instances, none when MIG is disabled.
+func (client *Client) GPUInstances() ([]GPUInstance, error) {
+	return client.GPUInstancesContext(context.TODO())
+}
+
+// GPUInstancesContext runs nvidia-smi mig -lgi and returns the MIG GPU instances, none when MIG is disabled.
+func (client *Client) GPUInstancesContext(ctx context.Context) ([]GPUInstance, error) {
+	// nvidia-smi fails when there are no instances, which the parser recognizes from the output.
+	output, err := client.RunContext(ctx, "mig", "-lgi")
+
+	gpuInstances, parseErr := ParseGPUInstances(output)
+	if parseErr != nil && err != nil {
+		return nil, err
+	}
+
+	return gpuInstances, parseErr
+}
+
+// ComputeInstances runs nvidia-smi mig -lci and returns the MIG compute instances, none when MIG is disabled.
+func (client *Client) ComputeInstances() ([]ComputeInstance, error) {
+	return client.ComputeInstancesContext(context.TODO())
+}
+
+// ComputeInstancesContext runs nvidia-smi mig -lci and returns the MIG compute instances, none when MIG is disabled.
+func (client *Client) ComputeInstancesContext(ctx context.Context) ([]ComputeInstance, error) {
+	output, err := client.RunContext(ctx, "mig", "-lci")
+
+	computeInstances, parseErr := ParseComputeInstances(output)
+	if parseErr != nil && err != nil {
+		return nil, err
+	}
+
+	return computeInstances, parseErr
+}
+
+// ParseQuery parses the output of nvidia-smi -q -x.
+func ParseQuery(output string) (*Log, error) {
+	// Anything the shell printed before the XML declaration is not XML.
+	if start := strings.Index(output, "<?xml"); start > 0 {
+		output = output[start:]
+	}
+
+	var log Log
+	if err := xml.Unmarshal([]byte(output), &log); err != nil {
+		return nil, fmt.Errorf("error parsing nvidia-smi XML output: %w", err)
+	}
+
+	if len(log.GPUs) != log.AttachedGPUs {
+		return nil, fmt.Errorf("nvidia-smi reports %d attached GPUs but lists %d", log.AttachedGPUs, len(log.GPUs))
+	}
+
+	return &log, nil
+}
+
+// PullDriverPod returns the running driver pod of the GPU operator on the node.
+func PullDriverPod(apiClient *clients.Settings, nodeName string) (*pod.Builder, error) {
+	return PullDriverPodContext(context.TODO(), apiClient, nodeName)
+}
+
+// PullDriverPodContext returns the running driver pod of the GPU operator on the node.
+func PullDriverPodContext(ctx context.Context, apiClient *clients.Settings, nodeName string) (*pod.Builder, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("cannot pull the driver pod with nil apiClient")
+	}
+
+	driverPods, err := apiClient.Pods(nvidiagpu.NvidiaGPUNamespace).List(ctx, metav1.ListOptions{
+		LabelSelector: nvidiagpu.DriverPodLabel,
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing driver pods: %w", err)
+	}
+
+	for _, driverPod := range driverPods.Items {
+		if driverPod.Status.Phase == corev1.PodRunning {
+			return pod.PullContext(ctx, apiClient, driverPod.Name, driverPod.Namespace)
+		}
+	}
+
+	return nil, fmt.Errorf("no running driver pod found on node %s", nodeName)
+}
+
+// QueryNode runs nvidia-smi -q -x in the driver pod of the node and returns the parsed GPUs.
+func QueryNode(apiClient *clients.Settings, nodeName string) (*Log, error) {
+	return QueryNodeContext(context.TODO(), apiClient, nodeName)
+}
+
+// QueryNodeContext runs nvidia-smi -q -x in the driver pod of the node and returns the parsed GPUs.
+func QueryNodeContext(ctx context.Context, apiClient *clients.Settings, nodeName string) (*Log, error) {
+	driverPod, err := PullDriverPodContext(ctx, apiClient, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(driverPod).QueryContext(ctx)
+}
@@ -0,0 +1,232 @@
+package nvidiasmi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func readTestOutput(t *testing.T, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read test output %s: %v", name, err)
+	}
+
+	return string(content)
+}
+
+func TestParseQuery(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		output                string
+		driverVersion         string
+		cudaVersion           string
+		gpuCount              int
+		architecture          string
+		busID                 string
+		migSupported          bool
+		migEnabled            bool
+		migDevices            int
+		correctable           int64
+		uncorrectable         int64
+		retiredPages          int64
+		powerDraw             Value
+		powerLimit            Value
+		graphicsClock         int64
+		maxSMClock            int64
+		remappedUncorrectable Value
+	}{
+		{
+			name:          "volta with driver 470",
+			output:        "nvidia-smi-q-x-v100.xml",
+			driverVersion: "470.223.02",
+			cudaVersion:   "11.4",
+			gpuCount:      1,
+			architecture:  "Volta",
+			busID:         "00000000:00:1E.0",
+			correctable:   7,
+			uncorrectable: 1,
+			retiredPages:  1,
+			powerDraw:     "42.51 W",
+			powerLimit:    "300.00 W",
+			graphicsClock: 1312,
+			maxSMClock:    1530,
+		},
+		{
+			name:                  "ampere in MIG mode with driver 535",
+			output:                "nvidia-smi-q-x-a100-mig.xml",
+			driverVersion:         "535.161.08",
+			cudaVersion:           "12.2",
+			gpuCount:              1,
+			architecture:          "Ampere",
+			busID:                 "00000000:17:00.0",
+			migSupported:          true,
+			migEnabled:            true,
+			migDevices:            3,
+			correctable:           12,
+			powerDraw:             "36.71 W",
+			powerLimit:            "250.00 W",
+			graphicsClock:         1410,
+			maxSMClock:            1410,
+			remappedUncorrectable: "0",
+		},
+		{
+			name:                  "hopper with driver 550",
+			output:                "nvidia-smi-q-x-h100.xml",
+			driverVersion:         "550.90.07",
+			cudaVersion:           "12.4",
+			gpuCount:              2,
+			architecture:          "Hopper",
+			busID:                 "00000000:1B:00.0",
+			migSupported:          true,
+			powerDraw:             "69.61 W",
+			powerLimit:            "700.00 W",
+			graphicsClock:         1980,
+			maxSMClock:            1980,
+			remappedUncorrectable: "0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			log, err := ParseQuery(readTestOutput(t, testCase.output))
+			if err != nil {
+				t.Fatalf("failed to parse nvidia-smi output: %v", err)
+			}
+
+			if log.DriverVersion != testCase.driverVersion || log.CUDAVersion != testCase.cudaVersion ||
+				len(log.GPUs) != testCase.gpuCount {
+				t.Fatalf("unexpected driver %s, CUDA %s and %d GPUs", log.DriverVersion, log.CUDAVersion,
+					len(log.GPUs))
+			}
+
+			gpu := log.GPUs[0]
+			if gpu.ProductArchitecture != testCase.architecture || gpu.PCI.BusID != testCase.busID ||
+				gpu.ID != testCase.busID || gpu.UUID == "" {
+				t.Errorf("unexpected GPU identity %s %s %s %s", gpu.ProductArchitecture, gpu.PCI.BusID, gpu.ID,
+					gpu.UUID)
+			}
+
+			if gpu.MIGMode.Supported() != testCase.migSupported || gpu.MIGMode.Enabled() != testCase.migEnabled ||
+				len(gpu.MIGDevices) != testCase.migDevices {
+				t.Errorf("unexpected MIG mode %+v with %d MIG devices", gpu.MIGMode, len(gpu.MIGDevices))
+			}
+
+			if gpu.ECCErrors.Aggregate.Correctable() != testCase.correctable ||
+				gpu.ECCErrors.Aggregate.Uncorrectable() != testCase.uncorrectable {
+				t.Errorf("unexpected ECC errors %+v", gpu.ECCErrors.Aggregate)
+			}
+
+			if gpu.RetiredPages.Count() != testCase.retiredPages || gpu.RetiredPages.Pending() {
+				t.Errorf("unexpected retired pages %+v", gpu.RetiredPages)
+			}
+
+			if gpu.RemappedRows.Uncorrectable != testCase.remappedUncorrectable {
+				t.Errorf("unexpected remapped rows %+v", gpu.RemappedRows)
+			}
+
+			if gpu.Power().Draw() != testCase.powerDraw || gpu.Power().Limit() != testCase.powerLimit {
+				t.Errorf("unexpected power readings %+v", gpu.Power())
+			}
+
+			if clock, err := gpu.Clocks.Graphics.Int(); err != nil || clock != testCase.graphicsClock {
+				t.Errorf("expected graphics clock %d, got %d: %v", testCase.graphicsClock, clock, err)
+			}
+
+			if clock, err := gpu.MaxClocks.SM.Int(); err != nil || clock != testCase.maxSMClock {
+				t.Errorf("expected max SM clock %d, got %d: %v", testCase.maxSMClock, clock, err)
+			}
+		})
+	}
+}
+
+func TestParseQueryDetails(t *testing.T) {
+	a100, err := ParseQuery(readTestOutput(t, "nvidia-smi-q-x-a100-mig.xml"))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi output: %v", err)
+	}
+
+	migDevice := a100.GPUs[0].MIGDevices[2]
+	if migDevice.Index != 2 || migDevice.GPUInstanceID != 9 || migDevice.MultiprocessorCount != 14 ||
+		migDevice.FBMemoryUsage.Total != "4864 MiB" {
+		t.Errorf("unexpected MIG device %+v", migDevice)
+	}
+
+	h100, err := ParseQuery(readTestOutput(t, "nvidia-smi-q-x-h100.xml"))
+	if err != nil {
+		t.Fatalf("failed to parse nvidia-smi output: %v", err)
+	}
+
+	// The second H100 has a pending MIG mode change and an uncorrectable error with a pending row remapping.
+	gpu := h100.GPUs[1]
+	if gpu.MIGMode.Enabled() || gpu.MIGMode.Pending != "Enabled" || gpu.ECCErrors.Volatile.Uncorrectable() != 1 ||
+		gpu.RemappedRows.Pending != "Yes" || gpu.MinorNumber != "1" {
+		t.Errorf("unexpected second H100 %+v", gpu)
+	}
+
+	if _, err := ParseQuery("NVIDIA-SMI has failed because it couldn't communicate with the NVIDIA driver."); err == nil {
+		t.Error("expected an error for an output without XML")
+	}
+}
+
+func TestValue(t *testing.T) {
+	if power, err := Value("42.51 W").Float(); err != nil || power != 42.51 {
+		t.Errorf("expected 42.51, got %v: %v", power, err)
+	}
+
+	for _, value := range []Value{"N/A", "[Not Supported]", ""} {
+		if value.Available() {
+			t.Errorf("expected %q to be unavailable", value)
+		}
+
+		if _, err := value.Int(); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+func TestPullDriverPod(t *testing.T) {
+	newDriverPod := func(name string, phase corev1.PodPhase) runtime.Object {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nvidiagpu.NvidiaGPUNamespace,
+				Labels:    map[string]string{"app.kubernetes.io/component": "nvidia-driver"},
+			},
+			Spec:   corev1.PodSpec{NodeName: "worker-gpu-0", Containers: []corev1.Container{{Name: "nvidia-driver-ctr"}}},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	apiClient, err := testfixtures.NewTestClients(nil, newDriverPod("nvidia-driver-daemonset-old", corev1.PodPending),
+		newDriverPod("nvidia-driver-daemonset-new", corev1.PodRunning))
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	driverPod, err := PullDriverPod(apiClient, "worker-gpu-0")
+	if err != nil {
+		t.Fatalf("failed to pull the driver pod: %v", err)
+	}
+
+	if driverPod.Object.Name != "nvidia-driver-daemonset-new" {
+		t.Errorf("expected the running driver pod, got %s", driverPod.Object.Name)
+	}
+
+	emptyClient, err := testfixtures.NewTestClients(nil)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	if _, err = PullDriverPod(emptyClient, "worker-gpu-0"); err == nil {
+		t.Error("expected an error without driver pods")
+	}
+}
@@ -0,0 +1,257 @@
+package nvidiasmi
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value is a reading of nvidia-smi, e.g. "1410 MHz", "42.51 W", "Enabled" or "N/A".
+type Value string
+
+// Available returns false for the readings nvidia-smi does not have, e.g. "N/A" or "[Not Supported]".
+func (value Value) Available() bool {
+	reading := strings.Trim(strings.TrimSpace(string(value)), "[]")
+
+	return reading != "" && reading != "N/A" && reading != "Not Supported" && reading != "Unknown Error"
+}
+
+// Int returns the number of the reading without its unit, e.g. 1410 for "1410 MHz".
+func (value Value) Int() (int64, error) {
+	if !value.Available() {
+		return 0, fmt.Errorf("nvidia-smi reading %q is not available", value)
+	}
+
+	return strconv.ParseInt(strings.Fields(string(value))[0], 10, 64)
+}
+
+// Float returns the number of the reading without its unit, e.g. 42.51 for "42.51 W".
+func (value Value) Float() (float64, error) {
+	if !value.Available() {
+		return 0, fmt.Errorf("nvidia-smi reading %q is not available", value)
+	}
+
+	return strconv.ParseFloat(strings.Fields(string(value))[0], 64)
+}
+
+// count returns the number of the reading, 0 when it is not available.
+func (value Value) count() int64 {
+	number, err := value.Int()
+	if err != nil {
+		return 0
+	}
+
+	return number
+}
+
+// Log is the output of nvidia-smi -q -x.
+type Log struct {
+	XMLName       xml.Name `xml:"nvidia_smi_log"`
+	Timestamp     string   `xml:"timestamp"`
+	DriverVersion string   `xml:"driver_version"`
+	CUDAVersion   string   `xml:"cuda_version"`
+	AttachedGPUs  int      `xml:"attached_gpus"`
+	GPUs          []GPU    `xml:"gpu"`
+}
+
+// GPU is a GPU of the nvidia-smi -q -x output. The readings nvidia-smi renamed across driver versions, like the
+// power readings, are read from whichever element the driver reports.
+type GPU struct {
+	ID                  string       `xml:"id,attr"`
+	ProductName         string       `xml:"product_name"`
+	ProductBrand        string       `xml:"product_brand"`
+	ProductArchitecture string       `xml:"product_architecture"`
+	PersistenceMode     string       `xml:"persistence_mode"`
+	MIGMode             MIGMode      `xml:"mig_mode"`
+	MIGDevices          []MIGDevice  `xml:"mig_devices>mig_device"`
+	Serial              string       `xml:"serial"`
+	UUID                string       `xml:"uuid"`
+	MinorNumber         Value        `xml:"minor_number"`
+	VBIOSVersion        string       `xml:"vbios_version"`
+	PCI                 PCI          `xml:"pci"`
+	PerformanceState    string       `xml:"performance_state"`
+	FBMemoryUsage       MemoryUsage  `xml:"fb_memory_usage"`
+	ECCMode             ECCMode      `xml:"ecc_mode"`
+	ECCErrors           ECCErrors    `xml:"ecc_errors"`
+	RetiredPages        RetiredPages `xml:"retired_pages"`
+	RemappedRows        RemappedRows `xml:"remapped_rows"`
+	Temperature         Temperature  `xml:"temperature"`
+	// PowerReadings are reported by drivers before 535, GPUPowerReadings by the later ones.
+	PowerReadings    PowerReadings `xml:"power_readings"`
+	GPUPowerReadings PowerReadings `xml:"gpu_power_readings"`
+	Clocks           Clocks        `xml:"clocks"`
+	MaxClocks        Clocks        `xml:"max_clocks"`
+}
+
+// Power returns the power readings of the GPU, whichever driver version reported them.
+func (gpu GPU) Power() PowerReadings {
+	if gpu.GPUPowerReadings != (PowerReadings{}) {
+		return gpu.GPUPowerReadings
+	}
+
+	return gpu.PowerReadings
+}
+
+// MIGMode is the current and pending MIG mode of a GPU, Enabled, Disabled or N/A on GPUs without MIG support.
+type MIGMode struct {
+	Current Value `xml:"current_mig"`
+	Pending Value `xml:"pending_mig"`
+}
+
+// Enabled returns whether MIG mode is currently enabled.
+func (mode MIGMode) Enabled() bool {
+	return mode.Current == "Enabled"
+}
+
+// Supported returns whether the GPU supports MIG mode.
+func (mode MIGMode) Supported() bool {
+	return mode.Current.Available()
+}
+
+// MIGDevice is a MIG device of a GPU in MIG mode.
+type MIGDevice struct {
+	Index               int         `xml:"index"`
+	GPUInstanceID       int         `xml:"gpu_instance_id"`
+	ComputeInstanceID   int         `xml:"compute_instance_id"`
+	MultiprocessorCount int         `xml:"device_attributes>shared>multiprocessor_count"`
+	FBMemoryUsage       MemoryUsage `xml:"fb_memory_usage"`
+}
+
+// PCI is the PCI location and identity of a GPU, DeviceID is e.g. 20F110DE for an A100-PCIE-40GB.
+type PCI struct {
+	Bus         string `xml:"pci_bus"`
+	Device      string `xml:"pci_device"`
+	Domain      string `xml:"pci_domain"`
+	DeviceID    string `xml:"pci_device_id"`
+	BusID       string `xml:"pci_bus_id"`
+	SubSystemID string `xml:"pci_sub_system_id"`
+}
+
+// MemoryUsage is the memory usage of a GPU or a MIG device, in MiB.
+type MemoryUsage struct {
+	Total    Value `xml:"total"`
+	Reserved Value `xml:"reserved"`
+	Used     Value `xml:"used"`
+	Free     Value `xml:"free"`
+}
+
+// ECCMode is the current and pending ECC mode of a GPU.
+type ECCMode struct {
+	Current Value `xml:"current_ecc"`
+	Pending Value `xml:"pending_ecc"`
+}
+
+// ECCErrors are the ECC error counters since the driver was loaded and over the life of the GPU.
+type ECCErrors struct {
+	Volatile  ECCCounters `xml:"volatile"`
+	Aggregate ECCCounters `xml:"aggregate"`
+}
+
+// ECCCounters are the ECC error counters of a GPU. Drivers before 510 report single and double bit errors, the later
+// ones SRAM and DRAM errors, with the SRAM uncorrectable errors split by parity and SEC-DED from Hopper on.
+type ECCCounters struct {
+	SingleBit               ECCBitCounters `xml:"single_bit"`
+	DoubleBit               ECCBitCounters `xml:"double_bit"`
+	SRAMCorrectable         Value          `xml:"sram_correctable"`
+	SRAMUncorrectable       Value          `xml:"sram_uncorrectable"`
+	SRAMUncorrectableParity Value          `xml:"sram_uncorrectable_parity"`
+	SRAMUncorrectableSECDED Value          `xml:"sram_uncorrectable_secded"`
+	DRAMCorrectable         Value          `xml:"dram_correctable"`
+	DRAMUncorrectable       Value          `xml:"dram_uncorrectable"`
+}
+
+// ECCBitCounters are the single or double bit ECC error counters of drivers before 510.
+type ECCBitCounters struct {
+	DeviceMemory Value `xml:"device_memory"`
+	RegisterFile Value `xml:"register_file"`
+	L1Cache      Value `xml:"l1_cache"`
+	L2Cache      Value `xml:"l2_cache"`
+	Total        Value `xml:"total"`
+}
+
+// Correctable returns the number of correctable ECC errors, whichever driver version reported them.
+func (counters ECCCounters) Correctable() int64 {
+	return counters.SingleBit.Total.count() + counters.SRAMCorrectable.count() + counters.DRAMCorrectable.count()
+}
+
+// Uncorrectable returns the number of uncorrectable ECC errors, whichever driver version reported them.
+func (counters ECCCounters) Uncorrectable() int64 {
+	return counters.DoubleBit.Total.count() + counters.SRAMUncorrectable.count() +
+		counters.SRAMUncorrectableParity.count() + counters.SRAMUncorrectableSECDED.count() +
+		counters.DRAMUncorrectable.count()
+}
+
+// RetiredPages are the memory pages retired because of ECC errors. GPUs from Ampere on remap rows instead and report
+// N/A, see RemappedRows.
+type RetiredPages struct {
+	MultipleSingleBitCount Value `xml:"multiple_single_bit_retirement>retired_count"`
+	DoubleBitCount         Value `xml:"double_bit_retirement>retired_count"`
+	PendingBlacklist       Value `xml:"pending_blacklist"`
+	PendingRetirement      Value `xml:"pending_retirement"`
+}
+
+// Count returns the number of retired pages.
+func (pages RetiredPages) Count() int64 {
+	return pages.MultipleSingleBitCount.count() + pages.DoubleBitCount.count()
+}
+
+// Pending returns whether pages are pending retirement, which requires a GPU reset.
+func (pages RetiredPages) Pending() bool {
+	return pages.PendingBlacklist == "Yes" || pages.PendingRetirement == "Yes"
+}
+
+// RemappedRows are the memory rows remapped because of ECC errors on GPUs from Ampere on.
+type RemappedRows struct {
+	Correctable   Value `xml:"remapped_row_corr"`
+	Uncorrectable Value `xml:"remapped_row_unc"`
+	Pending       Value `xml:"remapped_row_pending"`
+	Failure       Value `xml:"remapped_row_failure"`
+}
+
+// Temperature are the temperatures of a GPU, in C.
+type Temperature struct {
+	GPU             Value `xml:"gpu_temp"`
+	GPUMaxThreshold Value `xml:"gpu_temp_max_threshold"`
+	Memory          Value `xml:"memory_temp"`
+}
+
+// PowerReadings are the power readings of a GPU, in W. Drivers from 550 on report the average and instant power
+// draw instead of the power draw, drivers from 535 on the current power limit instead of the power limit.
+type PowerReadings struct {
+	PowerState        string `xml:"power_state"`
+	PowerDraw         Value  `xml:"power_draw"`
+	AveragePowerDraw  Value  `xml:"average_power_draw"`
+	InstantPowerDraw  Value  `xml:"instant_power_draw"`
+	PowerLimit        Value  `xml:"power_limit"`
+	CurrentPowerLimit Value  `xml:"current_power_limit"`
+	DefaultPowerLimit Value  `xml:"default_power_limit"`
+	MinPowerLimit     Value  `xml:"min_power_limit"`
+	MaxPowerLimit     Value  `xml:"max_power_limit"`
+}
+
+// Draw returns the power draw, whichever driver version reported it.
+func (readings PowerReadings) Draw() Value {
+	if readings.PowerDraw.Available() {
+		return readings.PowerDraw
+	}
+
+	return readings.AveragePowerDraw
+}
+
+// Limit returns the enforced power limit, whichever driver version reported it.
+func (readings PowerReadings) Limit() Value {
+	if readings.CurrentPowerLimit.Available() {
+		return readings.CurrentPowerLimit
+	}
+
+	return readings.PowerLimit
+}
+
+// Clocks are the clocks of a GPU, in MHz.
+type Clocks struct {
+	Graphics Value `xml:"graphics_clock"`
+	SM       Value `xml:"sm_clock"`
+	Memory   Value `xml:"mem_clock"`
+	Video    Value `xml:"video_clock"`
+}
@@ -0,0 +1,111 @@
+package olm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testCSVName      = "gpu-operator-certified.v24.9.2"
+	testCSVNamespace = "nvidia-gpu-operator"
+)
+
+func TestGetALMExampleByKind(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.CSV})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	csvBuilder, err := PullClusterServiceVersion(apiClient, testCSVName, testCSVNamespace)
+	if err != nil {
+		t.Fatalf("failed to pull clusterserviceversion: %v", err)
+	}
+
+	almExamples, err := csvBuilder.GetAlmExamples()
+	if err != nil {
+		t.Fatalf("failed to get alm-examples: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		almExample    string
+		kind          string
+		expectedName  string
+		expectedError string
+	}{
+		{
+			name:         "first item",
+			almExample:   almExamples,
+			kind:         "ClusterPolicy",
+			expectedName: "gpu-cluster-policy",
+		},
+		{
+			name:         "second item",
+			almExample:   almExamples,
+			kind:         "NVIDIADriver",
+			expectedName: "gpu-driver",
+		},
+		{
+			name:          "missing kind",
+			almExample:    almExamples,
+			kind:          "NicClusterPolicy",
+			expectedError: "alm-example for kind NicClusterPolicy not found",
+		},
+		{
+			name:          "empty alm-examples",
+			almExample:    "",
+			kind:          "ClusterPolicy",
+			expectedError: "almExample is an empty string",
+		},
+		{
+			name:          "empty list",
+			almExample:    "[]",
+			kind:          "ClusterPolicy",
+			expectedError: "no alm examples found",
+		},
+		{
+			name:          "not a list",
+			almExample:    `{"kind": "ClusterPolicy"}`,
+			kind:          "ClusterPolicy",
+			expectedError: "failed to unmarshal ALM examples",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rawItem, err := GetALMExampleByKind(testCase.almExample, testCase.kind)
+			if testCase.expectedError != "" {
+				if err == nil {
+					t.Fatalf("expected error %q, got nil", testCase.expectedError)
+				}
+
+				if !strings.HasPrefix(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error starting with %q, got %q", testCase.expectedError, err.Error())
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var object metav1.PartialObjectMetadata
+			if err := json.Unmarshal(rawItem, &object); err != nil {
+				t.Fatalf("failed to unmarshal alm-example item: %v", err)
+			}
+
+			if object.Kind != testCase.kind {
+				t.Errorf("expected kind %q, got %q", testCase.kind, object.Kind)
+			}
+
+			if object.Name != testCase.expectedName {
+				t.Errorf("expected name %q, got %q", testCase.expectedName, object.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,636 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultBundleRegistryImage is the opm image the registry pod of a bundle runs. It is pinned so that the opm
+	// commands of the registry pod do not change under the installer.
+	DefaultBundleRegistryImage = "quay.io/operator-framework/opm:v1.47.0"
+	// DefaultBundleRegistryTimeout is how long the registry pod of a bundle may take to serve the bundle.
+	DefaultBundleRegistryTimeout = 5 * time.Minute
+
+	bundleRegistryPort        = 50051
+	bundleRegistryDir         = "/catalog"
+	bundleRegistryContainer   = "registry-grpc"
+	bundleRegistryPodLabel    = "nvidia-ci/bundle-registry"
+	bundleRegistryTemplateEnv = "SEMVER_TEMPLATE"
+)
+
+const (
+	// InstallStepRegistryPod creates the pod rendering the bundle image into a file-based catalog and serving it,
+	// and the Service exposing the pod.
+	InstallStepRegistryPod InstallStep = "RegistryPod"
+	// InstallStepBundle resolves the package, channel and ClusterServiceVersion of the bundle from the
+	// PackageManifest served by the bundle CatalogSource.
+	InstallStepBundle InstallStep = "Bundle"
+)
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// BundleInstaller installs an operator from its bundle image, the way 'operator-sdk run bundle' does, without the
+// operator-sdk binary: a registry pod renders the bundle image into a file-based catalog with the opm semver
+// template and serves it, through a Service, to a CatalogSource. The package of the bundle is read from the served
+// PackageManifest and installed by an OperatorInstaller. Until the package is known, the Package of an
+// *InstallError is the bundle image.
+type BundleInstaller struct {
+	// Namespace is the operator namespace, set once it exists.
+	Namespace *namespace.Builder
+	// RegistryPod is the pod serving the bundle, set once it is running.
+	RegistryPod *pod.Builder
+	// RegistryService is the Service exposing the registry pod to the CatalogSource, set once it is created.
+	RegistryService *corev1.Service
+	// CatalogSource is the CatalogSource of the registry pod, set once it is ready.
+	CatalogSource *CatalogSourceBuilder
+	// PackageManifest is the PackageManifest of the bundle, set once it is served.
+	PackageManifest *PackageManifestBuilder
+	// Installer is the OperatorInstaller installing the package of the bundle, set once the package is known.
+	Installer *OperatorInstaller
+
+	apiClient            *clients.Settings
+	bundleImage          string
+	namespaceName        string
+	namespaceLabels      map[string]string
+	registryImage        string
+	catalogSourceName    string
+	registryTimeout      time.Duration
+	packageCheckInterval time.Duration
+	packageTimeout       time.Duration
+	csvCheckInterval     time.Duration
+	csvTimeout           time.Duration
+	cleanups             []bundleCleanup
+	errorMsg             string
+}
+
+// bundleCleanup deletes a resource created by the BundleInstaller.
+type bundleCleanup struct {
+	resource string
+	delete   func() error
+}
+
+// NewBundleInstaller returns a BundleInstaller for the given bundle image in the given namespace. The registry pod, its
+// Service and the CatalogSource are named after the repository of the bundle image, unless configured otherwise.
+func NewBundleInstaller(apiClient *clients.Settings, bundleImage, nsName string) *BundleInstaller {
+	glog.V(100).Infof("Initializing new BundleInstaller for bundle %s in namespace %s", bundleImage, nsName)
+
+	installer := &BundleInstaller{
+		apiClient:            apiClient,
+		bundleImage:          bundleImage,
+		namespaceName:        nsName,
+		registryImage:        DefaultBundleRegistryImage,
+		catalogSourceName:    bundleCatalogSourceName(bundleImage),
+		registryTimeout:      DefaultBundleRegistryTimeout,
+		packageCheckInterval: DefaultPackageManifestCheckInterval,
+		packageTimeout:       DefaultPackageManifestTimeout,
+		csvCheckInterval:     DefaultCSVSucceededCheckInterval,
+		csvTimeout:           DefaultCSVSucceededTimeout,
+	}
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient of the BundleInstaller is nil")
+
+		installer.errorMsg = "BundleInstaller cannot have nil apiClient"
+	}
+
+	if bundleImage == "" {
+		glog.V(100).Infof("The bundle image of the BundleInstaller is empty")
+
+		installer.errorMsg = "BundleInstaller 'bundleImage' cannot be empty"
+	}
+
+	if nsName == "" {
+		glog.V(100).Infof("The namespace of the BundleInstaller is empty")
+
+		installer.errorMsg = "BundleInstaller 'nsName' cannot be empty"
+	}
+
+	return installer
+}
+
+// WithNamespaceLabels sets the labels the operator namespace is created with.
+func (installer *BundleInstaller) WithNamespaceLabels(labels map[string]string) *BundleInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting BundleInstaller namespace labels to %v", labels)
+
+	installer.namespaceLabels = labels
+
+	return installer
+}
+
+// WithRegistryImage sets the opm image the registry pod runs, e.g. a mirrored one on disconnected clusters.
+func (installer *BundleInstaller) WithRegistryImage(registryImage string) *BundleInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting BundleInstaller registry image to %s", registryImage)
+
+	if registryImage == "" {
+		installer.errorMsg = "BundleInstaller registry image cannot be empty"
+
+		return installer
+	}
+
+	installer.registryImage = registryImage
+
+	return installer
+}
+
+// WithCatalogSourceName sets the name of the CatalogSource and of its registry pod and Service.
+func (installer *BundleInstaller) WithCatalogSourceName(name string) *BundleInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting BundleInstaller catalogsource name to %s", name)
+
+	if name == "" {
+		installer.errorMsg = "BundleInstaller catalogsource name cannot be empty"
+
+		return installer
+	}
+
+	installer.catalogSourceName = name
+
+	return installer
+}
+
+// WithRegistryTimeout sets how long the registry pod may take to run and its CatalogSource to become ready.
+func (installer *BundleInstaller) WithRegistryTimeout(timeout time.Duration) *BundleInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting BundleInstaller registry timeout to %s", timeout)
+
+	if timeout <= 0 {
+		installer.errorMsg = "BundleInstaller registry timeout must be positive"
+
+		return installer
+	}
+
+	installer.registryTimeout = timeout
+
+	return installer
+}
+
+// WithPackageManifestTimeout sets how often, and for how long, the CatalogSource is polled for the package of the
+// bundle.
+func (installer *BundleInstaller) WithPackageManifestTimeout(interval, timeout time.Duration) *BundleInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting BundleInstaller packagemanifest interval to %s and timeout to %s", interval, timeout)
+
+	if interval <= 0 || timeout <= 0 {
+		installer.errorMsg = "BundleInstaller packagemanifest interval and timeout must be positive"
+
+		return installer
+	}
+
+	installer.packageCheckInterval = interval
+	installer.packageTimeout = timeout
+
+	return installer
+}
+
+// WithCSVTimeout sets how often, and for how long, the installed ClusterServiceVersion is polled until Succeeded.
+func (installer *BundleInstaller) WithCSVTimeout(interval, timeout time.Duration) *BundleInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting BundleInstaller CSV interval to %s and timeout to %s", interval, timeout)
+
+	if interval <= 0 || timeout <= 0 {
+		installer.errorMsg = "BundleInstaller CSV interval and timeout must be positive"
+
+		return installer
+	}
+
+	installer.csvCheckInterval = interval
+	installer.csvTimeout = timeout
+
+	return installer
+}
+
+// Install serves the bundle from a registry pod, installs its package and returns the Succeeded
+// ClusterServiceVersion. A failing step is reported as an *InstallError, after the resources created so far, all
+// but the namespace, have been deleted.
+func (installer *BundleInstaller) Install() (*ClusterServiceVersionBuilder, error) {
+	return installer.InstallContext(context.TODO())
+}
+
+// InstallContext serves the bundle from a registry pod, installs its package and returns the Succeeded
+// ClusterServiceVersion. The resources created so far are deleted on failure even once ctx is done.
+func (installer *BundleInstaller) InstallContext(ctx context.Context) (*ClusterServiceVersionBuilder, error) {
+	if valid, err := installer.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Installing bundle %s in namespace %s", installer.bundleImage, installer.namespaceName)
+
+	csvBuilder, err := installer.install(ctx)
+	if err != nil {
+		if cleanupErr := installer.Cleanup(); cleanupErr != nil {
+			return nil, errors.Join(err, cleanupErr)
+		}
+
+		return nil, err
+	}
+
+	return csvBuilder, nil
+}
+
+// Cleanup deletes the resources created by Install, in the reverse order of their creation. The namespace is kept.
+func (installer *BundleInstaller) Cleanup() error {
+	if valid, err := installer.validate(); !valid {
+		return err
+	}
+
+	var cleanupErrs []error
+
+	for index := len(installer.cleanups) - 1; index >= 0; index-- {
+		cleanup := installer.cleanups[index]
+
+		glog.V(100).Infof("Deleting %s of bundle %s", cleanup.resource, installer.bundleImage)
+
+		if err := cleanup.delete(); err != nil {
+			cleanupErrs = append(cleanupErrs, fmt.Errorf("failed to delete %s: %w", cleanup.resource, err))
+		}
+	}
+
+	installer.cleanups = nil
+
+	return errors.Join(cleanupErrs...)
+}
+
+// install runs the installation steps, registering the cleanup of every resource it creates.
+func (installer *BundleInstaller) install(ctx context.Context) (*ClusterServiceVersionBuilder, error) {
+	nsBuilder, err := ensureNamespace(ctx, installer.apiClient, installer.namespaceName, installer.namespaceLabels)
+	if err != nil {
+		return nil, installer.stepError(InstallStepNamespace, err)
+	}
+
+	installer.Namespace = nsBuilder
+
+	if err := installer.createRegistryPod(ctx); err != nil {
+		return nil, installer.stepError(InstallStepRegistryPod, err)
+	}
+
+	if err := installer.createRegistryService(ctx); err != nil {
+		return nil, installer.stepError(InstallStepRegistryPod, err)
+	}
+
+	if err := installer.createCatalogSource(ctx); err != nil {
+		return nil, installer.stepError(InstallStepCatalogSource, err)
+	}
+
+	pkgManifest, err := installer.waitForBundlePackage(ctx)
+	if err != nil {
+		return nil, installer.stepError(InstallStepBundle, err)
+	}
+
+	installer.PackageManifest = pkgManifest
+
+	channel, err := bundleChannel(pkgManifest.Object)
+	if err != nil {
+		return nil, installer.stepError(InstallStepBundle, err)
+	}
+
+	packageName := pkgManifest.Object.Name
+
+	glog.V(100).Infof("Bundle %s serves ClusterServiceVersion %s of package %s on channel %s",
+		installer.bundleImage, channel.CurrentCSV, packageName, channel.Name)
+
+	installer.Installer = NewOperatorInstaller(installer.apiClient, packageName, installer.namespaceName).
+		WithCatalogSourceNamespace(installer.namespaceName).
+		WithCatalogSources(installer.catalogSourceName).
+		WithNamespaceLabels(installer.namespaceLabels).
+		WithChannel(channel.Name).
+		WithStartingCSV(channel.CurrentCSV).
+		WithInstallMode(bundleInstallMode(channel)).
+		WithCSVTimeout(installer.csvCheckInterval, installer.csvTimeout)
+
+	ownOperatorGroup := !NewOperatorGroupBuilder(installer.apiClient, packageName, installer.namespaceName).Exists()
+
+	installer.addCleanup("subscription and clusterserviceversions of package "+packageName, func() error {
+		return installer.deleteOperator(packageName, ownOperatorGroup)
+	})
+
+	return installer.Installer.InstallContext(ctx)
+}
+
+// createRegistryPod creates the pod rendering the bundle into a file-based catalog and serving it over gRPC, and
+// waits for it to run.
+func (installer *BundleInstaller) createRegistryPod(ctx context.Context) error {
+	configsDir := bundleRegistryDir + "/configs"
+	templatePath := bundleRegistryDir + "/semver-template.yaml"
+
+	registryPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      installer.catalogSourceName,
+			Namespace: installer.namespaceName,
+			Labels:    map[string]string{bundleRegistryPodLabel: installer.catalogSourceName},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  bundleRegistryContainer,
+				Image: installer.registryImage,
+				Command: []string{"/bin/sh", "-c", fmt.Sprintf(
+					`mkdir -p %[1]s && printf '%%s' "$%[2]s" > %[3]s && `+
+						`/bin/opm alpha render-template semver -o yaml %[3]s > %[1]s/catalog.yaml && `+
+						`exec /bin/opm serve %[1]s -p %[4]d`,
+					configsDir, bundleRegistryTemplateEnv, templatePath, bundleRegistryPort)},
+				Env: []corev1.EnvVar{{
+					Name: bundleRegistryTemplateEnv, Value: bundleSemverTemplate(installer.bundleImage),
+				}},
+				Ports: []corev1.ContainerPort{{Name: "grpc", ContainerPort: bundleRegistryPort}},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name: "catalog", MountPath: bundleRegistryDir,
+				}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "catalog", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}},
+		},
+	}
+
+	glog.V(100).Infof("Creating registry pod %s for bundle %s", registryPod.Name, installer.bundleImage)
+
+	podBuilder, err := pod.NewBuilderFromDefinition(installer.apiClient, registryPod).CreateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create registry pod %s: %w", registryPod.Name, err)
+	}
+
+	installer.addCleanup("registry pod "+registryPod.Name, func() error {
+		if !podBuilder.Exists() {
+			return nil
+		}
+
+		_, err := podBuilder.Delete()
+
+		return err
+	})
+
+	if err := podBuilder.WaitUntilRunningContext(ctx, installer.registryTimeout); err != nil {
+		return fmt.Errorf("registry pod %s is not running: %w", registryPod.Name, err)
+	}
+
+	installer.RegistryPod = podBuilder
+
+	return nil
+}
+
+// createRegistryService creates the Service exposing the registry pod, so the CatalogSource keeps addressing the
+// registry if the pod is recreated with another IP.
+func (installer *BundleInstaller) createRegistryService(ctx context.Context) error {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      installer.catalogSourceName,
+			Namespace: installer.namespaceName,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{bundleRegistryPodLabel: installer.catalogSourceName},
+			Ports: []corev1.ServicePort{{
+				Name: "grpc", Port: bundleRegistryPort, TargetPort: intstr.FromInt32(bundleRegistryPort),
+			}},
+		},
+	}
+
+	glog.V(100).Infof("Creating registry service %s for bundle %s", service.Name, installer.bundleImage)
+
+	createdService, err := installer.apiClient.Services(installer.namespaceName).Create(ctx, service,
+		metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create registry service %s: %w", service.Name, err)
+	}
+
+	installer.addCleanup("registry service "+service.Name, func() error {
+		err := installer.apiClient.Services(installer.namespaceName).Delete(context.TODO(), service.Name,
+			metav1.DeleteOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	})
+
+	installer.RegistryService = createdService
+
+	return nil
+}
+
+// createCatalogSource creates the CatalogSource addressing the registry Service and waits for it to be ready.
+func (installer *BundleInstaller) createCatalogSource(ctx context.Context) error {
+	catalogSource := NewCatalogSourceBuilder(installer.apiClient, installer.catalogSourceName, installer.namespaceName)
+	catalogSource.Definition.Spec = operatorsV1alpha1.CatalogSourceSpec{
+		SourceType: operatorsV1alpha1.SourceTypeGrpc,
+		Address: fmt.Sprintf("%s.%s.svc:%d", installer.RegistryService.Name, installer.namespaceName,
+			bundleRegistryPort),
+		DisplayName: installer.bundleImage,
+		Publisher:   "nvidia-ci",
+	}
+
+	createdCatalogSource, err := catalogSource.CreateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create catalogsource %s: %w", installer.catalogSourceName, err)
+	}
+
+	installer.addCleanup("catalogsource "+installer.catalogSourceName, createdCatalogSource.Delete)
+
+	if !createdCatalogSource.IsReadyContext(ctx, installer.registryTimeout) {
+		return fmt.Errorf("catalogsource %s is not ready after %s", installer.catalogSourceName,
+			installer.registryTimeout)
+	}
+
+	installer.CatalogSource = createdCatalogSource
+
+	return nil
+}
+
+// waitForBundlePackage waits for the CatalogSource to serve the package of the bundle. The registry holds the
+// bundle only, so it serves exactly one package.
+func (installer *BundleInstaller) waitForBundlePackage(ctx context.Context) (*PackageManifestBuilder, error) {
+	var (
+		pkgManifest *pkgManifestV1.PackageManifest
+		waitErr     error
+	)
+
+	err := wait.PollUntilContextTimeout(
+		ctx, installer.packageCheckInterval, installer.packageTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			pkgManifestList, err := installer.apiClient.PackageManifestInterface.PackageManifests(
+				installer.namespaceName).List(ctx, metav1.ListOptions{
+				LabelSelector: fmt.Sprintf("catalog=%s", installer.catalogSourceName),
+			})
+			if err != nil {
+				waitErr = fmt.Errorf("failed to list packagemanifests of catalogsource %s: %w",
+					installer.catalogSourceName, err)
+
+				return false, nil
+			}
+
+			if len(pkgManifestList.Items) != 1 {
+				waitErr = fmt.Errorf("catalogsource %s serves %d packages", installer.catalogSourceName,
+					len(pkgManifestList.Items))
+
+				return false, nil
+			}
+
+			pkgManifest = &pkgManifestList.Items[0]
+
+			return true, nil
+		})
+	if err != nil {
+		if waitErr != nil {
+			return nil, fmt.Errorf("%w: %w", waitErr, err)
+		}
+
+		return nil, err
+	}
+
+	return &PackageManifestBuilder{apiClient: installer.apiClient, Object: pkgManifest, Definition: pkgManifest}, nil
+}
+
+// deleteOperator deletes the Subscription and the ClusterServiceVersions of the package, and the OperatorGroup when
+// it was created for the bundle.
+func (installer *BundleInstaller) deleteOperator(packageName string, ownOperatorGroup bool) error {
+	if subscription := installer.Installer.Subscription; subscription != nil {
+		if err := subscription.Delete(); err != nil {
+			return err
+		}
+	}
+
+	csvList, err := installer.apiClient.ClusterServiceVersions(installer.namespaceName).List(context.TODO(),
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("operators.coreos.com/%s.%s", packageName, installer.namespaceName),
+		})
+	if err != nil {
+		return err
+	}
+
+	for _, csv := range csvList.Items {
+		if err := installer.apiClient.ClusterServiceVersions(installer.namespaceName).Delete(context.TODO(),
+			csv.Name, metav1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+
+	if operatorGroup := installer.Installer.OperatorGroup; ownOperatorGroup && operatorGroup != nil {
+		return operatorGroup.Delete()
+	}
+
+	return nil
+}
+
+// addCleanup registers the deletion of a resource created by Install.
+func (installer *BundleInstaller) addCleanup(resource string, deleteFunc func() error) {
+	installer.cleanups = append(installer.cleanups, bundleCleanup{resource: resource, delete: deleteFunc})
+}
+
+// stepError wraps err into an *InstallError for the given step, naming the package once it is known.
+func (installer *BundleInstaller) stepError(step InstallStep, err error) error {
+	glog.V(100).Infof("Installing bundle %s failed at step %s: %v", installer.bundleImage, step, err)
+
+	packageName := installer.bundleImage
+	if installer.PackageManifest != nil {
+		packageName = installer.PackageManifest.Object.Name
+	}
+
+	return &InstallError{Step: step, Package: packageName, Err: err}
+}
+
+// validate will check that the installer is properly initialized before accessing any member fields.
+func (installer *BundleInstaller) validate() (bool, error) {
+	if installer == nil {
+		glog.V(100).Infof("The BundleInstaller is uninitialized")
+
+		return false, fmt.Errorf("error: received nil BundleInstaller")
+	}
+
+	if installer.errorMsg != "" {
+		glog.V(100).Infof("The BundleInstaller has error message: %s", installer.errorMsg)
+
+		return false, errors.New(installer.errorMsg)
+	}
+
+	return true, nil
+}
+
+// bundleChannel returns the default channel of the package served for a bundle.
+func bundleChannel(pkgManifest *pkgManifestV1.PackageManifest) (pkgManifestV1.PackageChannel, error) {
+	channelIndex := slices.IndexFunc(pkgManifest.Status.Channels, func(channel pkgManifestV1.PackageChannel) bool {
+		return channel.Name == pkgManifest.Status.DefaultChannel
+	})
+
+	if channelIndex < 0 {
+		return pkgManifestV1.PackageChannel{}, fmt.Errorf("package %s has no default channel %q",
+			pkgManifest.Name, pkgManifest.Status.DefaultChannel)
+	}
+
+	return pkgManifest.Status.Channels[channelIndex], nil
+}
+
+// bundleInstallMode returns OwnNamespace, unless the bundle only supports AllNamespaces.
+func bundleInstallMode(channel pkgManifestV1.PackageChannel) operatorsV1alpha1.InstallModeType {
+	installModes := channel.CurrentCSVDesc.InstallModes
+
+	if !slices.Contains(installModes, operatorsV1alpha1.InstallMode{
+		Type: operatorsV1alpha1.InstallModeTypeOwnNamespace, Supported: true}) &&
+		slices.Contains(installModes, operatorsV1alpha1.InstallMode{
+			Type: operatorsV1alpha1.InstallModeTypeAllNamespaces, Supported: true}) {
+		return operatorsV1alpha1.InstallModeTypeAllNamespaces
+	}
+
+	return operatorsV1alpha1.InstallModeTypeOwnNamespace
+}
+
+// bundleSemverTemplate returns the opm semver template of a catalog holding the bundle only. opm derives the package
+// and the default channel from the bundle, replacing the deprecated 'opm registry add --mode=semver' flow.
+func bundleSemverTemplate(bundleImage string) string {
+	return fmt.Sprintf("schema: olm.semver\ngenerateMajorChannels: true\ngenerateMinorChannels: false\n"+
+		"stable:\n  bundles:\n  - image: %s\n", bundleImage)
+}
+
+// bundleCatalogSourceName returns a resource name derived from the repository of the bundle image, e.g.
+// 'gpu-operator-bundle-catalog' for 'ghcr.io/nvidia/gpu-operator/gpu-operator-bundle:main-latest'.
+func bundleCatalogSourceName(bundleImage string) string {
+	repository, _, _ := strings.Cut(bundleImage, "@")
+
+	if slash := strings.LastIndex(repository, "/"); slash >= 0 {
+		repository = repository[slash+1:]
+	}
+
+	repository, _, _ = strings.Cut(repository, ":")
+
+	name := strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(repository), "-"), "-")
+	if len(name) > 55 {
+		name = strings.Trim(name[:55], "-")
+	}
+
+	return name + "-catalog"
+}
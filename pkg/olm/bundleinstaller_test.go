@@ -0,0 +1,220 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	testBundleImage       = "ghcr.io/nvidia/gpu-operator/gpu-operator-bundle:main-latest"
+	testBundleCatalogName = "gpu-operator-bundle-catalog"
+)
+
+// newTestBundleObjects returns the registry pod, in the given phase, and the ready CatalogSource of the test bundle.
+func newTestBundleObjects(phase corev1.PodPhase) []runtime.Object {
+	catalogSource := NewCatalogSourceBuilder(nil, testBundleCatalogName, testCSVNamespace).Definition
+	catalogSource.Status.GRPCConnectionState = &oplmV1alpha1.GRPCConnectionState{LastObservedState: "READY"}
+
+	return []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: testBundleCatalogName, Namespace: testCSVNamespace},
+			Status:     corev1.PodStatus{Phase: phase},
+		},
+		catalogSource,
+	}
+}
+
+// newTestBundlePackageManifest returns the PackageManifest served by the CatalogSource of the test bundle.
+func newTestBundlePackageManifest() runtime.Object {
+	pkgManifest := newTestPackageManifest(testBundleCatalogName, oplmV1alpha1.InstallModeTypeOwnNamespace)
+	pkgManifest.Namespace = testCSVNamespace
+
+	return pkgManifest
+}
+
+func newTestBundleInstaller(t *testing.T, fixtures []string, objects ...runtime.Object) *BundleInstaller {
+	t.Helper()
+
+	apiClient, err := testfixtures.NewTestClients(fixtures, objects...)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	return NewBundleInstaller(apiClient, testBundleImage, testCSVNamespace).
+		WithRegistryTimeout(10*time.Millisecond).
+		WithPackageManifestTimeout(time.Millisecond, 10*time.Millisecond).
+		WithCSVTimeout(time.Millisecond, 10*time.Millisecond)
+}
+
+func TestBundleInstallerInstall(t *testing.T) {
+	installer := newTestBundleInstaller(t, []string{testfixtures.CSV, testfixtures.Subscription},
+		append(newTestBundleObjects(corev1.PodRunning), newTestBundlePackageManifest())...)
+
+	csvBuilder, err := installer.Install()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if csvBuilder.Object.Name != testCSVName {
+		t.Errorf("expected CSV %s, got %s", testCSVName, csvBuilder.Object.Name)
+	}
+
+	if installer.Installer.CatalogSource != testBundleCatalogName || installer.Installer.Channel != "v24.9" {
+		t.Errorf("expected package %s to be installed from %s on v24.9, got %s on %s", testPackage,
+			testBundleCatalogName, installer.Installer.CatalogSource, installer.Installer.Channel)
+	}
+
+	if address := installer.CatalogSource.Definition.Spec.Address; address !=
+		testBundleCatalogName+"."+testCSVNamespace+".svc:50051" {
+		t.Errorf("expected the catalogsource to address the registry service, got %s", address)
+	}
+
+	container := installer.RegistryPod.Definition.Spec.Containers[0]
+	if container.Image != DefaultBundleRegistryImage || len(container.Env) != 1 ||
+		container.Env[0].Value != bundleSemverTemplate(testBundleImage) {
+		t.Errorf("expected the registry pod to render the semver template of the bundle, got %+v", container)
+	}
+
+	if !reflect.DeepEqual(installer.RegistryService.Spec.Selector, installer.RegistryPod.Definition.Labels) {
+		t.Errorf("expected the registry service to select the registry pod, got %v",
+			installer.RegistryService.Spec.Selector)
+	}
+
+	if err := installer.Cleanup(); err != nil {
+		t.Fatalf("unexpected cleanup error: %v", err)
+	}
+
+	_, err = installer.apiClient.Services(testCSVNamespace).Get(context.TODO(), testBundleCatalogName,
+		metav1.GetOptions{})
+	if installer.CatalogSource.Exists() || installer.RegistryPod.Exists() || !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the catalogsource, the registry pod and the registry service to be deleted")
+	}
+}
+
+func TestBundleInstallerStepErrors(t *testing.T) {
+	testCases := []struct {
+		name         string
+		objects      []runtime.Object
+		expectedStep InstallStep
+		expectedPkg  string
+	}{
+		{
+			name:         "registry pod not running",
+			objects:      newTestBundleObjects(corev1.PodPending)[:1],
+			expectedStep: InstallStepRegistryPod,
+			expectedPkg:  testBundleImage,
+		},
+		{
+			name:         "package not served",
+			objects:      newTestBundleObjects(corev1.PodRunning),
+			expectedStep: InstallStepBundle,
+			expectedPkg:  testBundleImage,
+		},
+		{
+			name:         "clusterserviceversion not installed",
+			objects:      append(newTestBundleObjects(corev1.PodRunning), newTestBundlePackageManifest()),
+			expectedStep: InstallStepCSV,
+			expectedPkg:  testPackage,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			installer := newTestBundleInstaller(t, nil, testCase.objects...)
+
+			_, err := installer.Install()
+
+			var installErr *InstallError
+			if !errors.As(err, &installErr) {
+				t.Fatalf("expected an InstallError, got %v", err)
+			}
+
+			if installErr.Step != testCase.expectedStep || installErr.Package != testCase.expectedPkg {
+				t.Errorf("expected step %s of %s, got %v", testCase.expectedStep, testCase.expectedPkg, err)
+			}
+
+			apiClient := installer.apiClient
+
+			registryPod, _ := pod.Pull(apiClient, testBundleCatalogName, testCSVNamespace)
+			_, err = apiClient.Services(testCSVNamespace).Get(context.TODO(), testBundleCatalogName, metav1.GetOptions{})
+
+			if registryPod != nil || !k8serrors.IsNotFound(err) || NewCatalogSourceBuilder(apiClient,
+				testBundleCatalogName, testCSVNamespace).Exists() {
+				t.Errorf("expected the registry pod, the registry service and the catalogsource to be deleted")
+			}
+
+			if NewSubscriptionBuilder(apiClient, testPackage, testCSVNamespace, testBundleCatalogName,
+				testCSVNamespace, testPackage).Exists() ||
+				NewOperatorGroupBuilder(apiClient, testPackage, testCSVNamespace).Exists() {
+				t.Errorf("expected the subscription and the operatorgroup to be deleted")
+			}
+		})
+	}
+}
+
+func TestBundleInstallerValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		installer     *BundleInstaller
+		expectedError string
+	}{
+		{
+			name:          "nil apiClient",
+			installer:     NewBundleInstaller(nil, testBundleImage, testCSVNamespace),
+			expectedError: "BundleInstaller cannot have nil apiClient",
+		},
+		{
+			name:          "empty registry image",
+			installer:     newTestBundleInstaller(t, nil).WithRegistryImage(""),
+			expectedError: "BundleInstaller registry image cannot be empty",
+		},
+		{
+			name:          "registry timeout",
+			installer:     newTestBundleInstaller(t, nil).WithRegistryTimeout(0),
+			expectedError: "BundleInstaller registry timeout must be positive",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := testCase.installer.Install(); err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestBundleSemverTemplate(t *testing.T) {
+	template := bundleSemverTemplate(testBundleImage)
+
+	if !strings.HasPrefix(template, "schema: olm.semver\n") ||
+		!strings.HasSuffix(template, "  - image: "+testBundleImage+"\n") {
+		t.Errorf("unexpected semver template:\n%s", template)
+	}
+}
+
+func TestBundleCatalogSourceName(t *testing.T) {
+	testCases := map[string]string{
+		testBundleImage: testBundleCatalogName,
+		"registry.example.com:5000/nvidia/Network_Operator-Bundle@sha256:0123": "network-operator-bundle-catalog",
+		"nvidia-network-operator-bundle":                                       "nvidia-network-operator-bundle-catalog",
+	}
+
+	for bundleImage, expectedName := range testCases {
+		if name := bundleCatalogSourceName(bundleImage); name != expectedName {
+			t.Errorf("expected catalogsource name %s for %s, got %s", expectedName, bundleImage, name)
+		}
+	}
+}
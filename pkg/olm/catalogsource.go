@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/golang/glog"
 	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/msg"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -113,9 +116,170 @@ func NewCatalogSourceBuilderWithIndexImage(apiClient *clients.Settings, name, ns
 	return &builder
 }
 
+// WithPriority sets the priority of the catalogsource. When several catalogsources serve the same package, OLM
+// resolves dependencies from the one with the highest priority first.
+func (builder *CatalogSourceBuilder) WithPriority(priority int) *CatalogSourceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting catalogsource %s priority to %d", builder.Definition.Name, priority)
+
+	builder.Definition.Spec.Priority = priority
+
+	return builder
+}
+
+// WithRegistryPollInterval sets how often OLM polls the index image of the catalogsource for a new version.
+func (builder *CatalogSourceBuilder) WithRegistryPollInterval(interval time.Duration) *CatalogSourceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting catalogsource %s registry poll interval to %s", builder.Definition.Name, interval)
+
+	if interval <= 0 {
+		glog.V(100).Infof("The registry poll interval of the catalogsource is not positive")
+
+		builder.errorMsg = "catalogsource registry poll interval must be positive"
+
+		return builder
+	}
+
+	builder.Definition.Spec.UpdateStrategy = &oplmV1alpha1.UpdateStrategy{
+		RegistryPoll: &oplmV1alpha1.RegistryPoll{RawInterval: interval.String()},
+	}
+
+	return builder
+}
+
+// WithNodeSelector sets the node selector of the catalogsource registry pod.
+func (builder *CatalogSourceBuilder) WithNodeSelector(nodeSelector map[string]string) *CatalogSourceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting catalogsource %s node selector to %v", builder.Definition.Name, nodeSelector)
+
+	builder.grpcPodConfig().NodeSelector = nodeSelector
+
+	return builder
+}
+
+// WithTolerations adds tolerations to the catalogsource registry pod.
+func (builder *CatalogSourceBuilder) WithTolerations(tolerations ...corev1.Toleration) *CatalogSourceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding %d tolerations to catalogsource %s", len(tolerations), builder.Definition.Name)
+
+	podConfig := builder.grpcPodConfig()
+	podConfig.Tolerations = append(podConfig.Tolerations, tolerations...)
+
+	return builder
+}
+
+// WithSecurityContextConfig sets the security context of the catalogsource registry pod, either legacy or
+// restricted.
+func (builder *CatalogSourceBuilder) WithSecurityContextConfig(
+	securityConfig oplmV1alpha1.SecurityConfig) *CatalogSourceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting catalogsource %s security context config to %s", builder.Definition.Name,
+		securityConfig)
+
+	if securityConfig != oplmV1alpha1.Legacy && securityConfig != oplmV1alpha1.Restricted {
+		glog.V(100).Infof("The security context config of the catalogsource is not supported")
+
+		builder.errorMsg = fmt.Sprintf("catalogsource security context config %q is not supported", securityConfig)
+
+		return builder
+	}
+
+	builder.grpcPodConfig().SecurityContextConfig = securityConfig
+
+	return builder
+}
+
+// WithFileBasedCatalog makes the registry pod serve the file-based catalog stored in catalogDir of the index
+// image, using the pre-computed cache in cacheDir when not empty, instead of running the image entrypoint.
+func (builder *CatalogSourceBuilder) WithFileBasedCatalog(catalogDir, cacheDir string) *CatalogSourceBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting catalogsource %s file-based catalog directory to %s and cache directory to %s",
+		builder.Definition.Name, catalogDir, cacheDir)
+
+	if catalogDir == "" {
+		glog.V(100).Infof("The file-based catalog directory of the catalogsource is empty")
+
+		builder.errorMsg = "catalogsource file-based catalog directory cannot be empty"
+
+		return builder
+	}
+
+	builder.grpcPodConfig().ExtractContent = &oplmV1alpha1.ExtractContentConfig{
+		CatalogDir: catalogDir,
+		CacheDir:   cacheDir,
+	}
+
+	return builder
+}
+
+// CatalogSourceOptions holds the optional settings of a catalogsource, typically read from the environment. Zero
+// values are left unset.
+type CatalogSourceOptions struct {
+	Priority                 int
+	RegistryPollInterval     time.Duration
+	NodeSelector             map[string]string
+	Tolerations              []corev1.Toleration
+	SecurityContextConfig    oplmV1alpha1.SecurityConfig
+	FileBasedCatalogDir      string
+	FileBasedCatalogCacheDir string
+}
+
+// WithOptions applies the set options with WithPriority, WithRegistryPollInterval, WithNodeSelector,
+// WithTolerations, WithSecurityContextConfig and WithFileBasedCatalog.
+func (builder *CatalogSourceBuilder) WithOptions(options CatalogSourceOptions) *CatalogSourceBuilder {
+	if options.Priority != 0 {
+		builder = builder.WithPriority(options.Priority)
+	}
+
+	if options.RegistryPollInterval != 0 {
+		builder = builder.WithRegistryPollInterval(options.RegistryPollInterval)
+	}
+
+	if len(options.NodeSelector) != 0 {
+		builder = builder.WithNodeSelector(options.NodeSelector)
+	}
+
+	if len(options.Tolerations) != 0 {
+		builder = builder.WithTolerations(options.Tolerations...)
+	}
+
+	if options.SecurityContextConfig != "" {
+		builder = builder.WithSecurityContextConfig(options.SecurityContextConfig)
+	}
+
+	if options.FileBasedCatalogDir != "" || options.FileBasedCatalogCacheDir != "" {
+		builder = builder.WithFileBasedCatalog(options.FileBasedCatalogDir, options.FileBasedCatalogCacheDir)
+	}
+
+	return builder
+}
+
 // PullCatalogSource loads an existing catalogsource into Builder struct.
-func PullCatalogSource(apiClient *clients.Settings, name, nsname string) (*CatalogSourceBuilder,
-	error) {
+func PullCatalogSource(apiClient *clients.Settings, name, nsname string) (*CatalogSourceBuilder, error) {
+	return PullCatalogSourceContext(context.TODO(), apiClient, name, nsname)
+}
+
+// PullCatalogSourceContext loads an existing catalogsource into Builder struct.
+func PullCatalogSourceContext(ctx context.Context, apiClient *clients.Settings, name,
+	nsname string) (*CatalogSourceBuilder, error) {
 	glog.V(100).Infof("Pulling existing catalogsource name %s in namespace %s", name, nsname)
 
 	builder := CatalogSourceBuilder{
@@ -136,7 +300,7 @@ func PullCatalogSource(apiClient *clients.Settings, name, nsname string) (*Catal
 		builder.errorMsg = errCatalogSourceNsnameEmpty
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("catalogsource object %s doesn't exist in namespace %s", name, nsname)
 	}
 
@@ -147,6 +311,11 @@ func PullCatalogSource(apiClient *clients.Settings, name, nsname string) (*Catal
 
 // Create makes an CatalogSourceBuilder in cluster and stores the created object in struct.
 func (builder *CatalogSourceBuilder) Create() (*CatalogSourceBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes an CatalogSourceBuilder in cluster and stores the created object in struct.
+func (builder *CatalogSourceBuilder) CreateContext(ctx context.Context) (*CatalogSourceBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -155,8 +324,8 @@ func (builder *CatalogSourceBuilder) Create() (*CatalogSourceBuilder, error) {
 		builder.Definition.Name, builder.Definition.Namespace)
 
 	var err error
-	if !builder.Exists() {
-		builder.Object, err = builder.apiClient.CatalogSources(builder.Definition.Namespace).Create(context.TODO(),
+	if !builder.ExistsContext(ctx) {
+		builder.Object, err = builder.apiClient.CatalogSources(builder.Definition.Namespace).Create(ctx,
 			builder.Definition, metav1.CreateOptions{})
 	}
 
@@ -165,6 +334,11 @@ func (builder *CatalogSourceBuilder) Create() (*CatalogSourceBuilder, error) {
 
 // Exists checks whether the given catalogsource exists.
 func (builder *CatalogSourceBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given catalogsource exists.
+func (builder *CatalogSourceBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -176,13 +350,18 @@ func (builder *CatalogSourceBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.apiClient.OperatorsV1alpha1Interface.CatalogSources(
 		builder.Definition.Namespace).Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
 // Delete removes a catalogsource.
 func (builder *CatalogSourceBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a catalogsource.
+func (builder *CatalogSourceBuilder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -190,11 +369,11 @@ func (builder *CatalogSourceBuilder) Delete() error {
 	glog.V(100).Infof("Deleting catalogsource %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil
 	}
 
-	err := builder.apiClient.CatalogSources(builder.Definition.Namespace).Delete(context.TODO(),
+	err := builder.apiClient.CatalogSources(builder.Definition.Namespace).Delete(ctx,
 		builder.Object.Name, metav1.DeleteOptions{})
 
 	if err != nil {
@@ -208,6 +387,11 @@ func (builder *CatalogSourceBuilder) Delete() error {
 
 // IsReady periodically checks if catalogsource is in Ready state.
 func (builder *CatalogSourceBuilder) IsReady(timeout time.Duration) bool {
+	return builder.IsReadyContext(context.TODO(), timeout)
+}
+
+// IsReadyContext periodically checks if catalogsource is in Ready state.
+func (builder *CatalogSourceBuilder) IsReadyContext(ctx context.Context, timeout time.Duration) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -215,15 +399,15 @@ func (builder *CatalogSourceBuilder) IsReady(timeout time.Duration) bool {
 	glog.V(100).Infof("Running periodic check until catalogsource '%s' in namespace '%s' is ready",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return false
 	}
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
 			builder.Object, err = builder.apiClient.CatalogSources(builder.Definition.Namespace).Get(
-				context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+				ctx, builder.Definition.Name, metav1.GetOptions{})
 
 			if err != nil {
 				return false, err
@@ -239,6 +423,105 @@ func (builder *CatalogSourceBuilder) IsReady(timeout time.Duration) bool {
 	return err == nil
 }
 
+// WaitForPackage periodically checks until the catalogsource is ready and serves the given package, and the given
+// channel of it when not empty, and returns the PackageManifest of the package.
+func (builder *CatalogSourceBuilder) WaitForPackage(packageName, channel string, interval, timeout time.Duration) (*PackageManifestBuilder, error) {
+	return builder.WaitForPackageContext(context.TODO(), packageName, channel, interval, timeout)
+}
+
+// WaitForPackageContext periodically checks until the catalogsource is ready and serves the given package, and the
+// given channel of it when not empty, and returns the PackageManifest of the package.
+func (builder *CatalogSourceBuilder) WaitForPackageContext(ctx context.Context, packageName, channel string,
+	interval, timeout time.Duration) (*PackageManifestBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Waiting up to %s for catalogsource %s in namespace %s to serve package %s on channel %q",
+		timeout, builder.Definition.Name, builder.Definition.Namespace, packageName, channel)
+
+	var (
+		pkgManifest *pkgManifestV1.PackageManifest
+		waitErr     error
+	)
+
+	err := wait.PollUntilContextTimeout(
+		ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			pkgManifest, waitErr = builder.servedPackage(ctx, packageName, channel)
+			if waitErr != nil {
+				glog.V(100).Infof("Catalogsource %s is not serving package %s yet: %v",
+					builder.Definition.Name, packageName, waitErr)
+
+				return false, nil
+			}
+
+			return true, nil
+		})
+	if err != nil {
+		if waitErr != nil {
+			return nil, fmt.Errorf("%w: %w", waitErr, err)
+		}
+
+		return nil, err
+	}
+
+	return &PackageManifestBuilder{apiClient: builder.apiClient, Object: pkgManifest, Definition: pkgManifest}, nil
+}
+
+// servedPackage returns the PackageManifest of the package when the catalogsource is ready and serves it.
+func (builder *CatalogSourceBuilder) servedPackage(
+	ctx context.Context, packageName, channel string) (*pkgManifestV1.PackageManifest, error) {
+	catalogSource, err := builder.apiClient.CatalogSources(builder.Definition.Namespace).Get(
+		ctx, builder.Definition.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalogsource %s: %w", builder.Definition.Name, err)
+	}
+
+	builder.Object = catalogSource
+
+	if catalogSource.Status.GRPCConnectionState == nil ||
+		catalogSource.Status.GRPCConnectionState.LastObservedState != "READY" {
+		return nil, fmt.Errorf("catalogsource %s is not ready", builder.Definition.Name)
+	}
+
+	pkgManifestList, err := builder.apiClient.PackageManifestInterface.PackageManifests(
+		builder.Definition.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("catalog=%s", builder.Definition.Name),
+		FieldSelector: fmt.Sprintf("metadata.name=%s", packageName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packagemanifests of catalogsource %s: %w", builder.Definition.Name, err)
+	}
+
+	for index := range pkgManifestList.Items {
+		pkgManifest := &pkgManifestList.Items[index]
+		if pkgManifest.Name != packageName {
+			continue
+		}
+
+		if channel != "" && !slices.ContainsFunc(pkgManifest.Status.Channels,
+			func(packageChannel pkgManifestV1.PackageChannel) bool {
+				return packageChannel.Name == channel
+			}) {
+			return nil, fmt.Errorf("package %s is served by catalogsource %s without channel %s",
+				packageName, builder.Definition.Name, channel)
+		}
+
+		return pkgManifest, nil
+	}
+
+	return nil, fmt.Errorf("package %s is not served by catalogsource %s", packageName, builder.Definition.Name)
+}
+
+// grpcPodConfig returns the registry pod configuration of the definition, creating it when unset.
+func (builder *CatalogSourceBuilder) grpcPodConfig() *oplmV1alpha1.GrpcPodConfig {
+	if builder.Definition.Spec.GrpcPodConfig == nil {
+		builder.Definition.Spec.GrpcPodConfig = &oplmV1alpha1.GrpcPodConfig{}
+	}
+
+	return builder.Definition.Spec.GrpcPodConfig
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *CatalogSourceBuilder) validate() (bool, error) {
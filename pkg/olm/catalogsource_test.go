@@ -0,0 +1,227 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testCatalogSource = "certified-operators-custom"
+
+func newTestCatalogSourceBuilder(t *testing.T, objects ...runtime.Object) *CatalogSourceBuilder {
+	t.Helper()
+
+	apiClient, err := testfixtures.NewTestClients(nil, objects...)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	return NewCatalogSourceBuilderWithIndexImage(apiClient, testCatalogSource, DefaultCatalogSourceNamespace,
+		"quay.io/example/index:latest", "Custom", "Red Hat")
+}
+
+func newTestReadyCatalogSource() *oplmV1alpha1.CatalogSource {
+	catalogSource := NewCatalogSourceBuilderWithIndexImage(nil, testCatalogSource, DefaultCatalogSourceNamespace,
+		"quay.io/example/index:latest", "Custom", "Red Hat").Definition
+	catalogSource.Status.GRPCConnectionState = &oplmV1alpha1.GRPCConnectionState{LastObservedState: "READY"}
+
+	return catalogSource
+}
+
+func TestCatalogSourceBuilderOptions(t *testing.T) {
+	toleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}
+
+	builder := newTestCatalogSourceBuilder(t).
+		WithPriority(-100).
+		WithRegistryPollInterval(10*time.Minute).
+		WithNodeSelector(map[string]string{"node-role.kubernetes.io/master": ""}).
+		WithTolerations(toleration).
+		WithSecurityContextConfig(oplmV1alpha1.Restricted).
+		WithFileBasedCatalog("/configs", "/tmp/cache")
+
+	if _, err := builder.Create(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spec := builder.Object.Spec
+	if spec.Priority != -100 || spec.UpdateStrategy == nil || spec.UpdateStrategy.RegistryPoll == nil ||
+		spec.UpdateStrategy.RegistryPoll.RawInterval != "10m0s" {
+		t.Errorf("unexpected priority or update strategy in %+v", spec)
+	}
+
+	expectedPodConfig := &oplmV1alpha1.GrpcPodConfig{
+		NodeSelector:          map[string]string{"node-role.kubernetes.io/master": ""},
+		Tolerations:           []corev1.Toleration{toleration},
+		SecurityContextConfig: oplmV1alpha1.Restricted,
+		ExtractContent:        &oplmV1alpha1.ExtractContentConfig{CatalogDir: "/configs", CacheDir: "/tmp/cache"},
+	}
+
+	if !reflect.DeepEqual(spec.GrpcPodConfig, expectedPodConfig) {
+		t.Errorf("expected grpcPodConfig %+v, got %+v", expectedPodConfig, spec.GrpcPodConfig)
+	}
+}
+
+func TestCatalogSourceBuilderWithOptions(t *testing.T) {
+	toleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}
+
+	builder := newTestCatalogSourceBuilder(t).WithOptions(CatalogSourceOptions{
+		Priority:              10,
+		RegistryPollInterval:  time.Hour,
+		Tolerations:           []corev1.Toleration{toleration},
+		SecurityContextConfig: oplmV1alpha1.Legacy,
+	})
+
+	if _, err := builder.Create(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spec := builder.Object.Spec
+	if spec.Priority != 10 || spec.UpdateStrategy == nil || spec.UpdateStrategy.RegistryPoll.RawInterval != "1h0m0s" {
+		t.Errorf("unexpected priority or update strategy in %+v", spec)
+	}
+
+	expectedPodConfig := &oplmV1alpha1.GrpcPodConfig{
+		Tolerations:           []corev1.Toleration{toleration},
+		SecurityContextConfig: oplmV1alpha1.Legacy,
+	}
+
+	if !reflect.DeepEqual(spec.GrpcPodConfig, expectedPodConfig) {
+		t.Errorf("expected grpcPodConfig %+v, got %+v", expectedPodConfig, spec.GrpcPodConfig)
+	}
+
+	builder = newTestCatalogSourceBuilder(t).WithOptions(CatalogSourceOptions{})
+	if builder.Definition.Spec.UpdateStrategy != nil || builder.Definition.Spec.GrpcPodConfig != nil {
+		t.Errorf("expected empty options to leave the spec unset, got %+v", builder.Definition.Spec)
+	}
+
+	_, err := newTestCatalogSourceBuilder(t).WithOptions(CatalogSourceOptions{FileBasedCatalogCacheDir: "/tmp/cache"}).
+		Create()
+	if err == nil || err.Error() != "catalogsource file-based catalog directory cannot be empty" {
+		t.Errorf("expected the cache directory without a catalog directory to be rejected, got %v", err)
+	}
+}
+
+func TestCatalogSourceBuilderOptionsValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		option        func(*CatalogSourceBuilder) *CatalogSourceBuilder
+		expectedError string
+	}{
+		{
+			name: "registry poll interval",
+			option: func(builder *CatalogSourceBuilder) *CatalogSourceBuilder {
+				return builder.WithRegistryPollInterval(0)
+			},
+			expectedError: "catalogsource registry poll interval must be positive",
+		},
+		{
+			name: "security context config",
+			option: func(builder *CatalogSourceBuilder) *CatalogSourceBuilder {
+				return builder.WithSecurityContextConfig("privileged")
+			},
+			expectedError: `catalogsource security context config "privileged" is not supported`,
+		},
+		{
+			name: "file-based catalog",
+			option: func(builder *CatalogSourceBuilder) *CatalogSourceBuilder {
+				return builder.WithFileBasedCatalog("", "/tmp/cache")
+			},
+			expectedError: "catalogsource file-based catalog directory cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.option(newTestCatalogSourceBuilder(t)).Create()
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCatalogSourceBuilderWaitForPackage(t *testing.T) {
+	testCases := []struct {
+		name          string
+		objects       []runtime.Object
+		channel       string
+		expectedError string
+	}{
+		{
+			name:    "package served",
+			objects: []runtime.Object{newTestReadyCatalogSource(), newTestPackageManifest(testCatalogSource)},
+			channel: "v24.9",
+		},
+		{
+			name:          "catalogsource not ready",
+			objects:       []runtime.Object{newTestPackageManifest(testCatalogSource)},
+			expectedError: "catalogsource certified-operators-custom is not ready",
+		},
+		{
+			name:          "package not served",
+			objects:       []runtime.Object{newTestReadyCatalogSource(), newTestPackageManifest("certified-operators")},
+			expectedError: "package gpu-operator-certified is not served by catalogsource certified-operators-custom",
+		},
+		{
+			name:    "channel not served",
+			objects: []runtime.Object{newTestReadyCatalogSource(), newTestPackageManifest(testCatalogSource)},
+			channel: "v25.3",
+			expectedError: "package gpu-operator-certified is served by catalogsource certified-operators-custom " +
+				"without channel v25.3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := newTestCatalogSourceBuilder(t, testCase.objects...)
+			if _, err := builder.Create(); err != nil {
+				t.Fatalf("failed to create the catalogsource: %v", err)
+			}
+
+			pkgManifest, err := builder.WaitForPackage(testPackage, testCase.channel, time.Millisecond,
+				10*time.Millisecond)
+
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if pkgManifest.Object.Status.CatalogSource != testCatalogSource {
+				t.Errorf("expected the package of catalogsource %s, got %s", testCatalogSource,
+					pkgManifest.Object.Status.CatalogSource)
+			}
+		})
+	}
+}
+
+func TestCatalogSourceBuilderWaitForPackageContext(t *testing.T) {
+	builder := newTestCatalogSourceBuilder(t, newTestPackageManifest(testCatalogSource))
+	if _, err := builder.CreateContext(context.TODO()); err != nil {
+		t.Fatalf("failed to create the catalogsource: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	start := time.Now()
+
+	_, err := builder.WaitForPackageContext(ctx, testPackage, "", time.Millisecond, time.Hour)
+	if !errors.Is(err, context.Canceled) || time.Since(start) > time.Minute {
+		t.Errorf("expected the wait to end with its cancelled context, got %v after %s", err, time.Since(start))
+	}
+}
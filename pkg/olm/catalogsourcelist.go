@@ -11,6 +11,12 @@ import (
 
 // ListCatalogSources returns catalogsource inventory in the given namespace.
 func ListCatalogSources(apiClient *clients.Settings, nsname string, options ...metav1.ListOptions) ([]*CatalogSourceBuilder, error) {
+	return ListCatalogSourcesContext(context.TODO(), apiClient, nsname, options...)
+}
+
+// ListCatalogSourcesContext returns catalogsource inventory in the given namespace.
+func ListCatalogSourcesContext(ctx context.Context, apiClient *clients.Settings, nsname string,
+	options ...metav1.ListOptions) ([]*CatalogSourceBuilder, error) {
 	if nsname == "" {
 		glog.V(100).Infof("catalogsource 'namespace' parameter can not be empty")
 		return nil, fmt.Errorf("failed to list catalogsource, 'namespace' parameter is empty")
@@ -33,7 +39,7 @@ func ListCatalogSources(apiClient *clients.Settings, nsname string, options ...m
 	glog.V(100).Infof(logMessage)
 
 	catalogSourceList, err := apiClient.OperatorsV1alpha1Interface.CatalogSources(nsname).List(
-		context.TODO(), passedOptions)
+		ctx, passedOptions)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list catalogsources in the namespace %s due to %s", nsname, err.Error())
@@ -0,0 +1,288 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/msg"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DefaultClusterCatalog is the ClusterCatalog of the Red Hat certified operators shipped with OpenShift.
+	DefaultClusterCatalog = "openshift-certified-operators"
+	// ClusterCatalogServingCondition is the condition of a ClusterCatalog serving its content.
+	ClusterCatalogServingCondition = "Serving"
+
+	errClusterCatalogNameEmpty = "clustercatalog 'name' cannot be empty"
+)
+
+// ClusterCatalogBuilder provides a struct for the OLM v1 clustercatalog object from the cluster and a
+// clustercatalog definition. The OLM v1 API is not vendored, so both are unstructured.
+type ClusterCatalogBuilder struct {
+	// ClusterCatalog definition. Used to create
+	// ClusterCatalog object with minimum set of required elements.
+	Definition *unstructured.Unstructured
+	// Created ClusterCatalog object on the cluster.
+	Object *unstructured.Unstructured
+	// api client to interact with the cluster.
+	apiClient *clients.Settings
+	// errorMsg is processed before ClusterCatalogBuilder object is created.
+	errorMsg string
+}
+
+// NewClusterCatalogBuilder creates new instance of ClusterCatalogBuilder serving the given catalog image.
+func NewClusterCatalogBuilder(apiClient *clients.Settings, name, catalogImage string) *ClusterCatalogBuilder {
+	glog.V(100).Infof("Initializing new clustercatalog structure with name '%s' and image '%s'", name, catalogImage)
+
+	builder := &ClusterCatalogBuilder{
+		apiClient: apiClient,
+		Definition: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": OLMv1APIVersion,
+			"kind":       "ClusterCatalog",
+			"metadata":   map[string]interface{}{"name": name},
+			"spec": map[string]interface{}{
+				"source": map[string]interface{}{
+					"type":  "Image",
+					"image": map[string]interface{}{"ref": catalogImage},
+				},
+			},
+		}},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the clustercatalog is empty")
+
+		builder.errorMsg = errClusterCatalogNameEmpty
+	}
+
+	if catalogImage == "" {
+		glog.V(100).Infof("The image of the clustercatalog is empty")
+
+		builder.errorMsg = "clustercatalog 'catalogImage' cannot be empty"
+	}
+
+	return builder
+}
+
+// WithPriority sets the priority of the clustercatalog. When several clustercatalogs serve the same bundle,
+// OLM v1 resolves it from the one with the highest priority.
+func (builder *ClusterCatalogBuilder) WithPriority(priority int32) *ClusterCatalogBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting clustercatalog %s priority to %d", builder.Definition.GetName(), priority)
+
+	builder.setSpecField(int64(priority), "priority")
+
+	return builder
+}
+
+// WithPollInterval makes OLM v1 poll the catalog image for updates at the given interval, in whole minutes.
+func (builder *ClusterCatalogBuilder) WithPollInterval(interval time.Duration) *ClusterCatalogBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting clustercatalog %s poll interval to %s", builder.Definition.GetName(), interval)
+
+	if interval < time.Minute || interval%time.Minute != 0 {
+		builder.errorMsg = "clustercatalog poll interval must be a positive number of minutes"
+
+		return builder
+	}
+
+	builder.setSpecField(int64(interval/time.Minute), "source", "image", "pollIntervalMinutes")
+
+	return builder
+}
+
+// PullClusterCatalog loads an existing clustercatalog into the ClusterCatalogBuilder struct.
+func PullClusterCatalog(apiClient *clients.Settings, name string) (*ClusterCatalogBuilder, error) {
+	return PullClusterCatalogContext(context.TODO(), apiClient, name)
+}
+
+// PullClusterCatalogContext loads an existing clustercatalog into the ClusterCatalogBuilder struct.
+func PullClusterCatalogContext(ctx context.Context, apiClient *clients.Settings,
+	name string) (*ClusterCatalogBuilder, error) {
+	glog.V(100).Infof("Pulling existing clustercatalog name %s", name)
+
+	builder := &ClusterCatalogBuilder{
+		apiClient: apiClient,
+		Definition: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": OLMv1APIVersion,
+			"kind":       "ClusterCatalog",
+			"metadata":   map[string]interface{}{"name": name},
+		}},
+	}
+
+	if name == "" {
+		builder.errorMsg = errClusterCatalogNameEmpty
+	}
+
+	if !builder.ExistsContext(ctx) {
+		return nil, fmt.Errorf("clustercatalog object %s doesn't exist", name)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// Create makes a clustercatalog in the cluster and stores the created object in struct.
+func (builder *ClusterCatalogBuilder) Create() (*ClusterCatalogBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a clustercatalog in the cluster and stores the created object in struct.
+func (builder *ClusterCatalogBuilder) CreateContext(ctx context.Context) (*ClusterCatalogBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the clustercatalog %s", builder.Definition.GetName())
+
+	var err error
+	if !builder.ExistsContext(ctx) {
+		builder.Object, err = builder.apiClient.Resource(ClusterCatalogGVR).Create(ctx,
+			builder.Definition, metav1.CreateOptions{})
+	}
+
+	return builder, err
+}
+
+// Exists checks whether the given clustercatalog exists.
+func (builder *ClusterCatalogBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given clustercatalog exists.
+func (builder *ClusterCatalogBuilder) ExistsContext(ctx context.Context) bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if clustercatalog %s exists", builder.Definition.GetName())
+
+	var err error
+	builder.Object, err = builder.apiClient.Resource(ClusterCatalogGVR).Get(ctx,
+		builder.Definition.GetName(), metav1.GetOptions{})
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Delete removes a clustercatalog.
+func (builder *ClusterCatalogBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a clustercatalog.
+func (builder *ClusterCatalogBuilder) DeleteContext(ctx context.Context) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting clustercatalog %s", builder.Definition.GetName())
+
+	if !builder.ExistsContext(ctx) {
+		return nil
+	}
+
+	err := builder.apiClient.Resource(ClusterCatalogGVR).Delete(ctx, builder.Definition.GetName(),
+		metav1.DeleteOptions{})
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// IsServing returns whether the clustercatalog reports its content as served.
+func (builder *ClusterCatalogBuilder) IsServing() bool {
+	if !builder.Exists() || builder.Object == nil {
+		return false
+	}
+
+	status, _, _ := olmv1Condition(builder.Object, ClusterCatalogServingCondition)
+
+	return status == string(metav1.ConditionTrue)
+}
+
+// WaitUntilServing waits for the clustercatalog to serve its content, e.g. once its image has been unpacked.
+func (builder *ClusterCatalogBuilder) WaitUntilServing(interval, timeout time.Duration) error {
+	return builder.WaitUntilServingContext(context.TODO(), interval, timeout)
+}
+
+// WaitUntilServingContext waits for the clustercatalog to serve its content, e.g. once its image has been unpacked.
+func (builder *ClusterCatalogBuilder) WaitUntilServingContext(ctx context.Context, interval,
+	timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting up to %s for clustercatalog %s to be serving", timeout, builder.Definition.GetName())
+
+	err := wait.PollUntilContextTimeout(
+		ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			return builder.IsServing(), nil
+		})
+	if err != nil {
+		if _, message, _ := olmv1Condition(builder.Object, ClusterCatalogServingCondition); message != "" {
+			return fmt.Errorf("clustercatalog %s is not serving: %s: %w", builder.Definition.GetName(), message, err)
+		}
+
+		return fmt.Errorf("clustercatalog %s is not serving: %w", builder.Definition.GetName(), err)
+	}
+
+	return nil
+}
+
+// setSpecField sets a field of the definition spec, recording a failure in errorMsg.
+func (builder *ClusterCatalogBuilder) setSpecField(value interface{}, fields ...string) {
+	if err := unstructured.SetNestedField(builder.Definition.Object, value,
+		append([]string{"spec"}, fields...)...); err != nil {
+		builder.errorMsg = fmt.Sprintf("failed to set clustercatalog field %v: %v", fields, err)
+	}
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *ClusterCatalogBuilder) validate() (bool, error) {
+	resourceCRD := "clustercatalog"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, errors.New(builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,71 @@
+package olm
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newTestClusterCatalog returns a ClusterCatalog definition serving its content or not.
+func newTestClusterCatalog(name string, serving bool) *unstructured.Unstructured {
+	status := "False"
+	if serving {
+		status = "True"
+	}
+
+	return newTestOLMv1Object(NewClusterCatalogBuilder(nil, name, "quay.io/example/catalog:latest").Definition,
+		ClusterCatalogServingCondition, status)
+}
+
+func TestClusterCatalogBuilder(t *testing.T) {
+	apiClient := newTestOLMv1Clients(t, nil)
+
+	builder := NewClusterCatalogBuilder(apiClient, "nvidia-catalog", "quay.io/example/catalog:latest").
+		WithPriority(-100).
+		WithPollInterval(10 * time.Minute)
+
+	if _, err := builder.Create(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSpec := map[string]interface{}{
+		"priority": int64(-100),
+		"source": map[string]interface{}{
+			"type": "Image",
+			"image": map[string]interface{}{
+				"ref":                 "quay.io/example/catalog:latest",
+				"pollIntervalMinutes": int64(10),
+			},
+		},
+	}
+
+	if spec := builder.Object.Object["spec"]; !reflect.DeepEqual(spec, expectedSpec) {
+		t.Errorf("expected spec %v, got %v", expectedSpec, spec)
+	}
+
+	if err := builder.WaitUntilServing(time.Millisecond, 10*time.Millisecond); err == nil ||
+		!strings.Contains(err.Error(), "clustercatalog nvidia-catalog is not serving") {
+		t.Errorf("expected the clustercatalog not to be serving, got %v", err)
+	}
+
+	servingCatalog := newTestOLMv1Object(builder.Object, ClusterCatalogServingCondition, "True")
+	if _, err := apiClient.Resource(ClusterCatalogGVR).UpdateStatus(context.TODO(), servingCatalog,
+		metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("failed to update the clustercatalog status: %v", err)
+	}
+
+	if err := builder.WaitUntilServing(time.Millisecond, 10*time.Millisecond); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := NewClusterCatalogBuilder(apiClient, "nvidia-catalog", "quay.io/example/catalog:latest").
+		WithPollInterval(90 * time.Second).Create(); err == nil ||
+		err.Error() != "clustercatalog poll interval must be a positive number of minutes" {
+		t.Errorf("expected a poll interval error, got %v", err)
+	}
+}
@@ -0,0 +1,537 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/msg"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// ClusterExtensionInstalledCondition is the condition of a ClusterExtension with its bundle installed.
+	ClusterExtensionInstalledCondition = "Installed"
+	// ClusterExtensionProgressingCondition is the condition of a ClusterExtension reconciling towards its bundle,
+	// whose message explains why an installation is stuck.
+	ClusterExtensionProgressingCondition = "Progressing"
+
+	errClusterExtensionNameEmpty = "clusterextension 'name' cannot be empty"
+)
+
+// UpgradeConstraintPolicy is how OLM v1 constrains the upgrades of a ClusterExtension.
+type UpgradeConstraintPolicy string
+
+const (
+	// UpgradeConstraintPolicyCatalogProvided only allows the upgrades of the catalog upgrade graph.
+	UpgradeConstraintPolicyCatalogProvided UpgradeConstraintPolicy = "CatalogProvided"
+	// UpgradeConstraintPolicySelfCertified allows upgrades and downgrades to any version of the package.
+	UpgradeConstraintPolicySelfCertified UpgradeConstraintPolicy = "SelfCertified"
+)
+
+// ClusterExtensionBuilder provides a struct for the OLM v1 clusterextension object from the cluster and a
+// clusterextension definition. The OLM v1 API is not vendored, so both are unstructured.
+type ClusterExtensionBuilder struct {
+	// ClusterExtension definition. Used to create
+	// ClusterExtension object with minimum set of required elements.
+	Definition *unstructured.Unstructured
+	// Created ClusterExtension object on the cluster.
+	Object *unstructured.Unstructured
+	// api client to interact with the cluster.
+	apiClient *clients.Settings
+	// errorMsg is processed before ClusterExtensionBuilder object is created.
+	errorMsg string
+}
+
+// NewClusterExtensionBuilder creates new instance of ClusterExtensionBuilder installing the given package in the
+// given namespace from any clustercatalog, with the permissions of the given ServiceAccount of that namespace.
+func NewClusterExtensionBuilder(
+	apiClient *clients.Settings, name, packageName, nsName, serviceAccountName string) *ClusterExtensionBuilder {
+	glog.V(100).Infof("Initializing new clusterextension structure with name '%s', package '%s', namespace '%s' "+
+		"and serviceaccount '%s'", name, packageName, nsName, serviceAccountName)
+
+	builder := &ClusterExtensionBuilder{
+		apiClient: apiClient,
+		Definition: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": OLMv1APIVersion,
+			"kind":       "ClusterExtension",
+			"metadata":   map[string]interface{}{"name": name},
+			"spec": map[string]interface{}{
+				"namespace":      nsName,
+				"serviceAccount": map[string]interface{}{"name": serviceAccountName},
+				"source": map[string]interface{}{
+					"sourceType": "Catalog",
+					"catalog":    map[string]interface{}{"packageName": packageName},
+				},
+			},
+		}},
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the clusterextension is empty")
+
+		builder.errorMsg = errClusterExtensionNameEmpty
+	}
+
+	if packageName == "" {
+		glog.V(100).Infof("The package of the clusterextension is empty")
+
+		builder.errorMsg = "clusterextension 'packageName' cannot be empty"
+	}
+
+	if nsName == "" {
+		glog.V(100).Infof("The namespace of the clusterextension is empty")
+
+		builder.errorMsg = "clusterextension 'nsName' cannot be empty"
+	}
+
+	if serviceAccountName == "" {
+		glog.V(100).Infof("The serviceaccount of the clusterextension is empty")
+
+		builder.errorMsg = "clusterextension 'serviceAccountName' cannot be empty"
+	}
+
+	return builder
+}
+
+// WithChannels restricts the bundles the clusterextension installs to those of the given channels.
+func (builder *ClusterExtensionBuilder) WithChannels(channels ...string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting clusterextension %s channels to %v", builder.Definition.GetName(), channels)
+
+	if len(channels) == 0 || slices.Contains(channels, "") {
+		builder.errorMsg = "clusterextension channels cannot be empty"
+
+		return builder
+	}
+
+	builder.setCatalogField(toInterfaceSlice(channels), "channels")
+
+	return builder
+}
+
+// WithVersion restricts the bundles the clusterextension installs to the given version or version range,
+// e.g. 24.9.2 or '>=24.9.0 <25.0.0'.
+func (builder *ClusterExtensionBuilder) WithVersion(version string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting clusterextension %s version to %s", builder.Definition.GetName(), version)
+
+	if version == "" {
+		builder.errorMsg = "clusterextension version cannot be empty"
+
+		return builder
+	}
+
+	builder.setCatalogField(version, "version")
+
+	return builder
+}
+
+// WithCatalogs restricts the clustercatalogs the package is resolved from to the given ones.
+func (builder *ClusterExtensionBuilder) WithCatalogs(catalogs ...string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting clusterextension %s clustercatalogs to %v", builder.Definition.GetName(), catalogs)
+
+	if len(catalogs) == 0 || slices.Contains(catalogs, "") {
+		builder.errorMsg = "clusterextension clustercatalogs cannot be empty"
+
+		return builder
+	}
+
+	builder.setCatalogField(map[string]interface{}{
+		"matchExpressions": []interface{}{
+			map[string]interface{}{
+				"key":      ClusterCatalogNameLabel,
+				"operator": string(metav1.LabelSelectorOpIn),
+				"values":   toInterfaceSlice(catalogs),
+			},
+		},
+	}, "selector")
+
+	return builder
+}
+
+// WithUpgradeConstraintPolicy sets how the upgrades of the clusterextension are constrained, CatalogProvided by
+// default.
+func (builder *ClusterExtensionBuilder) WithUpgradeConstraintPolicy(
+	policy UpgradeConstraintPolicy) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting clusterextension %s upgrade constraint policy to %s",
+		builder.Definition.GetName(), policy)
+
+	if policy != UpgradeConstraintPolicyCatalogProvided && policy != UpgradeConstraintPolicySelfCertified {
+		builder.errorMsg = fmt.Sprintf("clusterextension upgrade constraint policy %q is not supported", policy)
+
+		return builder
+	}
+
+	builder.setCatalogField(string(policy), "upgradeConstraintPolicy")
+
+	return builder
+}
+
+// WithWatchNamespace sets the namespace watched by the operator. OLM v1 installs operators in AllNamespaces mode
+// unless a watch namespace is configured: operators supporting only OwnNamespace or SingleNamespace, like the GPU
+// operator, need it.
+func (builder *ClusterExtensionBuilder) WithWatchNamespace(nsName string) *ClusterExtensionBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting clusterextension %s watch namespace to %s", builder.Definition.GetName(), nsName)
+
+	if nsName == "" {
+		builder.errorMsg = "clusterextension watch namespace cannot be empty"
+
+		return builder
+	}
+
+	if err := unstructured.SetNestedField(builder.Definition.Object, map[string]interface{}{
+		"configType": "Inline",
+		"inline":     map[string]interface{}{"watchNamespace": nsName},
+	}, "spec", "config"); err != nil {
+		builder.errorMsg = fmt.Sprintf("failed to set clusterextension watch namespace: %v", err)
+	}
+
+	return builder
+}
+
+// PullClusterExtension loads an existing clusterextension into the ClusterExtensionBuilder struct.
+func PullClusterExtension(apiClient *clients.Settings, name string) (*ClusterExtensionBuilder, error) {
+	return PullClusterExtensionContext(context.TODO(), apiClient, name)
+}
+
+// PullClusterExtensionContext loads an existing clusterextension into the ClusterExtensionBuilder struct.
+func PullClusterExtensionContext(ctx context.Context, apiClient *clients.Settings,
+	name string) (*ClusterExtensionBuilder, error) {
+	glog.V(100).Infof("Pulling existing clusterextension name %s", name)
+
+	builder := &ClusterExtensionBuilder{
+		apiClient: apiClient,
+		Definition: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": OLMv1APIVersion,
+			"kind":       "ClusterExtension",
+			"metadata":   map[string]interface{}{"name": name},
+		}},
+	}
+
+	if name == "" {
+		builder.errorMsg = errClusterExtensionNameEmpty
+	}
+
+	if !builder.ExistsContext(ctx) {
+		return nil, fmt.Errorf("clusterextension object %s doesn't exist", name)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// Create makes a clusterextension in the cluster and stores the created object in struct.
+func (builder *ClusterExtensionBuilder) Create() (*ClusterExtensionBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a clusterextension in the cluster and stores the created object in struct.
+func (builder *ClusterExtensionBuilder) CreateContext(ctx context.Context) (*ClusterExtensionBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the clusterextension %s", builder.Definition.GetName())
+
+	var err error
+	if !builder.ExistsContext(ctx) {
+		builder.Object, err = builder.apiClient.Resource(ClusterExtensionGVR).Create(ctx,
+			builder.Definition, metav1.CreateOptions{})
+	}
+
+	return builder, err
+}
+
+// Exists checks whether the given clusterextension exists.
+func (builder *ClusterExtensionBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given clusterextension exists.
+func (builder *ClusterExtensionBuilder) ExistsContext(ctx context.Context) bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if clusterextension %s exists", builder.Definition.GetName())
+
+	var err error
+	builder.Object, err = builder.apiClient.Resource(ClusterExtensionGVR).Get(ctx,
+		builder.Definition.GetName(), metav1.GetOptions{})
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// Update modifies the existing clusterextension with the definition in ClusterExtensionBuilder, e.g. to upgrade
+// the operator to another channel or version.
+func (builder *ClusterExtensionBuilder) Update() (*ClusterExtensionBuilder, error) {
+	return builder.UpdateContext(context.TODO())
+}
+
+// UpdateContext modifies the existing clusterextension with the definition in ClusterExtensionBuilder, e.g. to upgrade
+// the operator to another channel or version.
+func (builder *ClusterExtensionBuilder) UpdateContext(ctx context.Context) (*ClusterExtensionBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating clusterextension %s", builder.Definition.GetName())
+
+	if !builder.ExistsContext(ctx) {
+		return builder, fmt.Errorf("clusterextension %s does not exist", builder.Definition.GetName())
+	}
+
+	builder.Definition.SetResourceVersion(builder.Object.GetResourceVersion())
+
+	var err error
+	builder.Object, err = builder.apiClient.Resource(ClusterExtensionGVR).Update(ctx,
+		builder.Definition, metav1.UpdateOptions{})
+
+	return builder, err
+}
+
+// Delete removes a clusterextension. OLM v1 then uninstalls the operator, including its CustomResourceDefinitions.
+func (builder *ClusterExtensionBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a clusterextension. OLM v1 then uninstalls the operator, including its
+// CustomResourceDefinitions.
+func (builder *ClusterExtensionBuilder) DeleteContext(ctx context.Context) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting clusterextension %s", builder.Definition.GetName())
+
+	if !builder.ExistsContext(ctx) {
+		return nil
+	}
+
+	err := builder.apiClient.Resource(ClusterExtensionGVR).Delete(ctx, builder.Definition.GetName(),
+		metav1.DeleteOptions{})
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// IsInstalled returns whether the clusterextension reports its bundle as installed.
+func (builder *ClusterExtensionBuilder) IsInstalled() bool {
+	if !builder.Exists() || builder.Object == nil {
+		return false
+	}
+
+	status, _, _ := olmv1Condition(builder.Object, ClusterExtensionInstalledCondition)
+
+	return status == string(metav1.ConditionTrue)
+}
+
+// WaitUntilInstalled waits for the clusterextension to install its bundle. On timeout, the error carries the
+// reason OLM v1 reports for the installation not progressing.
+func (builder *ClusterExtensionBuilder) WaitUntilInstalled(interval, timeout time.Duration) error {
+	return builder.WaitUntilInstalledContext(context.TODO(), interval, timeout)
+}
+
+// WaitUntilInstalledContext waits for the clusterextension to install its bundle. On timeout, the error carries the
+// reason OLM v1 reports for the installation not progressing.
+func (builder *ClusterExtensionBuilder) WaitUntilInstalledContext(ctx context.Context, interval,
+	timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting up to %s for clusterextension %s to be installed", timeout,
+		builder.Definition.GetName())
+
+	err := wait.PollUntilContextTimeout(
+		ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			return builder.IsInstalled(), nil
+		})
+	if err != nil {
+		for _, conditionType := range []string{ClusterExtensionProgressingCondition,
+			ClusterExtensionInstalledCondition} {
+			if _, message, _ := olmv1Condition(builder.Object, conditionType); message != "" {
+				return fmt.Errorf("clusterextension %s is not installed: %s: %w", builder.Definition.GetName(),
+					message, err)
+			}
+		}
+
+		return fmt.Errorf("clusterextension %s is not installed: %w", builder.Definition.GetName(), err)
+	}
+
+	return nil
+}
+
+// WaitUntilUpgradedContext waits for the clusterextension to replace fromBundle with another installed bundle, and
+// with toBundle when not empty. OLM v1 upgrades one edge of the upgrade graph at a time, so toBundle is only
+// reached once every edge leading to it was installed. It returns the installed bundle.
+func (builder *ClusterExtensionBuilder) WaitUntilUpgradedContext(ctx context.Context, fromBundle, toBundle string,
+	interval, timeout time.Duration) (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Infof("Waiting up to %s for clusterextension %s to upgrade from bundle %s to %q", timeout,
+		builder.Definition.GetName(), fromBundle, toBundle)
+
+	var installedBundle string
+
+	err := wait.PollUntilContextTimeout(
+		ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.ExistsContext(ctx) || builder.Object == nil {
+				return false, nil
+			}
+
+			if status, _, _ := olmv1Condition(builder.Object, ClusterExtensionInstalledCondition); status !=
+				string(metav1.ConditionTrue) {
+				return false, nil
+			}
+
+			installedBundle, _, _ = unstructured.NestedString(builder.Object.Object, "status", "install", "bundle",
+				"name")
+
+			return installedBundle != fromBundle && (toBundle == "" || installedBundle == toBundle), nil
+		})
+	if err != nil {
+		return installedBundle, fmt.Errorf("clusterextension %s did not upgrade from bundle %s, installed bundle "+
+			"is %q: %w", builder.Definition.GetName(), fromBundle, installedBundle, err)
+	}
+
+	return installedBundle, nil
+}
+
+// InstalledBundle returns the name and version of the bundle installed by the clusterextension, e.g.
+// gpu-operator-certified.v24.9.2 and 24.9.2.
+func (builder *ClusterExtensionBuilder) InstalledBundle() (string, string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", "", err
+	}
+
+	if !builder.Exists() || builder.Object == nil {
+		return "", "", fmt.Errorf("clusterextension %s does not exist", builder.Definition.GetName())
+	}
+
+	name, _, _ := unstructured.NestedString(builder.Object.Object, "status", "install", "bundle", "name")
+	version, _, _ := unstructured.NestedString(builder.Object.Object, "status", "install", "bundle", "version")
+
+	if name == "" {
+		return "", "", fmt.Errorf("clusterextension %s has no installed bundle", builder.Definition.GetName())
+	}
+
+	return name, version, nil
+}
+
+// GetAlmExamples returns the alm-examples of the installed bundle. OLM v1 does not create a
+// ClusterServiceVersion, so they are read from the pod template of the operator deployments it installed.
+func (builder *ClusterExtensionBuilder) GetAlmExamples() (string, error) {
+	return builder.GetAlmExamplesContext(context.TODO())
+}
+
+// GetAlmExamplesContext returns the alm-examples of the installed bundle, using ctx to list the deployments.
+func (builder *ClusterExtensionBuilder) GetAlmExamplesContext(ctx context.Context) (string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", err
+	}
+
+	nsName, _, _ := unstructured.NestedString(builder.Definition.Object, "spec", "namespace")
+
+	glog.V(100).Infof("Getting the alm-examples of clusterextension %s from its deployments in namespace %s",
+		builder.Definition.GetName(), nsName)
+
+	deploymentList, err := builder.apiClient.AppsV1Interface.Deployments(nsName).List(ctx,
+		metav1.ListOptions{LabelSelector: ClusterExtensionOwnerNameLabel + "=" + builder.Definition.GetName()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list the deployments of clusterextension %s: %w",
+			builder.Definition.GetName(), err)
+	}
+
+	for _, deployment := range deploymentList.Items {
+		if almExamples := deployment.Spec.Template.Annotations[AlmExamplesAnnotation]; almExamples != "" {
+			return almExamples, nil
+		}
+	}
+
+	return "", fmt.Errorf("no deployment of clusterextension %s in namespace %s has %s",
+		builder.Definition.GetName(), nsName, AlmExamplesAnnotation)
+}
+
+// setCatalogField sets a field of the catalog source of the definition, recording a failure in errorMsg.
+func (builder *ClusterExtensionBuilder) setCatalogField(value interface{}, fields ...string) {
+	if err := unstructured.SetNestedField(builder.Definition.Object, value,
+		append([]string{"spec", "source", "catalog"}, fields...)...); err != nil {
+		builder.errorMsg = fmt.Sprintf("failed to set clusterextension field %v: %v", fields, err)
+	}
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *ClusterExtensionBuilder) validate() (bool, error) {
+	resourceCRD := "clusterextension"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, errors.New(builder.errorMsg)
+	}
+
+	return true, nil
+}
+
+// toInterfaceSlice converts a string slice into the slice type of unstructured objects.
+func toInterfaceSlice(values []string) []interface{} {
+	items := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		items = append(items, value)
+	}
+
+	return items
+}
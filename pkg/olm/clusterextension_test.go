@@ -0,0 +1,282 @@
+package olm
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testServiceAccount = testPackage + installerServiceAccountSuffix
+
+// newTestOLMv1Object returns an OLM v1 object with the given status conditions, of type and status pairs.
+func newTestOLMv1Object(definition *unstructured.Unstructured, conditions ...string) *unstructured.Unstructured {
+	object := definition.DeepCopy()
+
+	var statusConditions []interface{}
+	for index := 0; index+1 < len(conditions); index += 2 {
+		statusConditions = append(statusConditions, map[string]interface{}{
+			"type": conditions[index], "status": conditions[index+1], "message": conditions[index] + " message"})
+	}
+
+	_ = unstructured.SetNestedSlice(object.Object, statusConditions, "status", "conditions")
+
+	return object
+}
+
+func newTestInstalledClusterExtension() *unstructured.Unstructured {
+	extension := newTestOLMv1Object(NewClusterExtensionBuilder(nil, testPackage, testPackage,
+		testCSVNamespace, testServiceAccount).Definition, ClusterExtensionInstalledCondition, "True")
+
+	_ = unstructured.SetNestedField(extension.Object, map[string]interface{}{
+		"name": testCSVName, "version": "24.9.2"}, "status", "install", "bundle")
+
+	return extension
+}
+
+func newTestOperatorDeployment(almExamples string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gpu-operator",
+			Namespace: testCSVNamespace,
+			Labels:    map[string]string{ClusterExtensionOwnerNameLabel: testPackage},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AlmExamplesAnnotation: almExamples}},
+			},
+		},
+	}
+}
+
+// newTestOLMv1Clients returns test clients serving the given OLM v1 objects through the dynamic client.
+func newTestOLMv1Clients(t *testing.T, olmv1Objects []*unstructured.Unstructured,
+	objects ...runtime.Object) *clients.Settings {
+	t.Helper()
+
+	apiClient, err := testfixtures.NewTestClients(nil, objects...)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	for _, object := range olmv1Objects {
+		gvr := ClusterExtensionGVR
+		if object.GetKind() == "ClusterCatalog" {
+			gvr = ClusterCatalogGVR
+		}
+
+		if _, err := apiClient.Resource(gvr).Create(context.TODO(), object, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create %s %s: %v", object.GetKind(), object.GetName(), err)
+		}
+	}
+
+	return apiClient
+}
+
+func TestClusterExtensionBuilderOptions(t *testing.T) {
+	builder := NewClusterExtensionBuilder(newTestOLMv1Clients(t, nil), testPackage, testPackage, testCSVNamespace,
+		testServiceAccount).
+		WithChannels("v24.9").
+		WithVersion(">=24.9.0 <25.0.0").
+		WithCatalogs(DefaultClusterCatalog).
+		WithUpgradeConstraintPolicy(UpgradeConstraintPolicySelfCertified).
+		WithWatchNamespace(testCSVNamespace)
+
+	if _, err := builder.Create(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSpec := map[string]interface{}{
+		"namespace":      testCSVNamespace,
+		"serviceAccount": map[string]interface{}{"name": testServiceAccount},
+		"source": map[string]interface{}{
+			"sourceType": "Catalog",
+			"catalog": map[string]interface{}{
+				"packageName": testPackage,
+				"channels":    []interface{}{"v24.9"},
+				"version":     ">=24.9.0 <25.0.0",
+				"selector": map[string]interface{}{
+					"matchExpressions": []interface{}{map[string]interface{}{
+						"key":      ClusterCatalogNameLabel,
+						"operator": "In",
+						"values":   []interface{}{DefaultClusterCatalog},
+					}},
+				},
+				"upgradeConstraintPolicy": "SelfCertified",
+			},
+		},
+		"config": map[string]interface{}{
+			"configType": "Inline",
+			"inline":     map[string]interface{}{"watchNamespace": testCSVNamespace},
+		},
+	}
+
+	if spec := builder.Object.Object["spec"]; !reflect.DeepEqual(spec, expectedSpec) {
+		t.Errorf("expected spec %v, got %v", expectedSpec, spec)
+	}
+
+	pulledBuilder, err := PullClusterExtension(builder.apiClient, testPackage)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterextension: %v", err)
+	}
+
+	pulledBuilder.WithChannels("v25.3")
+
+	if _, err := pulledBuilder.Update(); err != nil {
+		t.Fatalf("failed to update the clusterextension: %v", err)
+	}
+
+	if channels, _, _ := unstructured.NestedStringSlice(pulledBuilder.Object.Object, "spec", "source", "catalog",
+		"channels"); !reflect.DeepEqual(channels, []string{"v25.3"}) {
+		t.Errorf("expected the updated channels [v25.3], got %v", channels)
+	}
+
+	if err := pulledBuilder.Delete(); err != nil || pulledBuilder.Exists() {
+		t.Errorf("expected the clusterextension to be deleted, got %v", err)
+	}
+}
+
+func TestClusterExtensionBuilderValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		builder       func(*clients.Settings) *ClusterExtensionBuilder
+		expectedError string
+	}{
+		{
+			name: "empty serviceaccount",
+			builder: func(apiClient *clients.Settings) *ClusterExtensionBuilder {
+				return NewClusterExtensionBuilder(apiClient, testPackage, testPackage, testCSVNamespace, "")
+			},
+			expectedError: "clusterextension 'serviceAccountName' cannot be empty",
+		},
+		{
+			name: "empty channel",
+			builder: func(apiClient *clients.Settings) *ClusterExtensionBuilder {
+				return NewClusterExtensionBuilder(apiClient, testPackage, testPackage, testCSVNamespace,
+					testServiceAccount).WithChannels("")
+			},
+			expectedError: "clusterextension channels cannot be empty",
+		},
+		{
+			name: "unsupported upgrade constraint policy",
+			builder: func(apiClient *clients.Settings) *ClusterExtensionBuilder {
+				return NewClusterExtensionBuilder(apiClient, testPackage, testPackage, testCSVNamespace,
+					testServiceAccount).WithUpgradeConstraintPolicy("Ignore")
+			},
+			expectedError: `clusterextension upgrade constraint policy "Ignore" is not supported`,
+		},
+		{
+			name: "nil apiClient",
+			builder: func(*clients.Settings) *ClusterExtensionBuilder {
+				return NewClusterExtensionBuilder(nil, testPackage, testPackage, testCSVNamespace, testServiceAccount)
+			},
+			expectedError: "clusterextension builder cannot have nil apiClient",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.builder(newTestOLMv1Clients(t, nil)).Create()
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestClusterExtensionBuilderInstalledBundle(t *testing.T) {
+	apiClient := newTestOLMv1Clients(t, []*unstructured.Unstructured{newTestInstalledClusterExtension()},
+		newTestOperatorDeployment(`[{"kind": "ClusterPolicy"}]`))
+
+	extension, err := PullClusterExtension(apiClient, testPackage)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterextension: %v", err)
+	}
+
+	if err := extension.WaitUntilInstalled(time.Millisecond, 10*time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	name, version, err := extension.InstalledBundle()
+	if err != nil || name != testCSVName || version != "24.9.2" {
+		t.Errorf("expected bundle %s version 24.9.2, got %q %q: %v", testCSVName, name, version, err)
+	}
+
+	almExamples, err := extension.GetAlmExamples()
+	if err != nil || almExamples != `[{"kind": "ClusterPolicy"}]` {
+		t.Errorf("expected the alm-examples of the operator deployment, got %q: %v", almExamples, err)
+	}
+}
+
+func TestClusterExtensionBuilderWaitUntilInstalled(t *testing.T) {
+	extension := newTestOLMv1Object(NewClusterExtensionBuilder(nil, testPackage, testPackage, testCSVNamespace,
+		testServiceAccount).Definition, ClusterExtensionInstalledCondition, "False",
+		ClusterExtensionProgressingCondition, "True")
+
+	apiClient := newTestOLMv1Clients(t, []*unstructured.Unstructured{extension})
+
+	builder, err := PullClusterExtension(apiClient, testPackage)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterextension: %v", err)
+	}
+
+	err = builder.WaitUntilInstalled(time.Millisecond, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(),
+		"clusterextension gpu-operator-certified is not installed: Progressing message") {
+		t.Errorf("expected the Progressing message in the error, got %v", err)
+	}
+
+	if _, _, err := builder.InstalledBundle(); err == nil ||
+		err.Error() != "clusterextension gpu-operator-certified has no installed bundle" {
+		t.Errorf("expected no installed bundle, got %v", err)
+	}
+
+	if _, err := builder.GetAlmExamples(); err == nil || !strings.Contains(err.Error(), "has alm-examples") {
+		t.Errorf("expected no alm-examples, got %v", err)
+	}
+}
+
+func TestClusterExtensionBuilderWaitUntilUpgraded(t *testing.T) {
+	apiClient := newTestOLMv1Clients(t, []*unstructured.Unstructured{newTestInstalledClusterExtension()})
+
+	builder, err := PullClusterExtension(apiClient, testPackage)
+	if err != nil {
+		t.Fatalf("failed to pull the clusterextension: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		fromBundle    string
+		toBundle      string
+		expectedError bool
+	}{
+		{name: "upgraded to any bundle", fromBundle: "gpu-operator-certified.v24.6.2"},
+		{name: "upgraded to the target bundle", fromBundle: "gpu-operator-certified.v24.6.2", toBundle: testCSVName},
+		{name: "not upgraded", fromBundle: testCSVName, expectedError: true},
+		{name: "upgraded to another bundle", fromBundle: "gpu-operator-certified.v24.6.2",
+			toBundle: "gpu-operator-certified.v25.3.0", expectedError: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			installedBundle, err := builder.WaitUntilUpgradedContext(context.TODO(), testCase.fromBundle,
+				testCase.toBundle, time.Millisecond, 10*time.Millisecond)
+			if installedBundle != testCSVName {
+				t.Errorf("expected installed bundle %s, got %q", testCSVName, installedBundle)
+			}
+
+			if testCase.expectedError != (err != nil) {
+				t.Errorf("expected error %t, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
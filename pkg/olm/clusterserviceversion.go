@@ -30,6 +30,12 @@ type ClusterServiceVersionBuilder struct {
 
 // PullClusterServiceVersion loads an existing clusterserviceversion into Builder struct.
 func PullClusterServiceVersion(apiClient *clients.Settings, name, namespace string) (*ClusterServiceVersionBuilder, error) {
+	return PullClusterServiceVersionContext(context.TODO(), apiClient, name, namespace)
+}
+
+// PullClusterServiceVersionContext loads an existing clusterserviceversion into Builder struct.
+func PullClusterServiceVersionContext(ctx context.Context, apiClient *clients.Settings, name,
+	namespace string) (*ClusterServiceVersionBuilder, error) {
 	glog.V(100).Infof("Pulling existing clusterserviceversion name %s in namespace %s", name, namespace)
 
 	builder := ClusterServiceVersionBuilder{
@@ -50,7 +56,7 @@ func PullClusterServiceVersion(apiClient *clients.Settings, name, namespace stri
 		builder.errorMsg = "clusterserviceversion 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("clusterserviceversion object %s doesn't exist in namespace %s", name, namespace)
 	}
 
@@ -61,6 +67,11 @@ func PullClusterServiceVersion(apiClient *clients.Settings, name, namespace stri
 
 // Exists checks whether the given clusterserviceversion exists.
 func (builder *ClusterServiceVersionBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given clusterserviceversion exists.
+func (builder *ClusterServiceVersionBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -72,13 +83,18 @@ func (builder *ClusterServiceVersionBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.apiClient.OperatorsV1alpha1Interface.ClusterServiceVersions(
 		builder.Definition.Namespace).Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
 // Delete removes a clusterserviceversion.
 func (builder *ClusterServiceVersionBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a clusterserviceversion.
+func (builder *ClusterServiceVersionBuilder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -86,11 +102,11 @@ func (builder *ClusterServiceVersionBuilder) Delete() error {
 	glog.V(100).Infof("Deleting clusterserviceversion %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil
 	}
 
-	err := builder.apiClient.ClusterServiceVersions(builder.Definition.Namespace).Delete(context.TODO(),
+	err := builder.apiClient.ClusterServiceVersions(builder.Definition.Namespace).Delete(ctx,
 		builder.Object.Name, metav1.DeleteOptions{})
 
 	if err != nil {
@@ -11,9 +11,12 @@ import (
 )
 
 // ListClusterServiceVersion returns clusterserviceversion inventory in the given namespace.
-func ListClusterServiceVersion(
-	apiClient *clients.Settings,
-	nsname string,
+func ListClusterServiceVersion(apiClient *clients.Settings, nsname string, options ...metav1.ListOptions) ([]*ClusterServiceVersionBuilder, error) {
+	return ListClusterServiceVersionContext(context.TODO(), apiClient, nsname, options...)
+}
+
+// ListClusterServiceVersionContext returns clusterserviceversion inventory in the given namespace.
+func ListClusterServiceVersionContext(ctx context.Context, apiClient *clients.Settings, nsname string,
 	options ...metav1.ListOptions) ([]*ClusterServiceVersionBuilder, error) {
 	if nsname == "" {
 		glog.V(100).Infof("clusterserviceversion 'nsname' parameter can not be empty")
@@ -38,7 +41,7 @@ func ListClusterServiceVersion(
 	glog.V(100).Infof(logMessage)
 
 	csvList, err := apiClient.OperatorsV1alpha1Interface.ClusterServiceVersions(nsname).List(
-		context.TODO(), passedOptions)
+		ctx, passedOptions)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list clusterserviceversion in the nsname %s due to %s", nsname, err.Error())
@@ -64,11 +67,14 @@ func ListClusterServiceVersion(
 
 // ListClusterServiceVersionWithNamePattern returns a cluster-wide clusterserviceversion inventory
 // filtered by the name pattern.
-func ListClusterServiceVersionWithNamePattern(
-	apiClient *clients.Settings,
-	namePattern string,
-	nsname string,
-	options ...metav1.ListOptions) ([]*ClusterServiceVersionBuilder, error) {
+func ListClusterServiceVersionWithNamePattern(apiClient *clients.Settings, namePattern string, nsname string, options ...metav1.ListOptions) ([]*ClusterServiceVersionBuilder, error) {
+	return ListClusterServiceVersionWithNamePatternContext(context.TODO(), apiClient, namePattern, nsname, options...)
+}
+
+// ListClusterServiceVersionWithNamePatternContext returns a cluster-wide clusterserviceversion inventory
+// filtered by the name pattern.
+func ListClusterServiceVersionWithNamePatternContext(ctx context.Context, apiClient *clients.Settings,
+	namePattern string, nsname string, options ...metav1.ListOptions) ([]*ClusterServiceVersionBuilder, error) {
 	if namePattern == "" {
 		glog.V(100).Info(
 			"The namePattern field to filter out all relevant clusterserviceversion cannot be empty")
@@ -80,7 +86,7 @@ func ListClusterServiceVersionWithNamePattern(
 	glog.V(100).Infof("Listing clusterserviceversion filtered by the name pattern %s in %s namespace",
 		namePattern, nsname)
 
-	notFilteredCsvList, err := ListClusterServiceVersion(apiClient, nsname, options...)
+	notFilteredCsvList, err := ListClusterServiceVersionContext(ctx, apiClient, nsname, options...)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list all clusterserviceversions in namespace %s due to %s",
@@ -101,8 +107,12 @@ func ListClusterServiceVersionWithNamePattern(
 }
 
 // ListClusterServiceVersionInAllNamespaces returns cluster-wide clusterserviceversion inventory.
-func ListClusterServiceVersionInAllNamespaces(
-	apiClient *clients.Settings,
+func ListClusterServiceVersionInAllNamespaces(apiClient *clients.Settings, options ...metav1.ListOptions) ([]*ClusterServiceVersionBuilder, error) {
+	return ListClusterServiceVersionInAllNamespacesContext(context.TODO(), apiClient, options...)
+}
+
+// ListClusterServiceVersionInAllNamespacesContext returns cluster-wide clusterserviceversion inventory.
+func ListClusterServiceVersionInAllNamespacesContext(ctx context.Context, apiClient *clients.Settings,
 	options ...metav1.ListOptions) ([]*ClusterServiceVersionBuilder, error) {
 	passedOptions := metav1.ListOptions{}
 	logMessage := "Listing CSVs in all namespaces"
@@ -120,7 +130,7 @@ func ListClusterServiceVersionInAllNamespaces(
 
 	glog.V(100).Infof(logMessage)
 
-	csvList, err := apiClient.ClusterServiceVersions("").List(context.TODO(), passedOptions)
+	csvList, err := apiClient.ClusterServiceVersions("").List(ctx, passedOptions)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list CSVs in all namespaces due to %s", err.Error())
@@ -0,0 +1,454 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/golang/glog"
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// CSVDiagnosisReportFile is the report file a CSVDiagnosis is written to.
+	CSVDiagnosisReportFile = "csv-diagnosis.log"
+	// CSVConditionsLimit is the number of most recent ClusterServiceVersion conditions kept in a CSVDiagnosis.
+	CSVConditionsLimit = 5
+	// BundleUnpackLogLines is the number of last log lines kept per container of a bundle unpack job pod.
+	BundleUnpackLogLines = 20
+
+	bundleUnpackJobLabel = "operatorframework.io/bundle-unpack-ref"
+)
+
+// BundleUnpackJob is an OLM job unpacking the bundle of a ClusterServiceVersion.
+type BundleUnpackJob struct {
+	Name      string
+	Namespace string
+	// Status is Active, Complete or Failed, followed by the reason of a failure.
+	Status string
+	// Logs are the last BundleUnpackLogLines log lines of the job pod containers, keyed by "pod/container".
+	Logs map[string]string
+}
+
+// CSVDiagnosis gathers why a ClusterServiceVersion did not reach the Succeeded phase.
+type CSVDiagnosis struct {
+	Name      string
+	Namespace string
+	// Found is false when the ClusterServiceVersion does not exist, e.g. while its bundle is still unpacked.
+	Found   bool
+	Phase   oplmV1alpha1.ClusterServiceVersionPhase
+	Reason  oplmV1alpha1.ConditionReason
+	Message string
+	// Conditions are the last CSVConditionsLimit conditions of the ClusterServiceVersion, oldest first.
+	Conditions []string
+	// UnmetRequirements are the CRD, RBAC and other requirements not present or not satisfied.
+	UnmetRequirements []string
+	// InstallPlan is the name of the most recent InstallPlan installing the ClusterServiceVersion.
+	InstallPlan        string
+	InstallPlanPhase   oplmV1alpha1.InstallPlanPhase
+	InstallPlanMessage string
+	UnpackJobs         []BundleUnpackJob
+	// DeploymentPods are the status of the pods of the operator Deployments, or a note per
+	// Deployment missing or without pods.
+	DeploymentPods []string
+}
+
+// String returns the ClusterServiceVersion phase followed by its conditions, unmet requirements, InstallPlan,
+// bundle unpack jobs and operator Deployment pods.
+func (diagnosis *CSVDiagnosis) String() string {
+	var builder strings.Builder
+
+	if diagnosis.Found {
+		fmt.Fprintf(&builder, "ClusterServiceVersion %s is %s in namespace %s", diagnosis.Name,
+			diagnosis.Phase, diagnosis.Namespace)
+
+		if diagnosis.Reason != "" || diagnosis.Message != "" {
+			fmt.Fprintf(&builder, ": %s: %s", diagnosis.Reason, diagnosis.Message)
+		}
+	} else {
+		fmt.Fprintf(&builder, "ClusterServiceVersion %s not found in namespace %s", diagnosis.Name,
+			diagnosis.Namespace)
+	}
+
+	writeSection(&builder, "conditions", diagnosis.Conditions)
+	writeSection(&builder, "unmet requirements", diagnosis.UnmetRequirements)
+
+	if diagnosis.InstallPlan != "" {
+		fmt.Fprintf(&builder, "\n  installplan %s is %s", diagnosis.InstallPlan, diagnosis.InstallPlanPhase)
+
+		if diagnosis.InstallPlanMessage != "" {
+			fmt.Fprintf(&builder, ": %s", diagnosis.InstallPlanMessage)
+		}
+	}
+
+	for _, job := range diagnosis.UnpackJobs {
+		fmt.Fprintf(&builder, "\n  bundle unpack job %s/%s is %s", job.Namespace, job.Name, job.Status)
+
+		containers := make([]string, 0, len(job.Logs))
+		for container := range job.Logs {
+			containers = append(containers, container)
+		}
+
+		slices.Sort(containers)
+
+		for _, container := range containers {
+			fmt.Fprintf(&builder, "\n    %s logs:\n      %s", container,
+				strings.ReplaceAll(strings.TrimSpace(job.Logs[container]), "\n", "\n      "))
+		}
+	}
+
+	writeSection(&builder, "operator pods", diagnosis.DeploymentPods)
+
+	return builder.String()
+}
+
+// WriteReport writes the diagnosis to the given file of the report directory.
+func (diagnosis *CSVDiagnosis) WriteReport(generalConfig *config.GeneralConfig, fileName string) error {
+	return generalConfig.WriteReport(fileName, []byte(diagnosis.String()+"\n"))
+}
+
+// CSVNotSucceededError is returned when a ClusterServiceVersion does not reach the Succeeded phase in time.
+type CSVNotSucceededError struct {
+	Diagnosis *CSVDiagnosis
+	Err       error
+}
+
+// Error implements the error interface.
+func (csvErr *CSVNotSucceededError) Error() string {
+	return fmt.Sprintf("ClusterServiceVersion %s did not succeed: %v\n%s", csvErr.Diagnosis.Name, csvErr.Err,
+		csvErr.Diagnosis)
+}
+
+// Unwrap returns the underlying error.
+func (csvErr *CSVNotSucceededError) Unwrap() error {
+	return csvErr.Err
+}
+
+// WrapCSVNotSucceededError annotates err, returned while waiting for the ClusterServiceVersion to succeed, with its
+// diagnosis as a *CSVNotSucceededError. err is returned as is if the diagnosis fails.
+func WrapCSVNotSucceededError(apiClient *clients.Settings, csvName, nsName string, err error) error {
+	return WrapCSVNotSucceededErrorContext(context.TODO(), apiClient, csvName, nsName, err)
+}
+
+// WrapCSVNotSucceededErrorContext annotates err with the diagnosis of the ClusterServiceVersion like
+// WrapCSVNotSucceededError, using ctx for the API calls of the diagnosis.
+func WrapCSVNotSucceededErrorContext(ctx context.Context, apiClient *clients.Settings, csvName, nsName string,
+	err error) error {
+	diagnosis, diagnoseErr := DiagnoseCSVContext(ctx, apiClient, csvName, nsName)
+	if diagnoseErr != nil {
+		glog.V(100).Infof("Failed to diagnose ClusterServiceVersion %s: %v", csvName, diagnoseErr)
+
+		return err
+	}
+
+	return &CSVNotSucceededError{Diagnosis: diagnosis, Err: err}
+}
+
+// DiagnoseCSV reports the phase, conditions and unmet requirements of the ClusterServiceVersion, the phase of the
+// InstallPlan installing it along with the status and logs of its bundle unpack jobs, and the status of the pods
+// of the operator Deployments.
+func DiagnoseCSV(apiClient *clients.Settings, csvName, nsName string) (*CSVDiagnosis, error) {
+	return DiagnoseCSVContext(context.TODO(), apiClient, csvName, nsName)
+}
+
+// DiagnoseCSVContext diagnoses the ClusterServiceVersion like DiagnoseCSV, using ctx for the API calls.
+func DiagnoseCSVContext(ctx context.Context, apiClient *clients.Settings, csvName,
+	nsName string) (*CSVDiagnosis, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("cannot diagnose ClusterServiceVersion %s with nil apiClient", csvName)
+	}
+
+	glog.V(100).Infof("Diagnosing ClusterServiceVersion %s in namespace %s", csvName, nsName)
+
+	diagnosis := &CSVDiagnosis{Name: csvName, Namespace: nsName}
+
+	csv, err := apiClient.ClusterServiceVersions(nsName).Get(ctx, csvName, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get ClusterServiceVersion %s: %w", csvName, err)
+	}
+
+	if err == nil {
+		diagnosis.Found = true
+		diagnosis.inspectCSV(csv)
+	}
+
+	if err := diagnosis.inspectInstallPlan(ctx, apiClient); err != nil {
+		return nil, err
+	}
+
+	if diagnosis.Found {
+		if err := diagnosis.inspectDeployments(ctx, apiClient, csv); err != nil {
+			return nil, err
+		}
+	}
+
+	return diagnosis, nil
+}
+
+// inspectCSV records the phase, last conditions and unmet requirements of the ClusterServiceVersion.
+func (diagnosis *CSVDiagnosis) inspectCSV(csv *oplmV1alpha1.ClusterServiceVersion) {
+	diagnosis.Phase = csv.Status.Phase
+	diagnosis.Reason = csv.Status.Reason
+	diagnosis.Message = csv.Status.Message
+
+	conditions := csv.Status.Conditions
+	if len(conditions) > CSVConditionsLimit {
+		conditions = conditions[len(conditions)-CSVConditionsLimit:]
+	}
+
+	for _, condition := range conditions {
+		diagnosis.Conditions = append(diagnosis.Conditions, fmt.Sprintf("%s: %s: %s", condition.Phase,
+			condition.Reason, condition.Message))
+	}
+
+	for _, requirement := range csv.Status.RequirementStatus {
+		if requirement.Status != oplmV1alpha1.RequirementStatusReasonPresent {
+			diagnosis.UnmetRequirements = append(diagnosis.UnmetRequirements, fmt.Sprintf("%s %s: %s: %s",
+				requirement.Kind, requirement.Name, requirement.Status, requirement.Message))
+		}
+
+		for _, dependent := range requirement.Dependents {
+			if dependent.Status == oplmV1alpha1.DependentStatusReasonSatisfied {
+				continue
+			}
+
+			diagnosis.UnmetRequirements = append(diagnosis.UnmetRequirements, fmt.Sprintf("%s %s dependent %s: %s: %s",
+				requirement.Kind, requirement.Name, dependent.Kind, dependent.Status, dependent.Message))
+		}
+	}
+}
+
+// inspectInstallPlan records the most recent InstallPlan listing the ClusterServiceVersion and the bundle unpack
+// jobs of its pending bundle lookups.
+func (diagnosis *CSVDiagnosis) inspectInstallPlan(ctx context.Context, apiClient *clients.Settings) error {
+	installPlanList, err := apiClient.InstallPlans(diagnosis.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list installplans in namespace %s: %w", diagnosis.Namespace, err)
+	}
+
+	var installPlan *oplmV1alpha1.InstallPlan
+
+	for index := range installPlanList.Items {
+		candidate := &installPlanList.Items[index]
+		if !slices.Contains(candidate.Spec.ClusterServiceVersionNames, diagnosis.Name) {
+			continue
+		}
+
+		if installPlan == nil || installPlan.CreationTimestamp.Before(&candidate.CreationTimestamp) {
+			installPlan = candidate
+		}
+	}
+
+	if installPlan == nil {
+		return nil
+	}
+
+	diagnosis.InstallPlan = installPlan.Name
+	diagnosis.InstallPlanPhase = installPlan.Status.Phase
+
+	messages := []string{installPlan.Status.Message}
+
+	for _, condition := range installPlan.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			messages = append(messages, condition.Message)
+		}
+	}
+
+	diagnosis.InstallPlanMessage = strings.Join(slices.DeleteFunc(messages, func(message string) bool {
+		return message == ""
+	}), "; ")
+
+	for _, bundleLookup := range installPlan.Status.BundleLookups {
+		if bundleLookup.CatalogSourceRef == nil {
+			continue
+		}
+
+		jobs, err := bundleUnpackJobs(ctx, apiClient, bundleLookup)
+		if err != nil {
+			return err
+		}
+
+		diagnosis.UnpackJobs = append(diagnosis.UnpackJobs, jobs...)
+	}
+
+	return nil
+}
+
+// inspectDeployments records the pods of the Deployments of the ClusterServiceVersion install strategy.
+func (diagnosis *CSVDiagnosis) inspectDeployments(ctx context.Context, apiClient *clients.Settings,
+	csv *oplmV1alpha1.ClusterServiceVersion) error {
+	for _, deploymentSpec := range csv.Spec.InstallStrategy.StrategySpec.DeploymentSpecs {
+		deployment, err := apiClient.Deployments(diagnosis.Namespace).Get(ctx, deploymentSpec.Name,
+			metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			diagnosis.DeploymentPods = append(diagnosis.DeploymentPods,
+				fmt.Sprintf("deployment %s not found", deploymentSpec.Name))
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to get deployment %s: %w", deploymentSpec.Name, err)
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return fmt.Errorf("failed to parse the selector of deployment %s: %w", deployment.Name, err)
+		}
+
+		podList, err := apiClient.Pods(diagnosis.Namespace).List(ctx,
+			metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return fmt.Errorf("failed to list the pods of deployment %s: %w", deployment.Name, err)
+		}
+
+		if len(podList.Items) == 0 {
+			diagnosis.DeploymentPods = append(diagnosis.DeploymentPods,
+				fmt.Sprintf("deployment %s has no pods", deployment.Name))
+		}
+
+		for _, pod := range podList.Items {
+			diagnosis.DeploymentPods = append(diagnosis.DeploymentPods,
+				deploymentPodStatus(deployment.Name, &pod))
+		}
+	}
+
+	return nil
+}
+
+// bundleUnpackJobs returns the OLM jobs unpacking the bundle image of the bundle lookup, with the logs of their pods.
+func bundleUnpackJobs(ctx context.Context, apiClient *clients.Settings,
+	bundleLookup oplmV1alpha1.BundleLookup) ([]BundleUnpackJob, error) {
+	jobNamespace := bundleLookup.CatalogSourceRef.Namespace
+
+	jobList, err := apiClient.K8sClient.BatchV1().Jobs(jobNamespace).List(ctx,
+		metav1.ListOptions{LabelSelector: bundleUnpackJobLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bundle unpack jobs in namespace %s: %w", jobNamespace, err)
+	}
+
+	var unpackJobs []BundleUnpackJob
+
+	for _, job := range jobList.Items {
+		if !unpacksBundle(&job, bundleLookup.Path) {
+			continue
+		}
+
+		unpackJob := BundleUnpackJob{Name: job.Name, Namespace: job.Namespace, Status: jobStatus(&job),
+			Logs: make(map[string]string)}
+
+		podList, err := apiClient.Pods(jobNamespace).List(ctx,
+			metav1.ListOptions{LabelSelector: "job-name=" + job.Name})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the pods of job %s: %w", job.Name, err)
+		}
+
+		for _, pod := range podList.Items {
+			for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...),
+				pod.Spec.Containers...) {
+				if logs := tailLogs(ctx, apiClient, &pod, container.Name); logs != "" {
+					unpackJob.Logs[pod.Name+"/"+container.Name] = logs
+				}
+			}
+		}
+
+		unpackJobs = append(unpackJobs, unpackJob)
+	}
+
+	return unpackJobs, nil
+}
+
+// unpacksBundle returns true if one of the job containers runs the bundle image.
+func unpacksBundle(job *batchv1.Job, bundlePath string) bool {
+	podSpec := job.Spec.Template.Spec
+
+	return slices.ContainsFunc(append(append([]corev1.Container{}, podSpec.InitContainers...),
+		podSpec.Containers...), func(container corev1.Container) bool {
+		return container.Image == bundlePath
+	})
+}
+
+// jobStatus returns Complete or Failed, with the failure reason, once the job finished, and Active otherwise.
+func jobStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobFailed:
+			return fmt.Sprintf("%s: %s: %s", condition.Type, condition.Reason, condition.Message)
+		case batchv1.JobComplete:
+			return string(condition.Type)
+		}
+	}
+
+	return "Active"
+}
+
+// tailLogs returns the last BundleUnpackLogLines log lines of the pod container, or an empty string if they
+// cannot be read.
+func tailLogs(ctx context.Context, apiClient *clients.Settings, pod *corev1.Pod, containerName string) string {
+	logStream, err := apiClient.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Container: containerName, TailLines: ptr.To(int64(BundleUnpackLogLines))}).Stream(ctx)
+	if err != nil {
+		glog.V(100).Infof("Failed to read the logs of pod %s container %s: %v", pod.Name, containerName, err)
+
+		return ""
+	}
+
+	defer logStream.Close()
+
+	logs, err := io.ReadAll(logStream)
+	if err != nil {
+		glog.V(100).Infof("Failed to read the logs of pod %s container %s: %v", pod.Name, containerName, err)
+
+		return ""
+	}
+
+	return string(logs)
+}
+
+// deploymentPodStatus returns the pod of the Deployment formatted as "deployment/name on node: phase", followed by
+// the waiting or termination reason of its first failing container, e.g. ImagePullBackOff.
+func deploymentPodStatus(deploymentName string, pod *corev1.Pod) string {
+	status := fmt.Sprintf("%s/%s on %s: %s", deploymentName, pod.Name, pod.Spec.NodeName, pod.Status.Phase)
+
+	for _, containerStatus := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+		pod.Status.ContainerStatuses...) {
+		switch {
+		case containerStatus.State.Waiting != nil && containerStatus.State.Waiting.Reason != "PodInitializing":
+			return fmt.Sprintf("%s, container %s %s", status, containerStatus.Name,
+				containerStatus.State.Waiting.Reason)
+		case containerStatus.State.Terminated != nil && containerStatus.State.Terminated.ExitCode != 0:
+			return fmt.Sprintf("%s, container %s %s", status, containerStatus.Name,
+				containerStatus.State.Terminated.Reason)
+		case containerStatus.State.Running != nil && !containerStatus.Ready:
+			return fmt.Sprintf("%s, container %s not ready", status, containerStatus.Name)
+		}
+	}
+
+	return status
+}
+
+// writeSection writes the titled lines, if any, indented under the summary.
+func writeSection(builder *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Fprintf(builder, "\n  %s:", title)
+
+	for _, line := range lines {
+		fmt.Fprintf(builder, "\n    %s", line)
+	}
+}
@@ -0,0 +1,205 @@
+package olm
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	oplmV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/config"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testBundlePath = "registry.connect.redhat.com/nvidia/gpu-operator-bundle@sha256:0123"
+
+// newTestInstallingObjects returns a ClusterServiceVersion stuck in Installing with its InstallPlan, a failed bundle
+// unpack job and an operator Deployment whose pod cannot pull its image.
+func newTestInstallingObjects() []runtime.Object {
+	csv := &oplmV1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: testCSVName, Namespace: testCSVNamespace},
+		Spec: oplmV1alpha1.ClusterServiceVersionSpec{
+			InstallStrategy: oplmV1alpha1.NamedInstallStrategy{
+				StrategySpec: oplmV1alpha1.StrategyDetailsDeployment{
+					DeploymentSpecs: []oplmV1alpha1.StrategyDeploymentSpec{
+						{Name: "gpu-operator"}, {Name: "gpu-operator-webhook"}},
+				},
+			},
+		},
+		Status: oplmV1alpha1.ClusterServiceVersionStatus{
+			Phase:   oplmV1alpha1.CSVPhaseInstalling,
+			Reason:  oplmV1alpha1.CSVReasonWaiting,
+			Message: "installing: waiting for deployment gpu-operator to become ready",
+			Conditions: []oplmV1alpha1.ClusterServiceVersionCondition{
+				{Phase: oplmV1alpha1.CSVPhasePending, Reason: oplmV1alpha1.CSVReasonRequirementsUnknown},
+				{Phase: oplmV1alpha1.CSVPhasePending, Reason: oplmV1alpha1.CSVReasonRequirementsNotMet},
+				{Phase: oplmV1alpha1.CSVPhaseInstallReady, Reason: oplmV1alpha1.CSVReasonRequirementsMet},
+				{Phase: oplmV1alpha1.CSVPhaseInstalling, Reason: oplmV1alpha1.CSVReasonInstallSuccessful},
+				{Phase: oplmV1alpha1.CSVPhaseInstalling, Reason: oplmV1alpha1.CSVReasonWaiting},
+				{Phase: oplmV1alpha1.CSVPhaseInstalling, Reason: oplmV1alpha1.CSVReasonWaiting,
+					Message: "waiting for deployment gpu-operator to become ready"},
+			},
+			RequirementStatus: []oplmV1alpha1.RequirementStatus{
+				{Kind: "CustomResourceDefinition", Name: "clusterpolicies.nvidia.com",
+					Status: oplmV1alpha1.RequirementStatusReasonNotPresent, Message: "CRD is not present"},
+				{Kind: "CustomResourceDefinition", Name: "nvidiadrivers.nvidia.com",
+					Status: oplmV1alpha1.RequirementStatusReasonPresent},
+				{Kind: "ServiceAccount", Name: "gpu-operator", Status: oplmV1alpha1.RequirementStatusReasonPresent,
+					Dependents: []oplmV1alpha1.DependentStatus{
+						{Kind: "PolicyRule", Status: oplmV1alpha1.DependentStatusReasonSatisfied},
+						{Kind: "ClusterPolicyRule", Status: oplmV1alpha1.DependentStatusReasonNotSatisfied,
+							Message: "cluster rule not satisfied"},
+					}},
+			},
+		},
+	}
+
+	installPlan := &oplmV1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "install-abcde", Namespace: testCSVNamespace},
+		Spec:       oplmV1alpha1.InstallPlanSpec{ClusterServiceVersionNames: []string{testCSVName}},
+		Status: oplmV1alpha1.InstallPlanStatus{
+			Phase: oplmV1alpha1.InstallPlanPhaseInstalling,
+			Conditions: []oplmV1alpha1.InstallPlanCondition{{Type: oplmV1alpha1.InstallPlanInstalled,
+				Status: corev1.ConditionFalse, Message: "bundle unpacking failed"}},
+			BundleLookups: []oplmV1alpha1.BundleLookup{{Path: testBundlePath, Identifier: testCSVName,
+				CatalogSourceRef: &corev1.ObjectReference{Name: "certified-operators",
+					Namespace: DefaultCatalogSourceNamespace}}},
+		},
+	}
+
+	unpackJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "0123abcd", Namespace: DefaultCatalogSourceNamespace,
+			Labels: map[string]string{bundleUnpackJobLabel: "0123abcd"}},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "pull", Image: testBundlePath}},
+			Containers:     []corev1.Container{{Name: "extract"}},
+		}}},
+		Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed,
+			Status: corev1.ConditionTrue, Reason: "DeadlineExceeded", Message: "Job was active longer than specified"}}},
+	}
+
+	otherUnpackJob := unpackJob.DeepCopy()
+	otherUnpackJob.Name = "4567efgh"
+	otherUnpackJob.Spec.Template.Spec.InitContainers[0].Image = "registry.example.com/other-bundle:v1"
+
+	unpackPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "0123abcd-xyz", Namespace: DefaultCatalogSourceNamespace,
+			Labels: map[string]string{"job-name": "0123abcd"}},
+		Spec: unpackJob.Spec.Template.Spec,
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator", Namespace: testCSVNamespace},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "gpu-operator"}},
+		},
+	}
+
+	operatorPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-operator-6d5f8", Namespace: testCSVNamespace,
+			Labels: map[string]string{"app": "gpu-operator"}},
+		Spec: corev1.PodSpec{NodeName: "master-0"},
+		Status: corev1.PodStatus{Phase: corev1.PodPending, ContainerStatuses: []corev1.ContainerStatus{{
+			Name: "gpu-operator", State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}}}},
+	}
+
+	return []runtime.Object{csv, installPlan, unpackJob, otherUnpackJob, unpackPod, deployment, operatorPod}
+}
+
+func TestDiagnoseCSV(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients(nil, newTestInstallingObjects()...)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	diagnosis, err := DiagnoseCSV(apiClient, testCSVName, testCSVNamespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !diagnosis.Found || diagnosis.Phase != oplmV1alpha1.CSVPhaseInstalling ||
+		len(diagnosis.Conditions) != CSVConditionsLimit {
+		t.Errorf("expected the Installing CSV with its last %d conditions, got %+v", CSVConditionsLimit, diagnosis)
+	}
+
+	expectedRequirements := []string{
+		"CustomResourceDefinition clusterpolicies.nvidia.com: NotPresent: CRD is not present",
+		"ServiceAccount gpu-operator dependent ClusterPolicyRule: NotSatisfied: cluster rule not satisfied",
+	}
+	if strings.Join(diagnosis.UnmetRequirements, "\n") != strings.Join(expectedRequirements, "\n") {
+		t.Errorf("expected unmet requirements %q, got %q", expectedRequirements, diagnosis.UnmetRequirements)
+	}
+
+	if diagnosis.InstallPlan != "install-abcde" || diagnosis.InstallPlanMessage != "bundle unpacking failed" {
+		t.Errorf("expected installplan install-abcde with its condition message, got %s: %s",
+			diagnosis.InstallPlan, diagnosis.InstallPlanMessage)
+	}
+
+	if len(diagnosis.UnpackJobs) != 1 || diagnosis.UnpackJobs[0].Name != "0123abcd" ||
+		!strings.HasPrefix(diagnosis.UnpackJobs[0].Status, "Failed: DeadlineExceeded") ||
+		len(diagnosis.UnpackJobs[0].Logs) != 2 {
+		t.Errorf("expected the failed unpack job of the bundle with the logs of both containers, got %+v",
+			diagnosis.UnpackJobs)
+	}
+
+	expectedPods := []string{
+		"gpu-operator/gpu-operator-6d5f8 on master-0: Pending, container gpu-operator ImagePullBackOff",
+		"deployment gpu-operator-webhook not found",
+	}
+	if strings.Join(diagnosis.DeploymentPods, "\n") != strings.Join(expectedPods, "\n") {
+		t.Errorf("expected operator pods %q, got %q", expectedPods, diagnosis.DeploymentPods)
+	}
+
+	for _, expected := range []string{
+		"ClusterServiceVersion gpu-operator-certified.v24.9.2 is Installing in namespace nvidia-gpu-operator",
+		"installplan install-abcde is Installing: bundle unpacking failed",
+		"bundle unpack job openshift-marketplace/0123abcd is Failed",
+		"0123abcd-xyz/extract logs:",
+	} {
+		if !strings.Contains(diagnosis.String(), expected) {
+			t.Errorf("expected %q in diagnosis:\n%s", expected, diagnosis)
+		}
+	}
+}
+
+func TestWrapCSVNotSucceededError(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients(nil)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	waitErr := errors.New("context deadline exceeded")
+
+	err = WrapCSVNotSucceededError(apiClient, testCSVName, testCSVNamespace, waitErr)
+
+	var csvErr *CSVNotSucceededError
+	if !errors.As(err, &csvErr) || !errors.Is(err, waitErr) {
+		t.Fatalf("expected a CSVNotSucceededError wrapping the wait error, got %v", err)
+	}
+
+	if csvErr.Diagnosis.Found || !strings.Contains(err.Error(),
+		"ClusterServiceVersion gpu-operator-certified.v24.9.2 not found in namespace nvidia-gpu-operator") {
+		t.Errorf("expected the CSV to be reported as not found, got %v", err)
+	}
+
+	generalConfig := &config.GeneralConfig{ReportsDirAbsPath: t.TempDir()}
+
+	if err := csvErr.Diagnosis.WriteReport(generalConfig, CSVDiagnosisReportFile); err != nil {
+		t.Fatalf("failed to write report: %v", err)
+	}
+
+	content, err := os.ReadFile(generalConfig.GetReportPath(CSVDiagnosisReportFile))
+	if err != nil || string(content) != csvErr.Diagnosis.String()+"\n" {
+		t.Errorf("expected the diagnosis in the report, got %q: %v", content, err)
+	}
+
+	if err := WrapCSVNotSucceededError(nil, testCSVName, testCSVNamespace, waitErr); err != waitErr {
+		t.Errorf("expected the wait error when the diagnosis fails, got %v", err)
+	}
+}
@@ -6,8 +6,6 @@ import (
 	"github.com/golang/glog"
 	_ "github.com/rh-ecosystem-edge/nvidia-ci/internal/check"
 	_ "github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
-
-	_ "github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/logging"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
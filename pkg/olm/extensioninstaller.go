@@ -0,0 +1,501 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultClusterExtensionCheckInterval is the interval used to poll the ClusterCatalogs and the
+	// ClusterExtension during an OLM v1 installation.
+	DefaultClusterExtensionCheckInterval = 30 * time.Second
+	// DefaultClusterExtensionTimeout is how long a ClusterExtension may take to install its bundle.
+	DefaultClusterExtensionTimeout = 10 * time.Minute
+
+	// installerServiceAccountSuffix is appended to the package name to name the installer ServiceAccount.
+	installerServiceAccountSuffix = "-installer"
+)
+
+// installerVerbs are the verbs OLM v1 needs on the resources of a bundle to install, upgrade and remove them.
+var installerVerbs = []string{"create", "get", "list", "watch", "update", "patch", "delete"}
+
+// ExtensionInstaller installs an operator through OLM v1: it creates the namespace and the ServiceAccount the
+// operator is installed with, waits for the ClusterCatalogs to serve their content, creates the ClusterExtension
+// and waits for its bundle to be installed. It is the OLM v1 counterpart of OperatorInstaller.
+type ExtensionInstaller struct {
+	// Namespace is the operator namespace, set once it exists.
+	Namespace *namespace.Builder
+	// ClusterExtension is the operator ClusterExtension, set once it exists.
+	ClusterExtension *ClusterExtensionBuilder
+
+	apiClient           *clients.Settings
+	packageName         string
+	namespaceName       string
+	namespaceLabels     map[string]string
+	extensionName       string
+	serviceAccountName  string
+	serviceAccountRules []rbacv1.PolicyRule
+	catalogs            []string
+	channel             string
+	version             string
+	watchNamespace      string
+	checkInterval       time.Duration
+	timeout             time.Duration
+	errorMsg            string
+}
+
+// NewExtensionInstaller returns an ExtensionInstaller for the given package in the given namespace. The
+// ClusterExtension is named after the package and installed with the '<package>-installer' ServiceAccount,
+// unless configured otherwise.
+func NewExtensionInstaller(apiClient *clients.Settings, packageName, nsName string) *ExtensionInstaller {
+	glog.V(100).Infof("Initializing new ExtensionInstaller for package %s in namespace %s", packageName, nsName)
+
+	installer := &ExtensionInstaller{
+		apiClient:          apiClient,
+		packageName:        packageName,
+		namespaceName:      nsName,
+		extensionName:      packageName,
+		serviceAccountName: packageName + installerServiceAccountSuffix,
+		checkInterval:      DefaultClusterExtensionCheckInterval,
+		timeout:            DefaultClusterExtensionTimeout,
+	}
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient of the ExtensionInstaller is nil")
+
+		installer.errorMsg = "ExtensionInstaller cannot have nil apiClient"
+	}
+
+	if packageName == "" {
+		glog.V(100).Infof("The package name of the ExtensionInstaller is empty")
+
+		installer.errorMsg = "ExtensionInstaller 'packageName' cannot be empty"
+	}
+
+	if nsName == "" {
+		glog.V(100).Infof("The namespace of the ExtensionInstaller is empty")
+
+		installer.errorMsg = "ExtensionInstaller 'nsName' cannot be empty"
+	}
+
+	return installer
+}
+
+// WithExtensionName sets the name of the ClusterExtension.
+func (installer *ExtensionInstaller) WithExtensionName(name string) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting ExtensionInstaller clusterextension name to %s", name)
+
+	if name == "" {
+		installer.errorMsg = "ExtensionInstaller clusterextension name cannot be empty"
+
+		return installer
+	}
+
+	installer.extensionName = name
+
+	return installer
+}
+
+// WithServiceAccountName sets the name of the ServiceAccount OLM v1 installs the operator with.
+func (installer *ExtensionInstaller) WithServiceAccountName(name string) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting ExtensionInstaller serviceaccount name to %s", name)
+
+	if name == "" {
+		installer.errorMsg = "ExtensionInstaller serviceaccount name cannot be empty"
+
+		return installer
+	}
+
+	installer.serviceAccountName = name
+
+	return installer
+}
+
+// WithServiceAccountRules grants the installer ServiceAccount the given cluster-wide rules on top of those needed
+// by every bundle, for bundles shipping other resources, e.g. a PrometheusRule or a ConsolePlugin.
+func (installer *ExtensionInstaller) WithServiceAccountRules(rules ...rbacv1.PolicyRule) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Adding ExtensionInstaller serviceaccount rules %v", rules)
+
+	installer.serviceAccountRules = append(installer.serviceAccountRules, rules...)
+
+	return installer
+}
+
+// WithNamespaceLabels sets the labels the operator namespace is created with.
+func (installer *ExtensionInstaller) WithNamespaceLabels(labels map[string]string) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting ExtensionInstaller namespace labels to %v", labels)
+
+	installer.namespaceLabels = labels
+
+	return installer
+}
+
+// WithCatalogs restricts the ClusterCatalogs the package is resolved from to the given ones, which must be
+// serving before the ClusterExtension is created. All the ClusterCatalogs are used when unset.
+func (installer *ExtensionInstaller) WithCatalogs(catalogs ...string) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting ExtensionInstaller clustercatalogs to %v", catalogs)
+
+	if slices.Contains(catalogs, "") {
+		installer.errorMsg = "ExtensionInstaller clustercatalogs cannot be empty"
+
+		return installer
+	}
+
+	installer.catalogs = catalogs
+
+	return installer
+}
+
+// WithChannel sets the channel the operator is installed from. The default channel of the package is used when
+// unset.
+func (installer *ExtensionInstaller) WithChannel(channel string) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting ExtensionInstaller channel to %s", channel)
+
+	if channel == "" {
+		installer.errorMsg = "ExtensionInstaller channel cannot be empty"
+
+		return installer
+	}
+
+	installer.channel = channel
+
+	return installer
+}
+
+// WithVersion sets the version or version range of the operator, e.g. 24.9.2 or '>=24.9.0 <25.0.0'. The latest
+// version of the channel is installed when unset.
+func (installer *ExtensionInstaller) WithVersion(version string) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting ExtensionInstaller version to %s", version)
+
+	if version == "" {
+		installer.errorMsg = "ExtensionInstaller version cannot be empty"
+
+		return installer
+	}
+
+	installer.version = version
+
+	return installer
+}
+
+// WithWatchNamespace sets the namespace watched by the operator, needed by operators not supporting the
+// AllNamespaces install mode.
+func (installer *ExtensionInstaller) WithWatchNamespace(nsName string) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting ExtensionInstaller watch namespace to %s", nsName)
+
+	if nsName == "" {
+		installer.errorMsg = "ExtensionInstaller watch namespace cannot be empty"
+
+		return installer
+	}
+
+	installer.watchNamespace = nsName
+
+	return installer
+}
+
+// WithInstallTimeout sets how long, and how often, to wait for the ClusterCatalogs to serve their content and
+// for the ClusterExtension to install its bundle.
+func (installer *ExtensionInstaller) WithInstallTimeout(interval, timeout time.Duration) *ExtensionInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting ExtensionInstaller install timeout to %s, checking every %s", timeout, interval)
+
+	if interval <= 0 || timeout <= 0 {
+		installer.errorMsg = "ExtensionInstaller install interval and timeout must be positive"
+
+		return installer
+	}
+
+	installer.checkInterval = interval
+	installer.timeout = timeout
+
+	return installer
+}
+
+// Install runs all the installation steps and returns the installed ClusterExtension. Steps whose resources
+// already exist are not repeated. A failing step is reported as an *InstallError.
+func (installer *ExtensionInstaller) Install() (*ClusterExtensionBuilder, error) {
+	return installer.InstallContext(context.TODO())
+}
+
+// InstallContext runs all the installation steps and returns the installed ClusterExtension. Steps whose
+// resources already exist are not repeated. A failing step is reported as an *InstallError.
+func (installer *ExtensionInstaller) InstallContext(ctx context.Context) (*ClusterExtensionBuilder, error) {
+	if valid, err := installer.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Installing package %s in namespace %s with OLM v1", installer.packageName,
+		installer.namespaceName)
+
+	nsBuilder, err := ensureNamespace(ctx, installer.apiClient, installer.namespaceName, installer.namespaceLabels)
+	if err != nil {
+		return nil, installer.stepError(InstallStepNamespace, err)
+	}
+
+	installer.Namespace = nsBuilder
+
+	if err := installer.ensureServiceAccount(ctx); err != nil {
+		return nil, installer.stepError(InstallStepServiceAccount, err)
+	}
+
+	for _, catalog := range installer.catalogs {
+		catalogBuilder, err := PullClusterCatalogContext(ctx, installer.apiClient, catalog)
+		if err != nil {
+			return nil, installer.stepError(InstallStepClusterCatalog, err)
+		}
+
+		if err := catalogBuilder.WaitUntilServingContext(ctx, installer.checkInterval, installer.timeout); err != nil {
+			return nil, installer.stepError(InstallStepClusterCatalog, err)
+		}
+	}
+
+	extension := installer.newClusterExtensionBuilder()
+
+	if _, err := extension.CreateContext(ctx); err != nil {
+		return nil, installer.stepError(InstallStepClusterExtension, err)
+	}
+
+	installer.ClusterExtension = extension
+
+	if err := extension.WaitUntilInstalledContext(ctx, installer.checkInterval, installer.timeout); err != nil {
+		return nil, installer.stepError(InstallStepClusterExtension, err)
+	}
+
+	return extension, nil
+}
+
+// ensureServiceAccount creates the installer ServiceAccount, unless it already exists, with only the RBAC OLM v1
+// needs to manage the resources of a bundle: its cluster scoped resources and the finalizers of the
+// ClusterExtension through a ClusterRole, its namespaced resources through a Role in the operator namespace.
+// escalate and bind let the ServiceAccount create the RBAC of the operator without holding it.
+func (installer *ExtensionInstaller) ensureServiceAccount(ctx context.Context) error {
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: installer.serviceAccountName, Namespace: installer.namespaceName},
+	}
+
+	glog.V(100).Infof("Creating serviceaccount %s in namespace %s", serviceAccount.Name, serviceAccount.Namespace)
+
+	_, err := installer.apiClient.CoreV1Interface.ServiceAccounts(installer.namespaceName).Create(ctx,
+		serviceAccount, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create serviceaccount %s: %w", serviceAccount.Name, err)
+	}
+
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      installer.serviceAccountName,
+		Namespace: installer.namespaceName,
+	}}
+
+	clusterRole := &rbacv1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{Name: installer.serviceAccountName},
+		Rules:      installer.clusterRules(),
+	}
+
+	glog.V(100).Infof("Creating clusterrole %s", clusterRole.Name)
+
+	_, err = installer.apiClient.K8sClient.RbacV1().ClusterRoles().Create(ctx, clusterRole, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create clusterrole %s: %w", clusterRole.Name, err)
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: installer.serviceAccountName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     clusterRole.Name,
+		},
+		Subjects: subjects,
+	}
+
+	glog.V(100).Infof("Creating clusterrolebinding %s", clusterRoleBinding.Name)
+
+	_, err = installer.apiClient.K8sClient.RbacV1().ClusterRoleBindings().Create(ctx,
+		clusterRoleBinding, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create clusterrolebinding %s: %w", clusterRoleBinding.Name, err)
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: installer.serviceAccountName, Namespace: installer.namespaceName},
+		Rules:      namespaceRules(),
+	}
+
+	glog.V(100).Infof("Creating role %s in namespace %s", role.Name, role.Namespace)
+
+	_, err = installer.apiClient.K8sClient.RbacV1().Roles(installer.namespaceName).Create(ctx, role,
+		metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create role %s: %w", role.Name, err)
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: installer.serviceAccountName, Namespace: installer.namespaceName},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role.Name,
+		},
+		Subjects: subjects,
+	}
+
+	glog.V(100).Infof("Creating rolebinding %s in namespace %s", roleBinding.Name, roleBinding.Namespace)
+
+	_, err = installer.apiClient.K8sClient.RbacV1().RoleBindings(installer.namespaceName).Create(ctx, roleBinding,
+		metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create rolebinding %s: %w", roleBinding.Name, err)
+	}
+
+	return nil
+}
+
+// clusterRules returns the cluster-wide rules of the installer ServiceAccount: the finalizers of its
+// ClusterExtension and the cluster scoped resources of a bundle.
+func (installer *ExtensionInstaller) clusterRules() []rbacv1.PolicyRule {
+	return append([]rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{ClusterExtensionGVR.Group},
+			Resources:     []string{ClusterExtensionGVR.Resource + "/finalizers"},
+			ResourceNames: []string{installer.extensionName},
+			Verbs:         []string{"update"},
+		},
+		{
+			APIGroups: []string{"apiextensions.k8s.io"},
+			Resources: []string{"customresourcedefinitions"},
+			Verbs:     installerVerbs,
+		},
+		{
+			APIGroups: []string{rbacv1.GroupName},
+			Resources: []string{"clusterroles", "clusterrolebindings"},
+			Verbs:     append([]string{"escalate", "bind"}, installerVerbs...),
+		},
+		{
+			APIGroups: []string{"admissionregistration.k8s.io"},
+			Resources: []string{"validatingwebhookconfigurations", "mutatingwebhookconfigurations"},
+			Verbs:     installerVerbs,
+		},
+	}, installer.serviceAccountRules...)
+}
+
+// namespaceRules returns the rules of the installer ServiceAccount on the namespaced resources of a bundle.
+func namespaceRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			APIGroups: []string{""},
+			Resources: []string{"serviceaccounts", "services", "configmaps", "secrets"},
+			Verbs:     installerVerbs,
+		},
+		{
+			APIGroups: []string{"apps"},
+			Resources: []string{"deployments"},
+			Verbs:     installerVerbs,
+		},
+		{
+			APIGroups: []string{rbacv1.GroupName},
+			Resources: []string{"roles", "rolebindings"},
+			Verbs:     append([]string{"escalate", "bind"}, installerVerbs...),
+		},
+		{
+			APIGroups: []string{"networking.k8s.io"},
+			Resources: []string{"networkpolicies"},
+			Verbs:     installerVerbs,
+		},
+	}
+}
+
+// newClusterExtensionBuilder returns the ClusterExtensionBuilder of the configured installation.
+func (installer *ExtensionInstaller) newClusterExtensionBuilder() *ClusterExtensionBuilder {
+	extension := NewClusterExtensionBuilder(installer.apiClient, installer.extensionName, installer.packageName,
+		installer.namespaceName, installer.serviceAccountName)
+
+	if installer.channel != "" {
+		extension.WithChannels(installer.channel)
+	}
+
+	if installer.version != "" {
+		extension.WithVersion(installer.version)
+	}
+
+	if len(installer.catalogs) > 0 {
+		extension.WithCatalogs(installer.catalogs...)
+	}
+
+	if installer.watchNamespace != "" {
+		extension.WithWatchNamespace(installer.watchNamespace)
+	}
+
+	return extension
+}
+
+// stepError wraps err into an *InstallError for the given step.
+func (installer *ExtensionInstaller) stepError(step InstallStep, err error) error {
+	glog.V(100).Infof("Installing package %s with OLM v1 failed at step %s: %v", installer.packageName, step, err)
+
+	return &InstallError{Step: step, Package: installer.packageName, Err: err}
+}
+
+// validate will check that the installer is properly initialized before accessing any member fields.
+func (installer *ExtensionInstaller) validate() (bool, error) {
+	if installer == nil {
+		glog.V(100).Infof("The ExtensionInstaller is uninitialized")
+
+		return false, fmt.Errorf("error: received nil ExtensionInstaller")
+	}
+
+	if installer.errorMsg != "" {
+		glog.V(100).Infof("The ExtensionInstaller has error message: %s", installer.errorMsg)
+
+		return false, errors.New(installer.errorMsg)
+	}
+
+	return true, nil
+}
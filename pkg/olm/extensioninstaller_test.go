@@ -0,0 +1,160 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestExtensionInstallerInstall(t *testing.T) {
+	apiClient := newTestOLMv1Clients(t, []*unstructured.Unstructured{
+		newTestClusterCatalog(DefaultClusterCatalog, true), newTestInstalledClusterExtension()})
+
+	installer := NewExtensionInstaller(apiClient, testPackage, testCSVNamespace).
+		WithNamespaceLabels(map[string]string{"openshift.io/cluster-monitoring": "true"}).
+		WithCatalogs(DefaultClusterCatalog).
+		WithChannel("v24.9").
+		WithWatchNamespace(testCSVNamespace).
+		WithInstallTimeout(time.Millisecond, 10*time.Millisecond)
+
+	extension, err := installer.Install()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if name, _, err := extension.InstalledBundle(); err != nil || name != testCSVName {
+		t.Errorf("expected bundle %s to be installed, got %q: %v", testCSVName, name, err)
+	}
+
+	if installer.Namespace == nil ||
+		installer.Namespace.Object.Labels["openshift.io/cluster-monitoring"] != "true" {
+		t.Errorf("expected the labeled namespace to be created, got %v", installer.Namespace)
+	}
+
+	if _, err := apiClient.CoreV1Interface.ServiceAccounts(testCSVNamespace).Get(context.TODO(),
+		testServiceAccount, metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the installer serviceaccount to be created: %v", err)
+	}
+
+	clusterRoleBinding, err := apiClient.K8sClient.RbacV1().ClusterRoleBindings().Get(context.TODO(),
+		testServiceAccount, metav1.GetOptions{})
+	if err != nil || clusterRoleBinding.RoleRef.Name != testServiceAccount ||
+		clusterRoleBinding.Subjects[0].Namespace != testCSVNamespace {
+		t.Errorf("expected the installer serviceaccount to be bound to its clusterrole, got %v: %v",
+			clusterRoleBinding, err)
+	}
+
+	clusterRole, err := apiClient.K8sClient.RbacV1().ClusterRoles().Get(context.TODO(), testServiceAccount,
+		metav1.GetOptions{})
+	if err != nil || !slices.ContainsFunc(clusterRole.Rules, func(rule rbacv1.PolicyRule) bool {
+		return slices.Equal(rule.Resources, []string{"clusterextensions/finalizers"}) &&
+			slices.Equal(rule.ResourceNames, []string{testPackage})
+	}) || slices.ContainsFunc(clusterRole.Rules, func(rule rbacv1.PolicyRule) bool {
+		return slices.Contains(rule.Resources, "*") || slices.Contains(rule.Verbs, "*")
+	}) {
+		t.Errorf("expected the installer clusterrole to only grant the rules of the bundle, got %v: %v",
+			clusterRole, err)
+	}
+
+	roleBinding, err := apiClient.K8sClient.RbacV1().RoleBindings(testCSVNamespace).Get(context.TODO(),
+		testServiceAccount, metav1.GetOptions{})
+	if err != nil || roleBinding.RoleRef.Kind != "Role" || roleBinding.RoleRef.Name != testServiceAccount {
+		t.Errorf("expected the installer serviceaccount to be bound to its role, got %v: %v", roleBinding, err)
+	}
+
+	if _, err := installer.Install(); err != nil {
+		t.Errorf("expected a second install to reuse the existing resources, got %v", err)
+	}
+}
+
+func TestExtensionInstallerStepErrors(t *testing.T) {
+	notInstalledExtension := newTestOLMv1Object(NewClusterExtensionBuilder(nil, testPackage, testPackage,
+		testCSVNamespace, testServiceAccount).Definition, ClusterExtensionProgressingCondition, "True")
+
+	testCases := []struct {
+		name          string
+		objects       []*unstructured.Unstructured
+		expectedStep  InstallStep
+		expectedError string
+	}{
+		{
+			name:          "clustercatalog not found",
+			expectedStep:  InstallStepClusterCatalog,
+			expectedError: "clustercatalog object openshift-certified-operators doesn't exist",
+		},
+		{
+			name:          "clustercatalog not serving",
+			objects:       []*unstructured.Unstructured{newTestClusterCatalog(DefaultClusterCatalog, false)},
+			expectedStep:  InstallStepClusterCatalog,
+			expectedError: "clustercatalog openshift-certified-operators is not serving: Serving message",
+		},
+		{
+			name: "clusterextension not installed",
+			objects: []*unstructured.Unstructured{
+				newTestClusterCatalog(DefaultClusterCatalog, true), notInstalledExtension},
+			expectedStep:  InstallStepClusterExtension,
+			expectedError: "clusterextension gpu-operator-certified is not installed: Progressing message",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			installer := NewExtensionInstaller(newTestOLMv1Clients(t, testCase.objects), testPackage,
+				testCSVNamespace).
+				WithCatalogs(DefaultClusterCatalog).
+				WithInstallTimeout(time.Millisecond, 10*time.Millisecond)
+
+			_, err := installer.Install()
+
+			var installErr *InstallError
+			if !errors.As(err, &installErr) {
+				t.Fatalf("expected an InstallError, got %v", err)
+			}
+
+			if installErr.Step != testCase.expectedStep || !strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("expected step %s with error %q, got %v", testCase.expectedStep, testCase.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestExtensionInstallerValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		installer     *ExtensionInstaller
+		expectedError string
+	}{
+		{
+			name:          "nil apiClient",
+			installer:     NewExtensionInstaller(nil, testPackage, testCSVNamespace),
+			expectedError: "ExtensionInstaller cannot have nil apiClient",
+		},
+		{
+			name: "empty clustercatalog",
+			installer: NewExtensionInstaller(newTestOLMv1Clients(t, nil), testPackage, testCSVNamespace).
+				WithCatalogs(DefaultClusterCatalog, ""),
+			expectedError: "ExtensionInstaller clustercatalogs cannot be empty",
+		},
+		{
+			name: "install timeout",
+			installer: NewExtensionInstaller(newTestOLMv1Clients(t, nil), testPackage, testCSVNamespace).
+				WithInstallTimeout(0, time.Minute),
+			expectedError: "ExtensionInstaller install interval and timeout must be positive",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := testCase.installer.Install(); err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
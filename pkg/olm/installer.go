@@ -0,0 +1,826 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang/glog"
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
+	"gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultCatalogSourceNamespace is the namespace holding the cluster wide CatalogSources.
+	DefaultCatalogSourceNamespace = "openshift-marketplace"
+	// DefaultCatalogSourceReadyTimeout is how long a custom CatalogSource may take to become ready.
+	DefaultCatalogSourceReadyTimeout = 4 * time.Minute
+	// DefaultPackageManifestCheckInterval is the interval used to poll for the PackageManifest of a custom
+	// CatalogSource.
+	DefaultPackageManifestCheckInterval = 30 * time.Second
+	// DefaultPackageManifestTimeout is how long the PackageManifest of a custom CatalogSource may take to show up.
+	DefaultPackageManifestTimeout = 5 * time.Minute
+	// DefaultCSVSucceededCheckInterval is the interval used to poll the installed ClusterServiceVersion.
+	DefaultCSVSucceededCheckInterval = 30 * time.Second
+	// DefaultCSVSucceededTimeout is how long the installed ClusterServiceVersion may take to reach Succeeded.
+	DefaultCSVSucceededTimeout = 10 * time.Minute
+)
+
+// InstallStep names a step of the operator installation.
+type InstallStep string
+
+const (
+	// InstallStepPackageManifest looks up the package in the preferred CatalogSources.
+	InstallStepPackageManifest InstallStep = "PackageManifest"
+	// InstallStepCatalogSource creates the custom CatalogSource and waits for it to serve the package.
+	InstallStepCatalogSource InstallStep = "CatalogSource"
+	// InstallStepNamespace creates and labels the operator namespace.
+	InstallStepNamespace InstallStep = "Namespace"
+	// InstallStepOperatorGroup creates the OperatorGroup.
+	InstallStepOperatorGroup InstallStep = "OperatorGroup"
+	// InstallStepSubscription creates the Subscription.
+	InstallStepSubscription InstallStep = "Subscription"
+	// InstallStepInstallPlan approves the InstallPlan of a Subscription with Manual approval.
+	InstallStepInstallPlan InstallStep = "InstallPlan"
+	// InstallStepCSV waits for the installed ClusterServiceVersion to reach the Succeeded phase.
+	InstallStepCSV InstallStep = "ClusterServiceVersion"
+	// InstallStepServiceAccount creates the ServiceAccount OLM v1 installs the operator with, and grants it the
+	// RBAC needed to manage the resources of the bundle.
+	InstallStepServiceAccount InstallStep = "ServiceAccount"
+	// InstallStepClusterCatalog waits for the OLM v1 ClusterCatalogs to serve their content.
+	InstallStepClusterCatalog InstallStep = "ClusterCatalog"
+	// InstallStepClusterExtension creates the OLM v1 ClusterExtension and waits for its bundle to be installed.
+	InstallStepClusterExtension InstallStep = "ClusterExtension"
+)
+
+// ErrPackageNotFound is returned when none of the CatalogSources serves the package and no custom
+// CatalogSource is configured.
+var ErrPackageNotFound = errors.New("package not found in any of the catalogsources")
+
+// InstallError is returned by OperatorInstaller when one of the installation steps fails.
+type InstallError struct {
+	// Step is the installation step that failed.
+	Step InstallStep
+	// Package is the name of the package being installed, or the bundle image while the package of a bundle is
+	// not known yet.
+	Package string
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (installErr *InstallError) Error() string {
+	return fmt.Sprintf("failed to install package %s at step %s: %v", installErr.Package, installErr.Step,
+		installErr.Err)
+}
+
+// Unwrap returns the underlying error.
+func (installErr *InstallError) Unwrap() error {
+	return installErr.Err
+}
+
+// OperatorInstaller installs an operator through OLM: it resolves the package in the preferred CatalogSources,
+// optionally falling back to a custom CatalogSource, creates the namespace, OperatorGroup and Subscription and
+// waits for the ClusterServiceVersion to succeed.
+type OperatorInstaller struct {
+	// Namespace is the operator namespace, set once it exists.
+	Namespace *namespace.Builder
+	// OperatorGroup is the OperatorGroup of the operator namespace, set once it exists.
+	OperatorGroup *OperatorGroupBuilder
+	// Subscription is the operator Subscription, set once it exists.
+	Subscription *SubscriptionBuilder
+	// PackageManifest is the PackageManifest the operator is installed from.
+	PackageManifest *PackageManifestBuilder
+	// CatalogSource is the name of the CatalogSource the operator is installed from.
+	CatalogSource string
+	// Channel is the channel the operator is subscribed to.
+	Channel string
+
+	apiClient              *clients.Settings
+	packageName            string
+	namespaceName          string
+	namespaceLabels        map[string]string
+	operatorGroupName      string
+	subscriptionName       string
+	catalogSourceNamespace string
+	catalogSources         []string
+	customCatalogSource    *CatalogSourceBuilder
+	catalogReadyTimeout    time.Duration
+	packageCheckInterval   time.Duration
+	packageTimeout         time.Duration
+	channel                string
+	startingCSV            string
+	installPlanApproval    operatorsV1alpha1.Approval
+	installMode            operatorsV1alpha1.InstallModeType
+	targetNamespaces       []string
+	csvCheckInterval       time.Duration
+	csvTimeout             time.Duration
+	olmRestartRetries      int
+	errorMsg               string
+}
+
+// NewOperatorInstaller returns an OperatorInstaller for the given package in the given namespace. The Subscription
+// and OperatorGroup are named after the package and the operator is installed in OwnNamespace mode, unless
+// configured otherwise.
+func NewOperatorInstaller(apiClient *clients.Settings, packageName, nsName string) *OperatorInstaller {
+	glog.V(100).Infof("Initializing new OperatorInstaller for package %s in namespace %s", packageName, nsName)
+
+	installer := &OperatorInstaller{
+		apiClient:              apiClient,
+		packageName:            packageName,
+		namespaceName:          nsName,
+		operatorGroupName:      packageName,
+		subscriptionName:       packageName,
+		catalogSourceNamespace: DefaultCatalogSourceNamespace,
+		catalogReadyTimeout:    DefaultCatalogSourceReadyTimeout,
+		packageCheckInterval:   DefaultPackageManifestCheckInterval,
+		packageTimeout:         DefaultPackageManifestTimeout,
+		installPlanApproval:    operatorsV1alpha1.ApprovalAutomatic,
+		installMode:            operatorsV1alpha1.InstallModeTypeOwnNamespace,
+		csvCheckInterval:       DefaultCSVSucceededCheckInterval,
+		csvTimeout:             DefaultCSVSucceededTimeout,
+	}
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient of the OperatorInstaller is nil")
+
+		installer.errorMsg = "OperatorInstaller cannot have nil apiClient"
+	}
+
+	if packageName == "" {
+		glog.V(100).Infof("The package name of the OperatorInstaller is empty")
+
+		installer.errorMsg = "OperatorInstaller 'packageName' cannot be empty"
+	}
+
+	if nsName == "" {
+		glog.V(100).Infof("The namespace of the OperatorInstaller is empty")
+
+		installer.errorMsg = "OperatorInstaller 'nsName' cannot be empty"
+	}
+
+	return installer
+}
+
+// WithCatalogSources sets the CatalogSources the package is looked up in, in order of preference.
+func (installer *OperatorInstaller) WithCatalogSources(catalogSources ...string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller catalogsources to %v", catalogSources)
+
+	if slices.Contains(catalogSources, "") {
+		installer.errorMsg = "OperatorInstaller catalogsources cannot be empty"
+
+		return installer
+	}
+
+	installer.catalogSources = catalogSources
+
+	return installer
+}
+
+// WithCatalogSourceNamespace sets the namespace of the CatalogSources, openshift-marketplace by default.
+func (installer *OperatorInstaller) WithCatalogSourceNamespace(nsName string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller catalogsource namespace to %s", nsName)
+
+	if nsName == "" {
+		installer.errorMsg = "OperatorInstaller catalogsource namespace cannot be empty"
+
+		return installer
+	}
+
+	installer.catalogSourceNamespace = nsName
+
+	return installer
+}
+
+// WithCustomCatalogSource configures a CatalogSource with the given index image, created when the package is not
+// found in any of the preferred CatalogSources.
+func (installer *OperatorInstaller) WithCustomCatalogSource(
+	name, indexImage, displayName, publisher string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller custom catalogsource %s with index image %s", name, indexImage)
+
+	if name == "" || indexImage == "" {
+		installer.errorMsg = "OperatorInstaller custom catalogsource name and index image cannot be empty"
+
+		return installer
+	}
+
+	installer.customCatalogSource = NewCatalogSourceBuilderWithIndexImage(installer.apiClient, name,
+		installer.catalogSourceNamespace, indexImage, displayName, publisher)
+
+	return installer
+}
+
+// WithCustomCatalogSourceBuilder configures the CatalogSource created when the package is not found in any of the
+// preferred CatalogSources, for CatalogSources needing more than an index image, e.g. a registry poll interval or
+// a node selector. Its namespace is set to the CatalogSource namespace of the installer.
+func (installer *OperatorInstaller) WithCustomCatalogSourceBuilder(
+	catalogSource *CatalogSourceBuilder) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	if valid, err := catalogSource.validate(); !valid {
+		installer.errorMsg = fmt.Sprintf("OperatorInstaller custom catalogsource is invalid: %v", err)
+
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller custom catalogsource %s", catalogSource.Definition.Name)
+
+	installer.customCatalogSource = catalogSource
+
+	return installer
+}
+
+// WithCatalogSourceReadyTimeout sets how long the custom CatalogSource may take to become ready once created.
+func (installer *OperatorInstaller) WithCatalogSourceReadyTimeout(readyTimeout time.Duration) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller catalogsource ready timeout to %s", readyTimeout)
+
+	if readyTimeout <= 0 {
+		installer.errorMsg = "OperatorInstaller catalogsource ready timeout must be positive"
+
+		return installer
+	}
+
+	installer.catalogReadyTimeout = readyTimeout
+
+	return installer
+}
+
+// WithPackageManifestTimeout sets how long to wait for the package to be served by the custom CatalogSource.
+func (installer *OperatorInstaller) WithPackageManifestTimeout(interval, timeout time.Duration) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller packagemanifest interval to %s and timeout to %s", interval, timeout)
+
+	if interval <= 0 || timeout <= 0 {
+		installer.errorMsg = "OperatorInstaller packagemanifest interval and timeout must be positive"
+
+		return installer
+	}
+
+	installer.packageCheckInterval = interval
+	installer.packageTimeout = timeout
+
+	return installer
+}
+
+// WithChannel sets the Subscription channel. The default channel of the package is used when unset.
+func (installer *OperatorInstaller) WithChannel(channel string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller channel to %s", channel)
+
+	if channel == "" {
+		installer.errorMsg = "OperatorInstaller channel cannot be empty"
+
+		return installer
+	}
+
+	installer.channel = channel
+
+	return installer
+}
+
+// WithStartingCSV sets the ClusterServiceVersion the Subscription starts from.
+func (installer *OperatorInstaller) WithStartingCSV(startingCSV string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller startingCSV to %s", startingCSV)
+
+	if startingCSV == "" {
+		installer.errorMsg = "OperatorInstaller startingCSV cannot be empty"
+
+		return installer
+	}
+
+	installer.startingCSV = startingCSV
+
+	return installer
+}
+
+// WithInstallPlanApproval sets the InstallPlan approval of the Subscription, Automatic by default. With Manual
+// approval, Install approves the first InstallPlan only, checking that it installs the startingCSV if one is set,
+// so the operator stays pinned to that version until upgrades are approved, e.g. with
+// SubscriptionBuilder.UpgradeThrough.
+func (installer *OperatorInstaller) WithInstallPlanApproval(approval operatorsV1alpha1.Approval) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller installPlanApproval to %s", approval)
+
+	if approval != operatorsV1alpha1.ApprovalAutomatic && approval != operatorsV1alpha1.ApprovalManual {
+		installer.errorMsg = fmt.Sprintf("OperatorInstaller installPlanApproval %q is not supported", approval)
+
+		return installer
+	}
+
+	installer.installPlanApproval = approval
+
+	return installer
+}
+
+// WithInstallMode sets the install mode of the operator. OwnNamespace and AllNamespaces take no target namespaces,
+// SingleNamespace takes exactly one and MultiNamespace at least one.
+func (installer *OperatorInstaller) WithInstallMode(
+	installMode operatorsV1alpha1.InstallModeType, targetNamespaces ...string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller install mode to %s with target namespaces %v",
+		installMode, targetNamespaces)
+
+	switch installMode {
+	case operatorsV1alpha1.InstallModeTypeOwnNamespace, operatorsV1alpha1.InstallModeTypeAllNamespaces:
+		if len(targetNamespaces) != 0 {
+			installer.errorMsg = fmt.Sprintf("install mode %s does not take target namespaces", installMode)
+		}
+	case operatorsV1alpha1.InstallModeTypeSingleNamespace:
+		if len(targetNamespaces) != 1 {
+			installer.errorMsg = fmt.Sprintf("install mode %s takes exactly one target namespace", installMode)
+		}
+	case operatorsV1alpha1.InstallModeTypeMultiNamespace:
+		if len(targetNamespaces) == 0 {
+			installer.errorMsg = fmt.Sprintf("install mode %s takes at least one target namespace", installMode)
+		}
+	default:
+		installer.errorMsg = fmt.Sprintf("install mode %q is not supported", installMode)
+	}
+
+	if installer.errorMsg != "" {
+		return installer
+	}
+
+	installer.installMode = installMode
+	installer.targetNamespaces = targetNamespaces
+
+	return installer
+}
+
+// WithNamespaceLabels sets the labels the operator namespace is created with.
+func (installer *OperatorInstaller) WithNamespaceLabels(labels map[string]string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller namespace labels to %v", labels)
+
+	installer.namespaceLabels = labels
+
+	return installer
+}
+
+// WithOperatorGroupName sets the name of the OperatorGroup.
+func (installer *OperatorInstaller) WithOperatorGroupName(name string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller operatorgroup name to %s", name)
+
+	if name == "" {
+		installer.errorMsg = "OperatorInstaller operatorgroup name cannot be empty"
+
+		return installer
+	}
+
+	installer.operatorGroupName = name
+
+	return installer
+}
+
+// WithSubscriptionName sets the name of the Subscription.
+func (installer *OperatorInstaller) WithSubscriptionName(name string) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller subscription name to %s", name)
+
+	if name == "" {
+		installer.errorMsg = "OperatorInstaller subscription name cannot be empty"
+
+		return installer
+	}
+
+	installer.subscriptionName = name
+
+	return installer
+}
+
+// WithCSVTimeout sets how long the installed ClusterServiceVersion may take to reach the Succeeded phase.
+func (installer *OperatorInstaller) WithCSVTimeout(interval, timeout time.Duration) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller CSV interval to %s and timeout to %s", interval, timeout)
+
+	if interval <= 0 || timeout <= 0 {
+		installer.errorMsg = "OperatorInstaller CSV interval and timeout must be positive"
+
+		return installer
+	}
+
+	installer.csvCheckInterval = interval
+	installer.csvTimeout = timeout
+
+	return installer
+}
+
+// WithOLMRestartRetries enables the OLM workaround for operators stuck in installation: when the
+// ClusterServiceVersion does not succeed, the Subscription and the package ClusterServiceVersions are deleted,
+// the OLM pods are restarted and the Subscription is created again, up to the given number of times.
+func (installer *OperatorInstaller) WithOLMRestartRetries(retries int) *OperatorInstaller {
+	if valid, _ := installer.validate(); !valid {
+		return installer
+	}
+
+	glog.V(100).Infof("Setting OperatorInstaller OLM restart retries to %d", retries)
+
+	if retries < 0 {
+		installer.errorMsg = "OperatorInstaller OLM restart retries cannot be negative"
+
+		return installer
+	}
+
+	installer.olmRestartRetries = retries
+
+	return installer
+}
+
+// Install runs all the installation steps and returns the Succeeded ClusterServiceVersion. Steps whose resources
+// already exist are not repeated. A failing step is reported as an *InstallError.
+func (installer *OperatorInstaller) Install() (*ClusterServiceVersionBuilder, error) {
+	return installer.InstallContext(context.TODO())
+}
+
+// InstallContext runs all the installation steps and returns the Succeeded ClusterServiceVersion. Steps whose
+// resources already exist are not repeated. A failing step is reported as an *InstallError.
+func (installer *OperatorInstaller) InstallContext(ctx context.Context) (*ClusterServiceVersionBuilder, error) {
+	if valid, err := installer.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Installing package %s in namespace %s", installer.packageName, installer.namespaceName)
+
+	if len(installer.catalogSources) == 0 && installer.customCatalogSource == nil {
+		return nil, fmt.Errorf("OperatorInstaller for package %s has no catalogsource", installer.packageName)
+	}
+
+	if err := installer.resolvePackage(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := installer.EnsureNamespaceContext(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := installer.ensureOperatorGroup(ctx); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := installer.ensureSubscription(ctx); err != nil {
+			return nil, err
+		}
+
+		if installer.installPlanApproval == operatorsV1alpha1.ApprovalManual {
+			if _, err := installer.Subscription.ApproveInstallPlanContext(ctx, installer.startingCSV,
+				installer.csvCheckInterval, installer.csvTimeout); err != nil {
+				return nil, installer.stepError(InstallStepInstallPlan, err)
+			}
+		}
+
+		csvBuilder, err := installer.waitForCSV(ctx)
+		if err == nil {
+			return csvBuilder, nil
+		}
+
+		if attempt >= installer.olmRestartRetries {
+			return nil, err
+		}
+
+		glog.V(100).Infof("Package %s was not installed, restarting OLM and retrying: %v", installer.packageName, err)
+
+		if err := installer.restartOLM(ctx); err != nil {
+			return nil, installer.stepError(InstallStepSubscription, err)
+		}
+	}
+}
+
+// EnsureNamespace creates the operator namespace with the configured labels, unless it already exists. It is run
+// by Install, and may be called on its own when the operator is deployed by other means, e.g. from a bundle.
+func (installer *OperatorInstaller) EnsureNamespace() (*namespace.Builder, error) {
+	return installer.EnsureNamespaceContext(context.TODO())
+}
+
+// EnsureNamespaceContext creates the operator namespace with the configured labels, unless it already exists.
+func (installer *OperatorInstaller) EnsureNamespaceContext(ctx context.Context) (*namespace.Builder, error) {
+	if valid, err := installer.validate(); !valid {
+		return nil, err
+	}
+
+	nsBuilder, err := ensureNamespace(ctx, installer.apiClient, installer.namespaceName, installer.namespaceLabels)
+	if err != nil {
+		return nil, installer.stepError(InstallStepNamespace, err)
+	}
+
+	installer.Namespace = nsBuilder
+
+	return nsBuilder, nil
+}
+
+// resolvePackage finds the package in the first preferred CatalogSource serving it, falling back to the custom
+// CatalogSource, and resolves the channel to subscribe to.
+func (installer *OperatorInstaller) resolvePackage(ctx context.Context) error {
+	for _, catalogSource := range installer.catalogSources {
+		pkgManifest, err := PullPackageManifestByCatalogContext(ctx, installer.apiClient, installer.packageName,
+			installer.catalogSourceNamespace, catalogSource)
+		if err != nil {
+			glog.V(100).Infof("Package %s was not found in catalogsource %s: %v",
+				installer.packageName, catalogSource, err)
+
+			continue
+		}
+
+		installer.PackageManifest = pkgManifest
+		installer.CatalogSource = catalogSource
+
+		break
+	}
+
+	if installer.PackageManifest == nil {
+		if installer.customCatalogSource == nil {
+			return installer.stepError(InstallStepPackageManifest,
+				fmt.Errorf("%w %v", ErrPackageNotFound, installer.catalogSources))
+		}
+
+		if err := installer.createCustomCatalogSource(ctx); err != nil {
+			return installer.stepError(InstallStepCatalogSource, err)
+		}
+	}
+
+	glog.V(100).Infof("Package %s found in catalogsource %s", installer.packageName, installer.CatalogSource)
+
+	if err := installer.resolveChannel(); err != nil {
+		return installer.stepError(InstallStepPackageManifest, err)
+	}
+
+	return nil
+}
+
+// createCustomCatalogSource creates the custom CatalogSource and waits for it to serve the package.
+func (installer *OperatorInstaller) createCustomCatalogSource(ctx context.Context) error {
+	catalogSourceName := installer.customCatalogSource.Definition.Name
+	installer.customCatalogSource.Definition.Namespace = installer.catalogSourceNamespace
+
+	glog.V(100).Infof("Creating custom catalogsource %s for package %s", catalogSourceName, installer.packageName)
+
+	catalogSource, err := installer.customCatalogSource.CreateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create catalogsource %s: %w", catalogSourceName, err)
+	}
+
+	if !catalogSource.IsReadyContext(ctx, installer.catalogReadyTimeout) {
+		return fmt.Errorf("catalogsource %s is not ready after %s", catalogSourceName, installer.catalogReadyTimeout)
+	}
+
+	pkgManifest, err := catalogSource.WaitForPackageContext(ctx, installer.packageName, installer.channel,
+		installer.packageCheckInterval, installer.packageTimeout)
+	if err != nil {
+		return err
+	}
+
+	installer.PackageManifest = pkgManifest
+	installer.CatalogSource = catalogSourceName
+
+	return nil
+}
+
+// resolveChannel picks the configured or default channel and checks that its head supports the install mode.
+func (installer *OperatorInstaller) resolveChannel() error {
+	status := installer.PackageManifest.Object.Status
+
+	installer.Channel = installer.channel
+	if installer.Channel == "" {
+		installer.Channel = status.DefaultChannel
+	}
+
+	if installer.Channel == "" {
+		return fmt.Errorf("package %s has no default channel", installer.packageName)
+	}
+
+	channelIndex := slices.IndexFunc(status.Channels, func(channel pkgManifestV1.PackageChannel) bool {
+		return channel.Name == installer.Channel
+	})
+
+	if channelIndex < 0 {
+		// PackageManifests without channels carry no information to validate against.
+		if len(status.Channels) != 0 {
+			return fmt.Errorf("channel %s not found in package %s", installer.Channel, installer.packageName)
+		}
+
+		return nil
+	}
+
+	installModes := status.Channels[channelIndex].CurrentCSVDesc.InstallModes
+	if len(installModes) != 0 && !slices.Contains(installModes,
+		operatorsV1alpha1.InstallMode{Type: installer.installMode, Supported: true}) {
+		return fmt.Errorf("install mode %s is not supported by %s", installer.installMode,
+			status.Channels[channelIndex].CurrentCSV)
+	}
+
+	return nil
+}
+
+// ensureOperatorGroup creates the OperatorGroup matching the install mode, unless it already exists.
+func (installer *OperatorInstaller) ensureOperatorGroup(ctx context.Context) error {
+	ogBuilder := NewOperatorGroupBuilder(installer.apiClient, installer.operatorGroupName, installer.namespaceName)
+
+	if ogBuilder.ExistsContext(ctx) {
+		glog.V(100).Infof("The operatorgroup %s already exists", installer.operatorGroupName)
+
+		installer.OperatorGroup = ogBuilder
+
+		return nil
+	}
+
+	switch installer.installMode {
+	case operatorsV1alpha1.InstallModeTypeAllNamespaces:
+		ogBuilder.Definition.Spec.TargetNamespaces = nil
+	case operatorsV1alpha1.InstallModeTypeSingleNamespace, operatorsV1alpha1.InstallModeTypeMultiNamespace:
+		ogBuilder.Definition.Spec.TargetNamespaces = installer.targetNamespaces
+	}
+
+	glog.V(100).Infof("Creating operatorgroup %s with target namespaces %v", installer.operatorGroupName,
+		ogBuilder.Definition.Spec.TargetNamespaces)
+
+	createdOgBuilder, err := ogBuilder.CreateContext(ctx)
+	if err != nil {
+		return installer.stepError(InstallStepOperatorGroup, err)
+	}
+
+	installer.OperatorGroup = createdOgBuilder
+
+	return nil
+}
+
+// ensureSubscription creates the Subscription, unless it already exists.
+func (installer *OperatorInstaller) ensureSubscription(ctx context.Context) error {
+	subBuilder := NewSubscriptionBuilder(installer.apiClient, installer.subscriptionName, installer.namespaceName,
+		installer.CatalogSource, installer.catalogSourceNamespace, installer.packageName).
+		WithChannel(installer.Channel).
+		WithInstallPlanApproval(installer.installPlanApproval)
+
+	if installer.startingCSV != "" {
+		subBuilder.WithStartingCSV(installer.startingCSV)
+	}
+
+	glog.V(100).Infof("Creating subscription %s on channel %s of catalogsource %s",
+		installer.subscriptionName, installer.Channel, installer.CatalogSource)
+
+	createdSubBuilder, err := subBuilder.CreateContext(ctx)
+	if err != nil {
+		return installer.stepError(InstallStepSubscription, err)
+	}
+
+	installer.Subscription = createdSubBuilder
+
+	return nil
+}
+
+// waitForCSV waits for the ClusterServiceVersion installed by the Subscription to reach the Succeeded phase.
+// With Manual approval and a startingCSV, the operator is pinned to that exact ClusterServiceVersion. A
+// ClusterServiceVersion that does not succeed in time is diagnosed in a *CSVNotSucceededError.
+func (installer *OperatorInstaller) waitForCSV(ctx context.Context) (*ClusterServiceVersionBuilder, error) {
+	expectedCSV := ""
+	if installer.installPlanApproval == operatorsV1alpha1.ApprovalManual {
+		expectedCSV = installer.startingCSV
+	}
+
+	csvBuilder, err := installer.Subscription.WaitUntilCSVInstalledContext(ctx, expectedCSV,
+		installer.csvCheckInterval, installer.csvTimeout)
+	if err != nil {
+		if csvName := installer.subscriptionCSV(expectedCSV); csvName != "" {
+			err = WrapCSVNotSucceededErrorContext(ctx, installer.apiClient, csvName, installer.namespaceName, err)
+		}
+
+		return nil, installer.stepError(InstallStepCSV, err)
+	}
+
+	glog.V(100).Infof("ClusterServiceVersion %s of package %s succeeded", csvBuilder.Object.Name,
+		installer.packageName)
+
+	return csvBuilder, nil
+}
+
+// subscriptionCSV returns the ClusterServiceVersion installed by the Subscription, or expectedCSV when set.
+func (installer *OperatorInstaller) subscriptionCSV(expectedCSV string) string {
+	if expectedCSV != "" || installer.Subscription.Object == nil {
+		return expectedCSV
+	}
+
+	if installedCSV := installer.Subscription.Object.Status.InstalledCSV; installedCSV != "" {
+		return installedCSV
+	}
+
+	return installer.Subscription.Object.Status.CurrentCSV
+}
+
+// restartOLM deletes the Subscription and the package ClusterServiceVersions and restarts the OLM pods,
+// which clears the OLM operator cache.
+func (installer *OperatorInstaller) restartOLM(ctx context.Context) error {
+	if err := installer.Subscription.DeleteContext(ctx); err != nil {
+		return fmt.Errorf("failed to delete subscription %s: %w", installer.subscriptionName, err)
+	}
+
+	csvList, err := installer.apiClient.ClusterServiceVersions(installer.namespaceName).List(ctx,
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("operators.coreos.com/%s.%s", installer.packageName, installer.namespaceName),
+		})
+	if err != nil {
+		return fmt.Errorf("failed to list the ClusterServiceVersions of package %s: %w", installer.packageName, err)
+	}
+
+	for _, csv := range csvList.Items {
+		glog.V(100).Infof("Deleting ClusterServiceVersion %s in namespace %s", csv.Name, installer.namespaceName)
+
+		if err := installer.apiClient.ClusterServiceVersions(installer.namespaceName).Delete(ctx,
+			csv.Name, metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("failed to delete ClusterServiceVersion %s: %w", csv.Name, err)
+		}
+	}
+
+	return DeleteOLMPods(installer.apiClient, logging.Level(100))
+}
+
+// ensureNamespace creates the namespace with the given labels, unless it already exists.
+func ensureNamespace(ctx context.Context, apiClient *clients.Settings, nsName string,
+	labels map[string]string) (*namespace.Builder, error) {
+	nsBuilder := namespace.NewBuilder(apiClient, nsName)
+
+	if nsBuilder.ExistsContext(ctx) {
+		glog.V(100).Infof("The namespace %s already exists", nsName)
+
+		return nsBuilder, nil
+	}
+
+	glog.V(100).Infof("Creating namespace %s with labels %v", nsName, labels)
+
+	return nsBuilder.WithMultipleLabels(labels).CreateContext(ctx)
+}
+
+// stepError wraps err into an *InstallError for the given step.
+func (installer *OperatorInstaller) stepError(step InstallStep, err error) error {
+	glog.V(100).Infof("Installing package %s failed at step %s: %v", installer.packageName, step, err)
+
+	return &InstallError{Step: step, Package: installer.packageName, Err: err}
+}
+
+// validate will check that the installer is properly initialized before accessing any member fields.
+func (installer *OperatorInstaller) validate() (bool, error) {
+	if installer == nil {
+		glog.V(100).Infof("The OperatorInstaller is uninitialized")
+
+		return false, fmt.Errorf("error: received nil OperatorInstaller")
+	}
+
+	if installer.errorMsg != "" {
+		glog.V(100).Infof("The OperatorInstaller has error message: %s", installer.errorMsg)
+
+		return false, errors.New(installer.errorMsg)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,331 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testPackage = "gpu-operator-certified"
+
+func newTestPackageManifest(catalogSource string, installModes ...operatorsV1alpha1.InstallModeType) *pkgManifestV1.PackageManifest {
+	channel := pkgManifestV1.PackageChannel{Name: "v24.9", CurrentCSV: testCSVName}
+
+	for _, installMode := range installModes {
+		channel.CurrentCSVDesc.InstallModes = append(channel.CurrentCSVDesc.InstallModes,
+			operatorsV1alpha1.InstallMode{Type: installMode, Supported: true})
+	}
+
+	return &pkgManifestV1.PackageManifest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testPackage,
+			Namespace: DefaultCatalogSourceNamespace,
+			Labels:    map[string]string{"catalog": catalogSource},
+		},
+		Status: pkgManifestV1.PackageManifestStatus{
+			CatalogSource:  catalogSource,
+			PackageName:    testPackage,
+			DefaultChannel: "v24.9",
+			Channels:       []pkgManifestV1.PackageChannel{channel},
+		},
+	}
+}
+
+func newTestInstaller(t *testing.T, fixtures []string, objects ...runtime.Object) *OperatorInstaller {
+	t.Helper()
+
+	apiClient, err := testfixtures.NewTestClients(fixtures, objects...)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	return NewOperatorInstaller(apiClient, testPackage, testCSVNamespace).
+		WithCSVTimeout(time.Millisecond, 10*time.Millisecond)
+}
+
+func TestOperatorInstallerInstall(t *testing.T) {
+	installer := newTestInstaller(t, []string{testfixtures.CSV, testfixtures.Subscription},
+		newTestPackageManifest("certified-operators", operatorsV1alpha1.InstallModeTypeOwnNamespace)).
+		WithCatalogSources("redhat-operators", "certified-operators").
+		WithNamespaceLabels(map[string]string{"openshift.io/cluster-monitoring": "true"}).
+		WithOperatorGroupName("gpu-og")
+
+	csvBuilder, err := installer.Install()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if csvBuilder.Object.Name != testCSVName {
+		t.Errorf("expected CSV %s, got %s", testCSVName, csvBuilder.Object.Name)
+	}
+
+	if installer.CatalogSource != "certified-operators" || installer.Channel != "v24.9" {
+		t.Errorf("expected the package to be resolved from certified-operators on v24.9, got %s on %s",
+			installer.CatalogSource, installer.Channel)
+	}
+
+	if installer.Namespace.Object.Labels["openshift.io/cluster-monitoring"] != "true" {
+		t.Errorf("expected the namespace to be labeled, got %v", installer.Namespace.Object.Labels)
+	}
+
+	operatorGroup, err := PullOperatorGroup(installer.apiClient, "gpu-og", testCSVNamespace)
+	if err != nil {
+		t.Fatalf("failed to pull the operatorgroup: %v", err)
+	}
+
+	if !slices.Equal(operatorGroup.Object.Spec.TargetNamespaces, []string{testCSVNamespace}) {
+		t.Errorf("expected an OwnNamespace operatorgroup, got %v", operatorGroup.Object.Spec.TargetNamespaces)
+	}
+}
+
+func TestOperatorInstallerSubscription(t *testing.T) {
+	installer := newTestInstaller(t, nil, newTestPackageManifest("certified-operators")).
+		WithCatalogSources("certified-operators").
+		WithSubscriptionName("gpu-subscription").
+		WithStartingCSV(testCSVName).
+		WithInstallPlanApproval(operatorsV1alpha1.ApprovalManual).
+		WithInstallMode(operatorsV1alpha1.InstallModeTypeAllNamespaces)
+
+	_, err := installer.Install()
+
+	var installErr *InstallError
+	if !errors.As(err, &installErr) || installErr.Step != InstallStepInstallPlan ||
+		!strings.Contains(err.Error(), "has no installplan pending approval") {
+		t.Fatalf("expected the InstallPlan step to time out, got %v", err)
+	}
+
+	spec := installer.Subscription.Object.Spec
+	if spec.CatalogSource != "certified-operators" || spec.CatalogSourceNamespace != DefaultCatalogSourceNamespace ||
+		spec.Channel != "v24.9" || spec.StartingCSV != testCSVName ||
+		spec.InstallPlanApproval != operatorsV1alpha1.ApprovalManual {
+		t.Errorf("unexpected subscription spec %+v", spec)
+	}
+
+	if targetNamespaces := installer.OperatorGroup.Object.Spec.TargetNamespaces; len(targetNamespaces) != 0 {
+		t.Errorf("expected an AllNamespaces operatorgroup, got %v", targetNamespaces)
+	}
+}
+
+func TestOperatorInstallerCustomCatalogSource(t *testing.T) {
+	catalogSource := NewCatalogSourceBuilderWithIndexImage(nil, "certified-operators-custom",
+		DefaultCatalogSourceNamespace, "quay.io/example/index:latest", "Custom", "Red Hat").Definition
+	catalogSource.Status.GRPCConnectionState = &operatorsV1alpha1.GRPCConnectionState{LastObservedState: "READY"}
+
+	installer := newTestInstaller(t, []string{testfixtures.CSV, testfixtures.Subscription}, catalogSource,
+		newTestPackageManifest("certified-operators-custom")).
+		WithCatalogSources("certified-operators").
+		WithCustomCatalogSource("certified-operators-custom", "quay.io/example/index:latest", "Custom", "Red Hat").
+		WithCatalogSourceReadyTimeout(time.Second).
+		WithPackageManifestTimeout(time.Millisecond, time.Second)
+
+	if _, err := installer.Install(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if installer.CatalogSource != "certified-operators-custom" {
+		t.Errorf("expected the custom catalogsource to be used, got %s", installer.CatalogSource)
+	}
+}
+
+func TestOperatorInstallerCustomCatalogSourceBuilder(t *testing.T) {
+	installer := newTestInstaller(t, []string{testfixtures.CSV, testfixtures.Subscription},
+		newTestReadyCatalogSource(), newTestPackageManifest(testCatalogSource))
+
+	catalogSource := NewCatalogSourceBuilderWithIndexImage(installer.apiClient, testCatalogSource,
+		DefaultCatalogSourceNamespace, "quay.io/example/index:latest", "Custom", "Red Hat").
+		WithRegistryPollInterval(time.Hour)
+
+	installer.WithCustomCatalogSourceBuilder(catalogSource).
+		WithCatalogSourceReadyTimeout(time.Second).
+		WithPackageManifestTimeout(time.Millisecond, time.Second)
+
+	if _, err := installer.Install(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if installer.CatalogSource != testCatalogSource || catalogSource.Object == nil {
+		t.Errorf("expected the custom catalogsource builder to be used, got %s", installer.CatalogSource)
+	}
+}
+
+func TestOperatorInstallerStepErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		installer     func(*OperatorInstaller) *OperatorInstaller
+		expectedStep  InstallStep
+		expectedError string
+	}{
+		{
+			name: "package not found",
+			installer: func(installer *OperatorInstaller) *OperatorInstaller {
+				return installer.WithCatalogSources("redhat-operators")
+			},
+			expectedStep:  InstallStepPackageManifest,
+			expectedError: "package not found in any of the catalogsources [redhat-operators]",
+		},
+		{
+			name: "unknown channel",
+			installer: func(installer *OperatorInstaller) *OperatorInstaller {
+				return installer.WithCatalogSources("certified-operators").WithChannel("stable")
+			},
+			expectedStep:  InstallStepPackageManifest,
+			expectedError: "channel stable not found in package gpu-operator-certified",
+		},
+		{
+			name: "unsupported install mode",
+			installer: func(installer *OperatorInstaller) *OperatorInstaller {
+				return installer.WithCatalogSources("certified-operators").
+					WithInstallMode(operatorsV1alpha1.InstallModeTypeAllNamespaces)
+			},
+			expectedStep:  InstallStepPackageManifest,
+			expectedError: "install mode AllNamespaces is not supported by gpu-operator-certified.v24.9.2",
+		},
+		{
+			name: "custom catalogsource not ready",
+			installer: func(installer *OperatorInstaller) *OperatorInstaller {
+				return installer.WithCatalogSources("redhat-operators").
+					WithCustomCatalogSource("certified-operators-custom", "quay.io/example/index:latest", "Custom", "Red Hat").
+					WithCatalogSourceReadyTimeout(10 * time.Millisecond)
+			},
+			expectedStep:  InstallStepCatalogSource,
+			expectedError: "catalogsource certified-operators-custom is not ready",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			installer := testCase.installer(newTestInstaller(t, nil,
+				newTestPackageManifest("certified-operators", operatorsV1alpha1.InstallModeTypeOwnNamespace)))
+
+			_, err := installer.Install()
+
+			var installErr *InstallError
+			if !errors.As(err, &installErr) {
+				t.Fatalf("expected an InstallError, got %v", err)
+			}
+
+			if installErr.Step != testCase.expectedStep || installErr.Package != testPackage ||
+				!strings.Contains(err.Error(), testCase.expectedError) {
+				t.Errorf("expected step %s with error %q, got %v", testCase.expectedStep, testCase.expectedError, err)
+			}
+
+			if installer.Namespace != nil {
+				t.Errorf("expected the namespace not to be created when the package is not resolved")
+			}
+		})
+	}
+}
+
+func TestOperatorInstallerCSVNotSucceeded(t *testing.T) {
+	csv := &operatorsV1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: testCSVName, Namespace: testCSVNamespace},
+		Status:     operatorsV1alpha1.ClusterServiceVersionStatus{Phase: operatorsV1alpha1.CSVPhaseFailed},
+	}
+
+	installer := newTestInstaller(t, []string{testfixtures.Subscription}, csv,
+		newTestPackageManifest("certified-operators", operatorsV1alpha1.InstallModeTypeOwnNamespace)).
+		WithCatalogSources("certified-operators")
+
+	_, err := installer.Install()
+
+	var (
+		installErr      *InstallError
+		csvNotSucceeded *CSVNotSucceededError
+	)
+
+	if !errors.As(err, &installErr) || installErr.Step != InstallStepCSV || !errors.As(err, &csvNotSucceeded) {
+		t.Fatalf("expected a diagnosed CSV step error, got %v", err)
+	}
+
+	if diagnosis := csvNotSucceeded.Diagnosis; diagnosis.Name != testCSVName ||
+		diagnosis.Phase != operatorsV1alpha1.CSVPhaseFailed {
+		t.Errorf("expected the diagnosis of the failed %s, got %+v", testCSVName, csvNotSucceeded.Diagnosis)
+	}
+}
+
+func TestOperatorInstallerInstallContextCanceled(t *testing.T) {
+	installer := newTestInstaller(t, nil, newTestPackageManifest("certified-operators")).
+		WithCatalogSources("certified-operators").
+		WithCSVTimeout(time.Millisecond, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := installer.InstallContext(ctx)
+
+	var installErr *InstallError
+	if !errors.As(err, &installErr) || installErr.Step != InstallStepCSV || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the CSV wait to stop with the canceled context, got %v", err)
+	}
+}
+
+func TestOperatorInstallerOLMRestartRetries(t *testing.T) {
+	installer := newTestInstaller(t, nil, newTestPackageManifest("certified-operators")).
+		WithCatalogSources("certified-operators").
+		WithOLMRestartRetries(1)
+
+	_, err := installer.Install()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the CSV wait to time out after the retry, got %v", err)
+	}
+
+	if !installer.Subscription.Exists() {
+		t.Error("expected the subscription to be recreated after restarting OLM")
+	}
+}
+
+func TestOperatorInstallerValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		installer     *OperatorInstaller
+		expectedError string
+	}{
+		{
+			name:          "empty package",
+			installer:     NewOperatorInstaller(nil, "", testCSVNamespace),
+			expectedError: "OperatorInstaller 'packageName' cannot be empty",
+		},
+		{
+			name:          "no catalogsource",
+			installer:     NewOperatorInstaller(&clients.Settings{}, testPackage, testCSVNamespace),
+			expectedError: "OperatorInstaller for package gpu-operator-certified has no catalogsource",
+		},
+		{
+			name: "single namespace without target",
+			installer: NewOperatorInstaller(&clients.Settings{}, testPackage, testCSVNamespace).
+				WithInstallMode(operatorsV1alpha1.InstallModeTypeSingleNamespace),
+			expectedError: "install mode SingleNamespace takes exactly one target namespace",
+		},
+		{
+			name: "own namespace with target",
+			installer: NewOperatorInstaller(&clients.Settings{}, testPackage, testCSVNamespace).
+				WithInstallMode(operatorsV1alpha1.InstallModeTypeOwnNamespace, "default"),
+			expectedError: "install mode OwnNamespace does not take target namespaces",
+		},
+		{
+			name: "unsupported approval",
+			installer: NewOperatorInstaller(&clients.Settings{}, testPackage, testCSVNamespace).
+				WithInstallPlanApproval("Never"),
+			expectedError: `OperatorInstaller installPlanApproval "Never" is not supported`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.installer.Install()
+			if err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
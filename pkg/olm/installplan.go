@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/msg"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -58,6 +59,11 @@ func NewInstallPlanBuilder(apiClient *clients.Settings, name, nsname string) *In
 
 // Create makes an InstallPlanBuilder in cluster and stores the created object in struct.
 func (builder *InstallPlanBuilder) Create() (*InstallPlanBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes an InstallPlanBuilder in cluster and stores the created object in struct.
+func (builder *InstallPlanBuilder) CreateContext(ctx context.Context) (*InstallPlanBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -66,8 +72,8 @@ func (builder *InstallPlanBuilder) Create() (*InstallPlanBuilder, error) {
 		builder.Definition.Name, builder.Definition.Namespace)
 
 	var err error
-	if !builder.Exists() {
-		builder.Object, err = builder.apiClient.InstallPlans(builder.Definition.Namespace).Create(context.TODO(),
+	if !builder.ExistsContext(ctx) {
+		builder.Object, err = builder.apiClient.InstallPlans(builder.Definition.Namespace).Create(ctx,
 			builder.Definition, metav1.CreateOptions{})
 	}
 
@@ -76,6 +82,11 @@ func (builder *InstallPlanBuilder) Create() (*InstallPlanBuilder, error) {
 
 // Exists checks whether the given installplan exists.
 func (builder *InstallPlanBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given installplan exists.
+func (builder *InstallPlanBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -85,13 +96,18 @@ func (builder *InstallPlanBuilder) Exists() bool {
 
 	var err error
 	builder.Object, err = builder.apiClient.InstallPlans(builder.Definition.Namespace).Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
 // Delete removes an installplan.
 func (builder *InstallPlanBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes an installplan.
+func (builder *InstallPlanBuilder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -99,11 +115,11 @@ func (builder *InstallPlanBuilder) Delete() error {
 	glog.V(100).Infof("Deleting installplan %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil
 	}
 
-	err := builder.apiClient.InstallPlans(builder.Definition.Namespace).Delete(context.TODO(),
+	err := builder.apiClient.InstallPlans(builder.Definition.Namespace).Delete(ctx,
 		builder.Object.Name, metav1.DeleteOptions{})
 
 	if err != nil {
@@ -117,6 +133,11 @@ func (builder *InstallPlanBuilder) Delete() error {
 
 // Update modifies the existing InstallPlanBuilder with the InstallPlan definition in InstallPlanBuilder.
 func (builder *InstallPlanBuilder) Update() (*InstallPlanBuilder, error) {
+	return builder.UpdateContext(context.TODO())
+}
+
+// UpdateContext modifies the existing InstallPlanBuilder with the InstallPlan definition in InstallPlanBuilder.
+func (builder *InstallPlanBuilder) UpdateContext(ctx context.Context) (*InstallPlanBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -126,11 +147,80 @@ func (builder *InstallPlanBuilder) Update() (*InstallPlanBuilder, error) {
 
 	var err error
 	builder.Object, err = builder.apiClient.InstallPlans(builder.Definition.Namespace).Update(
-		context.TODO(), builder.Definition, metav1.UpdateOptions{})
+		ctx, builder.Definition, metav1.UpdateOptions{})
 
 	return builder, err
 }
 
+// PullInstallPlan loads an existing installplan into InstallPlanBuilder struct.
+func PullInstallPlan(apiClient *clients.Settings, name, nsname string) (*InstallPlanBuilder, error) {
+	return PullInstallPlanContext(context.TODO(), apiClient, name, nsname)
+}
+
+// PullInstallPlanContext loads an existing installplan into InstallPlanBuilder struct.
+func PullInstallPlanContext(ctx context.Context, apiClient *clients.Settings, name,
+	nsname string) (*InstallPlanBuilder, error) {
+	glog.V(100).Infof("Pulling existing installplan %s in namespace %s", name, nsname)
+
+	builder := NewInstallPlanBuilder(apiClient, name, nsname)
+
+	if !builder.ExistsContext(ctx) || builder.Object == nil {
+		return nil, fmt.Errorf("installplan object %s doesn't exist in namespace %s", name, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// IsPendingApproval checks if the installplan waits for a manual approval.
+func (builder *InstallPlanBuilder) IsPendingApproval() bool {
+	return builder.IsPendingApprovalContext(context.TODO())
+}
+
+// IsPendingApprovalContext checks if the installplan waits for a manual approval.
+func (builder *InstallPlanBuilder) IsPendingApprovalContext(ctx context.Context) bool {
+	if !builder.ExistsContext(ctx) || builder.Object == nil {
+		return false
+	}
+
+	return !builder.Object.Spec.Approved && builder.Object.Status.Phase == v1alpha1.InstallPlanPhaseRequiresApproval
+}
+
+// InstallsCSV checks if the given clusterserviceversion is one of the clusterserviceversions of the installplan.
+func (builder *InstallPlanBuilder) InstallsCSV(csvName string) bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	return slices.Contains(builder.Definition.Spec.ClusterServiceVersionNames, csvName)
+}
+
+// Approve approves the installplan, allowing OLM to install its clusterserviceversions.
+func (builder *InstallPlanBuilder) Approve() (*InstallPlanBuilder, error) {
+	return builder.ApproveContext(context.TODO())
+}
+
+// ApproveContext approves the installplan, allowing OLM to install its clusterserviceversions.
+func (builder *InstallPlanBuilder) ApproveContext(ctx context.Context) (*InstallPlanBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Approving installplan %s in namespace %s for clusterserviceversions %v",
+		builder.Definition.Name, builder.Definition.Namespace, builder.Definition.Spec.ClusterServiceVersionNames)
+
+	if !builder.ExistsContext(ctx) || builder.Object == nil {
+		return builder, fmt.Errorf("installplan %s doesn't exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	builder.Definition = builder.Object.DeepCopy()
+	builder.Definition.Spec.Approved = true
+
+	return builder.UpdateContext(ctx)
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *InstallPlanBuilder) validate() (bool, error) {
@@ -0,0 +1,216 @@
+package olm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var testUpgradePath = []string{
+	"gpu-operator-certified.v24.9.2",
+	"gpu-operator-certified.v25.3.4",
+	"gpu-operator-certified.v25.10.0",
+}
+
+func newTestInstallPlan(name, csvName string) *operatorsV1alpha1.InstallPlan {
+	return &operatorsV1alpha1.InstallPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testCSVNamespace},
+		Spec: operatorsV1alpha1.InstallPlanSpec{
+			ClusterServiceVersionNames: []string{csvName},
+			Approval:                   operatorsV1alpha1.ApprovalManual,
+		},
+		Status: operatorsV1alpha1.InstallPlanStatus{Phase: operatorsV1alpha1.InstallPlanPhaseRequiresApproval},
+	}
+}
+
+// runFakeOLM mimics OLM for a Subscription with Manual approval: it creates an InstallPlan for each of the
+// given CSVs in turn, and installs a CSV once its InstallPlan is approved.
+func runFakeOLM(t *testing.T, apiClient *clients.Settings, subName string, csvNames []string) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go func() {
+		next := 0
+
+		for ctx.Err() == nil {
+			time.Sleep(time.Millisecond)
+
+			subscription, err := apiClient.Subscriptions(testCSVNamespace).Get(ctx, subName, metav1.GetOptions{})
+			if err != nil || next == len(csvNames) {
+				continue
+			}
+
+			if subscription.Status.InstallPlanRef != nil {
+				installPlan, err := apiClient.InstallPlans(testCSVNamespace).Get(ctx,
+					subscription.Status.InstallPlanRef.Name, metav1.GetOptions{})
+				if err != nil || !installPlan.Spec.Approved ||
+					installPlan.Status.Phase != operatorsV1alpha1.InstallPlanPhaseRequiresApproval {
+					continue
+				}
+
+				installPlan.Status.Phase = operatorsV1alpha1.InstallPlanPhaseComplete
+				if _, err := apiClient.InstallPlans(testCSVNamespace).Update(ctx, installPlan,
+					metav1.UpdateOptions{}); err != nil {
+					continue
+				}
+
+				if _, err := apiClient.ClusterServiceVersions(testCSVNamespace).Create(ctx,
+					&operatorsV1alpha1.ClusterServiceVersion{
+						ObjectMeta: metav1.ObjectMeta{Name: csvNames[next], Namespace: testCSVNamespace},
+						Status: operatorsV1alpha1.ClusterServiceVersionStatus{
+							Phase: operatorsV1alpha1.CSVPhaseSucceeded,
+						},
+					}, metav1.CreateOptions{}); err != nil {
+					continue
+				}
+
+				subscription.Status.InstalledCSV = csvNames[next]
+				next++
+			}
+
+			if next < len(csvNames) {
+				installPlanName := fmt.Sprintf("install-%d", next)
+				if _, err := apiClient.InstallPlans(testCSVNamespace).Create(ctx,
+					newTestInstallPlan(installPlanName, csvNames[next]), metav1.CreateOptions{}); err != nil {
+					continue
+				}
+
+				subscription.Status.CurrentCSV = csvNames[next]
+				subscription.Status.InstallPlanRef = &corev1.ObjectReference{
+					Name: installPlanName, Namespace: testCSVNamespace}
+			}
+
+			_, _ = apiClient.Subscriptions(testCSVNamespace).Update(ctx, subscription, metav1.UpdateOptions{})
+		}
+	}()
+}
+
+func TestSubscriptionApproveInstallPlan(t *testing.T) {
+	testCases := []struct {
+		name          string
+		installPlan   *operatorsV1alpha1.InstallPlan
+		csvName       string
+		expectedError string
+	}{
+		{
+			name:        "matching clusterserviceversion",
+			installPlan: newTestInstallPlan("install-1", testUpgradePath[1]),
+			csvName:     testUpgradePath[1],
+		},
+		{
+			name:        "any clusterserviceversion",
+			installPlan: newTestInstallPlan("install-1", testUpgradePath[1]),
+		},
+		{
+			name:          "unexpected clusterserviceversion",
+			installPlan:   newTestInstallPlan("install-1", testUpgradePath[2]),
+			csvName:       testUpgradePath[1],
+			expectedError: "installplan install-1 installs [gpu-operator-certified.v25.10.0], expected " + testUpgradePath[1],
+		},
+		{
+			name: "already approved",
+			installPlan: func() *operatorsV1alpha1.InstallPlan {
+				installPlan := newTestInstallPlan("install-1", testUpgradePath[1])
+				installPlan.Spec.Approved = true
+
+				return installPlan
+			}(),
+			expectedError: "subscription gpu-operator-certified has no installplan pending approval",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Subscription}, testCase.installPlan)
+			if err != nil {
+				t.Fatalf("failed to create test clients: %v", err)
+			}
+
+			subscription, err := PullSubscription(apiClient, testPackage, testCSVNamespace)
+			if err != nil {
+				t.Fatalf("failed to pull the subscription: %v", err)
+			}
+
+			subscription.Object.Status.InstallPlanRef = &corev1.ObjectReference{Name: "install-1"}
+			if _, err := apiClient.Subscriptions(testCSVNamespace).Update(context.TODO(), subscription.Object,
+				metav1.UpdateOptions{}); err != nil {
+				t.Fatalf("failed to update the subscription: %v", err)
+			}
+
+			installPlan, err := subscription.ApproveInstallPlan(testCase.csvName, time.Millisecond, 10*time.Millisecond)
+
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !installPlan.Object.Spec.Approved {
+				t.Error("expected the installplan to be approved")
+			}
+		})
+	}
+}
+
+func TestOperatorInstallerManualApprovalUpgradeThrough(t *testing.T) {
+	installer := newTestInstaller(t, nil, newTestPackageManifest("certified-operators")).
+		WithCatalogSources("certified-operators").
+		WithStartingCSV(testUpgradePath[0]).
+		WithInstallPlanApproval(operatorsV1alpha1.ApprovalManual).
+		WithCSVTimeout(time.Millisecond, 5*time.Second)
+
+	runFakeOLM(t, installer.apiClient, testPackage, testUpgradePath)
+
+	csvBuilder, err := installer.Install()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if csvBuilder.Object.Name != testUpgradePath[0] {
+		t.Fatalf("expected the operator to be pinned to %s, got %s", testUpgradePath[0], csvBuilder.Object.Name)
+	}
+
+	if _, err := installer.Subscription.WaitForPendingInstallPlan(time.Millisecond, 5*time.Second); err != nil {
+		t.Fatalf("expected the upgrade to wait for approval: %v", err)
+	}
+
+	if installedCSV := installer.Subscription.Object.Status.InstalledCSV; installedCSV != testUpgradePath[0] {
+		t.Errorf("expected the upgrade not to proceed without approval, got %s installed", installedCSV)
+	}
+
+	csvBuilder, err = installer.Subscription.UpgradeThrough(testUpgradePath[1:], time.Millisecond, 5*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if csvBuilder.Object.Name != testUpgradePath[2] {
+		t.Errorf("expected the operator to be upgraded to %s, got %s", testUpgradePath[2], csvBuilder.Object.Name)
+	}
+
+	installPlans, err := ListInstallPlan(installer.apiClient, testCSVNamespace)
+	if err != nil {
+		t.Fatalf("failed to list the installplans: %v", err)
+	}
+
+	for _, installPlan := range installPlans {
+		if !installPlan.Object.Spec.Approved {
+			t.Errorf("expected installplan %s to be approved", installPlan.Object.Name)
+		}
+	}
+}
@@ -10,8 +10,13 @@ import (
 )
 
 // ListInstallPlan returns a list of installplans found for specific namespace.
-func ListInstallPlan(
-	apiClient *clients.Settings, nsname string, options ...v1.ListOptions) ([]*InstallPlanBuilder, error) {
+func ListInstallPlan(apiClient *clients.Settings, nsname string, options ...v1.ListOptions) ([]*InstallPlanBuilder, error) {
+	return ListInstallPlanContext(context.TODO(), apiClient, nsname, options...)
+}
+
+// ListInstallPlanContext returns a list of installplans found for specific namespace.
+func ListInstallPlanContext(ctx context.Context, apiClient *clients.Settings, nsname string,
+	options ...v1.ListOptions) ([]*InstallPlanBuilder, error) {
 	if nsname == "" {
 		glog.V(100).Info("The nsname of the installplan is empty")
 
@@ -34,7 +39,7 @@ func ListInstallPlan(
 
 	glog.V(100).Infof(logMessage)
 
-	installPlanList, err := apiClient.InstallPlans(nsname).List(context.TODO(), passedOptions)
+	installPlanList, err := apiClient.InstallPlans(nsname).List(ctx, passedOptions)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list all installplan in namespace %s due to %s",
@@ -0,0 +1,54 @@
+package olm
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// OLMv1APIGroup is the API group of the OLM v1 resources.
+	OLMv1APIGroup = "olm.operatorframework.io"
+	// OLMv1APIVersion is the API version of the OLM v1 resources.
+	OLMv1APIVersion = OLMv1APIGroup + "/v1"
+	// ClusterCatalogNameLabel is set by OLM v1 on every ClusterCatalog to its name, and is used to select the
+	// ClusterCatalogs a ClusterExtension resolves its package from.
+	ClusterCatalogNameLabel = OLMv1APIGroup + "/metadata.name"
+	// ClusterExtensionOwnerNameLabel is set by OLM v1 on the resources installed for a ClusterExtension.
+	ClusterExtensionOwnerNameLabel = OLMv1APIGroup + "/owner-name"
+	// AlmExamplesAnnotation holds the example custom resources of an operator bundle. OLM v1 does not create a
+	// ClusterServiceVersion, but copies its annotations onto the pod template of the operator deployments.
+	AlmExamplesAnnotation = "alm-examples"
+)
+
+var (
+	// ClusterCatalogGVR is the resource of the OLM v1 ClusterCatalogs.
+	ClusterCatalogGVR = schema.GroupVersionResource{
+		Group: OLMv1APIGroup, Version: "v1", Resource: "clustercatalogs"}
+	// ClusterExtensionGVR is the resource of the OLM v1 ClusterExtensions.
+	ClusterExtensionGVR = schema.GroupVersionResource{
+		Group: OLMv1APIGroup, Version: "v1", Resource: "clusterextensions"}
+)
+
+// olmv1Condition returns the status and message of the given status condition of an OLM v1 resource, and whether
+// the condition is reported at all.
+func olmv1Condition(object *unstructured.Unstructured, conditionType string) (string, string, bool) {
+	if object == nil {
+		return "", "", false
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if !ok || fields["type"] != conditionType {
+			continue
+		}
+
+		status, _ := fields["status"].(string)
+		message, _ := fields["message"].(string)
+
+		return status, message, true
+	}
+
+	return "", "", false
+}
@@ -64,6 +64,11 @@ func NewOperatorGroupBuilder(apiClient *clients.Settings, groupName, nsName stri
 
 // Create makes an OperatorGroup in cluster and stores the created object in struct.
 func (builder *OperatorGroupBuilder) Create() (*OperatorGroupBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes an OperatorGroup in cluster and stores the created object in struct.
+func (builder *OperatorGroupBuilder) CreateContext(ctx context.Context) (*OperatorGroupBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -72,8 +77,8 @@ func (builder *OperatorGroupBuilder) Create() (*OperatorGroupBuilder, error) {
 		builder.Definition.Name)
 
 	var err error
-	if !builder.Exists() {
-		builder.Object, err = builder.apiClient.OperatorGroups(builder.Definition.Namespace).Create(context.TODO(),
+	if !builder.ExistsContext(ctx) {
+		builder.Object, err = builder.apiClient.OperatorGroups(builder.Definition.Namespace).Create(ctx,
 			builder.Definition, metav1.CreateOptions{})
 	}
 
@@ -82,6 +87,11 @@ func (builder *OperatorGroupBuilder) Create() (*OperatorGroupBuilder, error) {
 
 // Exists checks whether the given OperatorGroup exists.
 func (builder *OperatorGroupBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given OperatorGroup exists.
+func (builder *OperatorGroupBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -92,13 +102,18 @@ func (builder *OperatorGroupBuilder) Exists() bool {
 	var err error
 
 	builder.Object, err = builder.apiClient.OperatorGroups(builder.Definition.Namespace).Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
 // Delete removes an OperatorGroup.
 func (builder *OperatorGroupBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes an OperatorGroup.
+func (builder *OperatorGroupBuilder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -106,11 +121,11 @@ func (builder *OperatorGroupBuilder) Delete() error {
 	glog.V(100).Infof("Deleting OperatorGroup %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil
 	}
 
-	err := builder.apiClient.OperatorGroups(builder.Definition.Namespace).Delete(context.TODO(), builder.Object.Name,
+	err := builder.apiClient.OperatorGroups(builder.Definition.Namespace).Delete(ctx, builder.Object.Name,
 		metav1.DeleteOptions{})
 
 	if err != nil {
@@ -124,6 +139,11 @@ func (builder *OperatorGroupBuilder) Delete() error {
 
 // Update modifies the existing OperatorGroup with the OperatorGroup definition in OperatorGroupBuilder.
 func (builder *OperatorGroupBuilder) Update() (*OperatorGroupBuilder, error) {
+	return builder.UpdateContext(context.TODO())
+}
+
+// UpdateContext modifies the existing OperatorGroup with the OperatorGroup definition in OperatorGroupBuilder.
+func (builder *OperatorGroupBuilder) UpdateContext(ctx context.Context) (*OperatorGroupBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -133,13 +153,19 @@ func (builder *OperatorGroupBuilder) Update() (*OperatorGroupBuilder, error) {
 
 	var err error
 	builder.Object, err = builder.apiClient.OperatorGroups(builder.Definition.Namespace).Update(
-		context.TODO(), builder.Definition, metav1.UpdateOptions{})
+		ctx, builder.Definition, metav1.UpdateOptions{})
 
 	return builder, err
 }
 
 // PullOperatorGroup loads existing OperatorGroup from cluster into the OperatorGroupBuilder struct.
 func PullOperatorGroup(apiClient *clients.Settings, groupName, nsName string) (*OperatorGroupBuilder, error) {
+	return PullOperatorGroupContext(context.TODO(), apiClient, groupName, nsName)
+}
+
+// PullOperatorGroupContext loads existing OperatorGroup from cluster into the OperatorGroupBuilder struct.
+func PullOperatorGroupContext(ctx context.Context, apiClient *clients.Settings, groupName,
+	nsName string) (*OperatorGroupBuilder, error) {
 	glog.V(100).Infof("Pulling existing OperatorGroup %s from cluster in namespace %s",
 		groupName, nsName)
 
@@ -166,7 +192,7 @@ func PullOperatorGroup(apiClient *clients.Settings, groupName, nsName string) (*
 		builder.errorMsg = "OperatorGroup 'Namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("OperatorGroup object named %s doesn't exist", nsName)
 	}
 
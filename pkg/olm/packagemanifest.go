@@ -31,6 +31,12 @@ type PackageManifestBuilder struct {
 
 // PullPackageManifest loads an existing PackageManifest into Builder struct.
 func PullPackageManifest(apiClient *clients.Settings, name, nsname string) (*PackageManifestBuilder, error) {
+	return PullPackageManifestContext(context.TODO(), apiClient, name, nsname)
+}
+
+// PullPackageManifestContext loads an existing PackageManifest into Builder struct.
+func PullPackageManifestContext(ctx context.Context, apiClient *clients.Settings, name,
+	nsname string) (*PackageManifestBuilder, error) {
 	glog.V(100).Infof("Pulling existing PackageManifest name %s in namespace %s", name, nsname)
 
 	builder := &PackageManifestBuilder{
@@ -55,7 +61,7 @@ func PullPackageManifest(apiClient *clients.Settings, name, nsname string) (*Pac
 		builder.errorMsg = "PackageManifest 'nsname' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("PackageManifest object %s doesn't exist in namespace %s", name, nsname)
 	}
 
@@ -65,8 +71,15 @@ func PullPackageManifest(apiClient *clients.Settings, name, nsname string) (*Pac
 }
 
 // PullPackageManifestByCatalogWithTimeout loads an existing PackageManifest from specified catalog into Builder struct with timeout.
-func PullPackageManifestByCatalogWithTimeout(apiClient *clients.Settings, name, nsname,
-	catalog string, backoff time.Duration, timeout time.Duration) (*PackageManifestBuilder, error) {
+func PullPackageManifestByCatalogWithTimeout(apiClient *clients.Settings, name, nsname, catalog string, backoff time.Duration, timeout time.Duration) (*PackageManifestBuilder, error) {
+	return PullPackageManifestByCatalogWithTimeoutContext(context.TODO(), apiClient, name, nsname, catalog, backoff,
+		timeout)
+}
+
+// PullPackageManifestByCatalogWithTimeoutContext loads an existing PackageManifest from specified catalog into
+// Builder struct with timeout.
+func PullPackageManifestByCatalogWithTimeoutContext(ctx context.Context, apiClient *clients.Settings, name,
+	nsname, catalog string, backoff time.Duration, timeout time.Duration) (*PackageManifestBuilder, error) {
 	glog.V(100).Infof("Pulling existing PackageManifest name %s in namespace %s and from catalog %s with backoff of %v and timeout of %v",
 		name, nsname, catalog, backoff, timeout)
 	if nsname == "" {
@@ -81,9 +94,9 @@ func PullPackageManifestByCatalogWithTimeout(apiClient *clients.Settings, name,
 	glog.V(100).Infof(logMessage)
 	var pkgManifestList *pkgManifestV1.PackageManifestList
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), backoff, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, backoff, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
-			pkgManifestList, err = apiClient.PackageManifestInterface.PackageManifests(nsname).List(context.TODO(),
+			pkgManifestList, err = apiClient.PackageManifestInterface.PackageManifests(nsname).List(ctx,
 				passedOptions)
 			if err != nil {
 				return false, err
@@ -120,7 +133,12 @@ func PullPackageManifestByCatalogWithTimeout(apiClient *clients.Settings, name,
 }
 
 // PullPackageManifestByCatalog loads an existing PackageManifest from specified catalog into Builder struct.
-func PullPackageManifestByCatalog(apiClient *clients.Settings, name, nsname,
+func PullPackageManifestByCatalog(apiClient *clients.Settings, name, nsname, catalog string) (*PackageManifestBuilder, error) {
+	return PullPackageManifestByCatalogContext(context.TODO(), apiClient, name, nsname, catalog)
+}
+
+// PullPackageManifestByCatalogContext loads an existing PackageManifest from specified catalog into Builder struct.
+func PullPackageManifestByCatalogContext(ctx context.Context, apiClient *clients.Settings, name, nsname,
 	catalog string) (*PackageManifestBuilder, error) {
 	glog.V(100).Infof("Pulling existing PackageManifest name %s in namespace %s and from catalog %s",
 		name, nsname, catalog)
@@ -134,7 +152,7 @@ func PullPackageManifestByCatalog(apiClient *clients.Settings, name, nsname,
 	}
 	logMessage := fmt.Sprintf("Listing PackageManifests in the namespace %s with the options %v", nsname, passedOptions)
 	glog.V(100).Infof(logMessage)
-	pkgManifestList, err := apiClient.PackageManifestInterface.PackageManifests(nsname).List(context.TODO(),
+	pkgManifestList, err := apiClient.PackageManifestInterface.PackageManifests(nsname).List(ctx,
 		passedOptions)
 	if err != nil {
 		glog.V(100).Infof("Failed to list PackageManifests in the namespace %s due to %s",
@@ -164,6 +182,11 @@ func PullPackageManifestByCatalog(apiClient *clients.Settings, name, nsname,
 
 // Exists checks whether the given PackageManifest exists.
 func (builder *PackageManifestBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given PackageManifest exists.
+func (builder *PackageManifestBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -173,13 +196,18 @@ func (builder *PackageManifestBuilder) Exists() bool {
 
 	var err error
 	builder.Object, err = builder.apiClient.PackageManifestInterface.PackageManifests(
-		builder.Definition.Namespace).Get(context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		builder.Definition.Namespace).Get(ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
 // Delete removes a PackageManifest.
 func (builder *PackageManifestBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a PackageManifest.
+func (builder *PackageManifestBuilder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -187,12 +215,12 @@ func (builder *PackageManifestBuilder) Delete() error {
 	glog.V(100).Infof("Deleting PackageManifest %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil
 	}
 
 	err := builder.apiClient.PackageManifestInterface.PackageManifests(builder.Definition.Namespace).Delete(
-		context.TODO(), builder.Object.Name, metav1.DeleteOptions{})
+		ctx, builder.Object.Name, metav1.DeleteOptions{})
 
 	if err != nil {
 		return err
@@ -13,9 +13,12 @@ import (
 )
 
 // ListPackageManifest returns PackageManifest inventory in the given namespace.
-func ListPackageManifest(
-	apiClient *clients.Settings,
-	nsname string,
+func ListPackageManifest(apiClient *clients.Settings, nsname string, options metav1.ListOptions) ([]*PackageManifestBuilder, error) {
+	return ListPackageManifestContext(context.TODO(), apiClient, nsname, options)
+}
+
+// ListPackageManifestContext returns PackageManifest inventory in the given namespace.
+func ListPackageManifestContext(ctx context.Context, apiClient *clients.Settings, nsname string,
 	options metav1.ListOptions) ([]*PackageManifestBuilder, error) {
 	if nsname == "" {
 		glog.V(100).Infof("packagemanifest 'nsname' parameter can not be empty")
@@ -24,7 +27,7 @@ func ListPackageManifest(
 
 	glog.V(100).Infof("Listing PackageManifests in the namespace %s", nsname)
 
-	pkgManifestList, err := apiClient.PackageManifestInterface.PackageManifests(nsname).List(context.TODO(), options)
+	pkgManifestList, err := apiClient.PackageManifestInterface.PackageManifests(nsname).List(ctx, options)
 	if err != nil {
 		glog.V(100).Infof("Failed to list PackageManifests in the namespace %s due to %s",
 			nsname, err.Error())
@@ -45,12 +48,13 @@ func ListPackageManifest(
 }
 
 // ListPackageManifestWithTimeout returns PackageManifest inventory in the given namespace and timeout.
-func ListPackageManifestWithTimeout(
-	apiClient *clients.Settings,
-	nsname string,
-	backoff time.Duration,
-	timeout time.Duration,
-	options metav1.ListOptions) ([]*PackageManifestBuilder, error) {
+func ListPackageManifestWithTimeout(apiClient *clients.Settings, nsname string, backoff time.Duration, timeout time.Duration, options metav1.ListOptions) ([]*PackageManifestBuilder, error) {
+	return ListPackageManifestWithTimeoutContext(context.TODO(), apiClient, nsname, backoff, timeout, options)
+}
+
+// ListPackageManifestWithTimeoutContext returns PackageManifest inventory in the given namespace and timeout.
+func ListPackageManifestWithTimeoutContext(ctx context.Context, apiClient *clients.Settings, nsname string,
+	backoff time.Duration, timeout time.Duration, options metav1.ListOptions) ([]*PackageManifestBuilder, error) {
 	if nsname == "" {
 		glog.V(100).Infof("packagemanifest 'nsname' parameter can not be empty")
 		return nil, fmt.Errorf("failed to list packagemanifests, 'nsname' parameter is empty")
@@ -59,9 +63,9 @@ func ListPackageManifestWithTimeout(
 	glog.V(100).Infof("Listing PackageManifests in the namespace %s", nsname)
 	var pkgManifestList *v1.PackageManifestList
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), backoff, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, backoff, timeout, true, func(ctx context.Context) (bool, error) {
 			var err error
-			pkgManifestList, err = apiClient.PackageManifestInterface.PackageManifests(nsname).List(context.TODO(), options)
+			pkgManifestList, err = apiClient.PackageManifestInterface.PackageManifests(nsname).List(ctx, options)
 			if err != nil {
 				return false, err
 			}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang/glog"
 	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -11,6 +12,7 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/msg"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // SubscriptionBuilder provides a struct for Subscription object containing connection to the
@@ -153,6 +155,11 @@ func (builder *SubscriptionBuilder) WithInstallPlanApproval(
 
 // Create makes an Subscription in cluster and stores the created object in struct.
 func (builder *SubscriptionBuilder) Create() (*SubscriptionBuilder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes an Subscription in cluster and stores the created object in struct.
+func (builder *SubscriptionBuilder) CreateContext(ctx context.Context) (*SubscriptionBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -161,8 +168,8 @@ func (builder *SubscriptionBuilder) Create() (*SubscriptionBuilder, error) {
 		builder.Definition.Name, builder.Definition.Namespace)
 
 	var err error
-	if !builder.Exists() {
-		builder.Object, err = builder.apiClient.Subscriptions(builder.Definition.Namespace).Create(context.TODO(),
+	if !builder.ExistsContext(ctx) {
+		builder.Object, err = builder.apiClient.Subscriptions(builder.Definition.Namespace).Create(ctx,
 			builder.Definition, metav1.CreateOptions{})
 	}
 
@@ -171,6 +178,11 @@ func (builder *SubscriptionBuilder) Create() (*SubscriptionBuilder, error) {
 
 // Exists checks whether the given Subscription exists.
 func (builder *SubscriptionBuilder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given Subscription exists.
+func (builder *SubscriptionBuilder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -181,13 +193,18 @@ func (builder *SubscriptionBuilder) Exists() bool {
 	var err error
 
 	builder.Object, err = builder.apiClient.Subscriptions(builder.Definition.Namespace).Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
 // Delete removes a Subscription.
 func (builder *SubscriptionBuilder) Delete() error {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes a Subscription.
+func (builder *SubscriptionBuilder) DeleteContext(ctx context.Context) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -195,11 +212,11 @@ func (builder *SubscriptionBuilder) Delete() error {
 	glog.V(100).Infof("Deleting Subscription %s in namespace %s", builder.Definition.Name,
 		builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil
 	}
 
-	err := builder.apiClient.Subscriptions(builder.Definition.Namespace).Delete(context.TODO(), builder.Object.Name,
+	err := builder.apiClient.Subscriptions(builder.Definition.Namespace).Delete(ctx, builder.Object.Name,
 		metav1.DeleteOptions{})
 
 	if err != nil {
@@ -213,6 +230,11 @@ func (builder *SubscriptionBuilder) Delete() error {
 
 // Update modifies the existing Subscription with the Subscription definition in SubscriptionBuilder.
 func (builder *SubscriptionBuilder) Update() (*SubscriptionBuilder, error) {
+	return builder.UpdateContext(context.TODO())
+}
+
+// UpdateContext modifies the existing Subscription with the Subscription definition in SubscriptionBuilder.
+func (builder *SubscriptionBuilder) UpdateContext(ctx context.Context) (*SubscriptionBuilder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -220,7 +242,7 @@ func (builder *SubscriptionBuilder) Update() (*SubscriptionBuilder, error) {
 	glog.V(100).Infof("Updating Subscription %s in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("subscription named %s in namespace %s doesn't exist",
 			builder.Definition.Name, builder.Definition.Namespace)
 	}
@@ -228,13 +250,205 @@ func (builder *SubscriptionBuilder) Update() (*SubscriptionBuilder, error) {
 	var err error
 
 	builder.Object, err = builder.apiClient.Subscriptions(builder.Definition.Namespace).Update(
-		context.TODO(), builder.Definition, metav1.UpdateOptions{})
+		ctx, builder.Definition, metav1.UpdateOptions{})
 
 	return builder, err
 }
 
+// WaitForPendingInstallPlan waits for the Subscription to reference an installplan pending manual approval.
+func (builder *SubscriptionBuilder) WaitForPendingInstallPlan(interval, timeout time.Duration) (*InstallPlanBuilder, error) {
+	return builder.WaitForPendingInstallPlanContext(context.TODO(), interval, timeout)
+}
+
+// WaitForPendingInstallPlanContext waits for the Subscription to reference an installplan pending manual approval.
+func (builder *SubscriptionBuilder) WaitForPendingInstallPlanContext(ctx context.Context, interval,
+	timeout time.Duration) (*InstallPlanBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Waiting up to %s for Subscription %s in namespace %s to have a pending installplan",
+		timeout, builder.Definition.Name, builder.Definition.Namespace)
+
+	var installPlan *InstallPlanBuilder
+
+	err := wait.PollUntilContextTimeout(
+		ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			installPlanName := builder.installPlanName(ctx)
+			if installPlanName == "" {
+				return false, nil
+			}
+
+			installPlan = NewInstallPlanBuilder(builder.apiClient, installPlanName, builder.Definition.Namespace)
+
+			return installPlan.IsPendingApprovalContext(ctx), nil
+		})
+
+	if err != nil {
+		return nil, fmt.Errorf("subscription %s has no installplan pending approval: %w", builder.Definition.Name, err)
+	}
+
+	installPlan.Definition = installPlan.Object
+
+	return installPlan, nil
+}
+
+// ApproveInstallPlan waits for the pending installplan of the Subscription, checks that it installs the given
+// clusterserviceversion and approves it. Any pending installplan is approved when csvName is empty.
+func (builder *SubscriptionBuilder) ApproveInstallPlan(
+	csvName string, interval, timeout time.Duration) (*InstallPlanBuilder, error) {
+	return builder.ApproveInstallPlanContext(context.TODO(), csvName, interval, timeout)
+}
+
+// ApproveInstallPlanContext waits for the pending installplan of the Subscription, checks that it installs the
+// given clusterserviceversion and approves it. Any pending installplan is approved when csvName is empty.
+func (builder *SubscriptionBuilder) ApproveInstallPlanContext(ctx context.Context,
+	csvName string, interval, timeout time.Duration) (*InstallPlanBuilder, error) {
+	installPlan, err := builder.WaitForPendingInstallPlanContext(ctx, interval, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if csvName != "" && !installPlan.InstallsCSV(csvName) {
+		return nil, fmt.Errorf("installplan %s installs %v, expected %s", installPlan.Definition.Name,
+			installPlan.Definition.Spec.ClusterServiceVersionNames, csvName)
+	}
+
+	return installPlan.ApproveContext(ctx)
+}
+
+// WaitUntilCSVInstalled waits for the given clusterserviceversion to be installed by the Subscription and to
+// reach the Succeeded phase. Any clusterserviceversion is accepted when csvName is empty.
+func (builder *SubscriptionBuilder) WaitUntilCSVInstalled(csvName string, interval, timeout time.Duration) (*ClusterServiceVersionBuilder, error) {
+	return builder.WaitUntilCSVInstalledContext(context.TODO(), csvName, interval, timeout)
+}
+
+// WaitUntilCSVInstalledContext waits for the given clusterserviceversion to be installed by the Subscription and to
+// reach the Succeeded phase. Any clusterserviceversion is accepted when csvName is empty.
+func (builder *SubscriptionBuilder) WaitUntilCSVInstalledContext(ctx context.Context, csvName string,
+	interval, timeout time.Duration) (*ClusterServiceVersionBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Waiting up to %s for Subscription %s in namespace %s to install clusterserviceversion %q",
+		timeout, builder.Definition.Name, builder.Definition.Namespace, csvName)
+
+	var (
+		csvBuilder   *ClusterServiceVersionBuilder
+		installedCSV string
+		phase        operatorsV1alpha1.ClusterServiceVersionPhase
+	)
+
+	err := wait.PollUntilContextTimeout(
+		ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+			if !builder.ExistsContext(ctx) || builder.Object == nil {
+				return false, nil
+			}
+
+			installedCSV = builder.Object.Status.InstalledCSV
+			if installedCSV == "" {
+				installedCSV = builder.Object.Status.CurrentCSV
+			}
+
+			if installedCSV == "" || (csvName != "" && installedCSV != csvName) {
+				return false, nil
+			}
+
+			pulledCSV, err := PullClusterServiceVersionContext(ctx, builder.apiClient, installedCSV,
+				builder.Definition.Namespace)
+			if err != nil {
+				glog.V(100).Infof("ClusterServiceVersion %s is not available yet: %v", installedCSV, err)
+
+				return false, nil
+			}
+
+			csvBuilder = pulledCSV
+			phase = pulledCSV.Object.Status.Phase
+
+			return phase == operatorsV1alpha1.CSVPhaseSucceeded, nil
+		})
+
+	switch {
+	case err == nil:
+		return csvBuilder, nil
+	case installedCSV == "":
+		return nil, fmt.Errorf("subscription %s did not resolve a ClusterServiceVersion: %w",
+			builder.Definition.Name, err)
+	case csvName != "" && installedCSV != csvName:
+		return nil, fmt.Errorf("subscription %s installed ClusterServiceVersion %s, expected %s: %w",
+			builder.Definition.Name, installedCSV, csvName, err)
+	default:
+		return nil, fmt.Errorf("ClusterServiceVersion %s is in phase %q: %w", installedCSV, phase, err)
+	}
+}
+
+// UpgradeThrough steps a Subscription with Manual installplan approval through the given clusterserviceversions,
+// one at a time: the installplan of each clusterserviceversion is approved only once the previous one succeeded.
+// This exercises every edge of the upgrade graph, rather than letting OLM jump to the head of the channel.
+func (builder *SubscriptionBuilder) UpgradeThrough(
+	csvNames []string, interval, timeout time.Duration) (*ClusterServiceVersionBuilder, error) {
+	return builder.UpgradeThroughContext(context.TODO(), csvNames, interval, timeout)
+}
+
+// UpgradeThroughContext steps a Subscription with Manual installplan approval through the given
+// clusterserviceversions, one at a time: the installplan of each clusterserviceversion is approved only once the
+// previous one succeeded.
+func (builder *SubscriptionBuilder) UpgradeThroughContext(ctx context.Context,
+	csvNames []string, interval, timeout time.Duration) (*ClusterServiceVersionBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	if len(csvNames) == 0 {
+		return nil, fmt.Errorf("no clusterserviceversion to upgrade subscription %s through", builder.Definition.Name)
+	}
+
+	var csvBuilder *ClusterServiceVersionBuilder
+
+	for _, csvName := range csvNames {
+		glog.V(100).Infof("Upgrading Subscription %s to clusterserviceversion %s", builder.Definition.Name, csvName)
+
+		if _, err := builder.ApproveInstallPlanContext(ctx, csvName, interval, timeout); err != nil {
+			return nil, fmt.Errorf("failed to approve the upgrade to %s: %w", csvName, err)
+		}
+
+		var err error
+
+		csvBuilder, err = builder.WaitUntilCSVInstalledContext(ctx, csvName, interval, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upgrade to %s: %w", csvName, err)
+		}
+	}
+
+	return csvBuilder, nil
+}
+
+// installPlanName returns the name of the installplan currently referenced by the Subscription.
+func (builder *SubscriptionBuilder) installPlanName(ctx context.Context) string {
+	if !builder.ExistsContext(ctx) || builder.Object == nil {
+		return ""
+	}
+
+	if builder.Object.Status.InstallPlanRef != nil {
+		return builder.Object.Status.InstallPlanRef.Name
+	}
+
+	if builder.Object.Status.Install != nil {
+		return builder.Object.Status.Install.Name
+	}
+
+	return ""
+}
+
 // PullSubscription loads existing Subscription from cluster into the SubscriptionBuilder struct.
 func PullSubscription(apiClient *clients.Settings, subName, subNamespace string) (*SubscriptionBuilder, error) {
+	return PullSubscriptionContext(context.TODO(), apiClient, subName, subNamespace)
+}
+
+// PullSubscriptionContext loads existing Subscription from cluster into the SubscriptionBuilder struct.
+func PullSubscriptionContext(ctx context.Context, apiClient *clients.Settings, subName,
+	subNamespace string) (*SubscriptionBuilder, error) {
 	glog.V(100).Infof("Pulling existing Subscription %s from cluster in namespace %s",
 		subName, subNamespace)
 
@@ -260,7 +474,7 @@ func PullSubscription(apiClient *clients.Settings, subName, subNamespace string)
 		builder.errorMsg = "Subscription 'subNamespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return nil, fmt.Errorf("subscription object named %s doesn't exist", subName)
 	}
 
@@ -0,0 +1,841 @@
+package olm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiExt "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// DefaultUninstallCheckInterval is the interval used to poll for the resources left behind by an uninstall.
+	DefaultUninstallCheckInterval = 10 * time.Second
+	// DefaultUninstallTimeout is how long the resources of an uninstalled operator may take to be removed.
+	DefaultUninstallTimeout = 5 * time.Minute
+
+	olmOwnerNamespaceLabel = "olm.owner.namespace"
+)
+
+// Leftover is a resource still present on the cluster after an operator was uninstalled.
+type Leftover struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Reason tells why the resource is attributed to the uninstalled operator.
+	Reason string
+}
+
+// String returns the leftover as 'Kind namespace/name: reason'.
+func (leftover Leftover) String() string {
+	name := leftover.Name
+	if leftover.Namespace != "" {
+		name = leftover.Namespace + "/" + name
+	}
+
+	return fmt.Sprintf("%s %s: %s", leftover.Kind, name, leftover.Reason)
+}
+
+// UninstallReport lists the resources an operator left behind on the cluster.
+type UninstallReport struct {
+	Package   string
+	Leftovers []Leftover
+}
+
+// Clean reports whether the operator left nothing behind.
+func (report *UninstallReport) Clean() bool {
+	return len(report.Leftovers) == 0
+}
+
+// String returns one line per leftover.
+func (report *UninstallReport) String() string {
+	if report.Clean() {
+		return fmt.Sprintf("package %s left no resources behind", report.Package)
+	}
+
+	lines := []string{fmt.Sprintf("package %s left %d resources behind:", report.Package, len(report.Leftovers))}
+	for _, leftover := range report.Leftovers {
+		lines = append(lines, "  "+leftover.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Err returns an error listing the leftovers, or nil when the uninstall was clean.
+func (report *UninstallReport) Err() error {
+	if report.Clean() {
+		return nil
+	}
+
+	return errors.New(report.String())
+}
+
+// OperatorUninstaller removes an operator installed through OLM along with what OLM does not remove: its
+// custom resources, the CustomResourceDefinitions it owns and the labels it set on the nodes. It then verifies
+// that no CustomResourceDefinition, cluster scoped RBAC, webhook configuration, DaemonSet, node label or
+// resource stuck on its finalizers is left behind, so a dirty uninstall does not leak into the next job.
+type OperatorUninstaller struct {
+	apiClient         *clients.Settings
+	packageName       string
+	namespaceName     string
+	subscriptionName  string
+	operatorGroupName string
+	extensionName     string
+	bundleInstaller   *BundleInstaller
+	customResources   []runtimeClient.Object
+	crdGroups         []string
+	ownedCRDs         []string
+	namePrefixes      []string
+	nodeLabelPrefixes []string
+	strict            bool
+	checkInterval     time.Duration
+	timeout           time.Duration
+	errorMsg          string
+}
+
+// NewOperatorUninstaller creates an OperatorUninstaller for a package installed in the given namespace.
+// Subscription and OperatorGroup names default to the package name.
+func NewOperatorUninstaller(apiClient *clients.Settings, packageName, nsName string) *OperatorUninstaller {
+	glog.V(100).Infof("Initializing new OperatorUninstaller for package %s in namespace %s", packageName, nsName)
+
+	uninstaller := &OperatorUninstaller{
+		apiClient:         apiClient,
+		packageName:       packageName,
+		namespaceName:     nsName,
+		subscriptionName:  packageName,
+		operatorGroupName: packageName,
+		checkInterval:     DefaultUninstallCheckInterval,
+		timeout:           DefaultUninstallTimeout,
+	}
+
+	if apiClient == nil {
+		glog.V(100).Infof("The apiClient of the OperatorUninstaller is nil")
+
+		uninstaller.errorMsg = "OperatorUninstaller cannot have nil apiClient"
+	}
+
+	if packageName == "" {
+		glog.V(100).Infof("The package of the OperatorUninstaller is empty")
+
+		uninstaller.errorMsg = "OperatorUninstaller 'packageName' cannot be empty"
+	}
+
+	if nsName == "" {
+		glog.V(100).Infof("The namespace of the OperatorUninstaller is empty")
+
+		uninstaller.errorMsg = "OperatorUninstaller 'nsName' cannot be empty"
+	}
+
+	return uninstaller
+}
+
+// WithSubscriptionName sets the name of the Subscription to delete.
+func (uninstaller *OperatorUninstaller) WithSubscriptionName(name string) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	uninstaller.subscriptionName = name
+
+	return uninstaller
+}
+
+// WithOperatorGroupName sets the name of the OperatorGroup to delete.
+func (uninstaller *OperatorUninstaller) WithOperatorGroupName(name string) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	uninstaller.operatorGroupName = name
+
+	return uninstaller
+}
+
+// WithClusterExtensionName sets the name of the OLM v1 ClusterExtension to delete, for operators installed with
+// an ExtensionInstaller. The ClusterRoleBindings of its installer ServiceAccount are deleted once OLM v1 has
+// removed the operator.
+func (uninstaller *OperatorUninstaller) WithClusterExtensionName(name string) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	uninstaller.extensionName = name
+
+	return uninstaller
+}
+
+// WithBundleInstaller sets the BundleInstaller the operator was deployed with, so that its registry pod, Service
+// and CatalogSource are deleted along with the operator instead of being left to the namespace deletion.
+func (uninstaller *OperatorUninstaller) WithBundleInstaller(installer *BundleInstaller) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	uninstaller.bundleInstaller = installer
+
+	return uninstaller
+}
+
+// WithCustomResources sets the custom resources to delete before the operator, so it can clean up its operands.
+func (uninstaller *OperatorUninstaller) WithCustomResources(objects ...runtimeClient.Object) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	for _, object := range objects {
+		if object == nil || object.GetName() == "" {
+			glog.V(100).Infof("The custom resource of the OperatorUninstaller has no name")
+
+			uninstaller.errorMsg = "OperatorUninstaller custom resources must have a name"
+
+			return uninstaller
+		}
+	}
+
+	uninstaller.customResources = append(uninstaller.customResources, objects...)
+
+	return uninstaller
+}
+
+// WithCRDGroups sets the API groups of the operator. Their CustomResourceDefinitions are deleted, in addition to
+// those owned by the ClusterServiceVersion, and resources owned by objects of these groups count as leftovers.
+func (uninstaller *OperatorUninstaller) WithCRDGroups(groups ...string) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	uninstaller.crdGroups = append(uninstaller.crdGroups, groups...)
+
+	return uninstaller
+}
+
+// WithNamePrefixes sets the name prefixes of the cluster scoped RBAC, webhook configurations and DaemonSets
+// created by the operator.
+func (uninstaller *OperatorUninstaller) WithNamePrefixes(prefixes ...string) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	uninstaller.namePrefixes = append(uninstaller.namePrefixes, prefixes...)
+
+	return uninstaller
+}
+
+// WithNodeLabelPrefixes sets the prefixes of the node labels set by the operator, e.g. 'nvidia.com/'.
+func (uninstaller *OperatorUninstaller) WithNodeLabelPrefixes(prefixes ...string) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	if slices.Contains(prefixes, "") {
+		glog.V(100).Infof("The node label prefix of the OperatorUninstaller is empty")
+
+		uninstaller.errorMsg = "OperatorUninstaller node label prefixes cannot be empty"
+
+		return uninstaller
+	}
+
+	uninstaller.nodeLabelPrefixes = append(uninstaller.nodeLabelPrefixes, prefixes...)
+
+	return uninstaller
+}
+
+// WithStrict makes Uninstall fail when resources are left behind, instead of only reporting them.
+func (uninstaller *OperatorUninstaller) WithStrict(strict bool) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	uninstaller.strict = strict
+
+	return uninstaller
+}
+
+// WithTimeout sets how long, and how often, Uninstall waits for the resources of the operator to be removed.
+func (uninstaller *OperatorUninstaller) WithTimeout(interval, timeout time.Duration) *OperatorUninstaller {
+	if valid, _ := uninstaller.validate(); !valid {
+		return uninstaller
+	}
+
+	uninstaller.checkInterval = interval
+	uninstaller.timeout = timeout
+
+	return uninstaller
+}
+
+// Uninstall deletes the custom resources, the ClusterExtension, the Subscription, the ClusterServiceVersions, the
+// OperatorGroup, the bundle resources, the CustomResourceDefinitions and the namespace of the operator, and waits for
+// the cluster to be clean. It returns the resources still left behind after the timeout; in strict mode they are also
+// returned as an error. Node labels are only checked there, as the operator has no resource left to remove them once
+// its namespace is gone: they are reported, then removed so they do not leak into the next job.
+func (uninstaller *OperatorUninstaller) Uninstall() (*UninstallReport, error) {
+	return uninstaller.UninstallContext(context.TODO())
+}
+
+// UninstallContext uninstalls the operator like Uninstall, using ctx for the API calls and the waits.
+func (uninstaller *OperatorUninstaller) UninstallContext(ctx context.Context) (*UninstallReport, error) {
+	if valid, err := uninstaller.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Uninstalling package %s from namespace %s", uninstaller.packageName, uninstaller.namespaceName)
+
+	var errs []error
+
+	for _, object := range uninstaller.customResources {
+		glog.V(100).Infof("Deleting %T %s", object, object.GetName())
+
+		if err := uninstaller.apiClient.Client.Delete(ctx, object); runtimeClient.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %T %s: %w", object, object.GetName(), err))
+		}
+	}
+
+	uninstaller.waitForCustomResourcesDeleted(ctx)
+
+	if err := uninstaller.deleteClusterExtension(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := uninstaller.deleteOLMResources(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if uninstaller.bundleInstaller != nil {
+		if err := uninstaller.bundleInstaller.Cleanup(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete the bundle resources: %w", err))
+		}
+	}
+
+	if err := uninstaller.deleteCRDs(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := namespace.NewBuilder(uninstaller.apiClient, uninstaller.namespaceName).DeleteContext(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete namespace %s: %w", uninstaller.namespaceName, err))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	var report *UninstallReport
+
+	err := wait.PollUntilContextTimeout(
+		ctx, uninstaller.checkInterval, uninstaller.timeout, true, func(ctx context.Context) (bool, error) {
+			var err error
+
+			report, err = uninstaller.VerifyContext(ctx)
+			if err != nil {
+				glog.V(100).Infof("Failed to verify the uninstall of package %s: %v", uninstaller.packageName, err)
+
+				return false, nil
+			}
+
+			return report.onlyNodesLeft(), nil
+		})
+
+	if report == nil {
+		return nil, fmt.Errorf("failed to verify the uninstall of package %s: %w", uninstaller.packageName, err)
+	}
+
+	glog.V(100).Infof("%s", report)
+
+	if err := uninstaller.removeNodeLabels(ctx); err != nil {
+		return report, err
+	}
+
+	if uninstaller.strict {
+		return report, report.Err()
+	}
+
+	return report, nil
+}
+
+// Verify reports the resources of the operator still present on the cluster.
+func (uninstaller *OperatorUninstaller) Verify() (*UninstallReport, error) {
+	return uninstaller.VerifyContext(context.TODO())
+}
+
+// VerifyContext reports the resources of the operator still present on the cluster, using ctx for the API calls.
+func (uninstaller *OperatorUninstaller) VerifyContext(ctx context.Context) (*UninstallReport, error) {
+	if valid, err := uninstaller.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Verifying package %s left no resources behind", uninstaller.packageName)
+
+	report := &UninstallReport{Package: uninstaller.packageName}
+
+	for _, check := range []func(context.Context, *UninstallReport) error{
+		uninstaller.checkNamespacedResources,
+		uninstaller.checkCRDs,
+		uninstaller.checkClusterRBAC,
+		uninstaller.checkWebhookConfigurations,
+		uninstaller.checkDaemonSets,
+		uninstaller.checkNodeLabels,
+	} {
+		if err := check(ctx, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// deleteClusterExtension deletes the OLM v1 ClusterExtension and waits for OLM v1 to remove the operator, before
+// deleting the ClusterRoleBindings of the ServiceAccount it removes the operator with, and the ClusterRoles
+// ExtensionInstaller created for it. Its Role and RoleBinding go with the namespace.
+func (uninstaller *OperatorUninstaller) deleteClusterExtension(ctx context.Context) error {
+	if uninstaller.extensionName == "" {
+		return nil
+	}
+
+	extension, err := PullClusterExtensionContext(ctx, uninstaller.apiClient, uninstaller.extensionName)
+	if err != nil {
+		glog.V(100).Infof("No clusterextension %s to delete: %v", uninstaller.extensionName, err)
+
+		return nil
+	}
+
+	serviceAccountName, _, _ := unstructured.NestedString(extension.Object.Object, "spec", "serviceAccount", "name")
+	serviceAccountNamespace, _, _ := unstructured.NestedString(extension.Object.Object, "spec", "namespace")
+
+	if err := extension.DeleteContext(ctx); err != nil {
+		return fmt.Errorf("failed to delete clusterextension %s: %w", uninstaller.extensionName, err)
+	}
+
+	err = wait.PollUntilContextTimeout(
+		ctx, uninstaller.checkInterval, uninstaller.timeout, true, func(ctx context.Context) (bool, error) {
+			return !extension.ExistsContext(ctx), nil
+		})
+	if err != nil {
+		return fmt.Errorf("clusterextension %s was not deleted: %w", uninstaller.extensionName, err)
+	}
+
+	clusterRoleBindings, err := uninstaller.apiClient.K8sClient.RbacV1().ClusterRoleBindings().List(ctx,
+		metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+	}
+
+	for _, clusterRoleBinding := range clusterRoleBindings.Items {
+		if !slices.ContainsFunc(clusterRoleBinding.Subjects, func(subject rbacv1.Subject) bool {
+			return subject.Kind == rbacv1.ServiceAccountKind && subject.Name == serviceAccountName &&
+				subject.Namespace == serviceAccountNamespace
+		}) {
+			continue
+		}
+
+		glog.V(100).Infof("Deleting ClusterRoleBinding %s of serviceaccount %s", clusterRoleBinding.Name,
+			serviceAccountName)
+
+		if err := uninstaller.apiClient.K8sClient.RbacV1().ClusterRoleBindings().Delete(ctx,
+			clusterRoleBinding.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ClusterRoleBinding %s: %w", clusterRoleBinding.Name, err)
+		}
+
+		if clusterRoleBinding.RoleRef.Kind != "ClusterRole" || clusterRoleBinding.RoleRef.Name != serviceAccountName {
+			continue
+		}
+
+		glog.V(100).Infof("Deleting ClusterRole %s of serviceaccount %s", clusterRoleBinding.RoleRef.Name,
+			serviceAccountName)
+
+		if err := uninstaller.apiClient.K8sClient.RbacV1().ClusterRoles().Delete(ctx,
+			clusterRoleBinding.RoleRef.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ClusterRole %s: %w", clusterRoleBinding.RoleRef.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteOLMResources deletes the Subscription, the ClusterServiceVersions and the OperatorGroup, remembering the
+// CustomResourceDefinitions owned by the ClusterServiceVersions.
+func (uninstaller *OperatorUninstaller) deleteOLMResources(ctx context.Context) error {
+	if err := uninstaller.apiClient.Subscriptions(uninstaller.namespaceName).Delete(ctx,
+		uninstaller.subscriptionName, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete subscription %s: %w", uninstaller.subscriptionName, err)
+	}
+
+	csvList, err := uninstaller.apiClient.ClusterServiceVersions(uninstaller.namespaceName).List(ctx,
+		metav1.ListOptions{LabelSelector: uninstaller.packageLabel()})
+	if err != nil {
+		return fmt.Errorf("failed to list the ClusterServiceVersions of package %s: %w", uninstaller.packageName, err)
+	}
+
+	for _, csv := range csvList.Items {
+		for _, crd := range csv.Spec.CustomResourceDefinitions.Owned {
+			if !slices.Contains(uninstaller.ownedCRDs, crd.Name) {
+				uninstaller.ownedCRDs = append(uninstaller.ownedCRDs, crd.Name)
+			}
+		}
+
+		glog.V(100).Infof("Deleting ClusterServiceVersion %s in namespace %s", csv.Name, uninstaller.namespaceName)
+
+		if err := uninstaller.apiClient.ClusterServiceVersions(uninstaller.namespaceName).Delete(ctx,
+			csv.Name, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ClusterServiceVersion %s: %w", csv.Name, err)
+		}
+	}
+
+	if err := uninstaller.apiClient.OperatorGroups(uninstaller.namespaceName).Delete(ctx,
+		uninstaller.operatorGroupName, metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete operatorgroup %s: %w", uninstaller.operatorGroupName, err)
+	}
+
+	return nil
+}
+
+// waitForCustomResourcesDeleted waits for the operator to remove the finalizers of its custom resources.
+// Custom resources still present afterwards are reported by Verify.
+func (uninstaller *OperatorUninstaller) waitForCustomResourcesDeleted(ctx context.Context) {
+	if len(uninstaller.customResources) == 0 {
+		return
+	}
+
+	err := wait.PollUntilContextTimeout(
+		ctx, uninstaller.checkInterval, uninstaller.timeout, true, func(ctx context.Context) (bool, error) {
+			for _, object := range uninstaller.customResources {
+				err := uninstaller.apiClient.Client.Get(ctx, runtimeClient.ObjectKeyFromObject(object),
+					object.DeepCopyObject().(runtimeClient.Object))
+				if !k8serrors.IsNotFound(err) {
+					return false, nil
+				}
+			}
+
+			return true, nil
+		})
+	if err != nil {
+		glog.V(100).Infof("Custom resources of package %s were not deleted: %v", uninstaller.packageName, err)
+	}
+}
+
+func (uninstaller *OperatorUninstaller) deleteCRDs(ctx context.Context) error {
+	crdList := &apiExt.CustomResourceDefinitionList{}
+	if err := uninstaller.apiClient.Client.List(ctx, crdList); err != nil {
+		return fmt.Errorf("failed to list CustomResourceDefinitions: %w", err)
+	}
+
+	for index := range crdList.Items {
+		crd := &crdList.Items[index]
+		if uninstaller.crdReason(crd) == "" {
+			continue
+		}
+
+		glog.V(100).Infof("Deleting CustomResourceDefinition %s", crd.Name)
+
+		if err := uninstaller.apiClient.Client.Delete(ctx, crd); runtimeClient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete CustomResourceDefinition %s: %w", crd.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (uninstaller *OperatorUninstaller) removeNodeLabels(ctx context.Context) error {
+	if len(uninstaller.nodeLabelPrefixes) == 0 {
+		return nil
+	}
+
+	nodeList, err := uninstaller.apiClient.CoreV1Interface.Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for index := range nodeList.Items {
+		node := &nodeList.Items[index]
+
+		labels := uninstaller.nodeLabels(node.Labels)
+		if len(labels) == 0 {
+			continue
+		}
+
+		glog.V(100).Infof("Removing labels %v from node %s", labels, node.Name)
+
+		for _, label := range labels {
+			delete(node.Labels, label)
+		}
+
+		if _, err := uninstaller.apiClient.CoreV1Interface.Nodes().Update(ctx, node,
+			metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to remove labels from node %s: %w", node.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (uninstaller *OperatorUninstaller) checkNamespacedResources(ctx context.Context, report *UninstallReport) error {
+	for _, object := range uninstaller.customResources {
+		leftover := object.DeepCopyObject().(runtimeClient.Object)
+
+		err := uninstaller.apiClient.Client.Get(ctx, runtimeClient.ObjectKeyFromObject(object), leftover)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to get %T %s: %w", object, object.GetName(), err)
+		}
+
+		report.add(uninstaller.kindOf(leftover), leftover, "custom resource of the operator")
+	}
+
+	csvList, err := uninstaller.apiClient.ClusterServiceVersions(uninstaller.namespaceName).List(ctx,
+		metav1.ListOptions{LabelSelector: uninstaller.packageLabel()})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to list the ClusterServiceVersions of package %s: %w", uninstaller.packageName, err)
+	}
+
+	if csvList != nil {
+		for index := range csvList.Items {
+			report.add("ClusterServiceVersion", &csvList.Items[index], "installed by the package")
+		}
+	}
+
+	if uninstaller.extensionName != "" {
+		if extension, err := PullClusterExtensionContext(ctx, uninstaller.apiClient, uninstaller.extensionName); err == nil &&
+			extension.Object != nil {
+			report.add("ClusterExtension", extension.Object, "installed the package")
+		}
+	}
+
+	operatorNamespace, err := uninstaller.apiClient.CoreV1Interface.Namespaces().Get(ctx,
+		uninstaller.namespaceName, metav1.GetOptions{})
+	if err == nil {
+		report.add("Namespace", operatorNamespace, "namespace of the operator")
+	} else if !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get namespace %s: %w", uninstaller.namespaceName, err)
+	}
+
+	return nil
+}
+
+func (uninstaller *OperatorUninstaller) checkCRDs(ctx context.Context, report *UninstallReport) error {
+	crdList := &apiExt.CustomResourceDefinitionList{}
+	if err := uninstaller.apiClient.Client.List(ctx, crdList); err != nil {
+		return fmt.Errorf("failed to list CustomResourceDefinitions: %w", err)
+	}
+
+	for index := range crdList.Items {
+		if reason := uninstaller.crdReason(&crdList.Items[index]); reason != "" {
+			report.add("CustomResourceDefinition", &crdList.Items[index], reason)
+		}
+	}
+
+	return nil
+}
+
+func (uninstaller *OperatorUninstaller) checkClusterRBAC(ctx context.Context, report *UninstallReport) error {
+	clusterRoles, err := uninstaller.apiClient.K8sClient.RbacV1().ClusterRoles().List(ctx,
+		metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterRoles: %w", err)
+	}
+
+	for index := range clusterRoles.Items {
+		if reason := uninstaller.ownerReason(&clusterRoles.Items[index]); reason != "" {
+			report.add("ClusterRole", &clusterRoles.Items[index], reason)
+		}
+	}
+
+	clusterRoleBindings, err := uninstaller.apiClient.K8sClient.RbacV1().ClusterRoleBindings().List(ctx,
+		metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+	}
+
+	for index := range clusterRoleBindings.Items {
+		if reason := uninstaller.ownerReason(&clusterRoleBindings.Items[index]); reason != "" {
+			report.add("ClusterRoleBinding", &clusterRoleBindings.Items[index], reason)
+		}
+	}
+
+	return nil
+}
+
+func (uninstaller *OperatorUninstaller) checkWebhookConfigurations(ctx context.Context, report *UninstallReport) error {
+	admissionClient := uninstaller.apiClient.K8sClient.AdmissionregistrationV1()
+
+	validatingWebhooks, err := admissionClient.ValidatingWebhookConfigurations().List(ctx,
+		metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list ValidatingWebhookConfigurations: %w", err)
+	}
+
+	for index := range validatingWebhooks.Items {
+		if reason := uninstaller.ownerReason(&validatingWebhooks.Items[index]); reason != "" {
+			report.add("ValidatingWebhookConfiguration", &validatingWebhooks.Items[index], reason)
+		}
+	}
+
+	mutatingWebhooks, err := admissionClient.MutatingWebhookConfigurations().List(ctx,
+		metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list MutatingWebhookConfigurations: %w", err)
+	}
+
+	for index := range mutatingWebhooks.Items {
+		if reason := uninstaller.ownerReason(&mutatingWebhooks.Items[index]); reason != "" {
+			report.add("MutatingWebhookConfiguration", &mutatingWebhooks.Items[index], reason)
+		}
+	}
+
+	return nil
+}
+
+func (uninstaller *OperatorUninstaller) checkDaemonSets(ctx context.Context, report *UninstallReport) error {
+	daemonSets, err := uninstaller.apiClient.AppsV1Interface.DaemonSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list DaemonSets: %w", err)
+	}
+
+	for index := range daemonSets.Items {
+		daemonSet := &daemonSets.Items[index]
+
+		reason := uninstaller.ownerReason(daemonSet)
+		if daemonSet.Namespace == uninstaller.namespaceName {
+			reason = "in the namespace of the operator"
+		}
+
+		if reason != "" {
+			report.add("DaemonSet", daemonSet, reason)
+		}
+	}
+
+	return nil
+}
+
+func (uninstaller *OperatorUninstaller) checkNodeLabels(ctx context.Context, report *UninstallReport) error {
+	if len(uninstaller.nodeLabelPrefixes) == 0 {
+		return nil
+	}
+
+	nodeList, err := uninstaller.apiClient.CoreV1Interface.Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for index := range nodeList.Items {
+		if labels := uninstaller.nodeLabels(nodeList.Items[index].Labels); len(labels) > 0 {
+			report.add("Node", &nodeList.Items[index], fmt.Sprintf("labels %v", labels))
+		}
+	}
+
+	return nil
+}
+
+// crdReason returns why a CustomResourceDefinition belongs to the operator, or an empty string.
+func (uninstaller *OperatorUninstaller) crdReason(crd *apiExt.CustomResourceDefinition) string {
+	switch {
+	case slices.Contains(uninstaller.ownedCRDs, crd.Name):
+		return "owned by the ClusterServiceVersion"
+	case slices.Contains(uninstaller.crdGroups, crd.Spec.Group):
+		return fmt.Sprintf("in API group %s", crd.Spec.Group)
+	default:
+		return ""
+	}
+}
+
+// ownerReason returns why a resource belongs to the operator, or an empty string: it is labeled by OLM as owned
+// by a ClusterServiceVersion in the operator namespace, it is owned by a resource of the operator API groups, or
+// its name has one of the operator prefixes.
+func (uninstaller *OperatorUninstaller) ownerReason(object metav1.Object) string {
+	if object.GetLabels()[olmOwnerNamespaceLabel] == uninstaller.namespaceName {
+		return "owned by OLM for the operator namespace"
+	}
+
+	for _, ownerReference := range object.GetOwnerReferences() {
+		group, err := schema.ParseGroupVersion(ownerReference.APIVersion)
+		if err == nil && slices.Contains(uninstaller.crdGroups, group.Group) {
+			return fmt.Sprintf("owned by %s %s", ownerReference.Kind, ownerReference.Name)
+		}
+	}
+
+	for _, prefix := range uninstaller.namePrefixes {
+		if strings.HasPrefix(object.GetName(), prefix) {
+			return fmt.Sprintf("name has prefix %s", prefix)
+		}
+	}
+
+	return ""
+}
+
+func (uninstaller *OperatorUninstaller) nodeLabels(labels map[string]string) []string {
+	var matching []string
+
+	for label := range labels {
+		if slices.ContainsFunc(uninstaller.nodeLabelPrefixes, func(prefix string) bool {
+			return strings.HasPrefix(label, prefix)
+		}) {
+			matching = append(matching, label)
+		}
+	}
+
+	slices.Sort(matching)
+
+	return matching
+}
+
+func (uninstaller *OperatorUninstaller) packageLabel() string {
+	return fmt.Sprintf("operators.coreos.com/%s.%s", uninstaller.packageName, uninstaller.namespaceName)
+}
+
+// validate will check that the uninstaller is properly initialized before accessing any member fields.
+func (uninstaller *OperatorUninstaller) validate() (bool, error) {
+	if uninstaller == nil {
+		glog.V(100).Infof("The OperatorUninstaller is uninitialized")
+
+		return false, fmt.Errorf("error: received nil OperatorUninstaller")
+	}
+
+	if uninstaller.errorMsg != "" {
+		glog.V(100).Infof("The OperatorUninstaller has error message: %s", uninstaller.errorMsg)
+
+		return false, errors.New(uninstaller.errorMsg)
+	}
+
+	return true, nil
+}
+
+// onlyNodesLeft reports whether the node labels are the only leftovers.
+func (report *UninstallReport) onlyNodesLeft() bool {
+	return !slices.ContainsFunc(report.Leftovers, func(leftover Leftover) bool {
+		return leftover.Kind != "Node"
+	})
+}
+
+// add records a leftover, flagging resources whose deletion is blocked by finalizers.
+func (report *UninstallReport) add(kind string, object metav1.Object, reason string) {
+	if object.GetDeletionTimestamp() != nil && len(object.GetFinalizers()) > 0 {
+		reason = fmt.Sprintf("%s, stuck deleting on finalizers %v", reason, object.GetFinalizers())
+	}
+
+	report.Leftovers = append(report.Leftovers, Leftover{
+		Kind: kind, Namespace: object.GetNamespace(), Name: object.GetName(), Reason: reason})
+}
+
+// kindOf returns the kind of a typed object, whose TypeMeta is usually empty.
+func (uninstaller *OperatorUninstaller) kindOf(object runtimeClient.Object) string {
+	gvk, err := apiutil.GVKForObject(object, uninstaller.apiClient.Client.Scheme())
+	if err != nil {
+		return fmt.Sprintf("%T", object)
+	}
+
+	return gvk.Kind
+}
@@ -0,0 +1,286 @@
+package olm
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	operatorsV1 "github.com/operator-framework/api/pkg/operators/v1"
+	operatorsV1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiExt "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const testClusterPolicyName = "gpu-cluster-policy"
+
+// newTestOperatorObjects returns an installed GPU operator: its OLM resources, namespace, CustomResourceDefinition
+// and ClusterPolicy.
+func newTestOperatorObjects(clusterPolicyFinalizers ...string) []runtime.Object {
+	return []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testCSVNamespace}},
+		&operatorsV1.OperatorGroup{ObjectMeta: metav1.ObjectMeta{Name: testPackage, Namespace: testCSVNamespace}},
+		&operatorsV1alpha1.Subscription{ObjectMeta: metav1.ObjectMeta{Name: testPackage, Namespace: testCSVNamespace}},
+		&operatorsV1alpha1.ClusterServiceVersion{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testCSVName,
+				Namespace: testCSVNamespace,
+				Labels:    map[string]string{"operators.coreos.com/" + testPackage + "." + testCSVNamespace: ""},
+			},
+			Spec: operatorsV1alpha1.ClusterServiceVersionSpec{
+				CustomResourceDefinitions: operatorsV1alpha1.CustomResourceDefinitions{
+					Owned: []operatorsV1alpha1.CRDDescription{{Name: "nvidiadrivers.nvidia.com"}},
+				},
+			},
+		},
+		&apiExt.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "clusterpolicies.nvidia.com"},
+			Spec:       apiExt.CustomResourceDefinitionSpec{Group: "nvidia.com"},
+		},
+		&apiExt.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "nvidiadrivers.nvidia.com"},
+			Spec:       apiExt.CustomResourceDefinitionSpec{Group: "nvidia.com"},
+		},
+		&apiExt.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodefeaturediscoveries.nfd.openshift.io"},
+			Spec:       apiExt.CustomResourceDefinitionSpec{Group: "nfd.openshift.io"},
+		},
+		&nvidiagpuv1.ClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: testClusterPolicyName, Finalizers: clusterPolicyFinalizers},
+		},
+	}
+}
+
+func newTestUninstaller(t *testing.T, objects ...runtime.Object) *OperatorUninstaller {
+	t.Helper()
+
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Nodes}, objects...)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	return NewOperatorUninstaller(apiClient, testPackage, testCSVNamespace).
+		WithCustomResources(&nvidiagpuv1.ClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: testClusterPolicyName}}).
+		WithCRDGroups("nvidia.com").
+		WithNamePrefixes("gpu-operator").
+		WithNodeLabelPrefixes("nvidia.com/").
+		WithTimeout(time.Millisecond, 10*time.Millisecond)
+}
+
+func TestOperatorUninstallerUninstall(t *testing.T) {
+	uninstaller := newTestUninstaller(t, newTestOperatorObjects()...)
+
+	report, err := uninstaller.Uninstall()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var leftovers []string
+	for _, leftover := range report.Leftovers {
+		leftovers = append(leftovers, leftover.String())
+	}
+
+	expectedLeftovers := []string{
+		"Node worker-gpu-0: labels [nvidia.com/gpu.present]",
+		"Node worker-gpu-1: labels [nvidia.com/gpu.present]",
+	}
+
+	if !slices.Equal(leftovers, expectedLeftovers) {
+		t.Errorf("expected the node labels to be reported before their removal, got %s", report)
+	}
+
+	crdList := &apiExt.CustomResourceDefinitionList{}
+	if err := uninstaller.apiClient.Client.List(context.TODO(), crdList); err != nil {
+		t.Fatalf("failed to list the CustomResourceDefinitions: %v", err)
+	}
+
+	if len(crdList.Items) != 1 || crdList.Items[0].Name != "nodefeaturediscoveries.nfd.openshift.io" {
+		t.Errorf("expected only the CustomResourceDefinitions of the operator to be deleted, got %v", crdList.Items)
+	}
+
+	node, err := uninstaller.apiClient.CoreV1Interface.Nodes().Get(context.TODO(), "worker-gpu-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get the node: %v", err)
+	}
+
+	if _, found := node.Labels["nvidia.com/gpu.present"]; found ||
+		node.Labels["feature.node.kubernetes.io/pci-10de.present"] != "true" {
+		t.Errorf("expected only the nvidia.com labels to be removed, got %v", node.Labels)
+	}
+
+	if report, err := uninstaller.WithStrict(true).Uninstall(); err != nil || !report.Clean() {
+		t.Errorf("expected a clean uninstall once the node labels were removed, got %v: %v", report, err)
+	}
+}
+
+func TestOperatorUninstallerLeftovers(t *testing.T) {
+	objects := append(newTestOperatorObjects("nvidia.com/finalizer"),
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{
+			Name: "nvidia-driver",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "nvidia.com/v1", Kind: "ClusterPolicy", Name: testClusterPolicyName}},
+		}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{
+			Name:   "gpu-operator.v24.9.2-abcde",
+			Labels: map[string]string{olmOwnerNamespaceLabel: testCSVNamespace},
+		}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker"}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "nvidia-driver-daemonset", Namespace: testCSVNamespace}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "nfd-worker", Namespace: "openshift-nfd"}},
+	)
+
+	uninstaller := newTestUninstaller(t, objects...)
+
+	report, err := uninstaller.Uninstall()
+	if err != nil {
+		t.Fatalf("expected leftovers not to fail outside of strict mode, got %v", err)
+	}
+
+	var leftovers []string
+	for _, leftover := range report.Leftovers {
+		leftovers = append(leftovers, leftover.String())
+	}
+
+	expectedLeftovers := []string{
+		"ClusterPolicy gpu-cluster-policy: custom resource of the operator, stuck deleting on finalizers " +
+			"[nvidia.com/finalizer]",
+		"ClusterRole nvidia-driver: owned by ClusterPolicy gpu-cluster-policy",
+		"ClusterRoleBinding gpu-operator.v24.9.2-abcde: owned by OLM for the operator namespace",
+		"DaemonSet nvidia-gpu-operator/nvidia-driver-daemonset: in the namespace of the operator",
+		"Node worker-gpu-0: labels [nvidia.com/gpu.present]",
+		"Node worker-gpu-1: labels [nvidia.com/gpu.present]",
+	}
+
+	if !slices.Equal(leftovers, expectedLeftovers) {
+		t.Errorf("expected leftovers:\n%s\ngot:\n%s", strings.Join(expectedLeftovers, "\n"),
+			strings.Join(leftovers, "\n"))
+	}
+
+	if _, err := uninstaller.WithStrict(true).Uninstall(); err == nil ||
+		!strings.Contains(err.Error(), "package gpu-operator-certified left 4 resources behind") {
+		t.Errorf("expected strict mode to fail on leftovers, got %v", err)
+	}
+}
+
+func TestOperatorUninstallerClusterExtension(t *testing.T) {
+	apiClient := newTestOLMv1Clients(t, []*unstructured.Unstructured{newTestInstalledClusterExtension()},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: testServiceAccount}},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: testServiceAccount},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: testServiceAccount},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: testServiceAccount, Namespace: testCSVNamespace}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-installer"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: testServiceAccount, Namespace: "other-namespace"}},
+		})
+
+	uninstaller := NewOperatorUninstaller(apiClient, testPackage, testCSVNamespace).
+		WithClusterExtensionName(testPackage).
+		WithTimeout(time.Millisecond, 10*time.Millisecond)
+
+	report, err := uninstaller.WithStrict(true).Uninstall()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !report.Clean() {
+		t.Errorf("expected a clean uninstall, got %s", report)
+	}
+
+	if _, err := PullClusterExtension(apiClient, testPackage); err == nil {
+		t.Errorf("expected the clusterextension to be deleted")
+	}
+
+	clusterRoleBindings, err := apiClient.K8sClient.RbacV1().ClusterRoleBindings().List(context.TODO(),
+		metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list the ClusterRoleBindings: %v", err)
+	}
+
+	if len(clusterRoleBindings.Items) != 1 || clusterRoleBindings.Items[0].Name != "other-installer" {
+		t.Errorf("expected only the installer ClusterRoleBinding to be deleted, got %v", clusterRoleBindings.Items)
+	}
+
+	if _, err := apiClient.K8sClient.RbacV1().ClusterRoles().Get(context.TODO(), testServiceAccount,
+		metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the installer ClusterRole to be deleted, got %v", err)
+	}
+}
+
+func TestOperatorUninstallerBundleInstaller(t *testing.T) {
+	installer := newTestBundleInstaller(t, []string{testfixtures.CSV, testfixtures.Subscription},
+		append(newTestBundleObjects(corev1.PodRunning), newTestBundlePackageManifest())...)
+
+	if _, err := installer.Install(); err != nil {
+		t.Fatalf("unexpected install error: %v", err)
+	}
+
+	_, err := NewOperatorUninstaller(installer.apiClient, testPackage, testCSVNamespace).
+		WithBundleInstaller(installer).
+		WithTimeout(time.Millisecond, 10*time.Millisecond).
+		Uninstall()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = installer.apiClient.Services(testCSVNamespace).Get(context.TODO(), testBundleCatalogName,
+		metav1.GetOptions{})
+	if installer.CatalogSource.Exists() || installer.RegistryPod.Exists() || !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the catalogsource, the registry pod and the registry service to be deleted")
+	}
+}
+
+func TestOperatorUninstallerValidation(t *testing.T) {
+	testCases := []struct {
+		name          string
+		uninstaller   *OperatorUninstaller
+		expectedError string
+	}{
+		{
+			name:          "nil apiClient",
+			uninstaller:   NewOperatorUninstaller(nil, testPackage, testCSVNamespace),
+			expectedError: "OperatorUninstaller cannot have nil apiClient",
+		},
+		{
+			name:          "empty namespace",
+			uninstaller:   NewOperatorUninstaller(newTestInstaller(t, nil).apiClient, testPackage, ""),
+			expectedError: "OperatorUninstaller 'nsName' cannot be empty",
+		},
+		{
+			name: "unnamed custom resource",
+			uninstaller: NewOperatorUninstaller(newTestInstaller(t, nil).apiClient, testPackage, testCSVNamespace).
+				WithCustomResources(&nvidiagpuv1.ClusterPolicy{}),
+			expectedError: "OperatorUninstaller custom resources must have a name",
+		},
+		{
+			name: "empty node label prefix",
+			uninstaller: NewOperatorUninstaller(newTestInstaller(t, nil).apiClient, testPackage, testCSVNamespace).
+				WithNodeLabelPrefixes(""),
+			expectedError: "OperatorUninstaller node label prefixes cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := testCase.uninstaller.Uninstall(); err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+
+			if _, err := testCase.uninstaller.Verify(); err == nil || err.Error() != testCase.expectedError {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+			}
+		})
+	}
+}
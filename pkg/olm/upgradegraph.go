@@ -0,0 +1,452 @@
+package olm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/golang/glog"
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SkipRangeAnnotation is the clusterserviceversion annotation listing the versions its bundle can upgrade from.
+	SkipRangeAnnotation = "olm.skipRange"
+	// CatalogConfigsDir is the directory the registry pod of a file-based catalog serves the catalog from.
+	CatalogConfigsDir = "/configs"
+)
+
+// UpgradeEdgeType is the kind of OLM upgrade edge between two clusterserviceversions.
+type UpgradeEdgeType string
+
+const (
+	// UpgradeEdgeReplaces is an edge from a clusterserviceversion to the one replacing it in a channel.
+	UpgradeEdgeReplaces UpgradeEdgeType = "replaces"
+	// UpgradeEdgeSkips is an edge from a clusterserviceversion to a channel entry listing it in its skips.
+	UpgradeEdgeSkips UpgradeEdgeType = "skips"
+	// UpgradeEdgeSkipRange is an edge from a clusterserviceversion to a channel entry whose skipRange includes it.
+	UpgradeEdgeSkipRange UpgradeEdgeType = "skipRange"
+)
+
+// UpgradeChannelEntry is an entry of an olm.channel of a file-based catalog, with the upgrade edges its bundle
+// declares.
+type UpgradeChannelEntry struct {
+	Name      string   `json:"name"`
+	Replaces  string   `json:"replaces,omitempty"`
+	Skips     []string `json:"skips,omitempty"`
+	SkipRange string   `json:"skipRange,omitempty"`
+}
+
+// catalogChannel is an olm.channel of a file-based catalog.
+type catalogChannel struct {
+	Schema  string                `json:"schema"`
+	Package string                `json:"package"`
+	Name    string                `json:"name"`
+	Entries []UpgradeChannelEntry `json:"entries"`
+}
+
+// UpgradeEdge is an upgrade from one clusterserviceversion to another within a channel.
+type UpgradeEdge struct {
+	From string
+	To   string
+	Type UpgradeEdgeType
+}
+
+// UpgradeChannel is the upgrade graph of a single channel of a package.
+type UpgradeChannel struct {
+	Name string
+	// Head is the clusterserviceversion a Subscription to the channel eventually upgrades to.
+	Head string
+	// SkipRange is the olm.skipRange of the channel head, empty if it has none.
+	SkipRange string
+	// Entries are the clusterserviceversions of the channel, from the oldest version to the head.
+	Entries    []string
+	Edges      []UpgradeEdge
+	Deprecated bool
+	skipRanges map[string]semver.Range
+}
+
+// UpgradeGraph is the upgrade graph of an operator package, built from the channels of its PackageManifest and the
+// replaces, skips and skipRange of their entries.
+//
+// The PackageManifest does not expose the replaces and skips fields of the bundles, so they are read from the
+// olm.channel entries of the file-based catalog served by the CatalogSource.
+type UpgradeGraph struct {
+	Package        string
+	CatalogSource  string
+	DefaultChannel string
+	Channels       []*UpgradeChannel
+	versions       map[string]semver.Version
+}
+
+// PullUpgradeGraph builds the UpgradeGraph of a package from its PackageManifest and the channel entries of the
+// file-based catalog of the given catalogsource.
+func PullUpgradeGraph(apiClient *clients.Settings, packageName, catalogSourceNamespace,
+	catalogSource string) (*UpgradeGraph, error) {
+	return PullUpgradeGraphContext(context.TODO(), apiClient, packageName, catalogSourceNamespace, catalogSource)
+}
+
+// PullUpgradeGraphContext builds the UpgradeGraph of a package from its PackageManifest and the channel entries of
+// the file-based catalog of the given catalogsource.
+func PullUpgradeGraphContext(ctx context.Context, apiClient *clients.Settings, packageName, catalogSourceNamespace,
+	catalogSource string) (*UpgradeGraph, error) {
+	glog.V(100).Infof("Pulling the upgrade graph of package %s from catalogsource %s in namespace %s",
+		packageName, catalogSource, catalogSourceNamespace)
+
+	pkgManifest, err := PullPackageManifestByCatalogContext(ctx, apiClient, packageName, catalogSourceNamespace,
+		catalogSource)
+	if err != nil {
+		return nil, err
+	}
+
+	channelEntries, err := PullCatalogChannelEntriesContext(ctx, apiClient, packageName, catalogSourceNamespace,
+		catalogSource)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUpgradeGraph(pkgManifest, channelEntries)
+}
+
+// PullCatalogChannelEntriesContext renders the file-based catalog of a package in the registry pod of the given
+// catalogsource and returns the entries of each of its channels, by channel name.
+func PullCatalogChannelEntriesContext(ctx context.Context, apiClient *clients.Settings, packageName,
+	catalogSourceNamespace, catalogSource string) (map[string][]UpgradeChannelEntry, error) {
+	glog.V(100).Infof("Pulling the channel entries of package %s from catalogsource %s in namespace %s",
+		packageName, catalogSource, catalogSourceNamespace)
+
+	registryPods, err := pod.ListContext(ctx, apiClient, catalogSourceNamespace,
+		metav1.ListOptions{LabelSelector: "olm.catalogSource=" + catalogSource})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, registryPod := range registryPods {
+		if registryPod.Object.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		output, err := registryPod.ExecCommandContext(ctx,
+			[]string{"opm", "render", CatalogConfigsDir + "/" + packageName, "--output=json"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render the catalog of package %s in pod %s: %w", packageName,
+				registryPod.Object.Name, err)
+		}
+
+		return ParseCatalogChannelEntries(packageName, &output)
+	}
+
+	return nil, fmt.Errorf("no running registry pod for catalogsource %s in namespace %s", catalogSource,
+		catalogSourceNamespace)
+}
+
+// ParseCatalogChannelEntries reads a file-based catalog rendered as a stream of JSON objects, as 'opm render'
+// outputs it, and returns the entries of each olm.channel of the package, by channel name.
+func ParseCatalogChannelEntries(packageName string, reader io.Reader) (map[string][]UpgradeChannelEntry, error) {
+	channelEntries := map[string][]UpgradeChannelEntry{}
+	decoder := json.NewDecoder(reader)
+
+	for {
+		var channel catalogChannel
+		if err := decoder.Decode(&channel); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read the catalog of package %s: %w", packageName, err)
+		}
+
+		if channel.Schema == "olm.channel" && channel.Package == packageName {
+			channelEntries[channel.Name] = channel.Entries
+		}
+	}
+
+	if len(channelEntries) == 0 {
+		return nil, fmt.Errorf("catalog has no olm.channel for package %s", packageName)
+	}
+
+	return channelEntries, nil
+}
+
+// NewUpgradeGraph builds the UpgradeGraph of every channel of a pulled PackageManifest, with the edges declared by
+// the given channel entries, by channel name.
+func NewUpgradeGraph(pkgManifest *PackageManifestBuilder,
+	channelEntries map[string][]UpgradeChannelEntry) (*UpgradeGraph, error) {
+	if valid, err := pkgManifest.validate(); !valid {
+		return nil, err
+	}
+
+	if pkgManifest.Object == nil {
+		return nil, fmt.Errorf("PackageManifest %s has not been pulled", pkgManifest.Definition.Name)
+	}
+
+	status := pkgManifest.Object.Status
+
+	glog.V(100).Infof("Building the upgrade graph of package %s with %d channels",
+		status.PackageName, len(status.Channels))
+
+	graph := &UpgradeGraph{
+		Package:        status.PackageName,
+		CatalogSource:  status.CatalogSource,
+		DefaultChannel: status.DefaultChannel,
+		versions:       map[string]semver.Version{},
+	}
+
+	for _, packageChannel := range status.Channels {
+		entries, found := channelEntries[packageChannel.Name]
+		if !found {
+			return nil, fmt.Errorf("no channel entries for channel %s of package %s", packageChannel.Name,
+				status.PackageName)
+		}
+
+		channel, err := graph.newUpgradeChannel(packageChannel, entries)
+		if err != nil {
+			return nil, err
+		}
+
+		graph.Channels = append(graph.Channels, channel)
+	}
+
+	return graph, nil
+}
+
+// Channel returns the upgrade graph of the named channel.
+func (graph *UpgradeGraph) Channel(name string) (*UpgradeChannel, error) {
+	for _, channel := range graph.Channels {
+		if channel.Name == name {
+			return channel, nil
+		}
+	}
+
+	return nil, fmt.Errorf("channel %s not found in package %s", name, graph.Package)
+}
+
+// ChannelsContaining returns the channels with an entry of the given version, e.g. 24.9.2 or v24.9.2.
+func (graph *UpgradeGraph) ChannelsContaining(version string) []string {
+	wanted, err := semver.ParseTolerant(version)
+	if err != nil {
+		glog.V(100).Infof("Version %q of package %s is not a semantic version: %v", version, graph.Package, err)
+
+		return nil
+	}
+
+	var channels []string
+
+	for _, channel := range graph.Channels {
+		if slices.ContainsFunc(channel.Entries, func(csvName string) bool {
+			csvVersion, ok := graph.versions[csvName]
+
+			return ok && csvVersion.EQ(wanted)
+		}) {
+			channels = append(channels, channel.Name)
+		}
+	}
+
+	return channels
+}
+
+// UpgradePaths lists every upgrade path from the given clusterserviceversion to the head of a channel, shortest
+// first. Each path starts with fromCSV and ends with the channel head. The clusterserviceversion does not have to
+// be in the channel, as long as the skipRange of a channel entry includes it.
+func (graph *UpgradeGraph) UpgradePaths(fromCSV, channelName string) ([][]string, error) {
+	channel, err := graph.Channel(channelName)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Listing upgrade paths of package %s from %s to %s, the head of channel %s",
+		graph.Package, fromCSV, channel.Head, channel.Name)
+
+	edges := slices.Clone(channel.Edges)
+
+	if !slices.Contains(channel.Entries, fromCSV) && len(channel.skipRanges) > 0 {
+		fromVersion, err := graph.csvVersion(fromCSV)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, csvName := range channel.Entries {
+			if skipRange, ok := channel.skipRanges[csvName]; ok && skipRange(fromVersion) {
+				edges = append(edges, UpgradeEdge{From: fromCSV, To: csvName, Type: UpgradeEdgeSkipRange})
+			}
+		}
+	}
+
+	var paths [][]string
+
+	var walk func(path []string)
+	walk = func(path []string) {
+		last := path[len(path)-1]
+		if last == channel.Head {
+			paths = append(paths, slices.Clone(path))
+
+			return
+		}
+
+		for _, edge := range edges {
+			if edge.From == last && !slices.Contains(path, edge.To) {
+				walk(append(path, edge.To))
+			}
+		}
+	}
+
+	walk([]string{fromCSV})
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no upgrade path from %s to %s, the head of channel %s", fromCSV, channel.Head,
+			channel.Name)
+	}
+
+	slices.SortStableFunc(paths, func(a, b []string) int {
+		return len(a) - len(b)
+	})
+
+	return paths, nil
+}
+
+// ShortestUpgradePath returns the upgrade path from the given clusterserviceversion to the head of a channel
+// with the fewest upgrades.
+func (graph *UpgradeGraph) ShortestUpgradePath(fromCSV, channelName string) ([]string, error) {
+	paths, err := graph.UpgradePaths(fromCSV, channelName)
+	if err != nil {
+		return nil, err
+	}
+
+	return paths[0], nil
+}
+
+// StepwiseUpgradePath returns the upgrade path from the given clusterserviceversion to the head of a channel
+// through the most clusterserviceversions, so that every upgrade edge between them is walked rather than skipped.
+func (graph *UpgradeGraph) StepwiseUpgradePath(fromCSV, channelName string) ([]string, error) {
+	paths, err := graph.UpgradePaths(fromCSV, channelName)
+	if err != nil {
+		return nil, err
+	}
+
+	stepwisePath := paths[0]
+	for _, path := range paths[1:] {
+		if len(path) > len(stepwisePath) {
+			stepwisePath = path
+		}
+	}
+
+	return stepwisePath, nil
+}
+
+// newUpgradeChannel builds the upgrade graph of a channel of the PackageManifest from the edges its catalog entries
+// declare.
+func (graph *UpgradeGraph) newUpgradeChannel(packageChannel pkgManifestV1.PackageChannel,
+	entries []UpgradeChannelEntry) (*UpgradeChannel, error) {
+	channel := &UpgradeChannel{
+		Name:       packageChannel.Name,
+		Head:       packageChannel.CurrentCSV,
+		Deprecated: packageChannel.Deprecation != nil,
+		skipRanges: map[string]semver.Range{},
+	}
+
+	for _, entry := range packageChannel.Entries {
+		if entryVersion, err := semver.ParseTolerant(entry.Version); err == nil {
+			graph.versions[entry.Name] = entryVersion
+		}
+	}
+
+	sorted := true
+
+	for _, entry := range entries {
+		channel.Entries = append(channel.Entries, entry.Name)
+
+		if entryVersion, err := graph.csvVersion(entry.Name); err == nil {
+			graph.versions[entry.Name] = entryVersion
+		} else {
+			sorted = false
+		}
+
+		if entry.SkipRange == "" {
+			continue
+		}
+
+		skipRange, err := semver.ParseRange(entry.SkipRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q of %s in channel %s: %w", SkipRangeAnnotation, entry.SkipRange,
+				entry.Name, channel.Name, err)
+		}
+
+		channel.skipRanges[entry.Name] = skipRange
+
+		if entry.Name == channel.Head {
+			channel.SkipRange = entry.SkipRange
+		}
+	}
+
+	if sorted {
+		slices.SortStableFunc(channel.Entries, func(a, b string) int {
+			return graph.versions[a].Compare(graph.versions[b])
+		})
+	}
+
+	if len(channel.Entries) == 0 {
+		channel.Entries = []string{channel.Head}
+	}
+
+	for _, csvName := range channel.Entries {
+		index := slices.IndexFunc(entries, func(entry UpgradeChannelEntry) bool { return entry.Name == csvName })
+		if index < 0 {
+			continue
+		}
+
+		if entries[index].Replaces != "" {
+			channel.addEdge(entries[index].Replaces, csvName, UpgradeEdgeReplaces)
+		}
+
+		for _, skipped := range entries[index].Skips {
+			channel.addEdge(skipped, csvName, UpgradeEdgeSkips)
+		}
+
+		if skipRange, ok := channel.skipRanges[csvName]; ok {
+			for _, skippedCSV := range channel.Entries {
+				csvVersion, ok := graph.versions[skippedCSV]
+				if ok && skippedCSV != csvName && skipRange(csvVersion) {
+					channel.addEdge(skippedCSV, csvName, UpgradeEdgeSkipRange)
+				}
+			}
+		}
+	}
+
+	return channel, nil
+}
+
+// addEdge adds an upgrade edge to the channel, unless the channel already has an edge between the same
+// clusterserviceversions.
+func (channel *UpgradeChannel) addEdge(from, to string, edgeType UpgradeEdgeType) {
+	if slices.ContainsFunc(channel.Edges, func(edge UpgradeEdge) bool { return edge.From == from && edge.To == to }) {
+		return
+	}
+
+	channel.Edges = append(channel.Edges, UpgradeEdge{From: from, To: to, Type: edgeType})
+}
+
+// csvVersion returns the version of a clusterserviceversion, read from its name when it is not in the graph.
+func (graph *UpgradeGraph) csvVersion(csvName string) (semver.Version, error) {
+	if csvVersion, ok := graph.versions[csvName]; ok {
+		return csvVersion, nil
+	}
+
+	_, version, found := strings.Cut(csvName, ".v")
+	if !found {
+		return semver.Version{}, fmt.Errorf("clusterserviceversion %s has no version", csvName)
+	}
+
+	csvVersion, err := semver.ParseTolerant(version)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("clusterserviceversion %s has no semantic version: %w", csvName, err)
+	}
+
+	return csvVersion, nil
+}
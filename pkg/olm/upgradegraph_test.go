@@ -0,0 +1,282 @@
+package olm
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
+)
+
+// newTestUpgradeChannel returns a PackageManifest channel of the given versions and its catalog entries, each
+// replacing the previous version and the head skipping the given range.
+func newTestUpgradeChannel(name, skipRange string,
+	versions ...string) (pkgManifestV1.PackageChannel, []UpgradeChannelEntry) {
+	channel := pkgManifestV1.PackageChannel{Name: name}
+
+	var entries []UpgradeChannelEntry
+
+	for index, version := range versions {
+		entry := UpgradeChannelEntry{Name: testPackage + ".v" + version}
+		if index > 0 {
+			entry.Replaces = testPackage + ".v" + versions[index-1]
+		}
+
+		entries = append(entries, entry)
+	}
+
+	// The packageserver lists the entries from the channel head down.
+	for _, version := range slices.Backward(versions) {
+		channel.Entries = append(channel.Entries, pkgManifestV1.ChannelEntry{
+			Name: testPackage + ".v" + version, Version: version})
+	}
+
+	channel.CurrentCSV = channel.Entries[0].Name
+	entries[len(entries)-1].SkipRange = skipRange
+
+	return channel, entries
+}
+
+func newTestUpgradeGraph(t *testing.T) *UpgradeGraph {
+	t.Helper()
+
+	pkgManifest := newTestPackageManifest("certified-operators")
+	pkgManifest.Status.DefaultChannel = "stable"
+	pkgManifest.Status.Channels = nil
+	channelEntries := map[string][]UpgradeChannelEntry{}
+
+	for _, channel := range []struct {
+		name      string
+		skipRange string
+		versions  []string
+	}{
+		{name: "v24.9", skipRange: ">=24.9.0 <24.9.2", versions: []string{"24.9.0", "24.9.1", "24.9.2"}},
+		{name: "v25.3", skipRange: ">=24.9.0 <25.3.4", versions: []string{"25.3.0", "25.3.4"}},
+		{name: "stable", skipRange: ">=25.3.0 <25.10.0", versions: []string{"24.9.2", "25.3.4", "25.10.0"}},
+	} {
+		packageChannel, entries := newTestUpgradeChannel(channel.name, channel.skipRange, channel.versions...)
+		pkgManifest.Status.Channels = append(pkgManifest.Status.Channels, packageChannel)
+		channelEntries[channel.name] = entries
+	}
+
+	graph, err := NewUpgradeGraph(&PackageManifestBuilder{
+		apiClient: newTestInstaller(t, nil).apiClient, Definition: pkgManifest, Object: pkgManifest}, channelEntries)
+	if err != nil {
+		t.Fatalf("failed to build the upgrade graph: %v", err)
+	}
+
+	return graph
+}
+
+func TestUpgradeGraphUpgradePaths(t *testing.T) {
+	graph := newTestUpgradeGraph(t)
+
+	testCases := []struct {
+		name          string
+		fromCSV       string
+		channel       string
+		expectedPaths [][]string
+		expectedError string
+	}{
+		{
+			name:    "replaces and skipRange within the channel",
+			fromCSV: testPackage + ".v24.9.0",
+			channel: "v24.9",
+			expectedPaths: [][]string{
+				{testPackage + ".v24.9.0", testPackage + ".v24.9.2"},
+				{testPackage + ".v24.9.0", testPackage + ".v24.9.1", testPackage + ".v24.9.2"},
+			},
+		},
+		{
+			name:          "replaces across versions",
+			fromCSV:       testPackage + ".v24.9.2",
+			channel:       "stable",
+			expectedPaths: [][]string{testUpgradePath},
+		},
+		{
+			name:          "skipRange from another channel",
+			fromCSV:       testPackage + ".v24.9.1",
+			channel:       "v25.3",
+			expectedPaths: [][]string{{testPackage + ".v24.9.1", testPackage + ".v25.3.4"}},
+		},
+		{
+			name:          "already at the channel head",
+			fromCSV:       testPackage + ".v25.10.0",
+			channel:       "stable",
+			expectedPaths: [][]string{{testPackage + ".v25.10.0"}},
+		},
+		{
+			name:          "no upgrade path",
+			fromCSV:       testPackage + ".v24.9.1",
+			channel:       "stable",
+			expectedError: "no upgrade path from gpu-operator-certified.v24.9.1 to gpu-operator-certified.v25.10.0",
+		},
+		{
+			name:          "unknown channel",
+			fromCSV:       testPackage + ".v24.9.2",
+			channel:       "v23.9",
+			expectedError: "channel v23.9 not found in package gpu-operator-certified",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			paths, err := graph.UpgradePaths(testCase.fromCSV, testCase.channel)
+
+			if testCase.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+					t.Errorf("expected error %q, got %v", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.EqualFunc(paths, testCase.expectedPaths, slices.Equal) {
+				t.Errorf("expected paths %v, got %v", testCase.expectedPaths, paths)
+			}
+		})
+	}
+}
+
+func TestUpgradeGraphChannelsContaining(t *testing.T) {
+	graph := newTestUpgradeGraph(t)
+
+	testCases := []struct {
+		version          string
+		expectedChannels []string
+	}{
+		{version: "24.9.2", expectedChannels: []string{"v24.9", "stable"}},
+		{version: "v25.3.4", expectedChannels: []string{"v25.3", "stable"}},
+		{version: "25.3.0", expectedChannels: []string{"v25.3"}},
+		{version: "23.9.0"},
+		{version: "latest"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.version, func(t *testing.T) {
+			if channels := graph.ChannelsContaining(testCase.version); !slices.Equal(channels,
+				testCase.expectedChannels) {
+				t.Errorf("expected channels %v, got %v", testCase.expectedChannels, channels)
+			}
+		})
+	}
+}
+
+func TestNewUpgradeGraphChannels(t *testing.T) {
+	graph := newTestUpgradeGraph(t)
+
+	if graph.Package != testPackage || graph.DefaultChannel != "stable" || len(graph.Channels) != 3 {
+		t.Fatalf("unexpected upgrade graph %+v", graph)
+	}
+
+	channel, err := graph.Channel("v24.9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedEdges := []UpgradeEdge{
+		{From: testPackage + ".v24.9.0", To: testPackage + ".v24.9.1", Type: UpgradeEdgeReplaces},
+		{From: testPackage + ".v24.9.1", To: testPackage + ".v24.9.2", Type: UpgradeEdgeReplaces},
+		{From: testPackage + ".v24.9.0", To: testPackage + ".v24.9.2", Type: UpgradeEdgeSkipRange},
+	}
+
+	if channel.Head != testPackage+".v24.9.2" || !slices.Equal(channel.Edges, expectedEdges) {
+		t.Errorf("expected head %s.v24.9.2 with edges %v, got %s with %v", testPackage, expectedEdges,
+			channel.Head, channel.Edges)
+	}
+
+	pkgManifest := newTestPackageManifest("certified-operators")
+	packageChannel, entries := newTestUpgradeChannel("stable", "", "25.3.4", "25.6.0", "25.10.0")
+	pkgManifest.Status.Channels = []pkgManifestV1.PackageChannel{packageChannel}
+	pkgManifestBuilder := &PackageManifestBuilder{
+		apiClient: newTestInstaller(t, nil).apiClient, Definition: pkgManifest, Object: pkgManifest}
+
+	// 25.10.0 replaces 25.3.4 and skips 25.6.0, whatever the version order of the entries.
+	entries[1].Replaces = ""
+	entries[2].Replaces = testPackage + ".v25.3.4"
+	entries[2].Skips = []string{testPackage + ".v25.6.0"}
+
+	graph, err = NewUpgradeGraph(pkgManifestBuilder, map[string][]UpgradeChannelEntry{"stable": entries})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedEdges = []UpgradeEdge{
+		{From: testPackage + ".v25.3.4", To: testPackage + ".v25.10.0", Type: UpgradeEdgeReplaces},
+		{From: testPackage + ".v25.6.0", To: testPackage + ".v25.10.0", Type: UpgradeEdgeSkips},
+	}
+
+	if !slices.Equal(graph.Channels[0].Edges, expectedEdges) {
+		t.Errorf("expected the edges declared by the catalog %v, got %v", expectedEdges, graph.Channels[0].Edges)
+	}
+
+	if _, err := NewUpgradeGraph(pkgManifestBuilder, nil); err == nil ||
+		err.Error() != "no channel entries for channel stable of package gpu-operator-certified" {
+		t.Errorf("expected a missing channel entries error, got %v", err)
+	}
+
+	entries[2].SkipRange = "<25.10.0 &&"
+
+	if _, err := NewUpgradeGraph(pkgManifestBuilder, map[string][]UpgradeChannelEntry{"stable": entries}); err == nil ||
+		!strings.Contains(err.Error(), "invalid olm.skipRange") {
+		t.Errorf("expected an invalid skipRange error, got %v", err)
+	}
+}
+
+func TestUpgradeGraphStepwiseUpgradePath(t *testing.T) {
+	graph := newTestUpgradeGraph(t)
+
+	path, err := graph.StepwiseUpgradePath(testPackage+".v24.9.0", "v24.9")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedPath := []string{testPackage + ".v24.9.0", testPackage + ".v24.9.1", testPackage + ".v24.9.2"}
+	if !slices.Equal(path, expectedPath) {
+		t.Errorf("expected the path through every version %v, got %v", expectedPath, path)
+	}
+
+	if _, err := graph.StepwiseUpgradePath(testPackage+".v24.9.1", "stable"); err == nil {
+		t.Errorf("expected no upgrade path from 24.9.1 to the head of stable")
+	}
+}
+
+func TestParseCatalogChannelEntries(t *testing.T) {
+	catalog := `{"schema": "olm.package", "name": "gpu-operator-certified", "defaultChannel": "stable"}
+{"schema": "olm.channel", "package": "gpu-operator-certified", "name": "stable", "entries": [
+  {"name": "gpu-operator-certified.v25.3.4"},
+  {"name": "gpu-operator-certified.v25.10.0", "replaces": "gpu-operator-certified.v25.3.4",
+   "skips": ["gpu-operator-certified.v25.6.0"], "skipRange": ">=25.3.0 <25.10.0"}]}
+{"schema": "olm.channel", "package": "nfd", "name": "stable", "entries": [{"name": "nfd.v4.18.0"}]}
+{"schema": "olm.bundle", "package": "gpu-operator-certified", "name": "gpu-operator-certified.v25.10.0"}
+`
+
+	channelEntries, err := ParseCatalogChannelEntries(testPackage, strings.NewReader(catalog))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedEntries := []UpgradeChannelEntry{
+		{Name: testPackage + ".v25.3.4"},
+		{Name: testPackage + ".v25.10.0", Replaces: testPackage + ".v25.3.4",
+			Skips: []string{testPackage + ".v25.6.0"}, SkipRange: ">=25.3.0 <25.10.0"},
+	}
+
+	if len(channelEntries) != 1 || !reflect.DeepEqual(channelEntries["stable"], expectedEntries) {
+		t.Errorf("expected the stable channel entries %+v, got %+v", expectedEntries, channelEntries)
+	}
+
+	if _, err := ParseCatalogChannelEntries("nvidia-network-operator", strings.NewReader(catalog)); err == nil ||
+		err.Error() != "catalog has no olm.channel for package nvidia-network-operator" {
+		t.Errorf("expected a missing package error, got %v", err)
+	}
+
+	if _, err := ParseCatalogChannelEntries(testPackage, strings.NewReader(`{"schema": `)); err == nil {
+		t.Errorf("expected an error reading a truncated catalog")
+	}
+}
@@ -1,12 +1,19 @@
 package operatorconfig
 
 import (
+	"time"
+
 	. "github.com/rh-ecosystem-edge/nvidia-ci/pkg/global" //nolint:staticcheck
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
 )
 
 type CustomConfig struct {
 	CustomCatalogSourceIndexImage string
 	CreateCustomCatalogsource     bool
+	// CustomCatalogSourceOptions are applied to the custom catalogsource, e.g. its registry poll interval.
+	CustomCatalogSourceOptions olm.CatalogSourceOptions
+	// CustomCatalogSourcePackageTimeout, when set, is how long the custom catalogsource may take to serve the package.
+	CustomCatalogSourcePackageTimeout time.Duration
 
 	CustomCatalogSource string
 	CatalogSource       string
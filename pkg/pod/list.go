@@ -13,6 +13,12 @@ import (
 
 // List returns pod inventory in the given namespace.
 func List(apiClient *clients.Settings, nsname string, options ...v1.ListOptions) ([]*Builder, error) {
+	return ListContext(context.TODO(), apiClient, nsname, options...)
+}
+
+// ListContext returns pod inventory in the given namespace.
+func ListContext(ctx context.Context, apiClient *clients.Settings, nsname string,
+	options ...v1.ListOptions) ([]*Builder, error) {
 	if nsname == "" {
 		glog.V(100).Infof("pod 'nsname' parameter can not be empty")
 
@@ -33,7 +39,7 @@ func List(apiClient *clients.Settings, nsname string, options ...v1.ListOptions)
 
 	glog.V(100).Infof(logMessage)
 
-	podList, err := apiClient.Pods(nsname).List(context.TODO(), passedOptions)
+	podList, err := apiClient.Pods(nsname).List(ctx, passedOptions)
 	if err != nil {
 		glog.V(100).Infof("Failed to list pods in the nsname %s due to %s", nsname, err.Error())
 
@@ -58,6 +64,12 @@ func List(apiClient *clients.Settings, nsname string, options ...v1.ListOptions)
 
 // ListInAllNamespaces returns a cluster-wide pod inventory.
 func ListInAllNamespaces(apiClient *clients.Settings, options ...v1.ListOptions) ([]*Builder, error) {
+	return ListInAllNamespacesContext(context.TODO(), apiClient, options...)
+}
+
+// ListInAllNamespacesContext returns a cluster-wide pod inventory.
+func ListInAllNamespacesContext(ctx context.Context, apiClient *clients.Settings,
+	options ...v1.ListOptions) ([]*Builder, error) {
 	logMessage := "Listing all pods in all namespaces"
 	passedOptions := v1.ListOptions{}
 
@@ -74,7 +86,7 @@ func ListInAllNamespaces(apiClient *clients.Settings, options ...v1.ListOptions)
 
 	glog.V(100).Infof(logMessage)
 
-	podList, err := apiClient.Pods("").List(context.TODO(), passedOptions)
+	podList, err := apiClient.Pods("").List(ctx, passedOptions)
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list all pods due to %s", err.Error())
@@ -100,6 +112,12 @@ func ListInAllNamespaces(apiClient *clients.Settings, options ...v1.ListOptions)
 
 // ListByNamePattern returns pod inventory in the given namespace filtered by name pattern.
 func ListByNamePattern(apiClient *clients.Settings, namePattern, nsname string) ([]*Builder, error) {
+	return ListByNamePatternContext(context.TODO(), apiClient, namePattern, nsname)
+}
+
+// ListByNamePatternContext returns pod inventory in the given namespace filtered by name pattern.
+func ListByNamePatternContext(ctx context.Context, apiClient *clients.Settings, namePattern,
+	nsname string) ([]*Builder, error) {
 	glog.V(100).Infof("Listing pods in the nsname %s filtered by the name pattern %s", nsname, namePattern)
 
 	if nsname == "" {
@@ -108,7 +126,7 @@ func ListByNamePattern(apiClient *clients.Settings, namePattern, nsname string)
 		return nil, fmt.Errorf("failed to list pods, 'nsname' parameter is empty")
 	}
 
-	podList, err := apiClient.Pods(nsname).List(context.TODO(), v1.ListOptions{})
+	podList, err := apiClient.Pods(nsname).List(ctx, v1.ListOptions{})
 
 	if err != nil {
 		glog.V(100).Infof("Failed to list pods filtered by the name pattern %s in the nsname %s due to %s",
@@ -136,11 +154,13 @@ func ListByNamePattern(apiClient *clients.Settings, namePattern, nsname string)
 }
 
 // WaitForAllPodsInNamespaceRunning wait until all pods in namespace that match options are in running state.
-func WaitForAllPodsInNamespaceRunning(
-	apiClient *clients.Settings,
-	nsname string,
-	timeout time.Duration,
-	options ...v1.ListOptions) (bool, error) {
+func WaitForAllPodsInNamespaceRunning(apiClient *clients.Settings, nsname string, timeout time.Duration, options ...v1.ListOptions) (bool, error) {
+	return WaitForAllPodsInNamespaceRunningContext(context.TODO(), apiClient, nsname, timeout, options...)
+}
+
+// WaitForAllPodsInNamespaceRunningContext wait until all pods in namespace that match options are in running state.
+func WaitForAllPodsInNamespaceRunningContext(ctx context.Context, apiClient *clients.Settings,
+	nsname string, timeout time.Duration, options ...v1.ListOptions) (bool, error) {
 	if nsname == "" {
 		glog.V(100).Infof("'nsname' parameter can not be empty")
 
@@ -163,7 +183,7 @@ func WaitForAllPodsInNamespaceRunning(
 
 	glog.V(100).Infof(logMessage + " are in running state")
 
-	podList, err := List(apiClient, nsname, passedOptions)
+	podList, err := ListContext(ctx, apiClient, nsname, passedOptions)
 	if err != nil {
 		glog.V(100).Infof("Failed to list all pods due to %s", err.Error())
 
@@ -171,7 +191,7 @@ func WaitForAllPodsInNamespaceRunning(
 	}
 
 	for _, podObj := range podList {
-		err = podObj.WaitUntilRunning(timeout)
+		err = podObj.WaitUntilRunningContext(ctx, timeout)
 		if err != nil {
 			glog.V(100).Infof("Timout was reached while waiting for all pods in running state: %s", err.Error())
 
@@ -0,0 +1,119 @@
+package pod
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestList(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Pods})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		namespace     string
+		options       []v1.ListOptions
+		expectedNames []string
+		expectedError bool
+	}{
+		{
+			name:          "all pods in namespace",
+			namespace:     testPodNamespace,
+			expectedNames: []string{"gpu-operator-6b8c9d7f5-klmno", testRunningPodName, testPendingPodName},
+		},
+		{
+			name:          "driver pods",
+			namespace:     testPodNamespace,
+			options:       []v1.ListOptions{{LabelSelector: "app.kubernetes.io/component=nvidia-driver"}},
+			expectedNames: []string{testRunningPodName, testPendingPodName},
+		},
+		{
+			name:          "empty namespace",
+			expectedError: true,
+		},
+		{
+			name:          "too many options",
+			namespace:     testPodNamespace,
+			options:       []v1.ListOptions{{}, {}},
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			podBuilders, err := List(apiClient, testCase.namespace, testCase.options...)
+			if testCase.expectedError {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if names := podNames(podBuilders); !slices.Equal(names, testCase.expectedNames) {
+				t.Errorf("expected pods %v, got %v", testCase.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestListInAllNamespaces(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Pods})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	podBuilders, err := ListInAllNamespaces(apiClient, v1.ListOptions{LabelSelector: "app=nfd-worker"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := podNames(podBuilders); !slices.Equal(names, []string{"nfd-worker-pqrst"}) {
+		t.Errorf("expected the NFD worker pod, got %v", names)
+	}
+
+	if podBuilders, err = ListInAllNamespaces(apiClient); err != nil || len(podBuilders) != 4 {
+		t.Errorf("expected 4 pods in all namespaces, got %d: %v", len(podBuilders), err)
+	}
+}
+
+func TestListByNamePattern(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Pods})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	podBuilders, err := ListByNamePattern(apiClient, "nvidia-driver-daemonset", testPodNamespace)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if names := podNames(podBuilders); !slices.Equal(names, []string{testRunningPodName, testPendingPodName}) {
+		t.Errorf("expected the driver pods, got %v", names)
+	}
+
+	if _, err := ListByNamePattern(apiClient, "nvidia-driver-daemonset", ""); err == nil {
+		t.Error("expected an error for an empty namespace")
+	}
+}
+
+// podNames returns the sorted names of the listed pods.
+func podNames(podBuilders []*Builder) []string {
+	var names []string
+	for _, podBuilder := range podBuilders {
+		names = append(names, podBuilder.Object.Name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -81,6 +81,8 @@ func NewBuilder(apiClient *clients.Settings, name, nsname, image string) *Builde
 		glog.V(100).Infof("Failed to define the default container settings")
 
 		builder.errorMsg = err.Error()
+
+		return builder
 	}
 
 	builder.Definition.Spec.Containers = append(builder.Definition.Spec.Containers, *defaultContainer)
@@ -90,6 +92,11 @@ func NewBuilder(apiClient *clients.Settings, name, nsname, image string) *Builde
 
 // Pull loads an existing pod into the Builder struct.
 func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
+	return PullContext(context.TODO(), apiClient, name, nsname)
+}
+
+// PullContext loads an existing pod into the Builder struct.
+func PullContext(ctx context.Context, apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	glog.V(100).Infof("Pulling existing pod name: %s namespace:%s", name, nsname)
 
 	builder := Builder{
@@ -118,7 +125,7 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 		return nil, fmt.Errorf("faield to pull pod object due to the following error: %s", builder.errorMsg)
 	}
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		glog.V(100).Infof("Failed to pull pod object %s from namespace %s. Object doesn't exist",
 			name, nsname)
 
@@ -194,6 +201,11 @@ func (builder *Builder) DefineOnNode(nodeName string) *Builder {
 
 // Create makes a pod according to the pod definition and stores the created object in the pod builder.
 func (builder *Builder) Create() (*Builder, error) {
+	return builder.CreateContext(context.TODO())
+}
+
+// CreateContext makes a pod according to the pod definition and stores the created object in the pod builder.
+func (builder *Builder) CreateContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -202,9 +214,9 @@ func (builder *Builder) Create() (*Builder, error) {
 		builder.Definition.Name, builder.Definition.Namespace)
 
 	var err error
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		builder.Object, err = builder.apiClient.Pods(builder.Definition.Namespace).Create(
-			context.TODO(), builder.Definition, metav1.CreateOptions{})
+			ctx, builder.Definition, metav1.CreateOptions{})
 	}
 
 	return builder, err
@@ -212,6 +224,11 @@ func (builder *Builder) Create() (*Builder, error) {
 
 // Delete removes the pod object and resets the builder object.
 func (builder *Builder) Delete() (*Builder, error) {
+	return builder.DeleteContext(context.TODO())
+}
+
+// DeleteContext removes the pod object and resets the builder object.
+func (builder *Builder) DeleteContext(ctx context.Context) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -219,12 +236,12 @@ func (builder *Builder) Delete() (*Builder, error) {
 	glog.V(100).Infof("Deleting pod %s in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	if !builder.Exists() {
+	if !builder.ExistsContext(ctx) {
 		return builder, fmt.Errorf("pod cannot be deleted because it does not exist")
 	}
 
 	err := builder.apiClient.Pods(builder.Definition.Namespace).Delete(
-		context.TODO(), builder.Object.Name, metav1.DeleteOptions{})
+		ctx, builder.Object.Name, metav1.DeleteOptions{})
 
 	if err != nil {
 		return builder, fmt.Errorf("can not delete pod: %w", err)
@@ -237,6 +254,11 @@ func (builder *Builder) Delete() (*Builder, error) {
 
 // DeleteAndWait deletes the pod object and waits until the pod is deleted.
 func (builder *Builder) DeleteAndWait(timeout time.Duration) (*Builder, error) {
+	return builder.DeleteAndWaitContext(context.TODO(), timeout)
+}
+
+// DeleteAndWaitContext deletes the pod object and waits until the pod is deleted.
+func (builder *Builder) DeleteAndWaitContext(ctx context.Context, timeout time.Duration) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -244,12 +266,12 @@ func (builder *Builder) DeleteAndWait(timeout time.Duration) (*Builder, error) {
 	glog.V(100).Infof("Deleting pod %s in namespace %s and waiting for the defined period until it's removed",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	builder, err := builder.Delete()
+	builder, err := builder.DeleteContext(ctx)
 	if err != nil {
 		return builder, err
 	}
 
-	err = builder.WaitUntilDeleted(timeout)
+	err = builder.WaitUntilDeletedContext(ctx, timeout)
 
 	if err != nil {
 		return builder, err
@@ -260,6 +282,11 @@ func (builder *Builder) DeleteAndWait(timeout time.Duration) (*Builder, error) {
 
 // CreateAndWaitUntilRunning creates the pod object and waits until the pod is running.
 func (builder *Builder) CreateAndWaitUntilRunning(timeout time.Duration) (*Builder, error) {
+	return builder.CreateAndWaitUntilRunningContext(context.TODO(), timeout)
+}
+
+// CreateAndWaitUntilRunningContext creates the pod object and waits until the pod is running.
+func (builder *Builder) CreateAndWaitUntilRunningContext(ctx context.Context, timeout time.Duration) (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
 		return builder, err
 	}
@@ -267,12 +294,12 @@ func (builder *Builder) CreateAndWaitUntilRunning(timeout time.Duration) (*Build
 	glog.V(100).Infof("Creating pod %s in namespace %s and waiting for the defined period until it's ready",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	builder, err := builder.Create()
+	builder, err := builder.CreateContext(ctx)
 	if err != nil {
 		return builder, err
 	}
 
-	err = builder.WaitUntilRunning(timeout)
+	err = builder.WaitUntilRunningContext(ctx, timeout)
 
 	if err != nil {
 		return builder, err
@@ -283,6 +310,11 @@ func (builder *Builder) CreateAndWaitUntilRunning(timeout time.Duration) (*Build
 
 // WaitUntilRunning waits for the duration of the defined timeout or until the pod is running.
 func (builder *Builder) WaitUntilRunning(timeout time.Duration) error {
+	return builder.WaitUntilRunningContext(context.TODO(), timeout)
+}
+
+// WaitUntilRunningContext waits for the duration of the defined timeout or until the pod is running.
+func (builder *Builder) WaitUntilRunningContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -290,11 +322,17 @@ func (builder *Builder) WaitUntilRunning(timeout time.Duration) error {
 	glog.V(100).Infof("Waiting for the defined period until pod %s in namespace %s is running",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	return builder.WaitUntilInStatus(corev1.PodRunning, timeout)
+	return builder.WaitUntilInStatusContext(ctx, corev1.PodRunning, timeout)
 }
 
 // WaitUntilInStatus waits for the duration of the defined timeout or until the pod gets to a specific status.
 func (builder *Builder) WaitUntilInStatus(status corev1.PodPhase, timeout time.Duration) error {
+	return builder.WaitUntilInStatusContext(context.TODO(), status, timeout)
+}
+
+// WaitUntilInStatusContext waits for the duration of the defined timeout or until the pod gets to a specific status.
+func (builder *Builder) WaitUntilInStatusContext(ctx context.Context, status corev1.PodPhase,
+	timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -303,7 +341,7 @@ func (builder *Builder) WaitUntilInStatus(status corev1.PodPhase, timeout time.D
 		builder.Definition.Name, builder.Definition.Namespace, status)
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), pollingInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, pollingInterval, timeout, true, func(ctx context.Context) (bool, error) {
 			updatePod, err := builder.apiClient.Pods(builder.Definition.Namespace).Get(
 				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if err != nil {
@@ -316,6 +354,11 @@ func (builder *Builder) WaitUntilInStatus(status corev1.PodPhase, timeout time.D
 
 // WaitUntilDeleted waits for the duration of the defined timeout or until the pod is deleted.
 func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
+	return builder.WaitUntilDeletedContext(context.TODO(), timeout)
+}
+
+// WaitUntilDeletedContext waits for the duration of the defined timeout or until the pod is deleted.
+func (builder *Builder) WaitUntilDeletedContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -324,7 +367,7 @@ func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
 		builder.Definition.Name, builder.Definition.Namespace)
 
 	err := wait.PollUntilContextTimeout(
-		context.TODO(), pollingInterval, timeout, false, func(ctx context.Context) (bool, error) {
+		ctx, pollingInterval, timeout, false, func(ctx context.Context) (bool, error) {
 			_, err := builder.apiClient.Pods(builder.Definition.Namespace).Get(
 				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if err == nil {
@@ -349,6 +392,11 @@ func (builder *Builder) WaitUntilDeleted(timeout time.Duration) error {
 
 // WaitUntilReady waits for the duration of the defined timeout or until the pod reaches the Ready condition.
 func (builder *Builder) WaitUntilReady(timeout time.Duration) error {
+	return builder.WaitUntilReadyContext(context.TODO(), timeout)
+}
+
+// WaitUntilReadyContext waits for the duration of the defined timeout or until the pod reaches the Ready condition.
+func (builder *Builder) WaitUntilReadyContext(ctx context.Context, timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -356,11 +404,18 @@ func (builder *Builder) WaitUntilReady(timeout time.Duration) error {
 	glog.V(100).Infof("Waiting for the defined period until pod %s in namespace %s is Ready",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	return builder.WaitUntilCondition(corev1.PodReady, timeout)
+	return builder.WaitUntilConditionContext(ctx, corev1.PodReady, timeout)
 }
 
 // WaitUntilCondition waits for the duration of the defined timeout or until the pod gets to a specific condition.
 func (builder *Builder) WaitUntilCondition(condition corev1.PodConditionType, timeout time.Duration) error {
+	return builder.WaitUntilConditionContext(context.TODO(), condition, timeout)
+}
+
+// WaitUntilConditionContext waits for the duration of the defined timeout or until the pod gets to a specific
+// condition.
+func (builder *Builder) WaitUntilConditionContext(ctx context.Context, condition corev1.PodConditionType,
+	timeout time.Duration) error {
 	if valid, err := builder.validate(); !valid {
 		return err
 	}
@@ -369,7 +424,7 @@ func (builder *Builder) WaitUntilCondition(condition corev1.PodConditionType, ti
 		builder.Definition.Name, builder.Definition.Namespace, condition)
 
 	return wait.PollUntilContextTimeout(
-		context.TODO(), pollingInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, pollingInterval, timeout, true, func(ctx context.Context) (bool, error) {
 			updatePod, err := builder.apiClient.Pods(builder.Definition.Namespace).Get(
 				ctx, builder.Definition.Name, metav1.GetOptions{})
 			if err != nil {
@@ -388,6 +443,12 @@ func (builder *Builder) WaitUntilCondition(condition corev1.PodConditionType, ti
 
 // ExecCommand runs command in the pod and returns the buffer output.
 func (builder *Builder) ExecCommand(command []string, containerName ...string) (bytes.Buffer, error) {
+	return builder.ExecCommandContext(context.TODO(), command, containerName...)
+}
+
+// ExecCommandContext runs command in the pod and returns the buffer output.
+func (builder *Builder) ExecCommandContext(ctx context.Context, command []string,
+	containerName ...string) (bytes.Buffer, error) {
 	if valid, err := builder.validate(); !valid {
 		return bytes.Buffer{}, err
 	}
@@ -427,7 +488,7 @@ func (builder *Builder) ExecCommand(command []string, containerName ...string) (
 		return buffer, err
 	}
 
-	err = exec.StreamWithContext(context.TODO(), remotecommand.StreamOptions{
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
 		Stdin:  os.Stdin,
 		Stdout: &buffer,
 		Stderr: os.Stderr,
@@ -444,6 +505,12 @@ func (builder *Builder) ExecCommand(command []string, containerName ...string) (
 // Copy returns the contents of a file or path from a specified container into a buffer.
 // Setting the tar option returns a tar archive of the specified path.
 func (builder *Builder) Copy(path, containerName string, tar bool) (bytes.Buffer, error) {
+	return builder.CopyContext(context.TODO(), path, containerName, tar)
+}
+
+// CopyContext returns the contents of a file or path from a specified container into a buffer.
+// Setting the tar option returns a tar archive of the specified path.
+func (builder *Builder) CopyContext(ctx context.Context, path, containerName string, tar bool) (bytes.Buffer, error) {
 	if valid, err := builder.validate(); !valid {
 		return bytes.Buffer{}, err
 	}
@@ -515,7 +582,7 @@ func (builder *Builder) Copy(path, containerName string, tar bool) (bytes.Buffer
 		return buffer, err
 	}
 
-	err = exec.StreamWithContext(context.TODO(), remotecommand.StreamOptions{
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
 		Stdin:  os.Stdin,
 		Stdout: &buffer,
 		Stderr: os.Stderr,
@@ -531,6 +598,11 @@ func (builder *Builder) Copy(path, containerName string, tar bool) (bytes.Buffer
 
 // Exists checks whether the given pod exists.
 func (builder *Builder) Exists() bool {
+	return builder.ExistsContext(context.TODO())
+}
+
+// ExistsContext checks whether the given pod exists.
+func (builder *Builder) ExistsContext(ctx context.Context) bool {
 	if valid, _ := builder.validate(); !valid {
 		return false
 	}
@@ -540,7 +612,7 @@ func (builder *Builder) Exists() bool {
 
 	var err error
 	builder.Object, err = builder.apiClient.Pods(builder.Definition.Namespace).Get(
-		context.TODO(), builder.Definition.Name, metav1.GetOptions{})
+		ctx, builder.Definition.Name, metav1.GetOptions{})
 
 	return err == nil || !k8serrors.IsNotFound(err)
 }
@@ -1107,6 +1179,12 @@ func (builder *Builder) WithOptions(options ...AdditionalOptions) *Builder {
 
 // GetLog connects to a pod and fetches log.
 func (builder *Builder) GetLog(logStartTime time.Duration, containerName string) (string, error) {
+	return builder.GetLogContext(context.TODO(), logStartTime, containerName)
+}
+
+// GetLogContext connects to a pod and fetches log.
+func (builder *Builder) GetLogContext(ctx context.Context, logStartTime time.Duration,
+	containerName string) (string, error) {
 	if valid, err := builder.validate(); !valid {
 		return "", err
 	}
@@ -1114,7 +1192,7 @@ func (builder *Builder) GetLog(logStartTime time.Duration, containerName string)
 	logStart := int64(logStartTime.Seconds())
 	req := builder.apiClient.Pods(builder.Definition.Namespace).GetLogs(builder.Definition.Name, &corev1.PodLogOptions{
 		SinceSeconds: &logStart, Container: containerName})
-	log, err := req.Stream(context.TODO())
+	log, err := req.Stream(ctx)
 
 	if err != nil {
 		return "", err
@@ -1136,12 +1214,17 @@ func (builder *Builder) GetLog(logStartTime time.Duration, containerName string)
 
 // GetFullLog connects to a pod and fetches the full log since pod creation.
 func (builder *Builder) GetFullLog(containerName string) (string, error) {
+	return builder.GetFullLogContext(context.TODO(), containerName)
+}
+
+// GetFullLogContext connects to a pod and fetches the full log since pod creation.
+func (builder *Builder) GetFullLogContext(ctx context.Context, containerName string) (string, error) {
 	if valid, err := builder.validate(); !valid {
 		return "", err
 	}
 
 	logStream, err := builder.apiClient.Pods(builder.Definition.Namespace).GetLogs(builder.Definition.Name,
-		&corev1.PodLogOptions{Container: containerName}).Stream(context.TODO())
+		&corev1.PodLogOptions{Container: containerName}).Stream(ctx)
 
 	if err != nil {
 		return "", err
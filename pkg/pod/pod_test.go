@@ -0,0 +1,184 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testfixtures"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	testPodNamespace   = "nvidia-gpu-operator"
+	testRunningPodName = "nvidia-driver-daemonset-417.94-abcde"
+	testPendingPodName = "nvidia-driver-daemonset-417.94-fghij"
+	testPodImage       = "registry.access.redhat.com/ubi9/ubi-minimal:latest"
+	testRunningPodNode = "worker-gpu-0"
+)
+
+func TestNewBuilder(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients(nil)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		podName       string
+		namespace     string
+		image         string
+		expectedError string
+	}{
+		{name: "valid", podName: "test-pod", namespace: testPodNamespace, image: testPodImage},
+		{name: "empty name", namespace: testPodNamespace, image: testPodImage, expectedError: "pod's name is empty"},
+		{name: "empty namespace", podName: "test-pod", image: testPodImage, expectedError: "namespace's name is empty"},
+		{name: "empty image", podName: "test-pod", namespace: testPodNamespace, expectedError: "container's image is empty"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := NewBuilder(apiClient, testCase.podName, testCase.namespace, testCase.image)
+			if builder.errorMsg != testCase.expectedError {
+				t.Fatalf("expected error %q, got %q", testCase.expectedError, builder.errorMsg)
+			}
+
+			if testCase.image == "" {
+				return
+			}
+
+			if len(builder.Definition.Spec.Containers) != 1 ||
+				builder.Definition.Spec.Containers[0].Image != testCase.image {
+				t.Errorf("expected a single default container with image %q, got %+v", testCase.image,
+					builder.Definition.Spec.Containers)
+			}
+		})
+	}
+}
+
+func TestNewBuilderFromDefinition(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients(nil)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	if builder := NewBuilderFromDefinition(apiClient, nil); builder.errorMsg == "" {
+		t.Error("expected an error for a nil definition")
+	}
+
+	definition := &corev1.Pod{}
+	definition.Name = "test-pod"
+
+	if builder := NewBuilderFromDefinition(apiClient, definition); builder.errorMsg != "pod's namespace is empty" {
+		t.Errorf("expected an error for a definition without namespace, got %q", builder.errorMsg)
+	}
+
+	definition.Namespace = testPodNamespace
+
+	builder := NewBuilderFromDefinition(apiClient, definition)
+	if builder.errorMsg != "" || builder.Definition != definition {
+		t.Errorf("expected the builder to keep the definition, got %q", builder.errorMsg)
+	}
+}
+
+func TestPull(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Pods})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	builder, err := Pull(apiClient, testRunningPodName, testPodNamespace)
+	if err != nil {
+		t.Fatalf("failed to pull pod: %v", err)
+	}
+
+	if builder.Object.Spec.NodeName != testRunningPodNode || builder.Definition != builder.Object {
+		t.Errorf("expected the pulled pod on node %s, got %+v", testRunningPodNode, builder.Object.Spec)
+	}
+
+	testCases := []struct {
+		name      string
+		podName   string
+		namespace string
+	}{
+		{name: "missing pod", podName: "missing", namespace: testPodNamespace},
+		{name: "empty name", namespace: testPodNamespace},
+		{name: "empty namespace", podName: testRunningPodName},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := Pull(apiClient, testCase.podName, testCase.namespace); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestCreateAndDelete(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients(nil)
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	builder, err := NewBuilder(apiClient, "test-pod", testPodNamespace, testPodImage).
+		DefineOnNode(testRunningPodNode).Create()
+	if err != nil {
+		t.Fatalf("failed to create pod: %v", err)
+	}
+
+	if !builder.Exists() || builder.Object.Spec.NodeName != testRunningPodNode {
+		t.Fatalf("expected the pod to be created on node %s", testRunningPodNode)
+	}
+
+	if builder.DefineOnNode("worker-gpu-1"); builder.errorMsg == "" {
+		t.Error("expected an error redefining the node of a created pod")
+	}
+
+	builder.errorMsg = ""
+
+	if _, err := builder.Delete(); err != nil {
+		t.Fatalf("failed to delete pod: %v", err)
+	}
+
+	if builder.Object != nil || builder.Exists() {
+		t.Error("expected the pod to be deleted")
+	}
+
+	if _, err := builder.Delete(); err == nil {
+		t.Error("expected an error deleting a pod that does not exist")
+	}
+}
+
+func TestWaitUntilInStatus(t *testing.T) {
+	apiClient, err := testfixtures.NewTestClients([]string{testfixtures.Pods})
+	if err != nil {
+		t.Fatalf("failed to create test clients: %v", err)
+	}
+
+	runningPod, err := Pull(apiClient, testRunningPodName, testPodNamespace)
+	if err != nil {
+		t.Fatalf("failed to pull pod: %v", err)
+	}
+
+	if err := runningPod.WaitUntilRunning(time.Second); err != nil {
+		t.Errorf("expected the running pod to be running: %v", err)
+	}
+
+	if err := runningPod.WaitUntilReady(time.Second); err != nil {
+		t.Errorf("expected the running pod to be ready: %v", err)
+	}
+
+	pendingPod, err := Pull(apiClient, testPendingPodName, testPodNamespace)
+	if err != nil {
+		t.Fatalf("failed to pull pod: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	if err := pendingPod.WaitUntilRunningContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the wait to end with its cancelled context, got %v", err)
+	}
+}
@@ -54,26 +54,26 @@ func createGPUResourceClaimTemplate(namespace, name string) error {
 var _ = Describe("DRA Driver Installation", Ordered, Label("dra", "dra-gpu"), func() {
 	var actionConfig *action.Configuration
 	var driver *dra.Driver
-	var originalDevicePluginEnabled bool
 
 	BeforeAll(func() {
 		By("Verifying DRA prerequisites")
 		err := shared.VerifyDRAPrerequisites(inittools.APIClient)
 		Expect(err).ToNot(HaveOccurred(), "Failed to verify DRA prerequisites")
 
+		By("Taking a snapshot of the ClusterPolicy to restore after the tests")
+		snapshot, err := nvidiagpu.TakeSnapshot(inittools.APIClient, nvidiagpu.ClusterPolicyName,
+			nvidiagpu.NvidiaGPUNamespace, map[string]string{nvidiagpu.GPUPresentLabel: "true"})
+		Expect(err).ToNot(HaveOccurred(), "Failed to take a snapshot of the ClusterPolicy")
+		DeferCleanup(func() error {
+			By("Restoring the ClusterPolicy snapshot")
+			return wait.ClusterPolicyRestored(inittools.APIClient, snapshot, nvidiagpu.ClusterPolicyReadyCheckInterval,
+				nvidiagpu.ClusterPolicyReadyTimeout)
+		})
+
 		By("Disabling device plugin for GPU allocation tests")
 		devicePluginEnabled, err := shared.SetDevicePluginEnabled(inittools.APIClient, false)
 		Expect(err).ToNot(HaveOccurred(), "Failed to disable device plugin")
-		originalDevicePluginEnabled = devicePluginEnabled
-		glog.V(gpuparams.GpuLogLevel).Infof("Device plugin originally enabled: %v", originalDevicePluginEnabled)
-
-		if originalDevicePluginEnabled {
-			DeferCleanup(func() error {
-				By("Restoring original device plugin state")
-				_, err := shared.SetDevicePluginEnabled(inittools.APIClient, originalDevicePluginEnabled)
-				return err
-			})
-		}
+		glog.V(gpuparams.GpuLogLevel).Infof("Device plugin originally enabled: %v", devicePluginEnabled)
 
 		By("Waiting for GPU capacity on all nodes with GPU present to become 0")
 		noGPUCapacityCondition := func(node *corev1.Node) (bool, error) {
@@ -94,9 +94,7 @@ func SetDevicePluginEnabled(apiClient *clients.Settings, enabled bool) (bool, er
 		return previousState, nil
 	}
 
-	clusterPolicy.Definition.Spec.DevicePlugin.Enabled = &enabled
-
-	_, err = clusterPolicy.Update(true)
+	_, err = clusterPolicy.WithDevicePluginEnabled(enabled).Update(true)
 	if err != nil {
 		return previousState, fmt.Errorf("failed to update ClusterPolicy: %w", err)
 	}
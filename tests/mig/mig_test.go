@@ -5,10 +5,10 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/nvidiagpuconfig"
 	_ "github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
 	. "github.com/rh-ecosystem-edge/nvidia-ci/pkg/global"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/mig"
 	nfd "github.com/rh-ecosystem-edge/nvidia-ci/pkg/nfd"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/operatorconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/tests/shared"
 
 	"github.com/golang/glog"
 	. "github.com/onsi/ginkgo/v2"
@@ -50,14 +50,15 @@ var _ = Describe("MIG", Ordered, Label(tsparams.LabelSuite), func() {
 		BeforeAll(func() {
 			glog.V(gpuparams.Gpu10LogLevel).Infof("Start of the test case, BeforeAll")
 			// Initialize CLI flag-derived values after flags are parsed
-			mig.ParseCLIParameters()
-			mig.LogCLIParameterValues()
+			shared.ParseCLIParameters()
+			shared.LogCLIParameterValues()
 			nvidiaGPUConfig = nvidiagpuconfig.NewNvidiaGPUConfig()
 			Expect(nvidiaGPUConfig).ToNot(BeNil(), "Failed to initialize NvidiaGPUConfig")
 
 			cleanupAfterTest = nvidiaGPUConfig.CleanupAfterTest
 			By("Report OpenShift version")
 			ReportOpenShiftVersionAndEnsureNFD(nfdInstance)
+			shared.WriteGPUInventory(WorkerNodeSelector, "mig-")
 		})
 
 		BeforeEach(func() {
@@ -70,32 +71,41 @@ var _ = Describe("MIG", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(gpuparams.Gpu100LogLevel).Infof("AfterEach")
 		})
 
-		AfterAll(func() {
+		AfterAll(func(ctx SpecContext) {
 			glog.V(gpuparams.Gpu10LogLevel).Infof("cleanup in AfterAll")
 			if nfdInstance.CleanupAfterInstall && cleanupAfterTest {
-				err := nfd.Cleanup(inittools.APIClient)
-				Expect(err).ToNot(HaveOccurred(), "Error cleaning up NFD resources: %v", err)
+				err := nfd.UninstallNFD(ctx, inittools.APIClient, inittools.GeneralConfig.StrictUninstall)
+				Expect(err).ToNot(HaveOccurred(), "Error uninstalling NFD Operator: %v", err)
 			}
 			// Cleanup GPU Operator Resources
-			mig.CleanupGPUOperatorResources(cleanupAfterTest, burn.Namespace)
+			shared.CleanupGPUOperatorResources(ctx, cleanupAfterTest, burn.Namespace)
 		})
 
-		It("Test GPU workload with single strategy MIG Configuration", Label("single-mig"), func() {
+		It("Test GPU workload with single strategy MIG Configuration", Label("single-mig"), func(ctx SpecContext) {
 			// Skip if single-mig label is not in the ginkgo label filter
-			if !mig.IsLabelInFilter("single-mig") {
+			if !shared.IsLabelInFilter("single-mig") {
 				glog.V(gpuparams.GpuLogLevel).Infof("Skipping test: 'single-mig' label not present in ginkgo label filter")
 				Skip("Test skipped: 'single-mig' label not present in ginkgo label filter")
 			}
-			mig.TestSingleMIGGPUWorkload(nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanupAfterTest)
+			shared.TestSingleMIGGPUWorkload(ctx, nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanupAfterTest)
 		})
 
-		It("Test GPU workload with mixed strategy MIG Configuration", Label("mixed-mig"), func() {
+		It("Test GPU workload with mixed strategy MIG Configuration", Label("mixed-mig"), func(ctx SpecContext) {
 			// Skip if mixed-mig label is not in the ginkgo label filter
-			if !mig.IsLabelInFilter("mixed-mig") {
+			if !shared.IsLabelInFilter("mixed-mig") {
 				glog.V(gpuparams.GpuLogLevel).Infof("Skipping test: 'mixed-mig' label not present in ginkgo label filter")
 				Skip("Test skipped: 'mixed-mig' label not present in ginkgo label filter")
 			}
-			mig.TestMixedMIGGPUWorkload(nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanupAfterTest)
+			shared.TestMixedMIGGPUWorkload(ctx, nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanupAfterTest)
+		})
+
+		It("Test GPU workload with a heterogeneous custom MIG configuration", Label("heterogeneous-mig"), func(ctx SpecContext) {
+			// Skip if heterogeneous-mig label is not in the ginkgo label filter
+			if !shared.IsLabelInFilter("heterogeneous-mig") {
+				glog.V(gpuparams.GpuLogLevel).Infof("Skipping test: 'heterogeneous-mig' label not present in ginkgo label filter")
+				Skip("Test skipped: 'heterogeneous-mig' label not present in ginkgo label filter")
+			}
+			shared.TestHeterogeneousMIGLayout(ctx, nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanupAfterTest)
 		})
 
 	})
@@ -114,5 +124,5 @@ func ReportOpenShiftVersionAndEnsureNFD(nfdInstance *operatorconfig.CustomConfig
 		glog.Error("Error writing an OpenShift version file: ", err)
 	}
 
-	nfd.EnsureNFDIsInstalled(inittools.APIClient, nfdInstance, ocpVersion, gpuparams.GpuLogLevel)
+	shared.EnsureNFDIsInstalled(inittools.APIClient, nfdInstance, ocpVersion, gpuparams.GpuLogLevel)
 }
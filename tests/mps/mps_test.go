@@ -15,6 +15,7 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/mps"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/nvidiagpuconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/tsparams"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/configmap"
 
@@ -234,44 +235,21 @@ var _ = Describe("MPS", Ordered, Label(tsparams.LabelSuite), func() {
 			Expect(configMap).ToNot(BeNil())
 
 			EnsureAllGpuPodsAreRunning()
-			testPodsCM, err := mps.CreateWorkerPodConfigMap(inittools.APIClient, TestNamespace)
-			Expect(err).ToNot(HaveOccurred(), "error creating MPS worker ConfigMap: %v", err)
-			DeferCleanup(func() {
-				err = testPodsCM.Delete()
-				glog.Error("failed deleteing mps worker config map %v", err)
-			})
-			// Create and run multiple worker pods
+			// Create and run multiple worker pods, the first one also creates the entrypoint ConfigMap
+			workerGroup := testworkloads.NewGroup(inittools.APIClient, TestNamespace)
 			for i := 0; i < NumWorkerPods; i++ {
 				workerPodName := fmt.Sprintf("mps-worker-%d", i)
+				workerGroup.Add(workerPodName, mps.NewWorkload(workerPodName, MPSImage))
+			}
 
-				// Create MPS worker pod
-				workerPod, err := mps.CreateMPSTestPod(
-					inittools.APIClient,
-					workerPodName,
-					TestNamespace,
-					MPSImage,
-				)
-				Expect(err).ToNot(HaveOccurred(), "error creating MPS worker pod %s: %v", workerPodName, err)
-				Expect(workerPod).ToNot(BeNil())
-
-				// Create pod in cluster
-				_, err = inittools.APIClient.Pods(TestNamespace).Create(context.TODO(), workerPod, metav1.CreateOptions{})
-				Expect(err).ToNot(HaveOccurred(), "error creating worker pod %s in cluster: %v", workerPodName, err)
-
-				// Cleanup worker pod
-				DeferCleanup(func() {
-					podBuilder, err := pod.Pull(inittools.APIClient, workerPodName, TestNamespace)
-					if err != nil {
-						glog.Errorf("Error pulling pod %s: %v", workerPodName, err)
-					} else {
-						_, err = podBuilder.Delete()
-						if err != nil {
-							glog.Errorf("Error deleting pod %s: %v", workerPodName, err)
-						}
-					}
-				})
+			// Cleanup worker pods
+			DeferCleanup(func() {
+				if err := workerGroup.Delete(); err != nil {
+					glog.Errorf("Error deleting MPS worker pods: %v", err)
+				}
+			})
 
-			}
+			Expect(workerGroup.Create().Error()).ToNot(HaveOccurred(), "error creating MPS worker pods")
 
 			// Wait for worker pods to run for a while
 			glog.V(gpuparams.GpuLogLevel).Infof("Waiting for worker pods to run for 2 minutes...")
@@ -299,6 +277,22 @@ var _ = Describe("MPS", Ordered, Label(tsparams.LabelSuite), func() {
 				return nil
 			}, TestDuration, TimeStep).Should(Succeed(), "Not enough worker pods are running")
 
+			// The entrypoint does not check the GPUs, nvidia-smi in the running worker pods does
+			workerPods, err := inittools.APIClient.Pods(TestNamespace).List(context.TODO(), metav1.ListOptions{
+				LabelSelector: "app=mps-test-app",
+			})
+			Expect(err).ToNot(HaveOccurred(), "error listing MPS worker pods: %v", err)
+
+			for _, workerPod := range workerPods.Items {
+				workerBuilder := workerGroup.Builder(workerPod.Name)
+				if workerBuilder == nil || workerPod.Status.Phase != corev1.PodRunning {
+					continue
+				}
+
+				Expect(workerBuilder.CheckGPUs().Error()).ToNot(HaveOccurred(),
+					"MPS worker pod %s does not see any GPU", workerPod.Name)
+			}
+
 			// Get NVIDIA driver pods from the GPU operator namespace
 			driverPods, err := inittools.APIClient.Pods(GPUOperatorNamespace).List(context.TODO(), metav1.ListOptions{
 				LabelSelector: "app.kubernetes.io/component=nvidia-driver",
@@ -3,12 +3,11 @@ package nvidiagpu
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
-	"strings"
 	"time"
 
-	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
 	nvidiagpuv1alpha1 "github.com/NVIDIA/k8s-operator-libs/api/upgrade/v1alpha1"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/networkparams"
@@ -20,7 +19,6 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/machine"
 
 	nfd "github.com/rh-ecosystem-edge/nvidia-ci/pkg/nfd"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nfdcheck"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/operatorconfig"
 
@@ -39,11 +37,10 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/get"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuburn"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/tsparams"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/wait"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/mig"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/rh-ecosystem-edge/nvidia-ci/tests/shared"
 )
 
 var (
@@ -70,6 +67,9 @@ var (
 	nvidiaGPUConfig *nvidiagpuconfig.NvidiaGPUConfig
 	nfdConfig       *internalNFD.NFDConfig
 
+	// burnThresholds are the limits gpu-burn results are validated against.
+	burnThresholds gpuburn.Thresholds
+
 	ScaleCluster  = false
 	CatalogSource = UndefinedValue
 
@@ -84,11 +84,17 @@ var (
 	OperatorUpgradeToChannel   = UndefinedValue
 	cleanupAfterTest           = true
 	deployFromBundle           = false
+	installWithOLMv1           = false
+	ClusterCatalog             = olm.DefaultClusterCatalog
 	operatorBundleImage        = ""
 	CurrentCSV                 = ""
 	CurrentCSVVersion          = ""
 	clusterArchitecture        = UndefinedValue
 	labelsToCheck              = []string{}
+
+	// gpuBundleInstaller is set when the GPU operator was deployed from a bundle, for the uninstall to delete the
+	// resources serving the bundle.
+	gpuBundleInstaller *olm.BundleInstaller
 )
 
 var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
@@ -145,6 +151,9 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 					" is set to '%s'", nvidiaGPUConfig.ClusterPolicyPatch)
 			}
 
+			burnThresholds = nvidiaGPUConfig.GPUBurnThresholds()
+			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn thresholds: %+v", burnThresholds)
+
 			cleanupAfterTest = nvidiaGPUConfig.CleanupAfterTest
 			glog.V(0).Infof("CleanupAfterTest: %v", cleanupAfterTest)
 
@@ -152,7 +161,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			labelsToCheck = []string{"operator-upgrade", "single-mig", "mixed-mig"}
 			glog.V(0).Infof("LabelsToCheck: %v", labelsToCheck)
 
-			if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
+			if cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck) {
 				glog.V(gpuparams.GpuLogLevel).Info("NVIDIAGPU_CLEANUP is not set or is set to true; cleaning up resources after test case execution.")
 			} else {
 				glog.V(gpuparams.GpuLogLevel).Infof("NVIDIAGPU_CLEANUP is set to '%v'; skipping cleanup after test case execution.", cleanupAfterTest)
@@ -179,6 +188,18 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				deployFromBundle = false
 			}
 
+			if nvidiaGPUConfig.InstallWithOLMv1 {
+				installWithOLMv1 = nvidiaGPUConfig.InstallWithOLMv1
+				glog.V(gpuparams.GpuLogLevel).Infof("Flag install GPU operator with OLM v1 is set to env "+
+					"variable NVIDIAGPU_INSTALL_WITH_OLMV1 value '%v'", installWithOLMv1)
+
+				if nvidiaGPUConfig.ClusterCatalog != "" {
+					ClusterCatalog = nvidiaGPUConfig.ClusterCatalog
+					glog.V(gpuparams.GpuLogLevel).Infof("GPU clustercatalog now set to env variable "+
+						"NVIDIAGPU_CLUSTERCATALOG value '%s'", ClusterCatalog)
+				}
+			}
+
 			if nvidiaGPUConfig.OperatorUpgradeToChannel == "" {
 				glog.V(gpuparams.GpuLogLevel).Infof("env variable NVIDIAGPU_SUBSCRIPTION_UPGRADE_TO_CHANNEL" +
 					" is not set, will not run the Upgrade Testcase")
@@ -223,6 +244,12 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 				nfdInstance.CreateCustomCatalogsource = true
 
+				catalogSourceOptions, err := nfdConfig.FallbackCatalogSourceOptions()
+				Expect(err).ToNot(HaveOccurred(), "Error reading the NFD custom catalogsource options: %v", err)
+
+				nfdInstance.CustomCatalogSourceOptions = catalogSourceOptions
+				nfdInstance.CustomCatalogSourcePackageTimeout = nfdConfig.FallbackCatalogSourcePackageTimeout
+
 				nfdInstance.CustomCatalogSource = nfd.CatalogSourceDefault + "-custom"
 				glog.V(gpuparams.GpuLogLevel).Infof("Setting custom NFD catalogsource name to '%s'",
 					nfdInstance.CustomCatalogSource)
@@ -243,11 +270,11 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				glog.Error("Error writing an OpenShift version file: ", err)
 			}
 
-			nfd.EnsureNFDIsInstalled(inittools.APIClient, nfdInstance, ocpVersion, gpuparams.GpuLogLevel)
+			shared.EnsureNFDIsInstalled(inittools.APIClient, nfdInstance, ocpVersion, gpuparams.GpuLogLevel)
 
-			if mig.IsLabelInFilter("single-mig") || mig.IsLabelInFilter("mixed-mig") {
-				mig.ParseCLIParameters()
-				mig.LogCLIParameterValues()
+			if shared.IsLabelInFilter("single-mig") || shared.IsLabelInFilter("mixed-mig") {
+				shared.ParseCLIParameters()
+				shared.LogCLIParameterValues()
 			}
 		})
 
@@ -261,21 +288,21 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 		})
 
-		AfterAll(func() {
+		AfterAll(func(ctx SpecContext) {
 			glog.V(gpuparams.Gpu10LogLevel).Infof("cleanup in AfterAll")
 			if nfdInstance.CleanupAfterInstall && cleanupAfterTest {
-				err := nfd.Cleanup(inittools.APIClient)
-				Expect(err).ToNot(HaveOccurred(), "Error cleaning up NFD resources: %v", err)
+				err := nfd.UninstallNFD(ctx, inittools.APIClient, inittools.GeneralConfig.StrictUninstall)
+				Expect(err).ToNot(HaveOccurred(), "Error uninstalling NFD Operator: %v", err)
 			}
 			// Cleanup GPU Operator Resources, if requested
 			if cleanupAfterTest {
-				cleanupGPUOperatorResources()
+				cleanupGPUOperatorResources(ctx)
 			}
 		})
 
-		It("Deploy NVIDIA GPU Operator with DTK", Label("nvidia-ci:gpu"), func() {
+		It("Deploy NVIDIA GPU Operator with DTK", Label("nvidia-ci:gpu"), func(ctx SpecContext) {
 
-			nfdcheck.CheckNfdInstallation(inittools.APIClient, nfd.OSLabel, nfd.GetAllowedOSLabels(), inittools.GeneralConfig.WorkerLabelMap, networkparams.LogLevel)
+			shared.CheckNfdInstallation(inittools.APIClient, nfd.OSLabel, nfd.GetAllowedOSLabels(), inittools.GeneralConfig.WorkerLabelMap, networkparams.LogLevel)
 
 			By("Check if at least one worker node is GPU enabled")
 			gpuNodeFound, _ := check.NodeWithLabel(inittools.APIClient, nvidiagpu.NvidiaGPULabel, inittools.GeneralConfig.WorkerLabelMap)
@@ -338,7 +365,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 				defer func() {
 					defer GinkgoRecover()
-					if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
+					if cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck) {
 						err := pulledMachineSetBuilder.Delete()
 						Expect(err).ToNot(HaveOccurred())
 					}
@@ -362,302 +389,211 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(gpuparams.GpuLogLevel).Infof("cluster architecture for GPU enabled worker node is: %s",
 				clusterArchitecture)
 
-			By("Check if GPU Operator Deployment is from Bundle")
-			if deployFromBundle {
-				// This returns the Deploy interface object initialized with the API client
-				deployBundle = deploy.NewDeploy(inittools.APIClient)
-				deployBundleConfig.BundleImage = operatorBundleImage
-				glog.V(gpuparams.GpuLogLevel).Infof("Deploying GPU operator from bundle image '%s'",
-					deployBundleConfig.BundleImage)
-			} else {
-				glog.V(gpuparams.GpuLogLevel).Infof("Deploying GPU operator from catalogsource")
-
-				By("Check if GPU packagemanifest exists in default GPU catalog")
-				glog.V(gpuparams.GpuLogLevel).Infof("Using default GPU catalogsource '%s'",
-					nvidiagpu.CatalogSourceDefault)
+			gpuNamespaceLabels := map[string]string{
+				"openshift.io/cluster-monitoring":    "true",
+				"pod-security.kubernetes.io/enforce": "privileged",
+			}
 
-				gpuPkgManifestBuilderByCatalog, err := olm.PullPackageManifestByCatalog(inittools.APIClient,
-					nvidiagpu.Package, nvidiagpu.CatalogSourceNamespace, nvidiagpu.CatalogSourceDefault)
+			By("Configure the GPU Operator installer")
+			gpuInstaller := olm.NewOperatorInstaller(inittools.APIClient, nvidiagpu.Package,
+				nvidiagpu.NvidiaGPUNamespace).
+				WithCatalogSourceNamespace(nvidiagpu.CatalogSourceNamespace).
+				WithCatalogSources(CatalogSource).
+				WithNamespaceLabels(gpuNamespaceLabels).
+				WithOperatorGroupName(nvidiagpu.OperatorGroupName).
+				WithSubscriptionName(nvidiagpu.SubscriptionName).
+				WithInstallPlanApproval(InstallPlanApproval).
+				WithPackageManifestTimeout(nvidiagpu.PackageManifestCheckInterval, nvidiagpu.PackageManifestTimeout).
+				WithCSVTimeout(nvidiagpu.CsvSucceededCheckInterval, nvidiagpu.CsvSucceededTimeout)
+
+			if SubscriptionChannel != UndefinedValue {
+				glog.V(gpuparams.GpuLogLevel).Infof("Setting the subscription channel to: '%s'",
+					SubscriptionChannel)
+				gpuInstaller.WithChannel(SubscriptionChannel)
+			}
 
-				if err != nil {
-					glog.V(gpuparams.GpuLogLevel).Infof("Error trying to pull GPU packagemanifest '%s' from"+
-						" default catalog '%s': '%v'", nvidiagpu.Package, nvidiagpu.CatalogSourceDefault, err.Error())
+			if createGPUCustomCatalogsource {
+				glog.V(gpuparams.GpuLogLevel).Infof("Falling back to custom catalogsource '%s' for GPU Operator, "+
+					"with index image '%s'", CustomCatalogSource, CustomCatalogsourceIndexImage)
+				catalogSourceOptions, err := nvidiaGPUConfig.GPUFallbackCatalogSourceOptions()
+				Expect(err).ToNot(HaveOccurred(), "Error reading the custom catalogsource options: %v", err)
+
+				gpuInstaller.WithCustomCatalogSourceBuilder(olm.NewCatalogSourceBuilderWithIndexImage(
+					inittools.APIClient, CustomCatalogSource, nvidiagpu.CatalogSourceNamespace,
+					CustomCatalogsourceIndexImage, nvidiagpu.CustomCatalogSourceDisplayName,
+					nvidiagpu.CustomCatalogSourcePublisherName).WithOptions(catalogSourceOptions)).
+					WithCatalogSourceReadyTimeout(nvidiagpu.CatalogSourceReadyTimeout)
+
+				if nvidiaGPUConfig.GPUFallbackCatalogsourcePackageTimeout > 0 {
+					gpuInstaller.WithPackageManifestTimeout(nvidiagpu.PackageManifestCheckInterval,
+						nvidiaGPUConfig.GPUFallbackCatalogsourcePackageTimeout)
 				}
+			}
 
-				if gpuPkgManifestBuilderByCatalog == nil {
-					glog.V(gpuparams.GpuLogLevel).Infof("The GPU packagemanifest '%s' was not "+
-						"found in the default '%s' catalog", nvidiagpu.Package, nvidiagpu.CatalogSourceDefault)
+			defer func() {
+				defer GinkgoRecover()
+				if cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck) {
+					By("Uninstalling GPU Operator and verifying the cluster is clean")
+					err := nvidiagpu.UninstallGPUOperator(ctx, inittools.APIClient, gpuBundleInstaller,
+						inittools.GeneralConfig.StrictUninstall)
+					Expect(err).ToNot(HaveOccurred(), "Error uninstalling GPU Operator: %v", err)
+				}
+			}()
 
-					if createGPUCustomCatalogsource {
-						glog.V(gpuparams.GpuLogLevel).Infof("Creating custom catalogsource '%s' for GPU Operator, "+
-							"with index image '%s'", CustomCatalogSource, CustomCatalogsourceIndexImage)
+			var almExamples string
 
-						glog.V(gpuparams.GpuLogLevel).Infof("Deploying a custom GPU catalogsource '%s' with '%s' "+
-							"index image", CustomCatalogSource, CustomCatalogsourceIndexImage)
+			if installWithOLMv1 {
+				By("Deploy the GPU Operator through an OLM v1 ClusterExtension")
+				glog.V(gpuparams.GpuLogLevel).Infof("Deploying GPU operator from clustercatalog '%s'", ClusterCatalog)
 
-						gpuCustomCatalogSourceBuilder := olm.NewCatalogSourceBuilderWithIndexImage(inittools.APIClient,
-							CustomCatalogSource, nvidiagpu.CatalogSourceNamespace, CustomCatalogsourceIndexImage,
-							nvidiagpu.CustomCatalogSourceDisplayName, nvidiagpu.CustomCatalogSourcePublisherName)
+				gpuExtensionInstaller := olm.NewExtensionInstaller(inittools.APIClient, nvidiagpu.Package,
+					nvidiagpu.NvidiaGPUNamespace).
+					WithExtensionName(nvidiagpu.ClusterExtensionName).
+					WithNamespaceLabels(gpuNamespaceLabels).
+					WithCatalogs(ClusterCatalog).
+					WithWatchNamespace(nvidiagpu.NvidiaGPUNamespace).
+					WithInstallTimeout(nvidiagpu.CsvSucceededCheckInterval, nvidiagpu.CsvSucceededTimeout)
 
-						Expect(gpuCustomCatalogSourceBuilder).NotTo(BeNil(), "Failed to Initialize "+
-							"CatalogSourceBuilder for custom GPU catalogsource '%s'", CustomCatalogSource)
+				if SubscriptionChannel != UndefinedValue {
+					gpuExtensionInstaller.WithChannel(SubscriptionChannel)
+				}
 
-						createdGPUCustomCatalogSourceBuilder, err := gpuCustomCatalogSourceBuilder.Create()
-						glog.V(gpuparams.GpuLogLevel).Infof("Creating custom GPU Catalogsource builder object "+
-							"'%s'", createdGPUCustomCatalogSourceBuilder.Definition.Name)
-						Expect(err).ToNot(HaveOccurred(), "error creating custom GPU catalogsource "+
-							"builder Object name %s:  %v", CustomCatalogSource, err)
+				gpuExtension, err := gpuExtensionInstaller.InstallContext(ctx)
+				Expect(err).ToNot(HaveOccurred(), "error installing the GPU operator with OLM v1:  %v", err)
 
-						By(fmt.Sprintf("Sleep for %s to allow the GPU custom catalogsource to be created", nvidiagpu.CatalogSourceCreationDelay))
-						time.Sleep(nvidiagpu.CatalogSourceCreationDelay)
+				CurrentCSV, CurrentCSVVersion, err = gpuExtension.InstalledBundle()
+				Expect(err).ToNot(HaveOccurred(), "error getting the bundle installed by the GPU operator "+
+					"clusterextension:  %v", err)
 
-						glog.V(gpuparams.GpuLogLevel).Infof("Wait up to %s for custom GPU catalogsource to be ready", nvidiagpu.CatalogSourceReadyTimeout)
+				glog.V(gpuparams.GpuLogLevel).Infof("Installed bundle is: '%s', version '%s'", CurrentCSV,
+					CurrentCSVVersion)
 
-						Expect(createdGPUCustomCatalogSourceBuilder.IsReady(nvidiagpu.CatalogSourceReadyTimeout)).NotTo(BeFalse())
+				if err := inittools.GeneralConfig.WriteReport(OperatorVersionFile,
+					[]byte(fmt.Sprintf("%s(olmv1)", CurrentCSVVersion))); err != nil {
+					glog.Error("Error writing an operator version file: ", err)
+				}
 
-						CatalogSource = createdGPUCustomCatalogSourceBuilder.Definition.Name
+				waitForGPUOperatorDeployment(ctx)
 
-						glog.V(gpuparams.GpuLogLevel).Infof("Custom GPU catalogsource '%s' is now ready",
-							createdGPUCustomCatalogSourceBuilder.Definition.Name)
+				By("Get ALM examples block from the GPU operator deployment")
+				almExamples, err = gpuExtension.GetAlmExamplesContext(ctx)
+				Expect(err).ToNot(HaveOccurred(), "Error from getting almExamples from the GPU operator "+
+					"deployment:  %v ", err)
+				glog.V(gpuparams.GpuLogLevel).Infof("almExamples block from the GPU operator deployment is : %v ",
+					almExamples)
+			} else {
+				var csvBuilder *olm.ClusterServiceVersionBuilder
 
-						gpuPkgManifestBuilderByCustomCatalog, err := olm.PullPackageManifestByCatalogWithTimeout(inittools.APIClient,
-							nvidiagpu.Package, nvidiagpu.CatalogSourceNamespace, CustomCatalogSource,
-							nvidiagpu.PackageManifestCheckInterval, nvidiagpu.PackageManifestTimeout)
+				By("Check if GPU Operator Deployment is from Bundle")
+				if deployFromBundle {
+					// This returns the Deploy interface object initialized with the API client
+					deployBundle = deploy.NewDeploy(inittools.APIClient)
+					deployBundleConfig.BundleImage = operatorBundleImage
+					deployBundleConfig.RegistryImage = nvidiaGPUConfig.BundleRegistryImage
 
-						Expect(err).ToNot(HaveOccurred(), "error getting GPU packagemanifest '%s' "+
-							"from custom catalog '%s':  %v", nvidiagpu.Package, CustomCatalogSource, err)
+					By("Check if NVIDIA GPU Operator namespace exists, otherwise created it and label it")
+					_, err = gpuInstaller.EnsureNamespaceContext(ctx)
+					Expect(err).ToNot(HaveOccurred(), "error creating namespace '%s' :  %v ",
+						nvidiagpu.NvidiaGPUNamespace, err)
 
-						By("Get the GPU Default Channel from Packagemanifest")
-						DefaultSubscriptionChannel = gpuPkgManifestBuilderByCustomCatalog.Object.Status.DefaultChannel
-						glog.V(gpuparams.GpuLogLevel).Infof("GPU channel '%s' retrieved from packagemanifest "+
-							"of custom catalogsource '%s'", DefaultSubscriptionChannel, CustomCatalogSource)
+					glog.V(gpuparams.GpuLogLevel).Infof("Deploy the GPU Operator bundle image '%s'",
+						deployBundleConfig.BundleImage)
 
-					} else {
-						Skip("gpu-operator-certified packagemanifest not found in default 'certified-operators'" +
-							"catalogsource, and flag to deploy custom GPU catalogsource is false")
-					}
+					gpuBundleInstaller, err = deployBundle.DeployBundle(ctx, gpuparams.GpuLogLevel,
+						&deployBundleConfig, nvidiagpu.NvidiaGPUNamespace, nvidiagpu.GpuBundleDeploymentTimeout)
+					shared.WriteCSVDiagnosisReport(err, "gpu-")
+					Expect(err).ToNot(HaveOccurred(), "error from deploy.DeployBundle():  '%v' ", err)
 
-				} else {
-					glog.V(gpuparams.GpuLogLevel).Infof("GPU packagemanifest '%s' was found in the default"+
-						" catalog '%s'", gpuPkgManifestBuilderByCatalog.Object.Name, nvidiagpu.CatalogSourceDefault)
+					glog.V(gpuparams.GpuLogLevel).Infof("GPU Operator bundle image '%s' deployed successfully "+
+						"in namespace '%s", deployBundleConfig.BundleImage, nvidiagpu.NvidiaGPUNamespace)
 
-					CatalogSource = nvidiagpu.CatalogSourceDefault
+					waitForGPUOperatorDeployment(ctx)
 
-					By("Get the GPU Default Channel from Packagemanifest")
-					DefaultSubscriptionChannel = gpuPkgManifestBuilderByCatalog.Object.Status.DefaultChannel
-					glog.V(gpuparams.GpuLogLevel).Infof("GPU channel '%s' was retrieved from GPU packagemanifest",
-						DefaultSubscriptionChannel)
-				}
+					By("Get the CSV deployed in NVIDIA GPU Operator namespace")
+					csvBuilderList, err := olm.ListClusterServiceVersion(inittools.APIClient, nvidiagpu.NvidiaGPUNamespace)
 
-			}
+					Expect(err).ToNot(HaveOccurred(), "Error getting list of CSVs in GPU operator "+
+						"namespace: '%v'", err)
+					Expect(csvBuilderList).To(HaveLen(1), "Exactly one GPU operator CSV is expected")
 
-			By("Check if NVIDIA GPU Operator namespace exists, otherwise created it and label it")
-			nsBuilder := namespace.NewBuilder(inittools.APIClient, nvidiagpu.NvidiaGPUNamespace)
-			if nsBuilder.Exists() {
-				glog.V(gpuparams.GpuLogLevel).Infof("The namespace '%s' already exists",
-					nsBuilder.Object.Name)
-			} else {
-				glog.V(gpuparams.GpuLogLevel).Infof("Creating the namespace:  %v", nvidiagpu.NvidiaGPUNamespace)
-				createdNsBuilder, err := nsBuilder.Create()
-				Expect(err).ToNot(HaveOccurred(), "error creating namespace '%s' :  %v ",
-					nsBuilder.Definition.Name, err)
+					csvBuilder = csvBuilderList[0]
+				} else {
+					By("Deploy the GPU Operator from catalogsource")
+					glog.V(gpuparams.GpuLogLevel).Infof("Deploying GPU operator from catalogsource '%s'", CatalogSource)
 
-				glog.V(gpuparams.GpuLogLevel).Infof("Successfully created namespace '%s'",
-					createdNsBuilder.Object.Name)
+					csvBuilder, err = gpuInstaller.InstallContext(ctx)
+					if errors.Is(err, olm.ErrPackageNotFound) {
+						Skip(fmt.Sprintf("gpu-operator-certified packagemanifest not found in catalogsource '%s', "+
+							"and flag to deploy custom GPU catalogsource is false", CatalogSource))
+					}
 
-				glog.V(gpuparams.GpuLogLevel).Infof("Labeling the newly created namespace '%s'",
-					nsBuilder.Object.Name)
+					shared.WriteCSVDiagnosisReport(err, "gpu-")
+					Expect(err).ToNot(HaveOccurred(), "error installing the GPU operator:  %v", err)
 
-				labeledNsBuilder := createdNsBuilder.WithMultipleLabels(map[string]string{
-					"openshift.io/cluster-monitoring":    "true",
-					"pod-security.kubernetes.io/enforce": "privileged",
-				})
-
-				newLabeledNsBuilder, err := labeledNsBuilder.Update()
-				Expect(err).ToNot(HaveOccurred(), "error labeling namespace %v :  %v ",
-					newLabeledNsBuilder.Definition.Name, err)
+					CatalogSource = gpuInstaller.CatalogSource
+					DefaultSubscriptionChannel = gpuInstaller.PackageManifest.Object.Status.DefaultChannel
 
-				glog.V(gpuparams.GpuLogLevel).Infof("The nvidia-gpu-operator labeled namespace has "+
-					"labels:  %v", newLabeledNsBuilder.Object.Labels)
-			}
-
-			defer func() {
-				defer GinkgoRecover()
-				if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
-					err := nsBuilder.Delete()
-					Expect(err).ToNot(HaveOccurred())
+					glog.V(gpuparams.GpuLogLevel).Infof("GPU operator installed from catalogsource '%s' on channel "+
+						"'%s'", CatalogSource, gpuInstaller.Channel)
 				}
-			}()
-
-			// Namespace needed to be created by this point or checked if created
-			if deployFromBundle {
-				deployBundleConfig.BundleImage = operatorBundleImage
 
-				glog.V(gpuparams.GpuLogLevel).Infof("Deploy the GPU Operator bundle image '%s'",
-					deployBundleConfig.BundleImage)
+				CurrentCSV = csvBuilder.Definition.Name
+				glog.V(gpuparams.GpuLogLevel).Infof("Deployed ClusterServiceVersion is: '%s", CurrentCSV)
 
-				err = deployBundle.DeployBundle(gpuparams.GpuLogLevel, &deployBundleConfig, nvidiagpu.NvidiaGPUNamespace,
-					nvidiagpu.GpuBundleDeploymentTimeout)
-				Expect(err).ToNot(HaveOccurred(), "error from deploy.DeployBundle():  '%v' ", err)
+				CurrentCSVVersion = csvBuilder.Definition.Spec.Version.String()
+				csvVersionString := CurrentCSVVersion
 
-				glog.V(gpuparams.GpuLogLevel).Infof("GPU Operator bundle image '%s' deployed successfully "+
-					"in namespace '%s", deployBundleConfig.BundleImage, nvidiagpu.NvidiaGPUNamespace)
-			} else {
-				By("Create OperatorGroup in NVIDIA GPU Operator Namespace")
-				ogBuilder := olm.NewOperatorGroupBuilder(inittools.APIClient, nvidiagpu.OperatorGroupName, nvidiagpu.NvidiaGPUNamespace)
-				if ogBuilder.Exists() {
-					glog.V(gpuparams.GpuLogLevel).Infof("The ogBuilder that exists has name:  %v",
-						ogBuilder.Object.Name)
-				} else {
-					glog.V(gpuparams.GpuLogLevel).Infof("Create a new operatorgroup with name:  %v",
-						ogBuilder.Object.Name)
-
-					ogBuilderCreated, err := ogBuilder.Create()
-					Expect(err).ToNot(HaveOccurred(), "error creating operatorgroup %v :  %v ",
-						ogBuilderCreated.Definition.Name, err)
+				if deployFromBundle {
+					csvVersionString = fmt.Sprintf("%s(bundle)", csvBuilder.Definition.Spec.Version.String())
 				}
 
-				defer func() {
-					defer GinkgoRecover()
-					if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
-						err := ogBuilder.Delete()
-						Expect(err).ToNot(HaveOccurred())
-					}
-				}()
-
-				By("Create Subscription in NVIDIA GPU Operator Namespace")
-				subBuilder := olm.NewSubscriptionBuilder(inittools.APIClient, nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace,
-					CatalogSource, nvidiagpu.CatalogSourceNamespace, nvidiagpu.Package)
+				glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion version to be written in the operator "+
+					"version file is: '%s'", csvVersionString)
 
-				if SubscriptionChannel != UndefinedValue {
-					glog.V(gpuparams.GpuLogLevel).Infof("Setting the subscription channel to: '%s'",
-						SubscriptionChannel)
-					subBuilder.WithChannel(SubscriptionChannel)
-				} else {
-					glog.V(gpuparams.GpuLogLevel).Infof("Setting the subscription channel to default channel: '%s'",
-						DefaultSubscriptionChannel)
-					subBuilder.WithChannel(DefaultSubscriptionChannel)
+				if err := inittools.GeneralConfig.WriteReport(OperatorVersionFile, []byte(csvVersionString)); err != nil {
+					glog.Error("Error writing an operator version file: ", err)
 				}
 
-				subBuilder.WithInstallPlanApproval(InstallPlanApproval)
+				By("Wait for deployed ClusterServiceVersion to be in Succeeded phase")
+				glog.V(gpuparams.GpuLogLevel).Infof("Waiting for ClusterServiceVersion '%s' to be in Succeeded phase",
+					CurrentCSV)
+				err = wait.CSVSucceededContext(ctx, inittools.APIClient, CurrentCSV, nvidiagpu.NvidiaGPUNamespace,
+					nvidiagpu.CsvSucceededCheckInterval, nvidiagpu.CsvSucceededTimeout)
+				glog.V(gpuparams.GpuLogLevel).Info("error waiting for ClusterServiceVersion '%s' to be "+
+					"in Succeeded phase:  %v ", CurrentCSV, err)
 
-				glog.V(gpuparams.GpuLogLevel).Infof("Creating the subscription, i.e Deploy the GPU operator")
-				createdSub, err := subBuilder.Create()
+				Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterServiceVersion to be "+
+					"in Succeeded phase: ", err)
 
-				Expect(err).ToNot(HaveOccurred(), "error creating subscription %v :  %v ",
-					createdSub.Definition.Name, err)
+				By("Pull existing CSV in NVIDIA GPU Operator Namespace")
+				clusterCSV, err := olm.PullClusterServiceVersion(inittools.APIClient, CurrentCSV, nvidiagpu.NvidiaGPUNamespace)
+				Expect(err).ToNot(HaveOccurred(), "error pulling CSV from cluster:  %v", err)
 
-				glog.V(gpuparams.GpuLogLevel).Infof("Newly created subscription: %s was successfully created",
-					createdSub.Object.Name)
+				glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster lastUpdatedTime is : %v ",
+					clusterCSV.Definition.Status.LastUpdateTime)
 
-				if createdSub.Exists() {
-					glog.V(gpuparams.GpuLogLevel).Infof("The newly created subscription '%s' in namespace '%v' "+
-						"has current CSV  '%v'", createdSub.Object.Name, createdSub.Object.Namespace,
-						createdSub.Object.Status.CurrentCSV)
-				}
+				glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
+					clusterCSV.Definition.Status.Phase)
+
+				succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
+				Expect(clusterCSV.Definition.Status.Phase).To(Equal(succeeded), "CSV Phase is not "+
+					"succeeded")
 
 				defer func() {
 					defer GinkgoRecover()
-					if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
-						err := createdSub.Delete()
+					if cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck) {
+						err := clusterCSV.Delete()
 						Expect(err).ToNot(HaveOccurred())
 					}
 				}()
 
+				By("Get ALM examples block form CSV")
+				almExamples, err = clusterCSV.GetAlmExamples()
+				Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from csv "+
+					"from cluster:  %v ", err)
+				glog.V(gpuparams.GpuLogLevel).Infof("almExamples block from clusterCSV  is : %v ", almExamples)
 			}
 
-			By(fmt.Sprintf("Sleep for %s to allow the GPU Operator deployment to be created", nvidiagpu.OperatorDeploymentCreationDelay))
-			glog.V(gpuparams.GpuLogLevel).Infof("Sleep for %s to allow the GPU Operator deployment to be created", nvidiagpu.OperatorDeploymentCreationDelay)
-			time.Sleep(nvidiagpu.OperatorDeploymentCreationDelay)
-
-			By(fmt.Sprintf("Wait for up to %s for GPU Operator deployment to be created", nvidiagpu.DeploymentCreationTimeout))
-			gpuDeploymentCreated := wait.DeploymentCreated(
-				inittools.APIClient,
-				nvidiagpu.OperatorDeployment,
-				nvidiagpu.NvidiaGPUNamespace,
-				nvidiagpu.DeploymentCreationCheckInterval,
-				nvidiagpu.DeploymentCreationTimeout)
-
-			Expect(gpuDeploymentCreated).ToNot(BeFalse(), "timed out waiting to deploy GPU operator")
-
-			By("Check if the GPU operator deployment is ready")
-			gpuOperatorDeployment, err := deployment.Pull(inittools.APIClient, nvidiagpu.OperatorDeployment, nvidiagpu.NvidiaGPUNamespace)
-
-			Expect(err).ToNot(HaveOccurred(), "Error trying to pull GPU operator "+
-				"deployment is: %v", err)
-
-			glog.V(gpuparams.GpuLogLevel).Infof("Pulled GPU operator deployment is:  %v ",
-				gpuOperatorDeployment.Definition.Name)
-
-			if gpuOperatorDeployment.IsReady(nvidiagpu.OperatorDeploymentReadyTimeout) {
-				glog.V(gpuparams.GpuLogLevel).Infof("Pulled GPU operator deployment '%s' is Ready",
-					gpuOperatorDeployment.Definition.Name)
-			}
-
-			By("Get the CSV deployed in NVIDIA GPU Operator namespace")
-			csvBuilderList, err := olm.ListClusterServiceVersion(inittools.APIClient, nvidiagpu.NvidiaGPUNamespace)
-
-			Expect(err).ToNot(HaveOccurred(), "Error getting list of CSVs in GPU operator "+
-				"namespace: '%v'", err)
-			Expect(csvBuilderList).To(HaveLen(1), "Exactly one GPU operator CSV is expected")
-
-			csvBuilder := csvBuilderList[0]
-
-			CurrentCSV = csvBuilder.Definition.Name
-			glog.V(gpuparams.GpuLogLevel).Infof("Deployed ClusterServiceVersion is: '%s", CurrentCSV)
-
-			CurrentCSVVersion = csvBuilder.Definition.Spec.Version.String()
-			csvVersionString := CurrentCSVVersion
-
-			if deployFromBundle {
-				csvVersionString = fmt.Sprintf("%s(bundle)", csvBuilder.Definition.Spec.Version.String())
-			}
-
-			glog.V(gpuparams.GpuLogLevel).Infof("ClusterServiceVersion version to be written in the operator "+
-				"version file is: '%s'", csvVersionString)
-
-			if err := inittools.GeneralConfig.WriteReport(OperatorVersionFile, []byte(csvVersionString)); err != nil {
-				glog.Error("Error writing an operator version file: ", err)
-			}
-
-			By("Wait for deployed ClusterServiceVersion to be in Succeeded phase")
-			glog.V(gpuparams.GpuLogLevel).Infof("Waiting for ClusterServiceVersion '%s' to be in Succeeded phase",
-				CurrentCSV)
-			err = wait.CSVSucceeded(inittools.APIClient, CurrentCSV, nvidiagpu.NvidiaGPUNamespace,
-				nvidiagpu.CsvSucceededCheckInterval, nvidiagpu.CsvSucceededTimeout)
-			glog.V(gpuparams.GpuLogLevel).Info("error waiting for ClusterServiceVersion '%s' to be "+
-				"in Succeeded phase:  %v ", CurrentCSV, err)
-			Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterServiceVersion to be "+
-				"in Succeeded phase: ", err)
-
-			By("Pull existing CSV in NVIDIA GPU Operator Namespace")
-			clusterCSV, err := olm.PullClusterServiceVersion(inittools.APIClient, CurrentCSV, nvidiagpu.NvidiaGPUNamespace)
-			Expect(err).ToNot(HaveOccurred(), "error pulling CSV from cluster:  %v", err)
-
-			glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster lastUpdatedTime is : %v ",
-				clusterCSV.Definition.Status.LastUpdateTime)
-
-			glog.V(gpuparams.GpuLogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
-				clusterCSV.Definition.Status.Phase)
-
-			succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
-			Expect(clusterCSV.Definition.Status.Phase).To(Equal(succeeded), "CSV Phase is not "+
-				"succeeded")
-
-			defer func() {
-				defer GinkgoRecover()
-				if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
-					err := clusterCSV.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				}
-			}()
-
-			By("Get ALM examples block form CSV")
-			almExamples, err := clusterCSV.GetAlmExamples()
-			Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from csv "+
-				"from cluster:  %v ", err)
-			glog.V(gpuparams.GpuLogLevel).Infof("almExamples block from clusterCSV  is : %v ", almExamples)
-
 			By("Deploy ClusterPolicy")
 
 			var clusterPolicyBuilder *nvidiagpu.Builder
@@ -677,7 +613,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 			defer func() {
 				defer GinkgoRecover()
-				if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
+				if cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck) {
 					_, err := createdClusterPolicyBuilder.Delete()
 					Expect(err).ToNot(HaveOccurred())
 				}
@@ -702,7 +638,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 			By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be ready", nvidiagpu.ClusterPolicyReadyTimeout))
 			glog.V(gpuparams.GpuLogLevel).Infof("Waiting up to %s for ClusterPolicy to be ready", nvidiagpu.ClusterPolicyReadyTimeout)
-			err = wait.ClusterPolicyReady(inittools.APIClient, nvidiagpu.ClusterPolicyName,
+			err = wait.ClusterPolicyReadyContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
 				nvidiagpu.ClusterPolicyReadyCheckInterval, nvidiagpu.ClusterPolicyReadyTimeout)
 
 			glog.V(gpuparams.GpuLogLevel).Infof("error waiting for ClusterPolicy to be Ready:  %v ", err)
@@ -726,6 +662,8 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 					err)
 			}
 
+			shared.WriteGPUInventory(WorkerNodeSelector, "nvidiagpu-")
+
 			By("Create GPU Burn namespace 'test-gpu-burn'")
 			gpuBurnNsBuilder := namespace.NewBuilder(inittools.APIClient, burn.Namespace)
 			if gpuBurnNsBuilder.Exists() {
@@ -759,7 +697,7 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 
 			defer func() {
 				defer GinkgoRecover()
-				if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
+				if cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck) {
 					err := gpuBurnNsBuilder.Delete()
 					Expect(err).ToNot(HaveOccurred())
 				}
@@ -782,101 +720,58 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				Expect(deleteErr).ToNot(HaveOccurred(), "Error deleting gpu-burn pod: %v", deleteErr)
 			}
 
-			By("Deploy GPU Burn configmap in test-gpu-burn namespace")
-			gpuBurnConfigMap, err := gpuburn.CreateGPUBurnConfigMap(inittools.APIClient, burn.ConfigMapName,
-				burn.Namespace)
-			Expect(err).ToNot(HaveOccurred(), "Error Creating gpu burn configmap: %v", err)
-
-			glog.V(gpuparams.GpuLogLevel).Infof("The created gpuBurnConfigMap has name: %s",
-				gpuBurnConfigMap.Name)
-
-			configmapBuilder, err := configmap.Pull(inittools.APIClient, burn.ConfigMapName, burn.Namespace)
-			Expect(err).ToNot(HaveOccurred(), "Error pulling gpu-burn configmap '%s' from "+
-				"namespace '%s': %v", burn.ConfigMapName, burn.Namespace, err)
-
-			glog.V(gpuparams.GpuLogLevel).Infof("The pulled gpuBurnConfigMap has name: %s",
-				configmapBuilder.Definition.Name)
-
-			defer func() {
-				defer GinkgoRecover()
-				if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
-					err := configmapBuilder.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				}
-			}()
-
-			By("Deploy gpu-burn pod in test-gpu-burn namespace")
+			By("Deploy gpu-burn configmap and pod in test-gpu-burn namespace")
 			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn pod image name is: '%s', in namespace '%s'",
 				BurnImageName[clusterArchitecture], burn.Namespace)
 
-			gpuBurnPod, err := gpuburn.CreateGPUBurnPod(inittools.APIClient, burn.PodName, burn.Namespace,
-				BurnImageName[(clusterArchitecture)], nvidiagpu.BurnPodCreationTimeout)
-			Expect(err).ToNot(HaveOccurred(), "Error creating gpu burn pod: %v", err)
-
-			glog.V(gpuparams.GpuLogLevel).Infof("Creating gpu-burn pod '%s' in namespace '%s'",
-				burn.Namespace, burn.Namespace)
-
-			_, err = inittools.APIClient.Pods(gpuBurnPod.Namespace).Create(context.TODO(), gpuBurnPod,
-				metav1.CreateOptions{})
-			Expect(err).ToNot(HaveOccurred(), "Error creating gpu-burn '%s' in "+
-				"namespace '%s': %v", burn.Namespace, burn.Namespace, err)
-
-			glog.V(gpuparams.GpuLogLevel).Infof("The created gpuBurnPod has name: %s has status: %v ",
-				gpuBurnPod.Name, gpuBurnPod.Status)
+			burnWorkload := gpuburn.NewWorkload(burn.PodName, BurnImageName[clusterArchitecture]).
+				WithConfigMapName(burn.ConfigMapName).
+				WithThresholds(burnThresholds)
 
-			By("Get the gpu-burn pod with label \"app=gpu-burn-app\"")
-			gpuPodName, err := get.GetFirstPodNameWithLabel(inittools.APIClient, burn.Namespace, burn.PodLabel)
-			Expect(err).ToNot(HaveOccurred(), "error getting gpu-burn pod with label "+
-				"'app=gpu-burn-app' from namespace '%s' :  %v ", burn.Namespace, err)
-			glog.V(gpuparams.GpuLogLevel).Infof("gpuPodName is %s ", gpuPodName)
-
-			By("Pull the gpu-burn pod object from the cluster")
-			gpuPodPulled, err := pod.Pull(inittools.APIClient, gpuPodName, burn.Namespace)
-			Expect(err).ToNot(HaveOccurred(), "error pulling gpu-burn pod from "+
-				"namespace '%s' :  %v ", burn.Namespace, err)
+			burnWorkloadBuilder := testworkloads.NewBuilder(inittools.APIClient, burn.Namespace, burnWorkload).Create()
+			Expect(burnWorkloadBuilder.Error()).ToNot(HaveOccurred(), "Error creating gpu-burn workload in "+
+				"namespace '%s': %v", burn.Namespace, burnWorkloadBuilder.Error())
 
-			By("Cleanup gpu-burn pod only if cleanupAfterTest is true and OperatorUpgradeToChannel is undefined")
+			By("Cleanup gpu-burn workload only if cleanupAfterTest is true and OperatorUpgradeToChannel is undefined")
 			defer func() {
 				defer GinkgoRecover()
-				if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) && OperatorUpgradeToChannel == UndefinedValue {
-					_, err := gpuPodPulled.Delete()
+				if cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck) && OperatorUpgradeToChannel == UndefinedValue {
+					err := burnWorkloadBuilder.Delete()
 					Expect(err).ToNot(HaveOccurred())
 				}
 			}()
 
 			By(fmt.Sprintf("Wait for up to %s for gpu-burn pod to be in Running phase", nvidiagpu.BurnPodRunningTimeout))
-			err = gpuPodPulled.WaitUntilInStatus(corev1.PodRunning, nvidiagpu.BurnPodRunningTimeout)
-			Expect(err).ToNot(HaveOccurred(), "timeout waiting for gpu-burn pod in "+
-				"namespace '%s' to go to Running phase:  %v ", burn.Namespace, err)
+			burnWorkloadBuilder.WaitUntilRunning(nvidiagpu.BurnPodRunningTimeout)
+			Expect(burnWorkloadBuilder.Error()).ToNot(HaveOccurred(), "timeout waiting for gpu-burn pod in "+
+				"namespace '%s' to go to Running phase:  %v ", burn.Namespace, burnWorkloadBuilder.Error())
 			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn pod now in Running phase")
 
-			By(fmt.Sprintf("Wait for up to %s for gpu-burn pod to run to completion and be in Succeeded phase/Completed status", nvidiagpu.BurnPodSuccessTimeout))
-			err = gpuPodPulled.WaitUntilInStatus(corev1.PodSucceeded, nvidiagpu.BurnPodSuccessTimeout)
-
-			Expect(err).ToNot(HaveOccurred(), "timeout waiting for gpu-burn pod '%s' in "+
-				"namespace '%s'to go Succeeded phase/Completed status:  %v ", burn.Namespace, burn.Namespace, err)
-			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn pod now in Succeeded Phase/Completed status")
-
-			By("Get the gpu-burn pod logs")
-			glog.V(gpuparams.GpuLogLevel).Infof("Get the gpu-burn pod logs")
+			By("Check that the gpu-burn pod sees the GPUs")
+			burnWorkloadBuilder.CheckGPUs()
+			Expect(burnWorkloadBuilder.Error()).ToNot(HaveOccurred(), "gpu-burn pod in namespace '%s' does not "+
+				"see any GPU: %v", burn.Namespace, burnWorkloadBuilder.Error())
 
-			gpuBurnLogs, err := gpuPodPulled.GetLog(nvidiagpu.BurnLogCollectionPeriod, "gpu-burn-ctr")
+			By(fmt.Sprintf("Wait for up to %s for gpu-burn pod to run to completion and check for successful execution", nvidiagpu.BurnPodSuccessTimeout))
+			burnWorkloadBuilder.WaitUntilSuccess(nvidiagpu.BurnPodSuccessTimeout)
 
-			Expect(err).ToNot(HaveOccurred(), "error getting gpu-burn pod '%s' logs "+
-				"from gpu burn namespace '%s' :  %v ", burn.Namespace, err)
-			glog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod '%s' logs:\n%s",
-				gpuPodPulled.Definition.Name, gpuBurnLogs)
+			if gpuBurnLogs, err := burnWorkloadBuilder.GetFullLogs(gpuburn.ContainerName); err == nil {
+				glog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod '%s' logs:\n%s", burn.PodName, gpuBurnLogs)
+			}
 
-			By("Parse the gpu-burn pod logs and check for successful execution")
-			match1 := strings.Contains(gpuBurnLogs, "GPU 0: OK")
-			match2 := strings.Contains(gpuBurnLogs, "100.0%  proc'd:")
+			if burnResult := burnWorkload.Result(); burnResult != nil {
+				if err := burnResult.WriteReport(inittools.GeneralConfig, gpuburn.ResultReportFile); err != nil {
+					glog.Error("Error writing the gpu-burn result file: ", err)
+				}
+			}
 
-			Expect(match1 && match2).ToNot(BeFalse(), "gpu-burn pod execution was FAILED")
+			Expect(burnWorkloadBuilder.Error()).ToNot(HaveOccurred(), "gpu-burn pod execution was FAILED: %v",
+				burnWorkloadBuilder.Error())
 			glog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod execution was successful")
 
 		})
 
-		It("Upgrade NVIDIA GPU Operator", Label("operator-upgrade"), func() {
+		It("Upgrade NVIDIA GPU Operator", Label("operator-upgrade"), func(ctx SpecContext) {
 
 			if OperatorUpgradeToChannel == UndefinedValue {
 				glog.V(gpuparams.GpuLogLevel).Infof("Operator Upgrade To Channel not set, skipping " +
@@ -908,22 +803,27 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 				"Setting pulled ClusterPolicy builder daemonset rollingUpdate.MaxUnavailable value to '%s'",
 				maxUnavailable)
 
-			myRollingUpdate := nvidiagpuv1.RollingUpdateSpec{
-				MaxUnavailable: maxUnavailable,
+			pulledClusterPolicyBuilder.WithDaemonsetsRollingUpdate(maxUnavailable)
+
+			// The driver upgrade tracker needs the operator to drive the upgrade, with the nodes drained so that the
+			// drain can be checked. The rest of the policy is kept.
+			driverUpgradePolicy := nvidiagpuv1alpha1.DriverUpgradePolicySpec{}
+			if pulledClusterPolicyBuilder.Definition.Spec.Driver.UpgradePolicy != nil {
+				driverUpgradePolicy = *pulledClusterPolicyBuilder.Definition.Spec.Driver.UpgradePolicy.DeepCopy()
 			}
 
-			if pulledClusterPolicyBuilder.Definition.Spec.Daemonsets.RollingUpdate == nil {
-				pulledClusterPolicyBuilder.Definition.Spec.Daemonsets.RollingUpdate = &myRollingUpdate
+			if driverUpgradePolicy.DrainSpec == nil {
+				driverUpgradePolicy.DrainSpec = &nvidiagpuv1alpha1.DrainSpec{}
 			}
 
-			myDriverAutoUpgradeTrue := nvidiagpuv1alpha1.DriverUpgradePolicySpec{
-				AutoUpgrade: true}
+			driverUpgradePolicy.AutoUpgrade = true
+			driverUpgradePolicy.DrainSpec.Enable = true
+			pulledClusterPolicyBuilder.WithDriverUpgradePolicy(driverUpgradePolicy)
 
-			if pulledClusterPolicyBuilder.Definition.Spec.Driver.UpgradePolicy == nil {
-				pulledClusterPolicyBuilder.Definition.Spec.Driver.UpgradePolicy = &myDriverAutoUpgradeTrue
-			}
+			clusterPolicyChanges, err := pulledClusterPolicyBuilder.Diff()
+			Expect(err).ToNot(HaveOccurred(), "error computing ClusterPolicy changes: %v", err)
+			glog.V(100).Infof("Updating ClusterPolicy fields: %v", clusterPolicyChanges)
 
-			pulledClusterPolicyBuilder.Definition.Spec.Daemonsets.RollingUpdate.MaxUnavailable = maxUnavailable
 			updatedPulledClusterPolicyBuilder, err := pulledClusterPolicyBuilder.Update(true)
 
 			Expect(err).ToNot(HaveOccurred(), "error updating pulled ClusterPolicy builder"+
@@ -939,53 +839,57 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 					"value is now '%v'",
 				updatedPulledClusterPolicyBuilder.Definition.Spec.Daemonsets.RollingUpdate.MaxUnavailable)
 
-			glog.V(100).Infof(
-				"Pulling SubscriptionBuilder structure with the following params: %s, %s", nvidiagpu.SubscriptionName,
-				nvidiagpu.SubscriptionNamespace)
-
-			pulledSubBuilder, err := olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName,
-				nvidiagpu.SubscriptionNamespace)
-
-			Expect(err).ToNot(HaveOccurred(), "Error pulling subscription '%s' in "+
-				"namespace '%s': %v", nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace, err)
-
-			glog.V(100).Infof(
-				"Successfully Initialized pulledNodeBuilder with name: %s", pulledSubBuilder.Definition.Name)
-
-			glog.V(100).Infof("Current Subscription Channel : %s", pulledSubBuilder.Definition.Spec.Channel)
-
-			pulledSubBuilder.Definition.Spec.Channel = OperatorUpgradeToChannel
-			glog.V(100).Infof("Updating Subscription Channel to upgrade to : %s",
-				pulledSubBuilder.Definition.Spec.Channel)
-
-			glog.V(100).Infof(
-				"Before Subcsription Channel upgrade the StartingCSV is now '%s'",
-				pulledSubBuilder.Object.Spec.StartingCSV)
+			By("Tracking the driver upgrade state of the GPU nodes")
+			driverUpgradeTracker, err := nvidiagpu.NewDriverUpgradeTrackerContext(ctx, inittools.APIClient,
+				nvidiagpu.ClusterPolicyName, WorkerNodeSelector)
+			Expect(err).ToNot(HaveOccurred(), "error creating the driver upgrade tracker: %v", err)
 
-			By("Update the Subscription builder object with new channel value")
-			updatedPulledSubBuilder, err := pulledSubBuilder.Update()
+			stopDriverUpgradeTracker := driverUpgradeTracker.StartContext(ctx,
+				nvidiagpu.DriverUpgradeTrackerPollInterval)
+			DeferCleanup(stopDriverUpgradeTracker)
 
-			Expect(err).ToNot(HaveOccurred(), "Error updating pulled subscription '%s' in "+
-				"namespace '%s': %v", nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace, err)
-
-			glog.V(100).Infof("Successfully updated Subscription Channel to upgrade to '%s'",
-				updatedPulledSubBuilder.Definition.Spec.Channel)
-
-			glog.V(100).Infof("Sleeping for %s to allow new CSV to be deployed", nvidiagpu.CsvDeploymentSleepInterval)
-			time.Sleep(nvidiagpu.CsvDeploymentSleepInterval)
-
-			glog.V(100).Infof("After Subscription Channel upgrade, the StartingCSV is now '%s'",
-				updatedPulledSubBuilder.Object.Spec.StartingCSV)
+			if installWithOLMv1 {
+				upgradeGPUOperatorExtension(ctx)
+			} else {
+				upgradeGPUOperatorSubscription(ctx)
+			}
 
 			By("Wait for daemonsets to be redeployed up to 15 minutes and for ClusterPolicy to be ready again")
 			glog.V(gpuparams.GpuLogLevel).Infof("Waiting up to 15 mins for ClusterPolicy to be ready again " +
 				"after upgrade")
-			err = wait.ClusterPolicyReady(inittools.APIClient, nvidiagpu.ClusterPolicyName, 60*time.Second, 15*time.Minute)
+			err = wait.ClusterPolicyReadyContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName, 60*time.Second,
+				15*time.Minute)
 
 			glog.V(gpuparams.GpuLogLevel).Infof("error waiting for ClusterPolicy to be Ready:  %v ", err)
 			Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterPolicy to be Ready:  %v ",
 				err)
 
+			By(fmt.Sprintf("Wait up to %s for every GPU node to reach the driver upgrade-done state",
+				nvidiagpu.DriverUpgradeTimeout))
+			stopDriverUpgradeTracker()
+			err = driverUpgradeTracker.WaitUntilUpgradedContext(ctx, nvidiagpu.DriverUpgradeTrackerPollInterval,
+				nvidiagpu.DriverUpgradeTimeout)
+			glog.V(gpuparams.GpuLogLevel).Infof("Driver upgrade timeline: %s", driverUpgradeTracker.Report())
+			Expect(err).ToNot(HaveOccurred(), "error waiting for the driver upgrade of the GPU nodes: %v", err)
+
+			By("Checking the driver upgrade respected the ClusterPolicy upgrade policy")
+			Expect(driverUpgradeTracker.Err()).ToNot(HaveOccurred(),
+				"driver upgrade exceeded the ClusterPolicy upgrade policy limits:\n%s", driverUpgradeTracker.Report())
+			Expect(driverUpgradeTracker.PeakUnavailable).To(BeNumerically("<=", driverUpgradeTracker.MaxUnavailable),
+				"driver upgrade exceeded maxUnavailable:\n%s", driverUpgradeTracker.Report())
+
+			if driverUpgradeTracker.MaxParallelUpgrades > 0 {
+				Expect(driverUpgradeTracker.PeakParallelUpgrades).To(
+					BeNumerically("<=", driverUpgradeTracker.MaxParallelUpgrades),
+					"driver upgrade exceeded maxParallelUpgrades:\n%s", driverUpgradeTracker.Report())
+			}
+
+			for _, nodeName := range driverUpgradeTracker.Upgraded() {
+				Expect(driverUpgradeTracker.Drained(nodeName)).To(BeTrue(),
+					"node %s was not cordoned and drained during the driver upgrade:\n%s", nodeName,
+					driverUpgradeTracker.Report())
+			}
+
 			By("Pull the post-upgrade Ready ClusterPolicy from cluster, with updated fields")
 			pulledUpdatedReadyClusterPolicy, err := nvidiagpu.Pull(inittools.APIClient, nvidiagpu.ClusterPolicyName)
 			Expect(err).ToNot(HaveOccurred(), "error pulling ClusterPolicy %s from cluster: "+
@@ -1028,7 +932,8 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			By("Delete the previously deployed gpu-burn-pod")
 			glog.V(gpuparams.GpuLogLevel).Infof("Deleting previously deployed and completed gpu-burn pod")
 
-			_, err = currentGpuBurnPodPulled.Delete()
+			// The re-deployed pod has the same name, it can only be created once this one is gone.
+			_, err = currentGpuBurnPodPulled.DeleteAndWait(nvidiagpu.BurnPodCreationTimeout)
 			Expect(err).ToNot(HaveOccurred(), "Error deleting gpu-burn pod")
 
 			By("Re-deploy gpu-burn pod in test-gpu-burn namespace")
@@ -1044,175 +949,281 @@ var _ = Describe("GPU", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(gpuparams.GpuLogLevel).Infof("cluster architecture for GPU enabled worker node is: %s",
 				clusterArch)
 
-			gpuBurnPod2, err := gpuburn.CreateGPUBurnPod(inittools.APIClient, burn.PodName, burn.Namespace,
-				BurnImageName[(clusterArch)], nvidiagpu.BurnPodPostUpgradeCreationTimeout)
-			Expect(err).ToNot(HaveOccurred(), "Error re-building gpu burn pod object after "+
-				"upgrade: %v", err)
+			burnWorkload2 := gpuburn.NewWorkload(burn.PodName, BurnImageName[clusterArch]).
+				WithConfigMapName(burn.ConfigMapName).
+				WithThresholds(burnThresholds)
 
 			glog.V(gpuparams.GpuLogLevel).Infof("Re-deploying gpu-burn pod '%s' in namespace '%s'",
-				burn.Namespace, burn.Namespace)
-
-			_, err = inittools.APIClient.Pods(burn.Namespace).Create(context.TODO(), gpuBurnPod2,
-				metav1.CreateOptions{})
-			Expect(err).ToNot(HaveOccurred(), "Error re-deploying gpu-burn '%s' after operator"+
-				" upgrade in namespace '%s': %v", burn.Namespace, burn.Namespace, err)
+				burn.PodName, burn.Namespace)
 
-			glog.V(gpuparams.GpuLogLevel).Infof("The re-deployed post upgrade gpuBurnPod has name: %s has "+
-				"status: %v ", gpuBurnPod2.Name, gpuBurnPod2.Status)
-
-			By("Get the re-deployed gpu-burn pod with label \"app=gpu-burn-app\"")
-			gpuBurnPod2Name, err := get.GetFirstPodNameWithLabel(inittools.APIClient, burn.Namespace, burn.PodLabel)
-			Expect(err).ToNot(HaveOccurred(), "error getting re-deployed gpu-burn pod with label "+
-				"'app=gpu-burn-app' from namespace '%s' :  %v ", burn.Namespace, err)
-			glog.V(gpuparams.GpuLogLevel).Infof("gpuPodName is %s ", gpuBurnPod2Name)
-
-			By("Pull the re-created gpu-burn pod object from the cluster")
-			gpuBurnPod2Pulled, err := pod.Pull(inittools.APIClient, gpuBurnPod2.Name, burn.Namespace)
-			Expect(err).ToNot(HaveOccurred(), "error pulling re-deployed gpu-burn pod from "+
-				"namespace '%s' :  %v ", burn.Namespace, err)
+			burnWorkloadBuilder2 := testworkloads.NewBuilder(inittools.APIClient, burn.Namespace, burnWorkload2).Create()
+			Expect(burnWorkloadBuilder2.Error()).ToNot(HaveOccurred(), "Error re-deploying gpu-burn '%s' after "+
+				"operator upgrade in namespace '%s': %v", burn.PodName, burn.Namespace, burnWorkloadBuilder2.Error())
 
 			defer func() {
 				defer GinkgoRecover()
-				if cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck) {
-					_, err := gpuBurnPod2Pulled.Delete()
+				if cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck) {
+					err := burnWorkloadBuilder2.Delete()
 					Expect(err).ToNot(HaveOccurred())
 				}
 			}()
 
 			By(fmt.Sprintf("Wait for up to %s for re-deployed burn pod to be in Running phase", nvidiagpu.RedeployedBurnPodRunningTimeout))
-			err = gpuBurnPod2Pulled.WaitUntilInStatus(corev1.PodRunning, nvidiagpu.RedeployedBurnPodRunningTimeout)
-			Expect(err).ToNot(HaveOccurred(), "timeout waiting for re-deployed gpu-burn pod in "+
-				"namespace '%s' to go to Running phase:  %v ", burn.Namespace, err)
+			burnWorkloadBuilder2.WaitUntilRunning(nvidiagpu.RedeployedBurnPodRunningTimeout)
+			Expect(burnWorkloadBuilder2.Error()).ToNot(HaveOccurred(), "timeout waiting for re-deployed gpu-burn "+
+				"pod in namespace '%s' to go to Running phase:  %v ", burn.Namespace, burnWorkloadBuilder2.Error())
 			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn pod now in Running phase")
 
-			By(fmt.Sprintf("Wait for up to %s for re-deployed burn pod to run to completion and be in Succeeded phase/Completed status", nvidiagpu.RedeployedBurnPodSuccessTimeout))
-			err = gpuBurnPod2Pulled.WaitUntilInStatus(corev1.PodSucceeded, nvidiagpu.RedeployedBurnPodSuccessTimeout)
-			Expect(err).ToNot(HaveOccurred(), "timeout waiting for gpu-burn pod '%s' in "+
-				"namespace '%s'to go Succeeded phase/Completed status:  %v ", burn.Namespace, burn.Namespace, err)
-			glog.V(gpuparams.GpuLogLevel).Infof("gpu-burn pod now in Succeeded Phase/Completed status")
-
-			By("Get the gpu-burn pod logs")
-			glog.V(gpuparams.GpuLogLevel).Infof("Get the re-created gpu-burn pod logs")
+			By("Check that the re-deployed gpu-burn pod sees the GPUs")
+			burnWorkloadBuilder2.CheckGPUs()
+			Expect(burnWorkloadBuilder2.Error()).ToNot(HaveOccurred(), "re-deployed gpu-burn pod does not see "+
+				"any GPU: %v", burnWorkloadBuilder2.Error())
 
-			gpuBurnPod2Logs, err := gpuBurnPod2Pulled.GetLog(nvidiagpu.RedeployedBurnLogCollectionPeriod, "gpu-burn-ctr")
+			By(fmt.Sprintf("Wait for up to %s for re-deployed burn pod to run to completion and check for "+
+				"successful execution", nvidiagpu.RedeployedBurnPodSuccessTimeout))
+			burnWorkloadBuilder2.WaitUntilSuccess(nvidiagpu.RedeployedBurnPodSuccessTimeout)
 
-			Expect(err).ToNot(HaveOccurred(), "error getting gpu-burn pod '%s' logs "+
-				"from gpu burn namespace '%s' :  %v ", burn.Namespace, err)
-			glog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod '%s' logs:\n%s",
-				gpuBurnPod2Pulled.Definition.Name, gpuBurnPod2Logs)
+			if gpuBurnPod2Logs, err := burnWorkloadBuilder2.GetFullLogs(gpuburn.ContainerName); err == nil {
+				glog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod '%s' logs:\n%s", burn.PodName, gpuBurnPod2Logs)
+			}
 
-			By("Parse the re-created gpu-burn pod logs and check for successful execution")
-			match1a := strings.Contains(gpuBurnPod2Logs, "GPU 0: OK")
-			match2a := strings.Contains(gpuBurnPod2Logs, "100.0%  proc'd:")
+			if burnResult := burnWorkload2.Result(); burnResult != nil {
+				if err := burnResult.WriteReport(inittools.GeneralConfig, "upgrade-"+gpuburn.ResultReportFile); err != nil {
+					glog.Error("Error writing the re-deployed gpu-burn result file: ", err)
+				}
+			}
 
-			Expect(match1a && match2a).ToNot(BeFalse(), "Re-deployed gpu-burn pod execution was FAILED")
+			Expect(burnWorkloadBuilder2.Error()).ToNot(HaveOccurred(), "Re-deployed gpu-burn pod execution was "+
+				"FAILED: %v", burnWorkloadBuilder2.Error())
 			glog.V(gpuparams.GpuLogLevel).Infof("Gpu-burn pod execution was successful")
 
 		})
 
-		It("Test GPU Workload with single strategy MIG Configuration in mig package", Label("single-mig"), func() {
+		It("Test GPU Workload with single strategy MIG Configuration in mig package", Label("single-mig"), func(ctx SpecContext) {
 			// Skip if single-mig label is not in the ginkgo label filter
-			if !mig.IsLabelInFilter("single-mig") {
+			if !shared.IsLabelInFilter("single-mig") {
 				glog.V(gpuparams.GpuLogLevel).Infof("Skipping test: 'single-mig' label not present in ginkgo label filter")
 				Skip("Test skipped: 'single-mig' label not present in ginkgo label filter")
 			}
-			cleanup := cleanupAfterTest && !mig.ShouldKeepOperator(labelsToCheck)
-			mig.TestSingleMIGGPUWorkload(nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanup)
+			cleanup := cleanupAfterTest && !shared.ShouldKeepOperator(labelsToCheck)
+			shared.TestSingleMIGGPUWorkload(ctx, nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanup)
 		})
 
-		It("Test GPU workload with mixed strategy MIG Configuration", Label("mixed-mig"), func() {
+		It("Test GPU workload with mixed strategy MIG Configuration", Label("mixed-mig"), func(ctx SpecContext) {
 			// Skip if mixed-mig label is not in the ginkgo label filter
-			if !mig.IsLabelInFilter("mixed-mig") {
+			if !shared.IsLabelInFilter("mixed-mig") {
 				glog.V(gpuparams.GpuLogLevel).Infof("Skipping test: 'mixed-mig' label not present in ginkgo label filter")
 				Skip("Test skipped: 'mixed-mig' label not present in ginkgo label filter")
 			}
-			mig.TestMixedMIGGPUWorkload(nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanupAfterTest)
+			shared.TestMixedMIGGPUWorkload(ctx, nvidiaGPUConfig, burn, BurnImageName, WorkerNodeSelector, cleanupAfterTest)
 		})
 	})
 })
 
-// cleanupGPUOperatorResources performs cleanup of GPU Operator resources
-// It checks if cleanup should run based on cleanupAfterTest and cleanup label
-func cleanupGPUOperatorResources() {
-	cleanupClusterPolicy()
-	cleanupCSV()
-	cleanupSubscription()
-	cleanupOperatorGroup()
-	cleanupGPUOperatorNamespace()
-	cleanupGPUBurnPod()
-	cleanupGPUBurnConfigmap()
-	cleanupGPUBurnNamespace()
+// upgradeGPUOperatorSubscription upgrades the GPU operator Subscription to OperatorUpgradeToChannel, approving the
+// upgrade one ClusterServiceVersion at a time when the upgrade path is known.
+func upgradeGPUOperatorSubscription(ctx context.Context) {
+	glog.V(100).Infof(
+		"Pulling SubscriptionBuilder structure with the following params: %s, %s", nvidiagpu.SubscriptionName,
+		nvidiagpu.SubscriptionNamespace)
 
-	glog.V(gpuparams.GpuLogLevel).Infof("Completed cleanup of GPU Operator Resources")
-}
+	pulledSubBuilder, err := olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName,
+		nvidiagpu.SubscriptionNamespace)
 
-// cleanupClusterPolicy deletes the ClusterPolicy resource
-func cleanupClusterPolicy() {
-	By("Deleting ClusterPolicy")
-	clusterPolicyBuilder, err := nvidiagpu.Pull(inittools.APIClient, nvidiagpu.ClusterPolicyName)
-	if err == nil && clusterPolicyBuilder.Exists() {
-		_, err = clusterPolicyBuilder.Delete()
-		Expect(err).ToNot(HaveOccurred(), "Error deleting ClusterPolicy: %v", err)
-		glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy deleted successfully")
-	} else {
-		glog.V(gpuparams.GpuLogLevel).Infof("ClusterPolicy not found or already deleted")
-	}
-}
+	Expect(err).ToNot(HaveOccurred(), "Error pulling subscription '%s' in "+
+		"namespace '%s': %v", nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace, err)
+
+	glog.V(100).Infof(
+		"Successfully Initialized pulledNodeBuilder with name: %s", pulledSubBuilder.Definition.Name)
+
+	glog.V(100).Infof("Current Subscription Channel : %s", pulledSubBuilder.Definition.Spec.Channel)
+
+	By("Resolving the upgrade path to the head of the target channel from the catalog")
+	installedCSV := pulledSubBuilder.Object.Status.InstalledCSV
+	upgradePath, upgradeTargetCSV := resolveGPUOperatorUpgradePath(ctx, pulledSubBuilder)
+
+	Expect(installedCSV).ToNot(Equal(upgradeTargetCSV), "ClusterServiceVersion '%s' is already the head of channel "+
+		"'%s', there is nothing to upgrade: deploy an older version, e.g. from an older NVIDIAGPU_SUBSCRIPTION_CHANNEL, "+
+		"before running the operator-upgrade testcase", installedCSV, OperatorUpgradeToChannel)
+
+	pulledSubBuilder.Definition.Spec.Channel = OperatorUpgradeToChannel
 
-// cleanupCSV deletes the ClusterServiceVersion resources
-func cleanupCSV() {
-	By("Deleting CSV")
-	// Since this is out of defer functions, the CSV need to be listed before deleting gpu-operator CSV.
-	csvList, err := olm.ListClusterServiceVersion(inittools.APIClient, nvidiagpu.SubscriptionNamespace)
-	Expect(err).ToNot(HaveOccurred(), "Error listing CSV: %v", err)
-	for _, csv := range csvList {
-		if strings.Contains(csv.Definition.Name, "gpu-operator") {
-			err := csv.Delete()
-			Expect(err).ToNot(HaveOccurred(), "Error deleting CSV: %v", err)
-			glog.V(gpuparams.GpuLogLevel).Infof("CSV %s deleted successfully", csv.Definition.Name)
+	if upgradePath != nil {
+		originalInstallPlanApproval := pulledSubBuilder.Definition.Spec.InstallPlanApproval
+		if originalInstallPlanApproval == "" {
+			originalInstallPlanApproval = v1alpha1.ApprovalAutomatic
 		}
+
+		pulledSubBuilder.WithInstallPlanApproval(v1alpha1.ApprovalManual)
+
+		DeferCleanup(func() error {
+			By(fmt.Sprintf("Restoring the Subscription installplan approval to '%s'",
+				originalInstallPlanApproval))
+			subBuilder, err := olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName,
+				nvidiagpu.SubscriptionNamespace)
+			if err != nil {
+				return err
+			}
+
+			_, err = subBuilder.WithInstallPlanApproval(originalInstallPlanApproval).Update()
+
+			return err
+		})
 	}
-}
 
-// cleanupSubscription deletes the Subscription resource
-func cleanupSubscription() {
-	By("Deleting Subscription")
-	subBuilder, err := olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace)
-	if err == nil && subBuilder.Exists() {
-		err = subBuilder.Delete()
-		Expect(err).ToNot(HaveOccurred(), "Error deleting Subscription: %v", err)
-		glog.V(gpuparams.GpuLogLevel).Infof("Subscription deleted successfully")
+	By(fmt.Sprintf("Update the Subscription with channel '%s' and '%s' installplan approval",
+		OperatorUpgradeToChannel, pulledSubBuilder.Definition.Spec.InstallPlanApproval))
+	updatedPulledSubBuilder, err := pulledSubBuilder.Update()
+
+	Expect(err).ToNot(HaveOccurred(), "Error updating pulled subscription '%s' in "+
+		"namespace '%s': %v", nvidiagpu.SubscriptionName, nvidiagpu.SubscriptionNamespace, err)
+
+	if upgradePath != nil {
+		By(fmt.Sprintf("Approving the upgrade one ClusterServiceVersion at a time through %v",
+			upgradePath[1:]))
+		_, err = updatedPulledSubBuilder.UpgradeThroughContext(ctx, upgradePath[1:],
+			nvidiagpu.CsvSucceededCheckInterval, nvidiagpu.CsvSucceededTimeout)
+		Expect(err).ToNot(HaveOccurred(), "error upgrading Subscription '%s' through %v: %v",
+			nvidiagpu.SubscriptionName, upgradePath[1:], err)
 	} else {
-		glog.V(gpuparams.GpuLogLevel).Infof("Subscription not found or already deleted")
+		By("Waiting for OLM to upgrade the Subscription from the installed ClusterServiceVersion")
+		Eventually(func() (string, error) {
+			subBuilder, err := olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName,
+				nvidiagpu.SubscriptionNamespace)
+			if err != nil {
+				return "", err
+			}
+
+			return subBuilder.Object.Status.InstalledCSV, nil
+		}).WithPolling(nvidiagpu.CsvSucceededCheckInterval).WithTimeout(nvidiagpu.CsvSucceededTimeout).
+			ShouldNot(Equal(installedCSV), "Subscription '%s' was not upgraded", nvidiagpu.SubscriptionName)
+
+		_, err = updatedPulledSubBuilder.WaitUntilCSVInstalled(upgradeTargetCSV,
+			nvidiagpu.CsvSucceededCheckInterval, nvidiagpu.CsvSucceededTimeout)
+		Expect(err).ToNot(HaveOccurred(), "error waiting for the upgraded ClusterServiceVersion: %v", err)
+	}
+
+	By("Checking the Subscription reached the head of the target channel")
+	upgradedSubBuilder, err := olm.PullSubscription(inittools.APIClient, nvidiagpu.SubscriptionName,
+		nvidiagpu.SubscriptionNamespace)
+	Expect(err).ToNot(HaveOccurred(), "Error pulling subscription '%s': %v", nvidiagpu.SubscriptionName, err)
+	Expect(upgradedSubBuilder.Object.Status.InstalledCSV).ToNot(Equal(installedCSV),
+		"Subscription '%s' is still on ClusterServiceVersion '%s'", nvidiagpu.SubscriptionName, installedCSV)
+
+	if upgradeTargetCSV != "" {
+		Expect(upgradedSubBuilder.Object.Status.InstalledCSV).To(Equal(upgradeTargetCSV),
+			"Subscription '%s' did not upgrade to the head of channel '%s'", nvidiagpu.SubscriptionName,
+			OperatorUpgradeToChannel)
 	}
 }
 
-// cleanupOperatorGroup deletes the OperatorGroup resource
-func cleanupOperatorGroup() {
-	By("Deleting OperatorGroup")
-	ogBuilder, err := olm.PullOperatorGroup(inittools.APIClient, nvidiagpu.OperatorGroupName, nvidiagpu.SubscriptionNamespace)
-	if err == nil && ogBuilder.Exists() {
-		err = ogBuilder.Delete()
-		Expect(err).ToNot(HaveOccurred(), "Error deleting OperatorGroup: %v", err)
-		glog.V(gpuparams.GpuLogLevel).Infof("OperatorGroup deleted successfully")
-	} else {
-		glog.V(gpuparams.GpuLogLevel).Infof("OperatorGroup not found or already deleted")
+// upgradeGPUOperatorExtension upgrades the GPU operator ClusterExtension by moving it to OperatorUpgradeToChannel, and
+// waits for OLM v1 to install a bundle of that channel in place of the installed one.
+func upgradeGPUOperatorExtension(ctx context.Context) {
+	By(fmt.Sprintf("Pulling the GPU operator clusterextension '%s'", nvidiagpu.ClusterExtensionName))
+	gpuExtension, err := olm.PullClusterExtensionContext(ctx, inittools.APIClient, nvidiagpu.ClusterExtensionName)
+	Expect(err).ToNot(HaveOccurred(), "error pulling clusterextension '%s': %v", nvidiagpu.ClusterExtensionName, err)
+
+	installedBundle, _, err := gpuExtension.InstalledBundle()
+	Expect(err).ToNot(HaveOccurred(), "error getting the bundle installed by clusterextension '%s': %v",
+		nvidiagpu.ClusterExtensionName, err)
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Current clusterextension bundle: %s", installedBundle)
+
+	By(fmt.Sprintf("Update the clusterextension with channel '%s'", OperatorUpgradeToChannel))
+	gpuExtension, err = gpuExtension.WithChannels(OperatorUpgradeToChannel).UpdateContext(ctx)
+	Expect(err).ToNot(HaveOccurred(), "error updating clusterextension '%s' with channel '%s': %v",
+		nvidiagpu.ClusterExtensionName, OperatorUpgradeToChannel, err)
+
+	By(fmt.Sprintf("Waiting for OLM v1 to upgrade the clusterextension from bundle '%s'", installedBundle))
+	upgradedBundle, err := gpuExtension.WaitUntilUpgradedContext(ctx, installedBundle, "",
+		nvidiagpu.CsvSucceededCheckInterval, nvidiagpu.CsvSucceededTimeout)
+	Expect(err).ToNot(HaveOccurred(), "error waiting for clusterextension '%s' to upgrade: %v",
+		nvidiagpu.ClusterExtensionName, err)
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Clusterextension '%s' upgraded from bundle '%s' to '%s'",
+		nvidiagpu.ClusterExtensionName, installedBundle, upgradedBundle)
+}
+
+// resolveGPUOperatorUpgradePath returns the upgrade path from the ClusterServiceVersion installed by the
+// Subscription to the head of OperatorUpgradeToChannel through every version in between, and that head. When the
+// catalog does not tell how to get there, the path is nil and the head is returned if known, so that the upgrade is
+// left to OLM instead of failing before it starts.
+func resolveGPUOperatorUpgradePath(ctx context.Context, subBuilder *olm.SubscriptionBuilder) ([]string, string) {
+	upgradeGraph, err := olm.PullUpgradeGraphContext(ctx, inittools.APIClient, nvidiagpu.Package,
+		subBuilder.Object.Spec.CatalogSourceNamespace, subBuilder.Object.Spec.CatalogSource)
+	if err != nil {
+		glog.V(gpuparams.GpuLogLevel).Infof("Cannot pull the upgrade graph of package '%s', leaving the upgrade "+
+			"to OLM: %v", nvidiagpu.Package, err)
+
+		return nil, ""
 	}
+
+	upgradePath, err := upgradeGraph.StepwiseUpgradePath(subBuilder.Object.Status.InstalledCSV,
+		OperatorUpgradeToChannel)
+	if err == nil {
+		glog.V(gpuparams.GpuLogLevel).Infof("Upgrading from '%s' to '%s' through %v",
+			upgradePath[0], upgradePath[len(upgradePath)-1], upgradePath)
+
+		return upgradePath, upgradePath[len(upgradePath)-1]
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Cannot resolve the upgrade path to channel '%s', leaving the upgrade "+
+		"to OLM: %v", OperatorUpgradeToChannel, err)
+
+	channel, err := upgradeGraph.Channel(OperatorUpgradeToChannel)
+	if err != nil {
+		glog.V(gpuparams.GpuLogLevel).Infof("Cannot find channel '%s': %v", OperatorUpgradeToChannel, err)
+
+		return nil, ""
+	}
+
+	return nil, channel.Head
 }
 
-// cleanupGPUOperatorNamespace deletes the GPU Operator namespace
-func cleanupGPUOperatorNamespace() {
-	By("Deleting GPU Operator Namespace")
-	nsBuilder, err := namespace.Pull(inittools.APIClient, nvidiagpu.SubscriptionNamespace)
-	if err == nil && nsBuilder.Exists() {
-		err = nsBuilder.Delete()
-		Expect(err).ToNot(HaveOccurred(), "Error deleting namespace: %v", err)
-		glog.V(gpuparams.GpuLogLevel).Infof("Namespace %s deleted successfully", nvidiagpu.SubscriptionNamespace)
-	} else {
-		glog.V(gpuparams.GpuLogLevel).Infof("Namespace %s not found or already deleted", nvidiagpu.SubscriptionNamespace)
+// waitForGPUOperatorDeployment waits for the GPU Operator deployment to be created and checks it is ready
+func waitForGPUOperatorDeployment(ctx context.Context) {
+	By(fmt.Sprintf("Wait for up to %s for GPU Operator deployment to be created", nvidiagpu.DeploymentCreationTimeout))
+	gpuDeploymentCreated := wait.DeploymentCreatedContext(
+		ctx,
+		inittools.APIClient,
+		nvidiagpu.OperatorDeployment,
+		nvidiagpu.NvidiaGPUNamespace,
+		nvidiagpu.DeploymentCreationCheckInterval,
+		nvidiagpu.DeploymentCreationTimeout)
+
+	Expect(gpuDeploymentCreated).ToNot(BeFalse(), "timed out waiting to deploy GPU operator")
+
+	By("Check if the GPU operator deployment is ready")
+	gpuOperatorDeployment, err := deployment.Pull(inittools.APIClient, nvidiagpu.OperatorDeployment, nvidiagpu.NvidiaGPUNamespace)
+
+	Expect(err).ToNot(HaveOccurred(), "Error trying to pull GPU operator "+
+		"deployment is: %v", err)
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Pulled GPU operator deployment is:  %v ",
+		gpuOperatorDeployment.Definition.Name)
+
+	if gpuOperatorDeployment.IsReady(nvidiagpu.OperatorDeploymentReadyTimeout) {
+		glog.V(gpuparams.GpuLogLevel).Infof("Pulled GPU operator deployment '%s' is Ready",
+			gpuOperatorDeployment.Definition.Name)
 	}
 }
 
+// cleanupGPUOperatorResources performs cleanup of GPU Operator resources
+// It checks if cleanup should run based on cleanupAfterTest and cleanup label
+func cleanupGPUOperatorResources(ctx context.Context) {
+	By("Uninstalling GPU Operator and verifying the cluster is clean")
+	err := nvidiagpu.UninstallGPUOperator(ctx, inittools.APIClient, gpuBundleInstaller,
+		inittools.GeneralConfig.StrictUninstall)
+	Expect(err).ToNot(HaveOccurred(), "Error uninstalling GPU Operator: %v", err)
+
+	cleanupGPUBurnPod()
+	cleanupGPUBurnConfigmap()
+	cleanupGPUBurnNamespace()
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Completed cleanup of GPU Operator Resources")
+}
+
 // cleanupGPUBurnPod deletes the GPU Burn pod
 func cleanupGPUBurnPod() {
 	By("Deleting GPU Burn Pod")
@@ -3,7 +3,9 @@ package nvidianetwork
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,17 +14,18 @@ import (
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/nvidianetworkconfig"
 	rdmatest "github.com/rh-ecosystem-edge/nvidia-ci/internal/rdma"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/deployment"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nfdcheck"
 	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/operatorconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/tests/shared"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	nvidianetworkv1alpha1 "github.com/Mellanox/network-operator/api/v1alpha1"
 	"github.com/golang/glog"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/rh-ecosystem-edge/nvidia-ci/pkg/global"
-	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
 
 	"github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/rh-ecosystem-edge/nvidia-ci/internal/check"
@@ -55,6 +58,8 @@ var (
 	networkOperatorUpgradeToChannel      = UndefinedValue
 	cleanupAfterTest                bool = true
 	deployFromBundle                bool = false
+	installWithOLMv1                bool = false
+	clusterCatalog                       = olm.DefaultClusterCatalog
 	networkOperatorBundleImage           = ""
 	clusterArchitecture                  = UndefinedValue
 
@@ -71,6 +76,10 @@ var (
 	// rdmaTestImage              = UndefinedValue
 	rdmaNetworkType      = "shared-device"
 	rdmaGPUDirect   bool = false
+	// perftestThresholds are the limits RDMA perftest results are validated against.
+	perftestThresholds = rdmatest.DefaultPerftestThresholds()
+	// rdmaBenchmarks are the perftest benchmarks each RDMA testcase runs, one server and client pair each.
+	rdmaBenchmarks = []string{rdmatest.BenchmarkWriteBW}
 
 	mellanoxEthernetInterfaceName   = UndefinedValue
 	mellanoxInfinibandInterfaceName = UndefinedValue
@@ -117,9 +126,16 @@ const (
 	nnoIPoIBNetworkNameDefault          = "example-ipoibnetwork"
 	nnoCustomCatalogSourcePublisherName = "Red Hat"
 	nnoCustomCatalogSourceDisplayName   = "Certified Operators Custom"
+	nnoCRDGroup                         = "mellanox.com"
+	nnoNodeLabelPrefix                  = "network.nvidia.com/"
 
 	mellanoxEthernetInterfaceNameDefault   = "ens1f0np0"
 	mellanoxInfinibandInterfaceNameDefault = "ibs1f1"
+
+	rdmaServerMember         = "server"
+	rdmaClientMember         = "client"
+	rdmaServerRunningTimeout = 4 * time.Minute
+	rdmaPerftestTimeout      = 7 * time.Minute
 )
 
 var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
@@ -127,6 +143,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 	var (
 		deployBundle       deploy.Deploy
 		deployBundleConfig deploy.BundleConfig
+		nnoBundleInstaller *olm.BundleInstaller
 	)
 
 	if mellanoxEthernetInterfaceName == "" {
@@ -356,6 +373,21 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 					"not set or set to False, will execute RDMA tests without cuda switch")
 			}
 
+			// The configured link types override their own entry only, the defaults keep applying to the others
+			perftestThresholds = rdmatest.DefaultPerftestThresholds().Merge(rdmatest.PerftestThresholds{
+				MinBandwidthGbps:   nvidiaNetworkConfig.RdmaMinBandwidth,
+				MinMsgRateMpps:     nvidiaNetworkConfig.RdmaMinMsgRate,
+				MaxLatencyUsec:     nvidiaNetworkConfig.RdmaMaxLatency,
+				MaxP99LatencyUsec:  nvidiaNetworkConfig.RdmaMaxLatencyP99,
+				MaxP999LatencyUsec: nvidiaNetworkConfig.RdmaMaxLatencyP999,
+			})
+			glog.V(networkparams.LogLevel).Infof("RDMA perftest thresholds: %+v", perftestThresholds)
+
+			if len(nvidiaNetworkConfig.RdmaBenchmarks) > 0 {
+				rdmaBenchmarks = nvidiaNetworkConfig.RdmaBenchmarks
+			}
+			glog.V(networkparams.LogLevel).Infof("RDMA perftest benchmarks: %v", rdmaBenchmarks)
+
 			switch nvidiaNetworkConfig.RdmaNetworkType {
 			case "sriov":
 				glog.V(networkparams.LogLevel).Infof("env variable NVIDIANETWORK_RDMA_NETWORK_TYPE" +
@@ -410,6 +442,18 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				deployFromBundle = false
 			}
 
+			if nvidiaNetworkConfig.InstallWithOLMv1 {
+				installWithOLMv1 = nvidiaNetworkConfig.InstallWithOLMv1
+				glog.V(networkparams.LogLevel).Infof("Flag install Network operator with OLM v1 is set "+
+					"to env variable NVIDIANETWORK_INSTALL_WITH_OLMV1 value '%v'", installWithOLMv1)
+
+				if nvidiaNetworkConfig.ClusterCatalog != "" {
+					clusterCatalog = nvidiaNetworkConfig.ClusterCatalog
+					glog.V(networkparams.LogLevel).Infof("Network Operator clustercatalog now set to env "+
+						"variable NVIDIANETWORK_CLUSTERCATALOG value '%s'", clusterCatalog)
+				}
+			}
+
 			if nvidiaNetworkConfig.OperatorUpgradeToChannel == "" {
 				glog.V(networkparams.LogLevel).Infof("env variable " +
 					"NVIDIANETWORK_SUBSCRIPTION_UPGRADE_TO_CHANNEL is not set, will not run the Upgrade Testcase")
@@ -454,6 +498,12 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 				nfdInstance.CreateCustomCatalogsource = true
 
+				catalogSourceOptions, err := nfdConfig.FallbackCatalogSourceOptions()
+				Expect(err).ToNot(HaveOccurred(), "Error reading the NFD custom catalogsource options: %v", err)
+
+				nfdInstance.CustomCatalogSourceOptions = catalogSourceOptions
+				nfdInstance.CustomCatalogSourcePackageTimeout = nfdConfig.FallbackCatalogSourcePackageTimeout
+
 				nfdInstance.CustomCatalogSource = nfd.CatalogSourceDefault + "-custom"
 				glog.V(networkparams.LogLevel).Infof("Setting custom NFD catalogsource name to '%s'",
 					nfdInstance.CustomCatalogSource)
@@ -487,7 +537,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				}
 			}
 
-			nfd.EnsureNFDIsInstalled(inittools.APIClient, nfdInstance, ocpVersion, networkparams.LogLevel)
+			shared.EnsureNFDIsInstalled(inittools.APIClient, nfdInstance, ocpVersion, networkparams.LogLevel)
 
 			// After NFD is installed, we can network operator enabled worker node architecture
 			By("Get Cluster Architecture from first NVIDIA Network enabled worker node")
@@ -520,18 +570,18 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 		})
 
-		AfterAll(func() {
+		AfterAll(func(ctx SpecContext) {
 
 			if nfdInstance.CleanupAfterInstall && cleanupAfterTest {
-				err := nfd.Cleanup(inittools.APIClient)
-				Expect(err).ToNot(HaveOccurred(), "Error cleaning up NFD resources: %v", err)
+				err := nfd.UninstallNFD(ctx, inittools.APIClient, inittools.GeneralConfig.StrictUninstall)
+				Expect(err).ToNot(HaveOccurred(), "Error uninstalling NFD Operator: %v", err)
 			}
 
 		})
 
-		It("Deploy NVIDIA Network Operator with DTK", Label("deploy"), func() {
+		It("Deploy NVIDIA Network Operator with DTK", Label("deploy"), func(ctx SpecContext) {
 
-			nfdcheck.CheckNfdInstallation(inittools.APIClient, nfd.OSLabel, nfd.GetAllowedOSLabels(),
+			shared.CheckNfdInstallation(inittools.APIClient, nfd.OSLabel, nfd.GetAllowedOSLabels(),
 				inittools.GeneralConfig.WorkerLabelMap, networkparams.LogLevel)
 
 			By("Check if at least one worker node is has label for Mellanox cards enabled")
@@ -565,330 +615,247 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 					" : \n%s", workerNode, deleteMofedRPMDirOutput)
 			}
 
-			By("Check if Network Operator Deployment is from Bundle")
-			if deployFromBundle {
-				glog.V(networkparams.LogLevel).Infof("Deploying Network operator from bundle")
-				// This returns the Deploy interface object initialized with the API client
-				deployBundle = deploy.NewDeploy(inittools.APIClient)
-				deployBundleConfig.BundleImage = networkOperatorBundleImage
-				glog.V(networkparams.LogLevel).Infof("Deploying Network operator from bundle image '%s'",
-					deployBundleConfig.BundleImage)
-
-			} else {
-				glog.V(networkparams.LogLevel).Infof("Deploying Network Operator from catalogsource")
-
-				By("Check if 'nvidia-network-operator' packagemanifest exists in certified-operators catalog")
-				glog.V(networkparams.LogLevel).Infof("Using NNO catalogsource '%s'", CatalogSource)
+			nnoNamespaceLabels := map[string]string{
+				"openshift.io/cluster-monitoring":    "true",
+				"pod-security.kubernetes.io/enforce": "privileged",
+			}
 
-				nnoPkgManifestBuilderByCatalog, err := olm.PullPackageManifestByCatalog(inittools.APIClient,
-					nnoPackage, nnoCatalogSourceNamespace, nnoCatalogSourceDefault)
+			By("Configure the Network Operator installer")
+			nnoInstaller := olm.NewOperatorInstaller(inittools.APIClient, nnoPackage, nnoNamespace).
+				WithCatalogSourceNamespace(nnoCatalogSourceNamespace).
+				WithCatalogSources(CatalogSource).
+				WithNamespaceLabels(nnoNamespaceLabels).
+				WithOperatorGroupName(nnoOperatorGroupName).
+				WithSubscriptionName(nnoSubscriptionName).
+				WithInstallPlanApproval(InstallPlanApproval).
+				WithCSVTimeout(60*time.Second, 5*time.Minute)
+
+			if SubscriptionChannel != UndefinedValue {
+				glog.V(networkparams.LogLevel).Infof("Setting the NNO subscription channel to: '%s'",
+					SubscriptionChannel)
+				nnoInstaller.WithChannel(SubscriptionChannel)
+			}
 
-				if err != nil {
-					glog.V(networkparams.LogLevel).Infof("Error trying to pull NNO packagemanifest"+
-						" '%s' from default catalog '%s': '%v'", nnoPackage, nnoCatalogSourceDefault, err.Error())
+			if createNNOCustomCatalogsource {
+				glog.V(networkparams.LogLevel).Infof("Falling back to custom catalogsource '%s' for Network "+
+					"Operator, with index image '%s'", CustomCatalogSource, CustomCatalogsourceIndexImage)
+				catalogSourceOptions, err := nvidiaNetworkConfig.NNOFallbackCatalogSourceOptions()
+				Expect(err).ToNot(HaveOccurred(), "Error reading the custom catalogsource options: %v", err)
+
+				nnoInstaller.WithCustomCatalogSourceBuilder(olm.NewCatalogSourceBuilderWithIndexImage(
+					inittools.APIClient, CustomCatalogSource, nnoCatalogSourceNamespace, CustomCatalogsourceIndexImage,
+					nnoCustomCatalogSourceDisplayName, nnoCustomCatalogSourcePublisherName).
+					WithOptions(catalogSourceOptions)).
+					WithCatalogSourceReadyTimeout(4 * time.Minute)
+
+				if nvidiaNetworkConfig.NNOFallbackCatalogsourcePackageTimeout > 0 {
+					nnoInstaller.WithPackageManifestTimeout(olm.DefaultPackageManifestCheckInterval,
+						nvidiaNetworkConfig.NNOFallbackCatalogsourcePackageTimeout)
 				}
+			}
 
-				if nnoPkgManifestBuilderByCatalog == nil {
-					glog.V(networkparams.LogLevel).Infof("The NNO packagemanifest '%s' was not "+
-						"found in the default '%s' catalog", nnoPackage, nnoCatalogSourceDefault)
+			defer func() {
+				if cleanupAfterTest {
+					By("Uninstalling Network Operator and verifying the cluster is clean")
+					report, err := olm.NewOperatorUninstaller(inittools.APIClient, nnoPackage, nnoNamespace).
+						WithSubscriptionName(nnoSubscriptionName).
+						WithOperatorGroupName(nnoOperatorGroupName).
+						WithClusterExtensionName(nnoPackage).
+						WithBundleInstaller(nnoBundleInstaller).
+						WithCustomResources(
+							&nvidianetworkv1alpha1.NicClusterPolicy{
+								ObjectMeta: metav1.ObjectMeta{Name: nnoNicClusterPolicyName}},
+							&nvidianetworkv1alpha1.MacvlanNetwork{ObjectMeta: metav1.ObjectMeta{Name: macvlanNetworkName}},
+							&nvidianetworkv1alpha1.IPoIBNetwork{ObjectMeta: metav1.ObjectMeta{Name: ipoibNetworkName}}).
+						WithCRDGroups(nnoCRDGroup).
+						WithNamePrefixes(nnoPackage).
+						WithNodeLabelPrefixes(nnoNodeLabelPrefix).
+						WithStrict(inittools.GeneralConfig.StrictUninstall).
+						Uninstall()
+					Expect(err).ToNot(HaveOccurred(), "Error uninstalling Network Operator: %v", err)
+					glog.V(networkparams.LogLevel).Infof("Network Operator uninstall report: %s", report)
+				}
+			}()
 
-					if createNNOCustomCatalogsource {
-						glog.V(networkparams.LogLevel).Infof("Creating custom catalogsource '%s' for Network "+
-							"Operator, with index image '%s'", CustomCatalogSource, CustomCatalogsourceIndexImage)
+			var almExamples string
 
-						glog.V(networkparams.LogLevel).Infof("Deploying a custom NNO catalogsource '%s' with '%s' "+
-							"index image", CustomCatalogSource, CustomCatalogsourceIndexImage)
+			if installWithOLMv1 {
+				By("Deploy the Network Operator through an OLM v1 ClusterExtension")
+				glog.V(networkparams.LogLevel).Infof("Deploying Network Operator from clustercatalog '%s'",
+					clusterCatalog)
 
-						nnoCustomCatalogSourceBuilder := olm.NewCatalogSourceBuilderWithIndexImage(inittools.APIClient,
-							CustomCatalogSource, nnoCatalogSourceNamespace, CustomCatalogsourceIndexImage,
-							nnoCustomCatalogSourceDisplayName, nnoCustomCatalogSourcePublisherName)
+				nnoExtensionInstaller := olm.NewExtensionInstaller(inittools.APIClient, nnoPackage, nnoNamespace).
+					WithNamespaceLabels(nnoNamespaceLabels).
+					WithCatalogs(clusterCatalog).
+					WithWatchNamespace(nnoNamespace).
+					WithInstallTimeout(60*time.Second, 5*time.Minute)
 
-						Expect(nnoCustomCatalogSourceBuilder).NotTo(BeNil(), "Failed to Initialize "+
-							"CatalogSourceBuilder for custom NNO catalogsource '%s'", CustomCatalogSource)
+				if SubscriptionChannel != UndefinedValue {
+					nnoExtensionInstaller.WithChannel(SubscriptionChannel)
+				}
 
-						createdNNOCustomCatalogSourceBuilder, err := nnoCustomCatalogSourceBuilder.Create()
-						glog.V(networkparams.LogLevel).Infof("Creating custom NNO Catalogsource builder object "+
-							"'%s'", createdNNOCustomCatalogSourceBuilder.Definition.Name)
-						Expect(err).ToNot(HaveOccurred(), "error creating custom NNO catalogsource "+
-							"builder Object name %s:  %v", CustomCatalogSource, err)
+				nnoExtension, err := nnoExtensionInstaller.InstallContext(ctx)
+				Expect(err).ToNot(HaveOccurred(), "error installing the Network Operator with OLM v1:  %v", err)
 
-						By("Sleep for 60 seconds to allow the NNO custom catalogsource to be created")
-						time.Sleep(60 * time.Second)
+				_, nnoBundleVersion, err := nnoExtension.InstalledBundle()
+				Expect(err).ToNot(HaveOccurred(), "error getting the bundle installed by the Network Operator "+
+					"clusterextension:  %v", err)
 
-						glog.V(networkparams.LogLevel).Infof("Wait up to 4 mins for custom NNO catalogsource " +
-							"to be ready")
+				glog.V(networkparams.LogLevel).Infof("Installed Network Operator version is: '%s'", nnoBundleVersion)
 
-						Expect(createdNNOCustomCatalogSourceBuilder.IsReady(4 * time.Minute)).NotTo(BeFalse())
+				if err := inittools.GeneralConfig.WriteReport(OperatorVersionFile,
+					[]byte(fmt.Sprintf("%s(olmv1)", nnoBundleVersion))); err != nil {
+					glog.Error("Error writing an operator version file: ", err)
+				}
 
-						CatalogSource = createdNNOCustomCatalogSourceBuilder.Definition.Name
+				waitForNNODeployment(ctx)
 
-						glog.V(networkparams.LogLevel).Infof("Custom NNO catalogsource '%s' is now ready",
-							createdNNOCustomCatalogSourceBuilder.Definition.Name)
+				By("Get ALM examples block from the Network Operator deployment")
+				almExamples, err = nnoExtension.GetAlmExamplesContext(ctx)
+				Expect(err).ToNot(HaveOccurred(), "Error from getting almExamples from the Network Operator "+
+					"deployment:  %v ", err)
+				glog.V(networkparams.LogLevel).Infof("almExamples block from the Network Operator deployment "+
+					"is : %v ", almExamples)
+			} else {
+				var nnoCSVBuilder *olm.ClusterServiceVersionBuilder
 
-						nnoPkgManifestBuilderByCustomCatalog, err := olm.PullPackageManifestByCatalog(inittools.APIClient,
-							nnoPackage, nnoCatalogSourceNamespace, CustomCatalogSource)
+				By("Check if Network Operator Deployment is from Bundle")
+				if deployFromBundle {
+					glog.V(networkparams.LogLevel).Infof("Deploying Network operator from bundle")
 
-						Expect(err).ToNot(HaveOccurred(), "error getting NNO packagemanifest '%s' "+
-							"from custom catalog '%s':  %v", nnoPackage, CustomCatalogSource, err)
+					By("Check if NVIDIA Network Operator namespace exists, otherwise created it and label it")
+					_, err := nnoInstaller.EnsureNamespaceContext(ctx)
+					Expect(err).ToNot(HaveOccurred(), "error creating namespace '%s' :  %v ", nnoNamespace, err)
 
-						By("Get the Network Operator Default Channel from Packagemanifest")
-						DefaultSubscriptionChannel = nnoPkgManifestBuilderByCustomCatalog.Object.Status.DefaultChannel
-						glog.V(networkparams.LogLevel).Infof("NNO channel '%s' retrieved from packagemanifest "+
-							"of custom catalogsource '%s'", DefaultSubscriptionChannel, CustomCatalogSource)
+					glog.V(networkparams.LogLevel).Infof("Initializing the kube API Client before deploying bundle")
+					deployBundle = deploy.NewDeploy(inittools.APIClient)
 
-					} else {
-						Skip("nvidia-network-operator packagemanifest not found in default 'certified-operators'" +
-							"catalogsource, and flag to deploy custom NNO catalogsource is false")
-					}
+					deployBundleConfig.BundleImage = networkOperatorBundleImage
+					deployBundleConfig.RegistryImage = nvidiaNetworkConfig.BundleRegistryImage
 
-				} else {
-					glog.V(networkparams.LogLevel).Infof("NNO packagemanifest '%s' was found in the default"+
-						" catalog '%s'", nnoPkgManifestBuilderByCatalog.Object.Name, nnoCatalogSourceDefault)
+					glog.V(networkparams.LogLevel).Infof("Deploy the Network Operator bundle image '%s'",
+						deployBundleConfig.BundleImage)
 
-					CatalogSource = nnoCatalogSourceDefault
+					nnoBundleInstaller, err = deployBundle.DeployBundle(ctx, networkparams.LogLevel,
+						&deployBundleConfig, nnoNamespace, 5*time.Minute)
+					shared.WriteCSVDiagnosisReport(err, "nno-")
+					Expect(err).ToNot(HaveOccurred(), "error from deploy.DeployBundle():  '%v' ", err)
 
-					By("Get the Network Operator Default Channel from Packagemanifest")
-					DefaultSubscriptionChannel = nnoPkgManifestBuilderByCatalog.Object.Status.DefaultChannel
-					glog.V(networkparams.LogLevel).Infof("NNO channel '%s' was retrieved from NNO "+
-						"packagemanifest", DefaultSubscriptionChannel)
-				}
+					glog.V(networkparams.LogLevel).Infof("Network Operator bundle image '%s' deployed successfully "+
+						"in namespace '%s", deployBundleConfig.BundleImage, nnoNamespace)
 
-			}
+					waitForNNODeployment(ctx)
 
-			By("Check if NVIDIA Network Operator namespace exists, otherwise created it and label it")
-			nsBuilder := namespace.NewBuilder(inittools.APIClient, nnoNamespace)
-			if nsBuilder.Exists() {
-				glog.V(networkparams.LogLevel).Infof("The namespace '%s' already exists",
-					nsBuilder.Object.Name)
-			} else {
-				glog.V(networkparams.LogLevel).Infof("Creating the namespace:  %v", nnoNamespace)
-				createdNsBuilder, err := nsBuilder.Create()
-				Expect(err).ToNot(HaveOccurred(), "error creating namespace '%s' :  %v ",
-					nsBuilder.Definition.Name, err)
+					By("Get the CSV deployed in NVIDIA Network Operator namespace")
+					csvBuilderList, err := olm.ListClusterServiceVersion(inittools.APIClient, nnoNamespace)
 
-				glog.V(networkparams.LogLevel).Infof("Successfully created namespace '%s'",
-					createdNsBuilder.Object.Name)
+					Expect(err).ToNot(HaveOccurred(), "Error getting list of CSVs in Network operator "+
+						"namespace: '%v'", err)
 
-				glog.V(networkparams.LogLevel).Infof("Labeling the newly created namespace '%s'",
-					nsBuilder.Object.Name)
+					// Need to handle case where there are more than one CSV in nvidia-network-operator namespace,
+					// such as in RHOAI environment
+					for _, csvBuilder := range csvBuilderList {
+						if strings.HasPrefix(csvBuilder.Object.Name, "nvidia-network-operator") {
+							// Found the matching CSV
+							nnoCSVBuilder = csvBuilder
+							glog.V(networkparams.LogLevel).Infof("Found nvidia-network-operator CSV '%s' in namespace '%s'",
+								csvBuilder.Object.Name, nnoNamespace)
+							break
+						}
+					}
 
-				labeledNsBuilder := createdNsBuilder.WithMultipleLabels(map[string]string{
-					"openshift.io/cluster-monitoring":    "true",
-					"pod-security.kubernetes.io/enforce": "privileged",
-				})
+					Expect(nnoCSVBuilder).ToNot(BeNil(), "nvidia-network-operator CSV not found in the list of CSVs in namespace '%s'", nnoNamespace)
+				} else {
+					By("Deploy the Network Operator from catalogsource")
+					glog.V(networkparams.LogLevel).Infof("Deploying Network Operator from catalogsource '%s'",
+						CatalogSource)
+
+					installedCSVBuilder, err := nnoInstaller.InstallContext(ctx)
+					if errors.Is(err, olm.ErrPackageNotFound) {
+						Skip(fmt.Sprintf("nvidia-network-operator packagemanifest not found in catalogsource '%s', "+
+							"and flag to deploy custom NNO catalogsource is false", CatalogSource))
+					}
 
-				newLabeledNsBuilder, err := labeledNsBuilder.Update()
-				Expect(err).ToNot(HaveOccurred(), "error labeling namespace %v :  %v ",
-					newLabeledNsBuilder.Definition.Name, err)
+					shared.WriteCSVDiagnosisReport(err, "nno-")
+					Expect(err).ToNot(HaveOccurred(), "error installing the Network Operator:  %v", err)
 
-				glog.V(networkparams.LogLevel).Infof("The nvidia-network-operator labeled namespace has "+
-					"labels:  %v", newLabeledNsBuilder.Object.Labels)
-			}
+					nnoCSVBuilder = installedCSVBuilder
+					CatalogSource = nnoInstaller.CatalogSource
+					DefaultSubscriptionChannel = nnoInstaller.PackageManifest.Object.Status.DefaultChannel
 
-			defer func() {
-				if cleanupAfterTest {
-					err := nsBuilder.Delete()
-					Expect(err).ToNot(HaveOccurred())
+					glog.V(networkparams.LogLevel).Infof("Network Operator installed from catalogsource '%s' on "+
+						"channel '%s'", CatalogSource, nnoInstaller.Channel)
 				}
-			}()
-
-			// NNO Namespace should be created at this point
-			if deployFromBundle {
-				glog.V(networkparams.LogLevel).Infof("Initializing the kube API Client before deploying bundle")
-				deployBundle = deploy.NewDeploy(inittools.APIClient)
 
-				deployBundleConfig.BundleImage = networkOperatorBundleImage
+				// 11-04-2025
+				/*
+					oc get csv -n nvidia-network-operator
+					NAME                                      DISPLAY                            VERSION          REPLACES                              PHASE
+					authorino-operator.v0.16.0                Authorino Operator                 0.16.0           authorino-operator.v0.15.1            Succeeded
+					cert-manager.v1.16.5                      cert-manager                       1.16.5           cert-manager.v1.16.1                  Succeeded
+					devworkspace-operator.v0.37.0             DevWorkspace Operator              0.37.0           devworkspace-operator.v0.36.0         Succeeded
+					nvidia-network-operator.v25.10.0-beta.2   NVIDIA Network Operator            25.10.0-beta.2                                         Succeeded
+					rhods-operator.2.25.0                     Red Hat OpenShift AI               2.25.0           rhods-operator.2.22.2                 Succeeded
+					serverless-operator.v1.36.1               Red Hat OpenShift Serverless       1.36.1           serverless-operator.v1.36.0           Succeeded
+					servicemeshoperator.v2.6.11               Red Hat OpenShift Service Mesh 2   2.6.11-0         servicemeshoperator.v2.6.10           Succeeded
+					web-terminal.v1.13.0                      Web Terminal                       1.13.0           web-terminal.v1.12.1-0.1745393748.p   Succeeded
 
-				glog.V(networkparams.LogLevel).Infof("Deploy the Network Operator bundle image '%s'",
-					deployBundleConfig.BundleImage)
+				*/
 
-				err := deployBundle.DeployBundle(networkparams.LogLevel, &deployBundleConfig, nnoNamespace,
-					5*time.Minute)
-				Expect(err).ToNot(HaveOccurred(), "error from deploy.DeployBundle():  '%v' ", err)
+				nnoCurrentCSV := nnoCSVBuilder.Definition.Name
 
-				glog.V(networkparams.LogLevel).Infof("Network Operator bundle image '%s' deployed successfully "+
-					"in namespace '%s", deployBundleConfig.BundleImage, nnoNamespace)
+				glog.V(networkparams.LogLevel).Infof("Deployed ClusterServiceVersion is: '%s'", nnoCurrentCSV)
 
-			} else {
-				By("Create OperatorGroup in NVIDIA Network Operator Namespace")
-				ogBuilder := olm.NewOperatorGroupBuilder(inittools.APIClient, nnoOperatorGroupName, nnoNamespace)
+				nnoCurrentCSVVersion := nnoCSVBuilder.Definition.Spec.Version.String()
+				csvVersionString := nnoCurrentCSVVersion
 
-				if ogBuilder.Exists() {
-					glog.V(networkparams.LogLevel).Infof("The ogBuilder that exists has name:  %v",
-						ogBuilder.Object.Name)
-				} else {
-					glog.V(networkparams.LogLevel).Infof("Create a new operatorgroup with name:  %v",
-						ogBuilder.Object.Name)
+				glog.V(networkparams.LogLevel).Infof("ClusterServiceVersion version to be written in the operator "+
+					"version file is: '%s'", csvVersionString)
 
-					ogBuilderCreated, err := ogBuilder.Create()
-					Expect(err).ToNot(HaveOccurred(), "error creating operatorgroup %v :  %v ",
-						ogBuilderCreated.Definition.Name, err)
+				if err := inittools.GeneralConfig.WriteReport(OperatorVersionFile, []byte(csvVersionString)); err != nil {
+					glog.Error("Error writing an operator version file: ", err)
 				}
 
-				defer func() {
-					if cleanupAfterTest {
-						err := ogBuilder.Delete()
-						Expect(err).ToNot(HaveOccurred())
-					}
-				}()
-
-				By("Create Subscription in NVIDIA Network Operator Namespace")
-				subBuilder := olm.NewSubscriptionBuilder(inittools.APIClient, nnoSubscriptionName,
-					nnoSubscriptionNamespace, CatalogSource, nnoCatalogSourceNamespace, nnoPackage)
-
-				if SubscriptionChannel != UndefinedValue {
-					glog.V(networkparams.LogLevel).Infof("Setting the NNO subscription channel to: '%s'",
-						SubscriptionChannel)
-					subBuilder.WithChannel(SubscriptionChannel)
-				} else {
-					glog.V(networkparams.LogLevel).Infof("Setting the NNO subscription channel to "+
-						"default channel: '%s'", DefaultSubscriptionChannel)
-					subBuilder.WithChannel(DefaultSubscriptionChannel)
+				By("Wait for deployed ClusterServiceVersion to be in Succeeded phase")
+				glog.V(networkparams.LogLevel).Infof("Waiting for ClusterServiceVersion '%s' to be in Succeeded phase",
+					nnoCurrentCSV)
+				err := wait.CSVSucceededContext(ctx, inittools.APIClient, nnoCurrentCSV, nnoNamespace, 60*time.Second,
+					5*time.Minute)
+				if err != nil {
+					glog.V(networkparams.LogLevel).Infof("error waiting for ClusterServiceVersion '%s' to be "+
+						"in Succeeded phase:  %v ", nnoCurrentCSV, err)
 				}
+				Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterServiceVersion to be "+
+					"in Succeeded phase: ", err)
 
-				subBuilder.WithInstallPlanApproval(InstallPlanApproval)
-
-				glog.V(networkparams.LogLevel).Infof("Creating the subscription, i.e Deploy the Network operator")
-				createdSub, err := subBuilder.Create()
+				By("Pull existing CSV in NVIDIA Network Operator Namespace")
+				clusterCSV, err := olm.PullClusterServiceVersion(inittools.APIClient, nnoCurrentCSV, nnoNamespace)
+				Expect(err).ToNot(HaveOccurred(), "error pulling CSV from cluster:  %v", err)
 
-				Expect(err).ToNot(HaveOccurred(), "error creating subscription %v :  %v ",
-					createdSub.Definition.Name, err)
+				glog.V(networkparams.LogLevel).Infof("clusterCSV from cluster lastUpdatedTime is : %v ",
+					clusterCSV.Definition.Status.LastUpdateTime)
 
-				glog.V(networkparams.LogLevel).Infof("Newly created subscription: %s was successfully created",
-					createdSub.Object.Name)
+				glog.V(networkparams.LogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
+					clusterCSV.Definition.Status.Phase)
 
-				if createdSub.Exists() {
-					glog.V(networkparams.LogLevel).Infof("The newly created NNO subscription '%s' in "+
-						"namespace '%v' has current CSV  '%v'", createdSub.Object.Name, createdSub.Object.Namespace,
-						createdSub.Object.Status.CurrentCSV)
-				}
+				succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
+				Expect(clusterCSV.Definition.Status.Phase).To(Equal(succeeded), "CSV Phase is not "+
+					"succeeded")
 
 				defer func() {
 					if cleanupAfterTest {
-						err := createdSub.Delete()
+						err := clusterCSV.Delete()
 						Expect(err).ToNot(HaveOccurred())
 					}
 				}()
 
+				By("Get ALM examples block form CSV")
+				almExamples, err = clusterCSV.GetAlmExamples()
+				Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from csv "+
+					"from cluster:  %v ", err)
+				glog.V(networkparams.LogLevel).Infof("almExamples block from clusterCSV  is : %v ", almExamples)
 			}
 
-			By("Sleep for 2 minutes to allow the Network Operator deployment to be created")
-			glog.V(networkparams.LogLevel).Infof("Sleep for 2 minutes to allow the Network Operator deployment" +
-				" to be created")
-			time.Sleep(2 * time.Minute)
-
-			By("Wait for up to 4 minutes for Network Operator deployment to be created")
-			nnoDeploymentCreated := wait.DeploymentCreated(inittools.APIClient, nnoDeployment, nnoNamespace,
-				30*time.Second, 4*time.Minute)
-			Expect(nnoDeploymentCreated).ToNot(BeFalse(), "timed out waiting to deploy "+
-				"Network operator")
-
-			By("Check if the Network operator deployment is ready")
-			nnoOperatorDeployment, err := deployment.Pull(inittools.APIClient, nnoDeployment, nnoNamespace)
-
-			Expect(err).ToNot(HaveOccurred(), "Error trying to pull Network operator "+
-				"deployment is: %v", err)
-
-			glog.V(networkparams.LogLevel).Infof("Pulled Network operator deployment is:  %v ",
-				nnoOperatorDeployment.Definition.Name)
-
-			if nnoOperatorDeployment.IsReady(4 * time.Minute) {
-				glog.V(networkparams.LogLevel).Infof("Pulled Network operator deployment '%s' is Ready",
-					nnoOperatorDeployment.Definition.Name)
-			}
-
-			By("Get the CSV deployed in NVIDIA Network Operator namespace")
-			csvBuilderList, err := olm.ListClusterServiceVersion(inittools.APIClient, nnoNamespace)
-
-			Expect(err).ToNot(HaveOccurred(), "Error getting list of CSVs in Network operator "+
-				"namespace: '%v'", err)
-
-			// Need to handle case where there are more than one CSV in nvidia-network-operator namespace,
-			// such as in RHOAI environment
-			nnoCSVName := ""
-			var nnoCSVBuilder *olm.ClusterServiceVersionBuilder = nil
-
-			for _, csvBuilder := range csvBuilderList {
-				if strings.HasPrefix(csvBuilder.Object.Name, "nvidia-network-operator") {
-					// Found the matching CSV
-					nnoCSVName = csvBuilder.Object.Name
-					nnoCSVBuilder = csvBuilder
-					glog.V(networkparams.LogLevel).Infof("Found nvidia-network-operator CSV '%s' in namespace '%s'",
-						nnoCSVName, nnoNamespace)
-					break
-				}
-			}
-
-			Expect(nnoCSVName).To(Not(BeEmpty()), "nvidia-network-operator CSV not found in the list of CSVs in namespace '%s'", nnoNamespace)
-
-			// 11-04-2025
-			/*
-				oc get csv -n nvidia-network-operator
-				NAME                                      DISPLAY                            VERSION          REPLACES                              PHASE
-				authorino-operator.v0.16.0                Authorino Operator                 0.16.0           authorino-operator.v0.15.1            Succeeded
-				cert-manager.v1.16.5                      cert-manager                       1.16.5           cert-manager.v1.16.1                  Succeeded
-				devworkspace-operator.v0.37.0             DevWorkspace Operator              0.37.0           devworkspace-operator.v0.36.0         Succeeded
-				nvidia-network-operator.v25.10.0-beta.2   NVIDIA Network Operator            25.10.0-beta.2                                         Succeeded
-				rhods-operator.2.25.0                     Red Hat OpenShift AI               2.25.0           rhods-operator.2.22.2                 Succeeded
-				serverless-operator.v1.36.1               Red Hat OpenShift Serverless       1.36.1           serverless-operator.v1.36.0           Succeeded
-				servicemeshoperator.v2.6.11               Red Hat OpenShift Service Mesh 2   2.6.11-0         servicemeshoperator.v2.6.10           Succeeded
-				web-terminal.v1.13.0                      Web Terminal                       1.13.0           web-terminal.v1.12.1-0.1745393748.p   Succeeded
-
-			*/
-
-			nnoCurrentCSV := nnoCSVBuilder.Definition.Name
-
-			glog.V(networkparams.LogLevel).Infof("Deployed ClusterServiceVersion is: '%s'", nnoCurrentCSV)
-
-			nnoCurrentCSVVersion := nnoCSVBuilder.Definition.Spec.Version.String()
-			csvVersionString := nnoCurrentCSVVersion
-
-			glog.V(networkparams.LogLevel).Infof("ClusterServiceVersion version to be written in the operator "+
-				"version file is: '%s'", csvVersionString)
-
-			if err := inittools.GeneralConfig.WriteReport(OperatorVersionFile, []byte(csvVersionString)); err != nil {
-				glog.Error("Error writing an operator version file: ", err)
-			}
-
-			By("Wait for deployed ClusterServiceVersion to be in Succeeded phase")
-			glog.V(networkparams.LogLevel).Infof("Waiting for ClusterServiceVersion '%s' to be in Succeeded phase",
-				nnoCurrentCSV)
-			err = wait.CSVSucceeded(inittools.APIClient, nnoCurrentCSV, nnoNamespace, 60*time.Second,
-				5*time.Minute)
-			if err != nil {
-				glog.V(networkparams.LogLevel).Infof("error waiting for ClusterServiceVersion '%s' to be "+
-					"in Succeeded phase:  %v ", nnoCurrentCSV, err)
-			}
-			Expect(err).ToNot(HaveOccurred(), "error waiting for ClusterServiceVersion to be "+
-				"in Succeeded phase: ", err)
-
-			By("Pull existing CSV in NVIDIA Network Operator Namespace")
-			clusterCSV, err := olm.PullClusterServiceVersion(inittools.APIClient, nnoCurrentCSV, nnoNamespace)
-			Expect(err).ToNot(HaveOccurred(), "error pulling CSV from cluster:  %v", err)
-
-			glog.V(networkparams.LogLevel).Infof("clusterCSV from cluster lastUpdatedTime is : %v ",
-				clusterCSV.Definition.Status.LastUpdateTime)
-
-			glog.V(networkparams.LogLevel).Infof("clusterCSV from cluster Phase is : \"%v\"",
-				clusterCSV.Definition.Status.Phase)
-
-			succeeded := v1alpha1.ClusterServiceVersionPhase("Succeeded")
-			Expect(clusterCSV.Definition.Status.Phase).To(Equal(succeeded), "CSV Phase is not "+
-				"succeeded")
-
-			defer func() {
-				if cleanupAfterTest {
-					err := clusterCSV.Delete()
-					Expect(err).ToNot(HaveOccurred())
-				}
-			}()
-
-			By("Get ALM examples block form CSV")
-			almExamples, err := clusterCSV.GetAlmExamples()
-			Expect(err).ToNot(HaveOccurred(), "Error from pulling almExamples from csv "+
-				"from cluster:  %v ", err)
-			glog.V(networkparams.LogLevel).Infof("almExamples block from clusterCSV  is : %v ", almExamples)
-
 			By("Deploy NicClusterPolicy")
 			glog.V(networkparams.LogLevel).Infof("Creating NicClusterPolicy from CSV almExamples")
 			nicClusterPolicyBuilder := nvidianetwork.NewNicClusterPolicyBuilderFromObjectString(inittools.APIClient,
@@ -1070,7 +1037,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 			By("Wait up to 24 minutes for NicClusterPolicy to be ready")
 			glog.V(networkparams.LogLevel).Infof("Waiting for NicClusterPolicy to be ready")
-			err = wait.NicClusterPolicyReady(inittools.APIClient, nnoNicClusterPolicyName, 60*time.Second,
+			err = wait.NicClusterPolicyReadyContext(ctx, inittools.APIClient, nnoNicClusterPolicyName, 60*time.Second,
 				24*time.Minute)
 
 			glog.V(networkparams.LogLevel).Infof("error waiting for NicClusterPolicy to be Ready:  %v ", err)
@@ -1152,7 +1119,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 			By("Wait up to 5 minutes for MacvlanNetwork to be ready")
 			glog.V(networkparams.LogLevel).Infof("Waiting for MacvlanNetwork to be ready")
-			err = wait.MacvlanNetworkReady(inittools.APIClient, macvlanNetworkName, 60*time.Second,
+			err = wait.MacvlanNetworkReadyContext(ctx, inittools.APIClient, macvlanNetworkName, 60*time.Second,
 				5*time.Minute)
 
 			glog.V(networkparams.LogLevel).Infof("error waiting for MacvlanNetwork to be Ready:  %v ", err)
@@ -1245,7 +1212,7 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 			By("Wait up to 5 minutes for IPoIBNetwork to be ready")
 			glog.V(networkparams.LogLevel).Infof("Waiting for IPoIBNetwork to be ready")
-			err = wait.IPoIBNetworkReady(inittools.APIClient, ipoibNetworkName, 60*time.Second,
+			err = wait.IPoIBNetworkReadyContext(ctx, inittools.APIClient, ipoibNetworkName, 60*time.Second,
 				5*time.Minute)
 
 			glog.V(networkparams.LogLevel).Infof("error waiting for IPoIBNetwork to be Ready:  %v ", err)
@@ -1284,9 +1251,6 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 			By("Starting RDMA connectivity test with ib_write_bw testcase")
 			glog.V(networkparams.LogLevel).Infof("\nStarting RDMA connectivity test with ib_write_bw testcase")
 
-			By("Create ib_write_bw server workload pod")
-			glog.V(networkparams.LogLevel).Infof("Create ib_write_bw server workload pod '%s'", rdmaServerPodName)
-
 			// need to set the network name ipoibNetworkName or  macvlanNetworkName
 			workloadPodNetworkName := UndefinedValue
 
@@ -1301,97 +1265,9 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 				workloadPodNetworkName = macvlanNetworkName
 			}
 
-			rdmaServerPod := rdmatest.CreateRdmaWorkloadPod(rdmaServerPodName,
-				rdmaWorkloadNamespace, withCuda, "server", rdmaServerHostname, rdmaMlxDevice,
-				workloadPodNetworkName, rdmaTestImage, rdmaLinkType, "none", rdmaNetworkType)
-
-			createdRdmaServerPod, err := inittools.APIClient.Pods(rdmaServerPod.Namespace).Create(context.TODO(),
-				rdmaServerPod, metav1.CreateOptions{})
-
-			// DEBUG:
-			glog.V(networkparams.LogLevel).Infof("####### Server side - Debug:  err '%v'", err)
-
-			Expect(err).ToNot(HaveOccurred(), "error creating RDMA Server '%s' in cluster: %v",
-				rdmaServerPodName, err)
-
-			glog.V(networkparams.LogLevel).Infof("Successfully created RDMA ib_write_bw server workload pod '%s'",
-				createdRdmaServerPod.Name)
-
-			By("Wait 4 minutes for RDMA server pod to be running")
-			glog.V(networkparams.LogLevel).Infof("Waiting for 4 minutes for the RDMA server to be running")
-			time.Sleep(4 * time.Minute)
-
-			By("Get the interface net1 IP address in the ib_write_bw server workload pod")
-			glog.V(networkparams.LogLevel).Infof("Get the interface net1 interface Ip address in the "+
-				"ib_write_bw server workload pod '%s'", rdmaServerPodName)
-
-			net1IntIpAddrServer, err := rdmatest.GetMyServerIP(inittools.APIClient, rdmaServerPodName,
-				rdmaWorkloadNamespace, "net1")
-
-			Expect(err).ToNot(HaveOccurred(), "error getting RDMA Server '%s' net1 interface ip "+
-				"address: %v", rdmaServerPodName, err)
-
-			glog.V(networkparams.LogLevel).Infof("RDMA Server interface net1 IP address captured: '%s'",
-				net1IntIpAddrServer)
-			Expect(net1IntIpAddrServer).ToNot(BeNil(), fmt.Sprintf("error RDMA Server '%s' net1 interface "+
-				"IP address: '%s' is null", rdmaServerPodName, net1IntIpAddrServer))
-
-			By("Create ib_write_bw client workload pod")
-			glog.V(networkparams.LogLevel).Infof("Create ib_write_bw Client workload pod '%s' and "+
-				"passing server ip address '%s'", rdmaClientPodName, net1IntIpAddrServer)
-
-			rdmaClientPod := rdmatest.CreateRdmaWorkloadPod(rdmaClientPodName, rdmaWorkloadNamespace, withCuda,
-				"client", rdmaClientHostname, rdmaMlxDevice, workloadPodNetworkName, rdmaTestImage,
-				rdmaLinkType, net1IntIpAddrServer, rdmaNetworkType)
-
-			createdRdmaClientPod, err := inittools.APIClient.Pods(rdmaClientPod.Namespace).Create(context.TODO(),
-				rdmaClientPod, metav1.CreateOptions{})
-
-			// DEBUG:
-			glog.V(networkparams.LogLevel).Infof("####### Client side - Debug:  err '%v'", err)
-
-			Expect(err).ToNot(HaveOccurred(), "error creating RDMA Client '%s' in cluster: %v",
-				rdmaClientPodName, err)
-
-			glog.V(networkparams.LogLevel).Infof("RDMA Client workload pod '%s' was successfully created in "+
-				"namespace '%s' and passed server IP Address '%s'", createdRdmaClientPod.Name,
-				createdRdmaClientPod.Namespace, net1IntIpAddrServer)
-
-			// Later remove sleep time and detect when RDMA test has completed
-			By("Wait 7 minutes for RDMA ib_write_bw tests to complete")
-			glog.V(networkparams.LogLevel).Infof("Waiting for 7 minutes for the RDMA ib_write_bw tests to " +
-				"complete")
-			time.Sleep(7 * time.Minute)
-
-			By("Collect logs from RDMA ib_write_bw tests from server workload pod")
-			glog.V(networkparams.LogLevel).Infof("Collect logs from RDMA ib_write_bw tests from server " +
-				"workload pod")
-
-			serverLogs, err := rdmatest.GetPodLogs(inittools.APIClient, rdmaWorkloadNamespace, rdmaServerPodName)
-
-			Expect(err).ToNot(HaveOccurred(), "error collecting RDMA server '%s' pod logs: %v",
-				rdmaServerPodName, err)
-
-			glog.V(networkparams.LogLevel).Infof("RDMA server logs collected: \n'%s'", serverLogs)
-
-			By("Parse logs from RDMA ib_write_bw tests from server workload pod")
-			parseLogsMap, err := rdmatest.ParseRdmaOutput(serverLogs)
-			Expect(err).ToNot(HaveOccurred(), "error parsing RDMA server '%s' pod logs: %v",
-				rdmaServerPodName, err)
-
-			// Pretty print JSON output
-			jsonParseLogsMap, err := json.MarshalIndent(parseLogsMap, "", "  ")
-			Expect(err).ToNot(HaveOccurred(), "error formatting parsed RDMA server pod logs: %v", err)
-
-			glog.V(networkparams.LogLevel).Infof("Parsed and formatted RDMA server logs: \n'%s'",
-				string(jsonParseLogsMap))
-
-			By("Validate logs from RDMA ib_write_bw tests from server workload pod")
-			rdmaTestPassFail, err := rdmatest.ValidateRDMAResults(parseLogsMap)
-
-			Expect(rdmaTestPassFail).ToNot(BeFalse(), "RDMA test workload execution was FAILED, "+
-				"errors encountered: %v", err)
-			glog.V(networkparams.LogLevel).Infof("RDMA test validation has PASSED.  Successful test !")
+			for _, benchmark := range rdmaBenchmarks {
+				runRdmaPerftest(benchmark, rdmaServerPodName, rdmaClientPodName, rdmaMlxDevice, workloadPodNetworkName)
+			}
 		})
 
 		// RDMA Legacy SRIOV testcase
@@ -1412,10 +1288,6 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 			glog.V(networkparams.LogLevel).Infof("\nStarting RDMA Legacy SRIOV connectivity test " +
 				"with ib_write_bw testcase")
 
-			By("Create ib_write_bw server workload pod")
-			glog.V(networkparams.LogLevel).Infof("Create ib_write_bw server workload pod '%s'",
-				rdmaServerPodName)
-
 			// For SRIOV we need to find the RDMA device ids for both server and client
 			// One way to do that is run `rdma link show` inside oc debug pod, but the workload
 			// rdma-tools container will dynamically find it at runtime
@@ -1453,105 +1325,96 @@ var _ = Describe("NNO", Ordered, Label(tsparams.LabelSuite), func() {
 
 			// NO Need to parse the mlx5_x device id from the logs
 
-			rdmaServerPod := rdmatest.CreateRdmaWorkloadPod(rdmaServerPodName, rdmaWorkloadNamespace, withCuda,
-				"server", rdmaServerHostname, "sriov", sriovNetworkName, rdmaTestImage, rdmaLinkType,
-				"none", rdmaNetworkType)
-
-			createdRdmaServerPod, err := inittools.APIClient.Pods(rdmaServerPod.Namespace).Create(context.TODO(),
-				rdmaServerPod, metav1.CreateOptions{})
-
-			// DEBUG:
-			glog.V(networkparams.LogLevel).Infof("####### Server side - Debug:  err '%v'", err)
-
-			Expect(err).ToNot(HaveOccurred(), "error creating RDMA Server '%s' in cluster: %v",
-				rdmaServerPodName, err)
-
-			glog.V(networkparams.LogLevel).Infof("Successfully created RDMA ib_write_bw server workload pod '%s'",
-				createdRdmaServerPod.Name)
-
-			By("Wait 4 minutes for RDMA server pod to be running")
-			glog.V(networkparams.LogLevel).Infof("Waiting for 4 minutes for the RDMA server to be running")
-			time.Sleep(4 * time.Minute)
-
-			By("Get the interface net1 IP address in the ib_write_bw server workload pod")
-			glog.V(networkparams.LogLevel).Infof("Get the interface net1 interface Ip address in the "+
-				"ib_write_bw server workload pod '%s'", rdmaServerPodName)
-
-			net1IntIpAddrServer, err := rdmatest.GetMyServerIP(inittools.APIClient, rdmaServerPodName,
-				rdmaWorkloadNamespace, "net1")
-
-			Expect(err).ToNot(HaveOccurred(), "error getting RDMA Server '%s' net1 interface ip "+
-				"address: %v", rdmaServerPodName, err)
-
-			glog.V(networkparams.LogLevel).Infof("RDMA Server interface net1 IP address captured: '%s'",
-				net1IntIpAddrServer)
-			Expect(net1IntIpAddrServer).ToNot(BeNil(), fmt.Sprintf("error RDMA Server '%s' net1 interface "+
-				"IP address: '%s' is null", rdmaServerPodName, net1IntIpAddrServer))
-
-			By("Create ib_write_bw client workload pod")
-			glog.V(networkparams.LogLevel).Infof("Create ib_write_bw Client workload pod '%s' and "+
-				"passing server ip address '%s'", rdmaClientPodName, net1IntIpAddrServer)
-
-			// Debug:
-			glog.V(networkparams.LogLevel).Infof("withCuda switch for RDMA workload is set to '%s'",
-				withCuda)
-
-			rdmaClientPod := rdmatest.CreateRdmaWorkloadPod(rdmaClientPodName, rdmaWorkloadNamespace, withCuda,
-				"client", rdmaClientHostname, "sriov", sriovNetworkName, rdmaTestImage,
-				rdmaLinkType, net1IntIpAddrServer, rdmaNetworkType)
-
-			createdRdmaClientPod, err := inittools.APIClient.Pods(rdmaClientPod.Namespace).Create(context.TODO(),
-				rdmaClientPod, metav1.CreateOptions{})
-
-			// DEBUG:
-			glog.V(networkparams.LogLevel).Infof("####### Client side - Debug:  err '%v'", err)
-
-			Expect(err).ToNot(HaveOccurred(), "error creating RDMA Client '%s' in cluster: %v",
-				rdmaClientPodName, err)
-
-			glog.V(networkparams.LogLevel).Infof("RDMA Client workload pod '%s' was successfully created in "+
-				"namespace '%s' and passed server IP Address '%s'", createdRdmaClientPod.Name,
-				createdRdmaClientPod.Namespace, net1IntIpAddrServer)
-
-			// Later remove sleep time and detect when RDMA test has completed
-			By("Wait 7 minutes for RDMA ib_write_bw tests to complete")
-			glog.V(networkparams.LogLevel).Infof("Waiting for 7 minutes for the RDMA ib_write_bw tests to " +
-				"complete")
-			time.Sleep(7 * time.Minute)
+			// Only the image entrypoint discovers the SR-IOV device at runtime, the other benchmarks need its name
+			if !slices.Contains(rdmaBenchmarks, rdmatest.BenchmarkWriteBW) {
+				Skip(fmt.Sprintf("the legacy SR-IOV RDMA testcase only runs %s, configured benchmarks: %v",
+					rdmatest.BenchmarkWriteBW, rdmaBenchmarks))
+			}
 
-			By("Collect logs from RDMA ib_write_bw tests from server workload pod")
-			glog.V(networkparams.LogLevel).Infof("Collect logs from RDMA ib_write_bw tests from server " +
-				"workload pod")
+			glog.V(networkparams.LogLevel).Infof("Running %s only, the legacy SR-IOV device is discovered by the "+
+				"test image entrypoint", rdmatest.BenchmarkWriteBW)
+			runRdmaPerftest(rdmatest.BenchmarkWriteBW, rdmaServerPodName, rdmaClientPodName, "sriov", sriovNetworkName)
+		})
 
-			serverLogs, err := rdmatest.GetPodLogs(inittools.APIClient, rdmaWorkloadNamespace, rdmaServerPodName)
+	})
+})
 
-			Expect(err).ToNot(HaveOccurred(), "error collecting RDMA server '%s' pod logs: %v",
-				rdmaServerPodName, err)
+// waitForNNODeployment waits for the Network Operator deployment to be created and checks it is ready
+func waitForNNODeployment(ctx context.Context) {
+	By("Wait for up to 4 minutes for Network Operator deployment to be created")
+	nnoDeploymentCreated := wait.DeploymentCreatedContext(ctx, inittools.APIClient, nnoDeployment, nnoNamespace,
+		30*time.Second, 4*time.Minute)
+	Expect(nnoDeploymentCreated).ToNot(BeFalse(), "timed out waiting to deploy "+
+		"Network operator")
 
-			glog.V(networkparams.LogLevel).Infof("RDMA server logs collected: \n'%s'", serverLogs)
+	By("Check if the Network operator deployment is ready")
+	nnoOperatorDeployment, err := deployment.Pull(inittools.APIClient, nnoDeployment, nnoNamespace)
 
-			By("Parse logs from RDMA ib_write_bw tests from server workload pod")
-			parseLogsMap, err := rdmatest.ParseRdmaOutput(serverLogs)
-			Expect(err).ToNot(HaveOccurred(), "error parsing RDMA server '%s' pod logs: %v",
-				rdmaServerPodName, err)
+	Expect(err).ToNot(HaveOccurred(), "Error trying to pull Network operator "+
+		"deployment is: %v", err)
 
-			// Pretty print JSON output
-			jsonParseLogsMap, err := json.MarshalIndent(parseLogsMap, "", "  ")
-			Expect(err).ToNot(HaveOccurred(), "error formatting parsed RDMA server pod logs: %v", err)
+	glog.V(networkparams.LogLevel).Infof("Pulled Network operator deployment is:  %v ",
+		nnoOperatorDeployment.Definition.Name)
 
-			glog.V(networkparams.LogLevel).Infof("Parsed and formatted RDMA server logs: \n'%s'",
-				string(jsonParseLogsMap))
+	if nnoOperatorDeployment.IsReady(4 * time.Minute) {
+		glog.V(networkparams.LogLevel).Infof("Pulled Network operator deployment '%s' is Ready",
+			nnoOperatorDeployment.Definition.Name)
+	}
+}
+
+// runRdmaPerftest runs the perftest benchmark server and client workload pods on the RDMA device and secondary
+// network as a workload group, the client connecting to the net1 IP of the server, and validates their perftest
+// results. The benchmark is appended to the pod names so every benchmark of a testcase gets its own pods.
+func runRdmaPerftest(benchmark, serverPodName, clientPodName, device, networkName string) {
+	benchmarkSuffix := "-" + strings.ReplaceAll(benchmark, "_", "-")
+	serverPodName += benchmarkSuffix
+	clientPodName += benchmarkSuffix
+
+	newRdmaWorkload := func(podName, mode, hostname string) *rdmatest.Workload {
+		return rdmatest.NewWorkload(podName, rdmaTestImage, mode).
+			WithBenchmark(benchmark).
+			WithCuda(withCuda == "yes").
+			WithNode(hostname).
+			WithDevice(device).
+			WithNetwork(networkName).
+			WithLinkType(rdmaLinkType).
+			WithNetworkType(rdmaNetworkType).
+			WithThresholds(perftestThresholds)
+	}
 
-			By("Validate logs from RDMA ib_write_bw tests from server workload pod")
-			rdmaTestPassFail, err := rdmatest.ValidateRDMAResults(parseLogsMap)
+	serverWorkload := newRdmaWorkload(serverPodName, rdmatest.ModeServer, rdmaServerHostname)
+	clientWorkload := newRdmaWorkload(clientPodName, rdmatest.ModeClient, rdmaClientHostname)
 
-			Expect(rdmaTestPassFail).ToNot(BeFalse(), "RDMA test workload execution was FAILED, "+
-				"errors encountered: %v", err)
-			glog.V(networkparams.LogLevel).Infof("RDMA test validation has PASSED.  Successful test !")
+	rdmaGroup := testworkloads.NewGroup(inittools.APIClient, rdmaWorkloadNamespace).
+		WithDependencyTimeout(rdmaServerRunningTimeout).
+		Add(rdmaServerMember, serverWorkload).
+		AddWithDependencies(rdmaClientMember, clientWorkload, []string{rdmaServerMember},
+			clientWorkload.ServerIPHook(inittools.APIClient, rdmaServerMember))
 
-			////// debug end
+	DeferCleanup(func() {
+		if cleanupAfterTest {
+			err := rdmaGroup.Delete()
+			Expect(err).ToNot(HaveOccurred(), "error deleting RDMA workload pods: %v", err)
+		}
+	})
 
-		})
+	By(fmt.Sprintf("Create %s server workload pod '%s' and, once it is running, client workload pod '%s' "+
+		"connecting to its net1 IP address", benchmark, serverPodName, clientPodName))
+	err := rdmaGroup.Create().Error()
+	Expect(err).ToNot(HaveOccurred(), "error creating RDMA workload pods: %v", err)
+
+	By(fmt.Sprintf("Wait up to %s for RDMA %s tests to complete and validate their results",
+		rdmaPerftestTimeout, benchmark))
+	err = rdmaGroup.WaitUntilSuccess(rdmaPerftestTimeout).Error()
+
+	for _, workload := range []*rdmatest.Workload{serverWorkload, clientWorkload} {
+		jsonPerftestResults, jsonErr := json.MarshalIndent(workload.Results(), "", "  ")
+		if jsonErr == nil && workload.Results() != nil {
+			glog.V(networkparams.LogLevel).Infof("Parsed RDMA %s results: \n'%s'", benchmark,
+				string(jsonPerftestResults))
+		}
+	}
 
-	})
-})
+	Expect(err).ToNot(HaveOccurred(), "RDMA test workload execution was FAILED, errors encountered: %v", err)
+	glog.V(networkparams.LogLevel).Infof("RDMA test validation has PASSED.  Successful test !")
+}
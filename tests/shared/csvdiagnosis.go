@@ -0,0 +1,23 @@
+package shared
+
+import (
+	"errors"
+
+	"github.com/golang/glog"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
+)
+
+// WriteCSVDiagnosisReport writes the diagnosis carried by an *olm.CSVNotSucceededError in err to the report
+// directory, the file name being prefix followed by olm.CSVDiagnosisReportFile. Other errors are ignored.
+func WriteCSVDiagnosisReport(err error, prefix string) {
+	var csvNotSucceeded *olm.CSVNotSucceededError
+	if !errors.As(err, &csvNotSucceeded) {
+		return
+	}
+
+	if err := csvNotSucceeded.Diagnosis.WriteReport(inittools.GeneralConfig,
+		prefix+olm.CSVDiagnosisReportFile); err != nil {
+		glog.Error("Error writing the ClusterServiceVersion diagnosis report: ", err)
+	}
+}
@@ -0,0 +1,36 @@
+package shared
+
+import (
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2" //nolint:staticcheck
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inventory"
+)
+
+// WriteGPUInventory writes the GPU inventory of the nodes matching workerNodeSelector as JSON and as JUnit
+// properties in the reports directory, the file names being prefix followed by inventory.ReportFile and
+// inventory.JUnitReportFile so that each suite keeps its own inventory. The inventory is also added as a report entry
+// of the running spec. It is informational, so errors are logged without failing the spec.
+func WriteGPUInventory(workerNodeSelector map[string]string, prefix string) {
+	By("Write the GPU inventory of the GPU nodes")
+
+	gpuInventory, err := inventory.Collect(inittools.APIClient, workerNodeSelector)
+	if err != nil {
+		glog.Error("Error collecting the GPU inventory: ", err)
+
+		return
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Collected the GPU inventory of %d nodes", len(gpuInventory.Nodes))
+
+	AddReportEntry("GPU inventory", gpuInventory, ReportEntryVisibilityFailureOrVerbose)
+
+	if err := gpuInventory.WriteReport(inittools.GeneralConfig, prefix+inventory.ReportFile); err != nil {
+		glog.Error("Error writing the GPU inventory file: ", err)
+	}
+
+	if err := gpuInventory.WriteJUnitReport(inittools.GeneralConfig, prefix+inventory.JUnitReportFile); err != nil {
+		glog.Error("Error writing the GPU inventory JUnit file: ", err)
+	}
+}
@@ -0,0 +1,633 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/nvidia/v1"
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2" //nolint:staticcheck
+	. "github.com/onsi/gomega"    //nolint:staticcheck
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuburn"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/inittools"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/nvidiagpuconfig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/testworkloads"
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/wait"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/mig"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/namespace"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nodes"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nvidiagpu"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// heterogeneousMIGConfigMapName is the configmap of the mig-parted configurations of the heterogeneous MIG test.
+	heterogeneousMIGConfigMapName = "heterogeneous-mig-parted-config"
+	// gpuCountLabel is the GPU feature discovery label with the number of GPUs of a node.
+	gpuCountLabel = "nvidia.com/gpu.count"
+)
+
+// TestSingleMIGGPUWorkload performs the GPU Burn test with single strategy MIG Configuration
+// Check mig.capable label (label might not exist after preceding tests, but it should reappear as either true or false)
+//
+//	therefore have to use the wait.NodeLabelExists() function to check for the label and value
+//	If the label is not found, skip the test
+//	If the label is found and value is false, skip the test
+//
+// Clean up existing GPU workload resources, if any
+// Read MIG parameters from CLI parameter, returns -1 for random selection
+// Query MIG profiles from hardware and select one of them as a strategy label for the GPU node
+// Set the strategy and config labels on the GPU node
+// Waiting for ClusterPolicy state transition first to notReady with quick timeout and interval, then to ready
+// Waiting for mig.strategy=single label to be present on GPU nodes
+// Pulling and updating ClusterPolicy, and waiting for the label to be present on GPU nodes
+// Prepare the workload and deploy it (namespace, then 1 single pod for one profile with its configmap)
+// After it has been running and finished, check its logs
+func TestSingleMIGGPUWorkload(ctx context.Context, nvidiaGPUConfig *nvidiagpuconfig.NvidiaGPUConfig,
+	burn *nvidiagpu.GPUBurnConfig, burnImageName map[string]string, workerNodeSelector map[string]string,
+	cleanupAfterTest bool) {
+	// select one mig profile from the list of mig profiles
+	var useMigProfile string // = "mig-1g.5gb"  // mig profiles are queried from the hardware
+	var useMigIndex int      // will be set to random value after migCapabilities is populated
+	var migCapabilities []mig.MIGProfileInfo
+
+	By("Check mig.capability on GPU nodes")
+	err := wait.NodeLabelExistsContext(ctx, inittools.APIClient, "nvidia.com/mig.capable", "true",
+		labels.Set(workerNodeSelector), nvidiagpu.LabelCheckInterval, nvidiagpu.LabelCheckTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Error checking MIG capability on nodes: %v", err)
+
+	// ***** Cleaning up previous GPU Burn resources
+	By("Cleanup if necessary")
+	err = mig.CleanupWorkloadResources(inittools.APIClient, burn)
+	Expect(err).ToNot(HaveOccurred(), "Error cleaning up workload resources: %v", err)
+
+	// Read MIG parameter from CLI parameter, returns -1 for random selection
+	// Read Mixed MIG parameter from CLI parameter, returns slice of instance counts per profile, or default values
+	// Query MIG capabilities and select MIG profile and index to be used later.
+	// Select MIG profile and index to be used later
+	By("Read single.mig.profile parameter and select MIG profile")
+	migStrategy := mig.MIGStrategySingle
+	migInstanceCounts := ReadMIGParameter()
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Parsed MIG instance counts: %v", migInstanceCounts)
+	useMigIndex = ReadSingleMIGParameter()
+	migCapabilities, useMigIndex, err = mig.SelectMigProfile(inittools.APIClient, workerNodeSelector, useMigIndex,
+		migInstanceCounts)
+	Expect(err).ToNot(HaveOccurred(), "Error selecting MIG profile: %v", err)
+	_ = mig.UpdateMIGCapabilities(migCapabilities, migInstanceCounts, migStrategy)
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Updated MigCapabilities: %v", migCapabilities)
+
+	// Pull existing ClusterPolicy
+	By("Pull existing ClusterPolicy")
+	pulledClusterPolicyBuilder, err := nvidiagpu.Pull(inittools.APIClient, nvidiagpu.ClusterPolicyName)
+	Expect(err).ToNot(HaveOccurred(), "error pulling ClusterPolicy: %v", err)
+	initialClusterPolicyResourceVersion := pulledClusterPolicyBuilder.Object.ResourceVersion
+	Expect(initialClusterPolicyResourceVersion).ToNot(BeEmpty(), "initialClusterPolicyResourceVersion is empty after pull ClusterPolicy")
+
+	// Restore the MIG strategy of the ClusterPolicy and the MIG labels of the GPU nodes after the test
+	By("Take a snapshot of the ClusterPolicy and the MIG labels of the GPU nodes")
+	defer restoreClusterPolicy(ctx, snapshotClusterPolicy(workerNodeSelector))
+
+	// Configure MIG strategy for the test
+	By("Configuring MIG strategy in ClusterPolicy")
+	clusterArch, err := mig.ConfigureMIGStrategy(inittools.APIClient, pulledClusterPolicyBuilder, workerNodeSelector,
+		nvidiagpuv1.MIGStrategySingle)
+	Expect(err).ToNot(HaveOccurred(), "error configuring MIG strategy and getting cluster architecture: %v", err)
+
+	// Set the single MIG strategy and mig.config labels on GPU worker nodes
+	By("Set the MIG strategy label on GPU worker nodes")
+	useMigProfile, err = mig.SetMIGLabelsOnNodes(inittools.APIClient, migCapabilities, useMigIndex, workerNodeSelector,
+		migStrategy)
+	Expect(err).ToNot(HaveOccurred(), "Error setting MIG labels on nodes: %v", err)
+
+	// Waiting for ClusterPolicy state transition first to notReady with quick timeout and interval, then to ready
+	// error is ignored in case of timeout, if the state transition from ready to notReady and back to ready.
+	// It is acceptable to continue after timeout to notReady state if the following state is ready.
+	By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be notReady after node label changes", nvidiagpu.ClusterPolicyNotReadyTimeout))
+	_ = wait.ClusterPolicyNotReadyContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+		nvidiagpu.ClusterPolicyNotReadyCheckInterval, nvidiagpu.ClusterPolicyNotReadyTimeout)
+
+	// Wait for ClusterPolicy to be ready. Changing labels will take a couple of minutes.
+	By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be ready", nvidiagpu.ClusterPolicyReadyTimeout))
+	err = wait.ClusterPolicyReadyContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+		nvidiagpu.ClusterPolicyReadyCheckInterval, nvidiagpu.ClusterPolicyReadyTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Error waiting for ClusterPolicy to be ready: %v", err)
+
+	// Node labels are updated after ClusterPolicy is ready, it takes some time for them to appear.
+	By("Check for MIG single strategy capability labels on GPU nodes")
+	migSingleLabel := "nvidia.com/mig.strategy"
+	expectedLabelValue := mig.MIGStrategySingle
+	err = wait.NodeLabelExistsContext(ctx, inittools.APIClient, migSingleLabel, expectedLabelValue,
+		labels.Set(workerNodeSelector), nvidiagpu.LabelCheckInterval, nvidiagpu.LabelCheckTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Could not find at least one node with label '%s' set to '%s'", migSingleLabel, expectedLabelValue)
+	glog.V(gpuparams.Gpu10LogLevel).Infof("MIG single strategy label found, proceeding with test")
+
+	// Check and create test-gpu-burn namespace if it is missing
+	By("Create test-gpu-burn namespace")
+	createGPUBurnNamespace(burn)
+
+	// Deploy GPU Burn pod with MIG single strategy configuration
+	By("Deploy gpu-burn pod with MIG configuration in test-gpu-burn namespace")
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Creating image '%s' pod with MIG profile '%s' in burn: '%s' requesting %d instances",
+		burnImageName[clusterArch], useMigProfile, burn, migCapabilities[useMigIndex].Total)
+	// Using total, because nvidia-smi Available field may sometimes be zero (e.g. pods are running for some reason)
+	// Using migCapabilities[useMigIndex].MixedCnt could be used to restrict the number of instances to use,
+	// but it would cause problems when both single-mig and mixed-mig testcases are run in the same test suite.
+	runMIGGPUBurn(nvidiaGPUConfig, burn, gpuburn.NewWorkload(burn.PodName, burnImageName[clusterArch]).
+		WithMIG(useMigProfile, migCapabilities[useMigIndex].Total), cleanupAfterTest)
+
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Single MIG Test completed")
+}
+
+// TestMixedMIGGPUWorkload performs the GPU Burn test with mixed strategy MIG Configuration
+// Check mig.capable label
+// Clean up existing GPU workload resources, if any
+// Read Mixed MIG parameter from CLI parameter
+// Query MIG capabilities and select MIG profiles to be used later.
+// Read Mixed MIG strategy to be used (e.g. mixed or flavor based)
+// Read the delay to be used between pod launches
+// Pull existing ClusterPolicy
+// Configure MIG strategy and set the label on GPU nodes
+// Wait for quick ClusterPolicy state transition to notReady and back to ready
+// Create namespace before starting creation of the pods
+// Launch the GPU Burn pods as a group, one for each requested profile with optional delay between them.
+// Ensure the pods get into Running state and see their MIG devices
+// After all pods are completed, check the logs of each pod.
+func TestMixedMIGGPUWorkload(ctx context.Context, nvidiaGPUConfig *nvidiagpuconfig.NvidiaGPUConfig,
+	burn *nvidiagpu.GPUBurnConfig, burnImageName map[string]string, workerNodeSelector map[string]string,
+	cleanupAfterTest bool) {
+	// Any combination of mig profiles can be selected, by default 2x 1g.5gb + 1x 2g.10gb + 1x 3g.20gb
+	// The valid combination for A100 is 2x 1g.5gb + 1x 2g.10gb + 1x 3g.20gb
+	// If so wished, 1x can be used insteady of 2x and 0x can be used instead of 1x or 2x.
+	var useMigIndex int // will be set to random value after migCapabilities is populated
+	var migCapabilities []mig.MIGProfileInfo
+
+	By("Check mig.capability on GPU nodes")
+	err := wait.NodeLabelExistsContext(ctx, inittools.APIClient, "nvidia.com/mig.capable", "true",
+		labels.Set(workerNodeSelector), nvidiagpu.LabelCheckInterval, nvidiagpu.LabelCheckTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Error checking MIG capability on nodes: %v", err)
+
+	// ***** Cleaning up previous GPU Burn resources
+	By("Cleanup if necessary")
+	err = mig.CleanupWorkloadResources(inittools.APIClient, burn)
+	Expect(err).ToNot(HaveOccurred(), "Error cleaning up workload resources: %v", err)
+
+	// Read Mixed MIG parameter from CLI parameter, returns slice of instance counts per profile, or default values
+	// Query MIG capabilities and select MIG profiles to be used later.
+	By("Read mixed.mig.instances parameter and select MIG profile")
+	migStrategy := mig.MIGStrategyMixed
+	migInstanceCounts := ReadMIGParameter()
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Parsed MIG instance counts: %v", migInstanceCounts)
+	useMigIndex = ReadSingleMIGParameter()
+	migCapabilities, useMigIndex, err = mig.SelectMigProfile(inittools.APIClient, workerNodeSelector, useMigIndex,
+		migInstanceCounts)
+	Expect(err).ToNot(HaveOccurred(), "Error selecting MIG profile: %v", err)
+
+	By("Check that the MIG instances fit the GPU")
+	migInstanceCounts = planMIGInstances(migCapabilities, migInstanceCounts, workerNodeSelector)
+	SumOfMixedCnt := mig.UpdateMIGCapabilities(migCapabilities, migInstanceCounts, migStrategy)
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Updated MigCapabilities: %v", migCapabilities)
+	// Requesting for specific MIG profile and requesting 0 instances is a dry run (just changing labels etc) without any pod creation.
+	if SumOfMixedCnt == 0 {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Dry run, no pod creation because of parameter settings: "+
+			"strategy=%s instances=%s count=%d", migStrategy, MigInstances, SumOfMixedCnt)
+	}
+
+	// Read the delay to be used between pod launches
+	// This can be used to have the pods running completely, mostly, slightly or not overlapping.
+	By("Read mixed.mig.pod-delay parameter and set delay between pods")
+	delayBetweenPods := ReadDelayBetweenPods()
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Read Delay between pods: %v seconds", delayBetweenPods)
+
+	// Pull existing ClusterPolicy
+	By("Pull existing ClusterPolicy")
+	pulledClusterPolicyBuilder, err := nvidiagpu.Pull(inittools.APIClient, nvidiagpu.ClusterPolicyName)
+	Expect(err).ToNot(HaveOccurred(), "error pulling ClusterPolicy: %v", err)
+	initialClusterPolicyResourceVersion := pulledClusterPolicyBuilder.Object.ResourceVersion
+	Expect(initialClusterPolicyResourceVersion).ToNot(BeEmpty(), "initialClusterPolicyResourceVersion is empty after pull ClusterPolicy")
+
+	// Restore the MIG strategy of the ClusterPolicy and the MIG labels of the GPU nodes after the test
+	By("Take a snapshot of the ClusterPolicy and the MIG labels of the GPU nodes")
+	defer restoreClusterPolicy(ctx, snapshotClusterPolicy(workerNodeSelector))
+
+	// Configure MIG strategy for the test in ClusterPolicy
+	By("Configuring MIG strategy in ClusterPolicy")
+	clusterArch, err := mig.ConfigureMIGStrategy(inittools.APIClient, pulledClusterPolicyBuilder, workerNodeSelector,
+		nvidiagpuv1.MIGStrategyMixed)
+	Expect(err).ToNot(HaveOccurred(), "error configuring MIG strategy and getting cluster architecture: %v", err)
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Cluster architecture: %v", clusterArch)
+
+	// Set MIG mixed strategy and mig.config labels on GPU nodes
+	// return values is irrelevant on mixed strategy testcase.
+	By("Set MIG mixed strategy label")
+	_, err = mig.SetMIGLabelsOnNodes(inittools.APIClient, migCapabilities, useMigIndex, workerNodeSelector, migStrategy)
+	Expect(err).ToNot(HaveOccurred(), "Error setting MIG labels on nodes: %v", err)
+
+	// Waiting for ClusterPolicy state transition first to notReady with quick timeout and interval, then to ready, timeout is one expected outcome.
+	// Checking that mig.config.state gets into success state
+	By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be notReady after node label changes", nvidiagpu.ClusterPolicyNotReadyTimeout))
+	_ = wait.ClusterPolicyNotReadyContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+		nvidiagpu.ClusterPolicyNotReadyCheckInterval, nvidiagpu.ClusterPolicyNotReadyTimeout)
+	err = mig.CheckMigConfigState(inittools.APIClient, workerNodeSelector)
+	Expect(err).ToNot(HaveOccurred(), "Could not find at least one node with label 'nvidia.com/mig.config.state' set to 'success'")
+
+	// Wait for ClusterPolicy to be ready. Changing labels will take a couple of minutes.
+	By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be ready", nvidiagpu.ClusterPolicyReadyTimeout))
+	err = wait.ClusterPolicyReadyContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+		nvidiagpu.ClusterPolicyReadyCheckInterval, nvidiagpu.ClusterPolicyReadyTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Error waiting for ClusterPolicy to be ready: %v", err)
+	err = mig.CheckMigConfigState(inittools.APIClient, workerNodeSelector)
+	Expect(err).ToNot(HaveOccurred(), "Could not find at least one node with label 'nvidia.com/mig.config.state' set to 'success'")
+
+	// Waiting for the mig.strategy=mixed label to be present on GPU nodes
+	By("Check for MIG mixed strategy capability labels on GPU nodes")
+	migSingleLabel := "nvidia.com/mig.strategy"
+	expectedLabelValue := mig.MIGStrategyMixed
+	err = wait.NodeLabelExistsContext(ctx, inittools.APIClient, migSingleLabel, expectedLabelValue,
+		labels.Set(workerNodeSelector), nvidiagpu.LabelCheckInterval, nvidiagpu.LabelCheckTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Could not find at least one node with label '%s' set to '%s'", migSingleLabel, expectedLabelValue)
+	glog.V(gpuparams.Gpu10LogLevel).Infof("MIG mixed strategy label found, proceeding with test")
+
+	// Checking that mig.config.state gets into success state
+	err = mig.CheckMigConfigState(inittools.APIClient, workerNodeSelector)
+	Expect(err).ToNot(HaveOccurred(), "Could not find at least one node with label 'nvidia.com/mig.config.state' set to 'success'")
+
+	// Check and create test-gpu-burn namespace if it is missing
+	By("Create test-gpu-burn namespace")
+	createGPUBurnNamespace(burn)
+
+	// Deploy GPU Burn pod with MIG mixed strategy configuration for each profile, the pods share the configmap.
+	// Optional delay between pod launches to have control on the pods running at the same time or not.
+	By("Deploy gpu-burn pods with MIG configuration in test-gpu-burn namespace")
+	burnGroup := testworkloads.NewGroup(inittools.APIClient, burn.Namespace).
+		WithStartDelay(time.Duration(delayBetweenPods) * time.Second)
+	burnWorkloads := make(map[string]*gpuburn.Workload)
+
+	for _, migCapability := range migCapabilities {
+		if migCapability.MixedCnt == 0 {
+			continue
+		}
+
+		podName := fmt.Sprintf("gpu-burn-pod-%d-of-mig-%s", migCapability.MixedCnt, migCapability.MigName)
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Adding image '%s' pod %s with MIG mixed strategy requesting %d "+
+			"instances", burnImageName[clusterArch], podName, migCapability.MixedCnt)
+		burnWorkloads[podName] = gpuburn.NewWorkload(podName, burnImageName[clusterArch]).
+			WithMIG(migCapability.MigName, migCapability.MixedCnt).
+			WithConfigMapName(burn.ConfigMapName).
+			WithThresholds(nvidiaGPUConfig.GPUBurnThresholds())
+		burnGroup.Add(podName, burnWorkloads[podName])
+	}
+
+	if len(burnWorkloads) == 0 {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Mixed MIG Test completed without gpu-burn pods")
+		return
+	}
+
+	defer func() {
+		defer GinkgoRecover()
+		glog.V(gpuparams.Gpu100LogLevel).Infof("defer2 (Deleting gpu-burn pods)")
+		if cleanupAfterTest {
+			Expect(burnGroup.Delete()).To(Succeed(), "Error deleting gpu-burn pods")
+		}
+	}()
+
+	burnGroup.Create()
+	Expect(burnGroup.Error()).ToNot(HaveOccurred(), "Error deploying gpu-burn pods with MIG: %v", burnGroup.Error())
+
+	// Previous pods may be completed while the later ones are still running because of mixed.mig.pod-delay,
+	// those are left out of the GPU check.
+	By("Ensure all pods get into Running state and see their MIG devices")
+	burnGroup.CheckGPUs(nvidiagpu.BurnPodRunningTimeout)
+	Expect(burnGroup.Error()).ToNot(HaveOccurred(), "Error checking the GPUs of gpu-burn pods: %v",
+		burnGroup.Error())
+
+	By("Wait for GPU Burn pods to complete and check for successful execution with MIG")
+	burnGroup.WaitUntilSuccess(nvidiagpu.BurnPodSuccessTimeout)
+
+	if burnLogs, err := burnGroup.GetFullLogs(); err == nil {
+		for podName, containerLogs := range burnLogs {
+			glog.V(gpuparams.Gpu10LogLevel).Infof("Gpu-burn pod '%s' with MIG logs:\n%s", podName,
+				containerLogs[gpuburn.ContainerName])
+		}
+	}
+
+	for podName, burnWorkload := range burnWorkloads {
+		writeGPUBurnReport(podName, burnWorkload)
+	}
+
+	Expect(burnGroup.Error()).ToNot(HaveOccurred(), "gpu-burn pod execution with MIG was FAILED: %v",
+		burnGroup.Error())
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Mixed MIG Test completed")
+}
+
+// TestHeterogeneousMIGLayout performs the GPU Burn test on GPU nodes with a custom mig-parted configuration, where
+// the first half of the GPUs of a node are in MIG mode and the other half are full GPUs. Nodes alternate between
+// that layout and all GPUs in MIG mode, each node selecting its named configuration with the nvidia.com/mig.config
+// label. Nodes with a single GPU get it in MIG mode.
+func TestHeterogeneousMIGLayout(ctx context.Context, nvidiaGPUConfig *nvidiagpuconfig.NvidiaGPUConfig,
+	burn *nvidiagpu.GPUBurnConfig, burnImageName map[string]string, workerNodeSelector map[string]string,
+	cleanupAfterTest bool) {
+	By("Check mig.capability on GPU nodes")
+	err := wait.NodeLabelExistsContext(ctx, inittools.APIClient, "nvidia.com/mig.capable", "true",
+		labels.Set(workerNodeSelector), nvidiagpu.LabelCheckInterval, nvidiagpu.LabelCheckTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Error checking MIG capability on nodes: %v", err)
+
+	By("Cleanup if necessary")
+	err = mig.CleanupWorkloadResources(inittools.APIClient, burn)
+	Expect(err).ToNot(HaveOccurred(), "Error cleaning up workload resources: %v", err)
+
+	By("Read single.mig.profile parameter and select MIG profile")
+	migCapabilities, useMigIndex, err := mig.SelectMigProfile(inittools.APIClient, workerNodeSelector,
+		ReadSingleMIGParameter(), nil)
+	Expect(err).ToNot(HaveOccurred(), "Error selecting MIG profile: %v", err)
+	migProfile := migCapabilities[useMigIndex]
+	migDevices := map[string]int{migProfile.MigName: migProfile.Total}
+
+	By("Build the per-node mig-parted configurations")
+	nodeBuilders, err := nodes.List(inittools.APIClient,
+		metav1.ListOptions{LabelSelector: labels.Set(workerNodeSelector).String()})
+	Expect(err).ToNot(HaveOccurred(), "Error listing GPU nodes: %v", err)
+	Expect(nodeBuilders).ToNot(BeEmpty(), "No GPU nodes found matching selector %v", workerNodeSelector)
+
+	migPartedConfig := mig.NewMigPartedConfigBuilder(inittools.APIClient, heterogeneousMIGConfigMapName,
+		nvidiagpu.NvidiaGPUNamespace)
+	allMIGConfig := "all-" + migProfile.MigName
+	migPartedConfig.WithConfig(allMIGConfig, mig.MigEnabledDevices(migDevices))
+
+	nodeConfigs := map[string]string{}
+	halfMIGNodes := map[string]bool{}
+
+	for index, nodeBuilder := range nodeBuilders {
+		nodeName := nodeBuilder.Object.Name
+		gpuCount, err := strconv.Atoi(nodeBuilder.Object.Labels[gpuCountLabel])
+		Expect(err).ToNot(HaveOccurred(), "Error reading label %s of node %s: %v", gpuCountLabel, nodeName, err)
+
+		if index%2 == 1 || gpuCount < 2 {
+			nodeConfigs[nodeName] = allMIGConfig
+
+			continue
+		}
+
+		// Nodes with different GPU counts need configurations with different GPU indexes.
+		halfMIGConfig := fmt.Sprintf("half-%s-of-%d", migProfile.MigName, gpuCount)
+		migPartedConfig.WithConfig(halfMIGConfig, mig.HalfMIGDevices(gpuCount, migDevices)...)
+		nodeConfigs[nodeName] = halfMIGConfig
+		halfMIGNodes[nodeName] = true
+	}
+
+	glog.V(gpuparams.Gpu10LogLevel).Infof("mig-parted configurations of the GPU nodes: %v", nodeConfigs)
+
+	By("Pull existing ClusterPolicy")
+	pulledClusterPolicyBuilder, err := nvidiagpu.Pull(inittools.APIClient, nvidiagpu.ClusterPolicyName)
+	Expect(err).ToNot(HaveOccurred(), "error pulling ClusterPolicy: %v", err)
+
+	By("Take a snapshot of the ClusterPolicy and the MIG labels of the GPU nodes")
+	// Restoring the snapshot also points the MIG manager back to its original mig-parted configmap and default,
+	// and deletes the custom configmap created below.
+	defer restoreClusterPolicy(ctx, snapshotClusterPolicy(workerNodeSelector))
+
+	By("Configuring MIG strategy in ClusterPolicy")
+	clusterArch, err := mig.ConfigureMIGStrategy(inittools.APIClient, pulledClusterPolicyBuilder, workerNodeSelector,
+		nvidiagpuv1.MIGStrategyMixed)
+	Expect(err).ToNot(HaveOccurred(), "error configuring MIG strategy and getting cluster architecture: %v", err)
+
+	By("Configure the custom mig-parted configmap in ClusterPolicy")
+	pulledClusterPolicyBuilder, err = nvidiagpu.Pull(inittools.APIClient, nvidiagpu.ClusterPolicyName)
+	Expect(err).ToNot(HaveOccurred(), "error pulling ClusterPolicy: %v", err)
+	err = mig.ConfigureMigPartedConfig(inittools.APIClient, pulledClusterPolicyBuilder, migPartedConfig)
+	Expect(err).ToNot(HaveOccurred(), "Error configuring the custom mig-parted config: %v", err)
+
+	By("Set the mig.config label of each GPU node")
+	err = mig.SetMIGConfigLabelsPerNode(inittools.APIClient, nodeConfigs)
+	Expect(err).ToNot(HaveOccurred(), "Error setting MIG config labels on nodes: %v", err)
+
+	By(fmt.Sprintf("Wait up to %s for ClusterPolicy to be ready", nvidiagpu.ClusterPolicyReadyTimeout))
+	_ = wait.ClusterPolicyNotReadyContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+		nvidiagpu.ClusterPolicyNotReadyCheckInterval, nvidiagpu.ClusterPolicyNotReadyTimeout)
+	err = wait.ClusterPolicyReadyContext(ctx, inittools.APIClient, nvidiagpu.ClusterPolicyName,
+		nvidiagpu.ClusterPolicyReadyCheckInterval, nvidiagpu.ClusterPolicyReadyTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Error waiting for ClusterPolicy to be ready: %v", err)
+	err = mig.CheckMigConfigState(inittools.APIClient, workerNodeSelector)
+	Expect(err).ToNot(HaveOccurred(), "Could not find at least one node with label 'nvidia.com/mig.config.state' set to 'success'")
+
+	By("Check that the half MIG nodes advertise both MIG devices and full GPUs")
+	migResource := corev1.ResourceName("nvidia.com/mig-" + migProfile.MigName)
+	for nodeName := range halfMIGNodes {
+		Eventually(func() error {
+			nodeBuilder, err := nodes.Pull(inittools.APIClient, nodeName)
+			if err != nil {
+				return err
+			}
+
+			allocatable := nodeBuilder.Object.Status.Allocatable
+			if allocatable.Name(migResource, resource.DecimalSI).IsZero() ||
+				allocatable.Name(nvidiagpu.GPUCapacityKey, resource.DecimalSI).IsZero() {
+				return fmt.Errorf("node %s allocatable %s and %s: %v", nodeName, migResource,
+					nvidiagpu.GPUCapacityKey, allocatable)
+			}
+
+			return nil
+		}).WithPolling(nvidiagpu.LabelCheckInterval).WithTimeout(nvidiagpu.LabelCheckTimeout).
+			Should(Succeed(), "Node %s does not advertise both MIG devices and full GPUs", nodeName)
+	}
+
+	By("Create test-gpu-burn namespace")
+	createGPUBurnNamespace(burn)
+
+	By("Deploy gpu-burn pod on a MIG device of the custom configuration")
+	burn.PodName = "gpu-burn-pod-1-of-mig-" + migProfile.MigName
+	runMIGGPUBurn(nvidiaGPUConfig, burn, gpuburn.NewWorkload(burn.PodName, burnImageName[clusterArch]).
+		WithMIG(migProfile.MigName, 1), cleanupAfterTest)
+	glog.V(gpuparams.Gpu10LogLevel).Infof("Heterogeneous MIG Test completed")
+}
+
+// planMIGInstances fails the spec unless the instance counts fit the placements of the GPU, replacing them with a
+// random mix that fits when --mixed.mig.instances=random.
+func planMIGInstances(migCapabilities []mig.MIGProfileInfo, migInstanceCounts []int,
+	workerNodeSelector map[string]string) []int {
+	placements, err := mig.MIGPlacements(inittools.APIClient, workerNodeSelector)
+	Expect(err).ToNot(HaveOccurred(), "Error getting MIG placements: %v", err)
+
+	planner, err := mig.NewPlanner(migCapabilities, placements)
+	Expect(err).ToNot(HaveOccurred(), "Error creating MIG planner: %v", err)
+
+	if RandomMigInstances {
+		seed := MixedMigSeed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+
+		migInstanceCounts = planner.RandomMix(rand.New(rand.NewSource(seed)))
+		glog.V(gpuparams.GpuLogLevel).Infof("Random MIG instance counts %v, rerun with --mixed.mig.seed=%d",
+			migInstanceCounts, seed)
+	}
+
+	// Like UpdateMIGCapabilities, only the counts of the profiles the GPU has are used.
+	placed, err := planner.Place(migInstanceCounts[:min(len(migInstanceCounts), len(migCapabilities))])
+	Expect(err).ToNot(HaveOccurred(), "MIG instance counts do not fit the GPU: %v", err)
+
+	for _, instance := range placed {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("MIG instance %s placed on memory slices %d-%d", instance.MigName,
+			instance.Start, instance.Start+instance.Size-1)
+	}
+
+	return migInstanceCounts
+}
+
+// snapshotClusterPolicy records the ClusterPolicy and the MIG labels of the GPU nodes for restoreClusterPolicy.
+func snapshotClusterPolicy(workerNodeSelector map[string]string) *nvidiagpu.Snapshot {
+	snapshot, err := nvidiagpu.TakeSnapshot(inittools.APIClient, nvidiagpu.ClusterPolicyName,
+		nvidiagpu.NvidiaGPUNamespace, workerNodeSelector)
+	Expect(err).ToNot(HaveOccurred(), "Error taking a snapshot of the ClusterPolicy: %v", err)
+
+	return snapshot
+}
+
+// restoreClusterPolicy puts the ClusterPolicy and the MIG labels of the GPU nodes back as recorded by the snapshot
+// and waits for the ClusterPolicy to be ready again, so that the following specs start from the original state.
+func restoreClusterPolicy(ctx context.Context, snapshot *nvidiagpu.Snapshot) {
+	defer GinkgoRecover()
+	glog.V(gpuparams.Gpu100LogLevel).Infof("defer1 (restore the ClusterPolicy snapshot)")
+
+	err := wait.ClusterPolicyRestoredContext(ctx, inittools.APIClient, snapshot,
+		nvidiagpu.ClusterPolicyReadyCheckInterval, nvidiagpu.ClusterPolicyReadyTimeout)
+	Expect(err).ToNot(HaveOccurred(), "Error restoring the ClusterPolicy snapshot: %v", err)
+}
+
+// createGPUBurnNamespace creates the GPU Burn namespace if it is missing.
+func createGPUBurnNamespace(burn *nvidiagpu.GPUBurnConfig) {
+	gpuBurnNsBuilder := namespace.NewBuilder(inittools.APIClient, burn.Namespace)
+	if !gpuBurnNsBuilder.Exists() {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Creating the gpu burn namespace '%s'", burn.Namespace)
+		_, err := gpuBurnNsBuilder.Create()
+		Expect(err).ToNot(HaveOccurred(), "error creating gpu burn "+
+			"namespace '%s' : %v ", burn.Namespace, err)
+	}
+}
+
+// runMIGGPUBurn deploys the gpu-burn workload on its MIG devices and fails the spec unless gpu-burn succeeded on
+// every one of them within the thresholds, writing the parsed result as a report either way.
+func runMIGGPUBurn(nvidiaGPUConfig *nvidiagpuconfig.NvidiaGPUConfig, burn *nvidiagpu.GPUBurnConfig,
+	burnWorkload *gpuburn.Workload, cleanupAfterTest bool) {
+	burnWorkloadBuilder := testworkloads.NewBuilder(inittools.APIClient, burn.Namespace,
+		burnWorkload.WithConfigMapName(burn.ConfigMapName).WithThresholds(nvidiaGPUConfig.GPUBurnThresholds()))
+
+	defer func() {
+		defer GinkgoRecover()
+		glog.V(gpuparams.Gpu100LogLevel).Infof("defer2 (Deleting gpu-burn pod)")
+		if cleanupAfterTest {
+			Expect(burnWorkloadBuilder.Delete()).To(Succeed(), "Error deleting gpu-burn pod")
+		}
+	}()
+
+	burnWorkloadBuilder.Create()
+	Expect(burnWorkloadBuilder.Error()).ToNot(HaveOccurred(), "Error deploying gpu-burn pod with MIG: %v",
+		burnWorkloadBuilder.Error())
+
+	By(fmt.Sprintf("Wait for up to %s for gpu-burn pod with MIG to be in Running phase", nvidiagpu.BurnPodRunningTimeout))
+	burnWorkloadBuilder.WaitUntilRunning(nvidiagpu.BurnPodRunningTimeout)
+	Expect(burnWorkloadBuilder.Error()).ToNot(HaveOccurred(), "Error waiting for gpu-burn pod with MIG to be "+
+		"running: %v", burnWorkloadBuilder.Error())
+
+	By("Check that the gpu-burn pod sees its MIG devices")
+	burnWorkloadBuilder.CheckGPUs()
+	Expect(burnWorkloadBuilder.Error()).ToNot(HaveOccurred(), "gpu-burn pod with MIG does not see any GPU: %v",
+		burnWorkloadBuilder.Error())
+
+	By("Wait for the gpu-burn pod to complete and check for successful execution with MIG")
+	burnWorkloadBuilder.WaitUntilSuccess(nvidiagpu.BurnPodSuccessTimeout)
+
+	if burnLogs, err := burnWorkloadBuilder.GetFullLogs(gpuburn.ContainerName); err == nil {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Gpu-burn pod '%s' with MIG logs:\n%s", burn.PodName, burnLogs)
+	}
+
+	writeGPUBurnReport(burn.PodName, burnWorkload)
+
+	Expect(burnWorkloadBuilder.Error()).ToNot(HaveOccurred(), "gpu-burn pod execution with MIG was FAILED: %v",
+		burnWorkloadBuilder.Error())
+}
+
+// writeGPUBurnReport writes the result parsed by the gpu-burn workload as a report named after its pod, if it got
+// that far.
+func writeGPUBurnReport(podName string, burnWorkload *gpuburn.Workload) {
+	if burnResult := burnWorkload.Result(); burnResult != nil {
+		if err := burnResult.WriteReport(inittools.GeneralConfig, "mig-"+podName+"-"+gpuburn.ResultReportFile); err != nil {
+			glog.Error("Error writing the gpu-burn result file: ", err)
+		}
+	}
+}
+
+// CleanupGPUOperatorResources performs cleanup of GPU Operator resources
+// It checks if cleanup should run based on cleanupAfterTest and cleanup label
+func CleanupGPUOperatorResources(ctx context.Context, cleanupAfterTest bool, burnNamespace string) {
+	if !cleanupAfterTest {
+		glog.V(gpuparams.GpuLogLevel).Infof("Cleanup is disabled, skipping GPU operator cleanup")
+		return
+	}
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Starting cleanup of GPU Operator Resources")
+
+	By("Uninstalling GPU Operator and verifying the cluster is clean")
+	err := nvidiagpu.UninstallGPUOperator(ctx, inittools.APIClient, nil, inittools.GeneralConfig.StrictUninstall)
+	Expect(err).ToNot(HaveOccurred(), "Error uninstalling GPU Operator: %v", err)
+
+	By("Deleting GPU Burn Namespace")
+	err = mig.DeleteGPUBurnNamespace(inittools.APIClient, burnNamespace)
+	Expect(err).ToNot(HaveOccurred(), "Error deleting burn namespace: %v", err)
+
+	glog.V(gpuparams.GpuLogLevel).Infof("Completed cleanup of GPU Operator Resources")
+}
+
+// IsLabelInFilter checks if a specific label is present in the Ginkgo label filter from command line.
+// Returns true if the label is found in the filter, false otherwise.
+func IsLabelInFilter(label string) bool {
+	filterQuery := GinkgoLabelFilter()
+	glog.V(gpuparams.Gpu100LogLevel).Infof("Checking if label '%s' is present in Ginkgo label filter: %s", label, filterQuery)
+
+	// If no filter is set, the label is not in the filter
+	if filterQuery == "" {
+		glog.V(gpuparams.Gpu100LogLevel).Infof("No label filter set, label '%s' is not in filter", label)
+		return false
+	}
+
+	// Check if the label is present in the filter string
+	// Use word boundaries to avoid partial matches (e.g., "single-mig" should not match "single-mig-test")
+	// Simple check: label should appear as a whole word (comma-separated or at boundaries)
+	labelInFilter := strings.Contains(filterQuery, label)
+	if labelInFilter {
+		glog.V(gpuparams.GpuLogLevel).Infof("Label '%s' is present in Ginkgo label filter", label)
+	} else {
+		glog.V(gpuparams.GpuLogLevel).Infof("Label '%s' is not present in Ginkgo label filter", label)
+	}
+	return labelInFilter
+}
+
+// ShouldKeepOperator checks if the operator should be kept based on test labels and upgrade channel
+func ShouldKeepOperator(labelsToCheck []string) bool {
+	// Get the label filter from Ginkgo command line
+	filterQuery := GinkgoLabelFilter()
+	specReport := CurrentSpecReport()
+	currentLabels := specReport.Labels()
+
+	// Log the labels present in the ginkgo command line before the for loop
+	glog.V(gpuparams.Gpu100LogLevel).Infof("Ginkgo label filter from command line: %s", filterQuery)
+	glog.V(gpuparams.Gpu100LogLevel).Infof("Current test labels from Ginkgo: %v", currentLabels)
+
+	// Check if test has any of these labels
+	for _, label := range labelsToCheck {
+		glog.V(gpuparams.Gpu100LogLevel).Infof("Checking if label %s is present in Ginkgo label filter", label)
+		if strings.Contains(filterQuery, label) {
+			glog.V(gpuparams.Gpu100LogLevel).Infof("Label %s is present in Ginkgo label filter", label)
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package shared
+
+import (
+	"flag"
+	"strconv"
+
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2" //nolint:staticcheck
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/gpuparams"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/mig"
+)
+
+// Global variables for ginkgo CLI parameters and values derived from them
+var (
+	PodDelay          int
+	SingleMigProfile  int
+	MigInstances      string
+	MixedMigSeed      int64
+	MixedMigInstances []int
+	// RandomMigInstances is set when --mixed.mig.instances=random asks for a random mix that fits the GPU.
+	RandomMigInstances bool
+)
+
+const (
+	defaultMigInstances     int = -1 // parameter not provided
+	defaultSingleMigProfile int = -2 // parameter not provided
+)
+
+func init() {
+	// Register flags before Ginkgo parses them
+	flag.IntVar(&PodDelay, "mixed.mig.pod-delay", 0, "delay in seconds between pod creation on mixed-mig testcase")
+	flag.IntVar(&SingleMigProfile, "single.mig.profile", -2, "index of the MIG profile to be used for single-mig testcase")
+	flag.StringVar(&MigInstances, "mixed.mig.instances", "-1", "comma-separated number of instances for mixed-mig testcase, "+
+		"or 'random' for a random mix that fits the GPU, defaults are for A100 GPU [2,0,1,1,0,0]")
+	flag.Int64Var(&MixedMigSeed, "mixed.mig.seed", 0, "seed of the random mix of the mixed-mig testcase, 0 for a time based seed")
+	flag.BoolVar(&mig.NoColor, "no-color", false, "disable color output")
+}
+
+// ParseCLIParameters parses CLI parameters and sets the global variables.
+// This must be called after flags are parsed (e.g., in a BeforeSuite or BeforeAll hook).
+func ParseCLIParameters() {
+	wasProvided := isFlagProvided("mixed.mig.instances")
+	RandomMigInstances = wasProvided && MigInstances == "random"
+	if wasProvided && !RandomMigInstances {
+		MixedMigInstances = mig.ParseMigInstances(MigInstances, strconv.Itoa(defaultMigInstances))
+	} else {
+		MixedMigInstances = nil
+	}
+}
+
+// isFlagProvided checks if a flag was explicitly set on the command line.
+// Returns true if the flag was provided, false if it's using the default value.
+func isFlagProvided(flagName string) bool {
+	provided := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == flagName {
+			provided = true
+		}
+	})
+	return provided
+}
+
+// LogCLIParameterValues logs the MIG CLI parameters, and the defaults used for the ones not provided.
+func LogCLIParameterValues() {
+	// Check if the flags were explicitly provided on the command line
+	wasProvided := isFlagProvided("single.mig.profile")
+	if !wasProvided {
+		GinkgoWriter.Printf("Flag --single.mig.profile not provided, using default: %d\n", SingleMigProfile)
+	} else {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Value of --single.mig.profile parameter: %d", SingleMigProfile)
+	}
+
+	wasProvided = isFlagProvided("mixed.mig.pod-delay")
+	if !wasProvided {
+		GinkgoWriter.Printf("Flag --mixed.mig.pod-delay not provided, using default: %d\n", PodDelay)
+	} else {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Value of --mixed.mig.pod-delay parameter: %d", PodDelay)
+	}
+
+	wasProvided = isFlagProvided("mixed.mig.instances")
+	switch {
+	case !wasProvided:
+		GinkgoWriter.Printf("Flag --mixed.mig.instances not provided, using default: %v\n", defaultMigInstances)
+	case MigInstances == "random":
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Value of --mixed.mig.instances parameter: random, "+
+			"--mixed.mig.seed parameter: %d", MixedMigSeed)
+	default:
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Value of --mixed.mig.instances parameter: %v, parsed values: %v",
+			MigInstances, mig.ParseMigInstances(MigInstances, strconv.Itoa(defaultMigInstances)))
+	}
+
+	wasProvided = isFlagProvided("no-color")
+	if !wasProvided {
+		GinkgoWriter.Printf("Flag --no-color not provided, using default: %v\n", mig.NoColor)
+	} else {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("Value of --no-color parameter: %v", mig.NoColor)
+	}
+}
+
+// ReadSingleMIGParameter checks the singleMIGProfile parameter and parses the MIG index if provided.
+// Function returns the selected MIG index, or -1 if not set or invalid (i.e. contains no digits)
+// -1 translates to random selection of MIG profile
+func ReadSingleMIGParameter() int {
+	if SingleMigProfile != defaultSingleMigProfile {
+		// CLI parameter is set, use it
+		glog.V(gpuparams.Gpu10LogLevel).Infof("CLI parameter --single.mig.profile"+
+			" is set to '%d', using it as requested MIG profile", SingleMigProfile)
+		return SingleMigProfile
+	}
+	return -1
+}
+
+// ReadMIGParameter returns the value of the --mixed.mig.instances parameter or defaults if the parameter was not set.
+// It returns a slice of integers representing the number of instances for each MIG profile.
+// If the parameter is not set, it returns the hardcoded default values for A100 GPU [2,0,1,1,0,0].
+func ReadMIGParameter() []int {
+	defaults := []int{2, 0, 1, 1, 0, 0}
+
+	if MixedMigInstances != nil {
+		glog.V(gpuparams.Gpu10LogLevel).Infof("CLI parameter --mixed.mig.instances is set to: '%v', "+
+			"using it as requested MIG instance counts", MixedMigInstances)
+		return MixedMigInstances
+	}
+	// If no valid numbers found, return default values
+	glog.V(gpuparams.GpuLogLevel).Infof("No valid numbers found in --mixed.mig.instances, using default values %v", defaults)
+	return defaults
+}
+
+// ReadDelayBetweenPods returns the value of mixed.mig.pod-delay, clamped to 0-315 seconds.
+func ReadDelayBetweenPods() int {
+	var podDelay int
+	switch {
+	case PodDelay < 0:
+		podDelay = 0
+	case PodDelay > 315:
+		podDelay = 315
+	default:
+		podDelay = PodDelay
+	}
+
+	glog.V(gpuparams.Gpu10LogLevel).Infof("--mixed.mig.pod-delay parameter value: %d", podDelay)
+	return podDelay
+}
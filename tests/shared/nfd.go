@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2" //nolint:staticcheck
+	. "github.com/onsi/gomega"    //nolint:staticcheck
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/clients"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nfd"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/nfdcheck"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/olm"
+	"github.com/rh-ecosystem-edge/nvidia-ci/pkg/operatorconfig"
+)
+
+// EnsureNFDIsInstalled installs NFD when it is missing, skipping the spec when no catalogsource provides it.
+func EnsureNFDIsInstalled(apiClient *clients.Settings, nfdInstance *operatorconfig.CustomConfig, ocpVersion string,
+	level glog.Level) {
+	By("Check if NFD is installed")
+	err := nfd.EnsureNFDIsInstalled(apiClient, nfdInstance, ocpVersion, level)
+	if errors.Is(err, olm.ErrPackageNotFound) {
+		Skip("NFD packagemanifest not found in default 'redhat-operators' catalogsource, " +
+			"and no custom catalogsource is defined")
+	}
+
+	Expect(err).ToNot(HaveOccurred(), "error ensuring NFD is installed: %v", err)
+}
+
+// CheckNfdInstallation fails the spec unless the NFD label is set on all worker nodes and the NFD deployments are
+// ready.
+func CheckNfdInstallation(apiClient *clients.Settings, label string, allowedLabelValues []string,
+	workerLabelMap map[string]string, logLevel int) {
+	By(fmt.Sprintf("Check if NFD is installed using label: %s", label))
+	err := nfdcheck.CheckNfdInstallation(apiClient, label, allowedLabelValues, workerLabelMap, logLevel)
+	Expect(err).ToNot(HaveOccurred(), "NFD installation check failed: %v", err)
+}
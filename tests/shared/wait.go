@@ -0,0 +1,11 @@
+package shared
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:staticcheck
+	"github.com/rh-ecosystem-edge/nvidia-ci/internal/wait"
+)
+
+// init streams the progress of the waits of the suites using the shared helpers to the GinkgoWriter.
+func init() {
+	wait.SetProgressWriter(GinkgoWriter)
+}